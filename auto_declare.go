@@ -0,0 +1,83 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// SetAutoDeclare enables or disables the soft schema mode for rapid prototyping. If enabled, upserting an individual declares all
+// referenced classes and properties that are not declared yet as stubs (`owl:Class`, `owl:ObjectProperty` or `owl:DatatypeProperty`)
+// instead of leaving dangling URIs. Each declaration is reported with `ErrAutoDeclared` to the warning collector (if set).
+func (ont *OntologyGraph) SetAutoDeclare(enabled bool) {
+	ont.autoDeclare = enabled
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// declareReferencedTerms adds stub declarations for the undeclared types and properties of the individual.
+func (ont *OntologyGraph) declareReferencedTerms(indiv *OntologyIndividual) error {
+	stubs := []Triple{}
+	declared := map[string]bool{}
+	declare := func(uri, kind string) error {
+		if declared[uri] || isBuiltinSchemaTerm(uri) {
+			return nil
+		}
+		declared[uri] = true
+		match, err := ont.graph.GetFirstMatch(NewResourceTerm(uri).String(), NewResourceTerm(RDFType).String(), "")
+		if err != nil {
+			return err
+		}
+		if match == nil {
+			stubs = append(stubs, Triple{Subject: NewResourceTerm(uri), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(kind)})
+		}
+		return nil
+	}
+	for _, class := range indiv.Types {
+		if err := declare(class, OWLClass); err != nil {
+			return err
+		}
+	}
+	// Declare properties in sorted order to keep the reported warnings stable
+	objProps := make([]string, 0, len(indiv.ObjectProperties))
+	for prop := range indiv.ObjectProperties {
+		objProps = append(objProps, prop)
+	}
+	sort.Strings(objProps)
+	for _, prop := range objProps {
+		if err := declare(prop, OWLObjectProperty); err != nil {
+			return err
+		}
+	}
+	dataProps := make([]string, 0, len(indiv.DataProperties))
+	for prop := range indiv.DataProperties {
+		dataProps = append(dataProps, prop)
+	}
+	sort.Strings(dataProps)
+	for _, prop := range dataProps {
+		if err := declare(prop, OWLDatatypeProperty); err != nil {
+			return err
+		}
+	}
+	if len(stubs) == 0 {
+		return nil
+	}
+	if err := ont.graph.AddTriplesUnchecked(stubs); err != nil {
+		return err
+	}
+	if ont.warnings != nil {
+		for _, stub := range stubs {
+			ont.warnings.Warn(Warning{Triple: stub, Err: fmt.Errorf("%w: %s", ErrAutoDeclared, stub.Subject)})
+		}
+	}
+	return nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrAutoDeclared is reported when a referenced class or property was declared automatically as stub (see `SetAutoDeclare`).
+var ErrAutoDeclared error = errors.New("The referenced term was declared automatically")
@@ -0,0 +1,71 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Auto-declaring referenced terms", func() {
+	const ns = "http://example.com/onto#"
+	var store *MemoryStore
+	var ont *OntologyGraph
+	var report *Report
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:Person a owl:Class .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		report = &Report{}
+		ont.SetWarningCollector(report)
+	})
+
+	newIndividual := func() OntologyIndividual {
+		return OntologyIndividual{
+			URI:              ns + "alice",
+			Types:            []string{OWLNamedIndividual, ns + "Person", ns + "Employee"},
+			ObjectProperties: map[string][]string{ns + "worksFor": {ns + "acme"}},
+			DataProperties:   map[string][]GenericLiteral{ns + "age": {*NewGenericLiteral(NewLiteralTerm("42", "", XSDInteger))}},
+		}
+	}
+
+	It("should declare missing classes and properties as stubs", func() {
+		ont.SetAutoDeclare(true)
+		indiv := newIndividual()
+		Expect(ont.UpsertResource(&indiv)).To(Succeed())
+
+		Expect(store.GetFirstMatch(NewResourceTerm(ns+"Employee").String(), NewResourceTerm(RDFType).String(), NewResourceTerm(OWLClass).String())).NotTo(BeNil())
+		Expect(store.GetFirstMatch(NewResourceTerm(ns+"worksFor").String(), NewResourceTerm(RDFType).String(), NewResourceTerm(OWLObjectProperty).String())).NotTo(BeNil())
+		Expect(store.GetFirstMatch(NewResourceTerm(ns+"age").String(), NewResourceTerm(RDFType).String(), NewResourceTerm(OWLDatatypeProperty).String())).NotTo(BeNil())
+		// Declared and builtin terms are left untouched
+		Expect(store.GetAllMatches(NewResourceTerm(ns+"Person").String(), "", "")).To(HaveLen(1))
+		Expect(store.GetAllMatches(NewResourceTerm(OWLNamedIndividual).String(), "", "")).To(BeEmpty())
+
+		subjects := []Term{}
+		for _, w := range report.Warnings() {
+			Expect(w.Err).To(MatchError(ErrAutoDeclared))
+			subjects = append(subjects, w.Triple.Subject)
+		}
+		Expect(subjects).To(Equal([]Term{NewResourceTerm(ns + "Employee"), NewResourceTerm(ns + "worksFor"), NewResourceTerm(ns + "age")}))
+
+		// Upserting again declares nothing new
+		Expect(ont.UpsertResource(&indiv)).To(Succeed())
+		Expect(report.Warnings()).To(HaveLen(3))
+	})
+
+	It("should leave references dangling by default", func() {
+		indiv := newIndividual()
+		Expect(ont.UpsertResource(&indiv)).To(Succeed())
+		Expect(store.GetAllMatches(NewResourceTerm(ns+"Employee").String(), "", "")).To(BeEmpty())
+		Expect(report.HasWarnings()).To(BeFalse())
+	})
+})
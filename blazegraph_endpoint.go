@@ -1,19 +1,24 @@
 package ontograph
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // BlazegraphEndpoint is the SPARQL endpoint for a Blazegraph database
 type BlazegraphEndpoint struct {
-	host   string
-	client *http.Client
+	host    string
+	client  *http.Client
+	ctx     context.Context
+	metrics MetricsHook
 }
 
 // NewBlazegraphEndpoint creates a new endpoint on the specified host address of the Blazegraph database.
@@ -26,15 +31,32 @@ func NewBlazegraphEndpoint(hostAddr string) *BlazegraphEndpoint {
 }
 
 // NewBlazegraphStore creates a new store associated with a graph URI in the specified namespace. Operations will be conducted through the specified endpoint. This constructor does neither check if the namespace or graph exist nor if the endpoint is online.
-func (ep *BlazegraphEndpoint) NewBlazegraphStore(uri, namespace string) *BlazegraphStore {
+// The store can be configured with store options, e.g. to use a custom HTTP client or request timeout for its requests.
+func (ep *BlazegraphEndpoint) NewBlazegraphStore(uri, namespace string, opts ...StoreOption) *BlazegraphStore {
+	options := newStoreOptions(opts)
+	if options.client != nil || options.timeout > 0 || options.metrics != nil {
+		configured := *ep
+		configured.client = options.httpClient(ep.client)
+		configured.metrics = options.metrics
+		ep = &configured
+	}
 	store := BlazegraphStore{
 		uri:       uri,
 		namespace: namespace,
 		endpoint:  ep,
+		options:   options,
 	}
 	return &store
 }
 
+// WithContext returns a copy of the endpoint whose HTTP requests use the context, i.e. they are aborted when the context is cancelled
+// or its deadline is exceeded.
+func (ep *BlazegraphEndpoint) WithContext(ctx context.Context) *BlazegraphEndpoint {
+	bound := *ep
+	bound.ctx = ctx
+	return &bound
+}
+
 // IsOnline checks if the Blazegraph endpoint is online (i.e. if it responds with HTTP 200 on its status endpoint).
 func (ep *BlazegraphEndpoint) IsOnline() (bool, error) {
 	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/bigdata/status", ep.host), nil)
@@ -173,7 +195,8 @@ func (ep *BlazegraphEndpoint) GetGraphs(namespace string) ([]string, error) {
 }
 
 // DoSparqlTurtleQuery queries the database for data in Turtle (ttl) format.
-func (ep *BlazegraphEndpoint) DoSparqlTurtleQuery(namespace, sparqlQuery string) ([]byte, int, error) {
+func (ep *BlazegraphEndpoint) DoSparqlTurtleQuery(namespace, sparqlQuery string) (data []byte, code int, err error) {
+	defer ep.observe("query", time.Now(), &code, &err)
 	// Setup request payload
 	encQuery := fmt.Sprintf("query=%s", url.QueryEscape(sparqlQuery))
 	// Create request
@@ -186,7 +209,7 @@ func (ep *BlazegraphEndpoint) DoSparqlTurtleQuery(namespace, sparqlQuery string)
 	req.Header.Set("Accept", "application/x-turtle")
 
 	// Execute request
-	code, data, err := ep.doHTTP(req)
+	code, data, err = ep.doHTTP(req)
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
 	}
@@ -195,18 +218,60 @@ func (ep *BlazegraphEndpoint) DoSparqlTurtleQuery(namespace, sparqlQuery string)
 
 // DoSparqlJSONQuery queries the database for data in JSON Result Set format.
 func (ep *BlazegraphEndpoint) DoSparqlJSONQuery(namespace, sparqlQuery string) (JSONResultSet, int, error) {
-	var resSet JSONResultSet
-	// Setup request payload
-	encQuery := fmt.Sprintf("query=%s", url.QueryEscape(sparqlQuery))
+	return ep.doSparqlJSONQuery(namespace, sparqlQuery, "")
+}
 
+// DoSparqlUpdate performs a SPARQL update on the database
+func (ep *BlazegraphEndpoint) DoSparqlUpdate(namespace, sparqlUpdate string) (code int, err error) {
+	defer ep.observe("update", time.Now(), &code, &err)
+	// Setup request payload
+	encUpdate := fmt.Sprintf("update=%s", url.QueryEscape(sparqlUpdate))
 	// Create request
 	path := fmt.Sprintf("%s/bigdata/namespace/%s/sparql", ep.host, url.PathEscape(namespace))
-	req, err := http.NewRequest(http.MethodPost, path, strings.NewReader(encQuery))
+	req, err := http.NewRequest(http.MethodPost, path, strings.NewReader(encUpdate))
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=UTF-8")
+
+	// Execute request
+	code, _, err = ep.doHTTP(req)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	// Return status
+	return code, nil
+}
+
+// DoDataInsert inserts the RDF data streamed from the reader into the named graph of the namespace with a single request to the REST
+// API of the database. The content type must be a RDF format supported by Blazegraph (e.g. `text/plain` for N-Triples).
+func (ep *BlazegraphEndpoint) DoDataInsert(namespace, graphURI, contentType string, data io.Reader) (code int, err error) {
+	defer ep.observe("insert", time.Now(), &code, &err)
+	// Create request
+	path := fmt.Sprintf("%s/bigdata/namespace/%s/sparql?context-uri=%s", ep.host, url.PathEscape(namespace), url.QueryEscape(graphURI))
+	req, err := http.NewRequest(http.MethodPost, path, data)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	// Execute request
+	code, _, err = ep.doHTTP(req)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	// Return status
+	return code, nil
+}
+
+// doSparqlJSONQuery queries the database for data in JSON Result Set format. If a graph URI is given, the dataset of the query is
+// restricted to the graph, i.e. it is the default graph and the only named graph of the query.
+func (ep *BlazegraphEndpoint) doSparqlJSONQuery(namespace, sparqlQuery, graphURI string) (resSet JSONResultSet, code int, err error) {
+	defer ep.observe("query", time.Now(), &code, &err)
+	req, err := ep.newSparqlJSONQueryRequest(namespace, sparqlQuery, graphURI)
 	if err != nil {
 		return resSet, http.StatusInternalServerError, err
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("Accept", "application/sparql-results+json")
 
 	// Execute request
 	code, data, err := ep.doHTTP(req)
@@ -222,25 +287,129 @@ func (ep *BlazegraphEndpoint) DoSparqlJSONQuery(namespace, sparqlQuery string) (
 	return resSet, code, err
 }
 
-// DoSparqlUpdate performs a SPARQL update on the database
-func (ep *BlazegraphEndpoint) DoSparqlUpdate(namespace, sparqlUpdate string) (int, error) {
+// doSparqlJSONQueryStream queries the database for data in JSON Result Set format like `doSparqlJSONQuery`, but decodes the bindings
+// one by one while the response is read and passes them to fn. If fn returns an error, reading stops and the error is returned.
+func (ep *BlazegraphEndpoint) doSparqlJSONQueryStream(namespace, sparqlQuery string, fn func(map[string]JSONResultSetBinding) error) (int, error) {
+	var code int
+	var failure error
+	defer ep.observe("query", time.Now(), &code, &failure)
+	req, err := ep.newSparqlJSONQueryRequest(namespace, sparqlQuery, "")
+	if err != nil {
+		code, failure = http.StatusInternalServerError, err
+		return code, err
+	}
+
+	// Execute request
+	res, err := ep.send(req)
+	if err != nil {
+		code, failure = http.StatusInternalServerError, err
+		return code, err
+	}
+	defer res.Body.Close()
+	code = res.StatusCode
+	if code != http.StatusOK {
+		return code, nil
+	}
+
+	// Decode response body, errors of fn do not count as failed request
+	var fnErr error
+	err = decodeJSONBindings(res.Body, func(binding map[string]JSONResultSetBinding) error {
+		fnErr = fn(binding)
+		return fnErr
+	})
+	if err != nil && fnErr == nil {
+		failure = err
+	}
+	return code, err
+}
+
+// newSparqlJSONQueryRequest creates the request of a SPARQL query for data in JSON Result Set format. If a graph URI is given, the dataset
+// of the query is restricted to the graph.
+func (ep *BlazegraphEndpoint) newSparqlJSONQueryRequest(namespace, sparqlQuery, graphURI string) (*http.Request, error) {
 	// Setup request payload
-	encUpdate := fmt.Sprintf("update=%s", url.QueryEscape(sparqlUpdate))
+	encQuery := fmt.Sprintf("query=%s", url.QueryEscape(sparqlQuery))
+	if graphURI != "" {
+		encQuery += fmt.Sprintf("&default-graph-uri=%s&named-graph-uri=%s", url.QueryEscape(graphURI), url.QueryEscape(graphURI))
+	}
+
 	// Create request
 	path := fmt.Sprintf("%s/bigdata/namespace/%s/sparql", ep.host, url.PathEscape(namespace))
-	req, err := http.NewRequest(http.MethodPost, path, strings.NewReader(encUpdate))
+	req, err := http.NewRequest(http.MethodPost, path, strings.NewReader(encQuery))
 	if err != nil {
-		return http.StatusInternalServerError, err
+		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=UTF-8")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/sparql-results+json")
+	return req, nil
+}
 
-	// Execute request
-	code, _, err := ep.doHTTP(req)
+// decodeJSONBindings decodes the bindings of the JSON result set read from the reader one by one and passes them to fn. All other
+// members of the result set are skipped.
+func decodeJSONBindings(r io.Reader, fn func(map[string]JSONResultSetBinding) error) error {
+	dec := json.NewDecoder(r)
+	return decodeJSONObject(dec, func(key string) error {
+		if key != "results" {
+			return skipJSONValue(dec)
+		}
+		return decodeJSONObject(dec, func(key string) error {
+			if key != "bindings" {
+				return skipJSONValue(dec)
+			}
+			if err := expectJSONDelim(dec, '['); err != nil {
+				return err
+			}
+			for dec.More() {
+				binding := map[string]JSONResultSetBinding{}
+				if err := dec.Decode(&binding); err != nil {
+					return err
+				}
+				if err := fn(binding); err != nil {
+					return err
+				}
+			}
+			return expectJSONDelim(dec, ']')
+		})
+	})
+}
+
+// decodeJSONObject reads a JSON object from the decoder and calls fn with the key of every member, which must read the value of the
+// member from the decoder.
+func decodeJSONObject(dec *json.Decoder, fn func(key string) error) error {
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("Expected JSON object key but found %v", tok)
+		}
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+	return expectJSONDelim(dec, '}')
+}
+
+// expectJSONDelim reads the next token from the decoder and errors if it is not the given delimiter.
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
 	if err != nil {
-		return http.StatusInternalServerError, err
+		return err
 	}
-	// Return status
-	return code, nil
+	if tok != delim {
+		return fmt.Errorf("Expected '%s' in JSON result set but found %v", delim, tok)
+	}
+	return nil
+}
+
+// skipJSONValue reads the next value from the decoder and discards it.
+func skipJSONValue(dec *json.Decoder) error {
+	var value json.RawMessage
+	return dec.Decode(&value)
 }
 
 // doHTTP executes the given request and returns HTTP status code, result data and error.
@@ -248,7 +417,7 @@ func (ep *BlazegraphEndpoint) DoSparqlUpdate(namespace, sparqlUpdate string) (in
 // If the status code is a valid HTTP code and error is not nil, there was an error with
 // decoding the response body.
 func (ep *BlazegraphEndpoint) doHTTP(req *http.Request) (int, []byte, error) {
-	res, err := ep.client.Do(req)
+	res, err := ep.send(req)
 	if err != nil {
 		return -1, nil, err
 	}
@@ -261,6 +430,27 @@ func (ep *BlazegraphEndpoint) doHTTP(req *http.Request) (int, []byte, error) {
 	return res.StatusCode, data, nil
 }
 
+// send executes the given request with the client and context of the endpoint. The caller must close the body of the response.
+func (ep *BlazegraphEndpoint) send(req *http.Request) (*http.Response, error) {
+	if ep.ctx != nil {
+		req = req.WithContext(ep.ctx)
+	}
+	return ep.client.Do(req)
+}
+
+// observe passes the latency and outcome of a request started at the given time to the metrics hook of the endpoint (if any). Requests
+// that are answered with an error status count as failed.
+func (ep *BlazegraphEndpoint) observe(kind string, start time.Time, code *int, err *error) {
+	if ep.metrics == nil {
+		return
+	}
+	failure := *err
+	if failure == nil && (*code < 200 || *code > 299) {
+		failure = fmt.Errorf("Unexpected status code (HTTP %d)", *code)
+	}
+	ep.metrics.ObserveQuery("blazegraph", kind, time.Since(start), failure)
+}
+
 // A JSONResultSet represents the result set for SPARQL queries in JSON format (see https://www.w3.org/TR/sparql11-results-json for details)
 type JSONResultSet struct {
 	Head struct {
@@ -282,3 +472,14 @@ type JSONResultSetBinding struct {
 	Lang     string `json:"xml:lang,omitempty"`
 	DataType string `json:"datatype,omitempty"`
 }
+
+// Term converts the binding into a term in NTriple format.
+func (b JSONResultSetBinding) Term() Term {
+	switch b.Type {
+	case "uri":
+		return NewResourceTerm(b.Value)
+	case "bnode":
+		return Term("_:" + b.Value)
+	}
+	return NewLiteralTerm(escapeLiteral(b.Value), b.Lang, b.DataType)
+}
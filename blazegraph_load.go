@@ -0,0 +1,52 @@
+package ontograph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// LoadTurtle streams the TTL data given in the reader into the graph with a single bulk insert request, which is much faster than adding
+// the triples with SPARQL updates. The data is parsed statement by statement (see `ParseTurtleStream`) and sent as N-Triples while it is
+// parsed, so it is never held in memory completely. Existing triples do not cause an error. Returns the number of parsed triples. If
+// parsing fails, the request is aborted. Note that Blazegraph might still have inserted the triples received until then.
+func (store *BlazegraphStore) LoadTurtle(r io.Reader, opts ...ParseOption) (int, error) {
+	count := 0
+	pr, pw := io.Pipe()
+	parsed := make(chan error, 1)
+	go func() {
+		w := bufio.NewWriter(pw)
+		err := ParseTurtleStream(r, func(trp Triple) error {
+			if err := store.options.check(store, []Triple{trp}); err != nil {
+				return err
+			}
+			count++
+			_, err := fmt.Fprintln(w, sparqlTriple(trp))
+			return err
+		}, opts...)
+		if err == nil {
+			err = w.Flush()
+		}
+		pw.CloseWithError(err)
+		parsed <- err
+	}()
+	code, err := store.endpoint.DoDataInsert(store.namespace, store.uri, "text/plain", pr)
+	// Stop the parser if the request ended early and report parse errors first
+	pr.CloseWithError(io.ErrClosedPipe)
+	if parseErr := <-parsed; parseErr != nil && !errors.Is(parseErr, io.ErrClosedPipe) {
+		return count, store.wrapErr("LoadTurtle", nil, "", parseErr)
+	}
+	if err != nil {
+		return count, store.wrapErr("LoadTurtle", nil, "", err)
+	}
+	if code == http.StatusNotFound {
+		return count, store.wrapErr("LoadTurtle", nil, "", fmt.Errorf("Namespace '%s' does not exist (HTTP %d)", store.namespace, http.StatusNotFound))
+	}
+	if code != http.StatusOK {
+		return count, store.wrapErr("LoadTurtle", nil, "", fmt.Errorf("Failed to load triples into graph '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code))
+	}
+	store.options.observeTriples("blazegraph", count, 0)
+	return count, nil
+}
@@ -0,0 +1,69 @@
+package ontograph_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Bulk loading into Blazegraph stores", func() {
+	var server *httptest.Server
+	var requests []*http.Request
+	var bodies []string
+
+	BeforeEach(func() {
+		requests, bodies = nil, nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			requests = append(requests, r)
+			bodies = append(bodies, string(body))
+			w.Write([]byte(`<?xml version="1.0"?><data modified="2" milliseconds="1"/>`))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should stream the triples as N-Triples in a single request", func() {
+		store := NewBlazegraphEndpoint(server.URL).NewBlazegraphStore("http://example.com/onto", "test")
+		n, err := store.LoadTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+:A a owl:Class .
+:B :note "x"@en ; :ref _:b1 .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(3))
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].URL.Path).To(Equal("/bigdata/namespace/test/sparql"))
+		Expect(requests[0].URL.Query().Get("context-uri")).To(Equal("http://example.com/onto"))
+		Expect(requests[0].Header.Get("Content-Type")).To(Equal("text/plain"))
+		Expect(strings.Split(strings.TrimSpace(bodies[0]), "\n")).To(ConsistOf(
+			`<http://example.com/onto#A> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/2002/07/owl#Class> .`,
+			`<http://example.com/onto#B> <http://example.com/onto#note> "x"@en .`,
+			MatchRegexp(`^<http://example.com/onto#B> <http://example.com/onto#ref> <urn:ontograph:bnode:n\d+> .$`),
+		))
+	})
+
+	It("should report parse errors", func() {
+		store := NewBlazegraphEndpoint(server.URL).NewBlazegraphStore("http://example.com/onto", "test")
+		_, err := store.LoadTurtle(strings.NewReader(`<http://example.com/onto#A> a <http://www.w3.org/2002/07/owl#Class> .
+<http://example.com/onto#B> "broken" .
+`))
+		Expect(err).To(HaveOccurred())
+		var storeErr *StoreError
+		Expect(errors.As(err, &storeErr)).To(BeTrue())
+		Expect(storeErr.Op).To(Equal("LoadTurtle"))
+	})
+})
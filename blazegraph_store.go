@@ -1,6 +1,7 @@
 package ontograph
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,6 +15,7 @@ type BlazegraphStore struct {
 	uri       string
 	namespace string
 	endpoint  *BlazegraphEndpoint
+	options   storeOptions
 }
 
 // GetURI returns the named graph URI.
@@ -21,6 +23,14 @@ func (store *BlazegraphStore) GetURI() string {
 	return store.uri
 }
 
+// WithContext returns a copy of the store whose SPARQL requests use the context, i.e. they are aborted when the context is cancelled or
+// its deadline is exceeded.
+func (store *BlazegraphStore) WithContext(ctx context.Context) GraphStore {
+	bound := *store
+	bound.endpoint = store.endpoint.WithContext(ctx)
+	return &bound
+}
+
 // GetFirstMatch retrieves the first triple that matches the pattern. Empty strings in subject, predicate or object are treated as wildcards.
 func (store *BlazegraphStore) GetFirstMatch(subj, pred, obj string) (*Triple, error) {
 	// TODO: might be implemented more efficiently?
@@ -36,53 +46,7 @@ func (store *BlazegraphStore) GetFirstMatch(subj, pred, obj string) (*Triple, er
 
 // GetAllMatches retrieves all triples that match the pattern. Empty strings in subject, predicate or object are treated as wildcards.
 func (store *BlazegraphStore) GetAllMatches(subj, pred, obj string) ([]Triple, error) {
-	// Parse pattern to query parameters
-	s := "?s"
-	p := "?p"
-	o := "?o"
-	if subj != "" {
-		s = Term(subj).String()
-	}
-	if pred != "" {
-		p = Term(pred).String()
-	}
-	if obj != "" {
-		o = Term(obj).String()
-	}
-	// Construct SPARQL query
-	sparqlReq := fmt.Sprintf(`SELECT ?s ?p ?o WHERE { GRAPH <%s> { %s %s %s. } }`, store.uri, s, p, o)
-
-	// Execute SPARQL query
-	resSet, code, err := store.endpoint.DoSparqlJSONQuery(store.namespace, sparqlReq)
-	if err != nil {
-		return nil, err
-	}
-	if code != http.StatusOK {
-		return nil, fmt.Errorf("Received unexpected status code from SPARQL query (HTTP %d): %s", code, sparqlReq)
-	}
-	// We got a result set, iterate through bindings and parse corresponding triples
-	resTrps := []Triple{}
-	for _, trpBinding := range resSet.Results.Bindings {
-		sTerm := Term(subj)
-		if subj == "" {
-			sTerm = binding2Term(trpBinding["s"])
-		}
-		pTerm := Term(pred)
-		if pred == "" {
-			pTerm = binding2Term(trpBinding["p"])
-		}
-		oTerm := Term(obj)
-		if obj == "" {
-			oTerm = binding2Term(trpBinding["o"])
-		}
-		// Return result triple
-		resTrps = append(resTrps, Triple{
-			Subject:   sTerm,
-			Predicate: pTerm,
-			Object:    oTerm,
-		})
-	}
-	return resTrps, nil
+	return store.getMatches("GetAllMatches", subj, pred, obj, "")
 }
 
 // DeleteAllMatches removes all triples that match the pattern. Empty strings in subject, predicate or object are treated as wildcards.
@@ -92,26 +56,26 @@ func (store *BlazegraphStore) DeleteAllMatches(subj, pred, obj string) error {
 	p := "?p"
 	o := "?o"
 	if subj != "" {
-		s = Term(subj).String()
+		s = sparqlTerm(Term(subj))
 	}
 	if pred != "" {
-		p = Term(pred).String()
+		p = sparqlTerm(Term(pred))
 	}
 	if obj != "" {
-		o = Term(obj).String()
+		o = sparqlTerm(Term(obj))
 	}
 	// Setup SPARQL query for deletion
 	sparqlReq := fmt.Sprintf(`DELETE WHERE { GRAPH <%s> { %s %s %s . } }`, store.uri, s, p, o)
 	code, err := store.endpoint.DoSparqlUpdate(store.namespace, sparqlReq)
 	// Check response status
 	if err != nil {
-		return err
+		return store.wrapPatternErr("DeleteAllMatches", subj, pred, obj, sparqlReq, err)
 	}
 	if code == http.StatusNotFound {
 		return nil
 	}
 	if code != http.StatusOK {
-		return fmt.Errorf("Failed to delete triples from graph '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code)
+		return store.wrapPatternErr("DeleteAllMatches", subj, pred, obj, sparqlReq, fmt.Errorf("Failed to delete triples from graph '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code))
 	}
 	// We succeeded
 	return nil
@@ -130,7 +94,7 @@ func (store *BlazegraphStore) AddTriple(trp Triple) error {
 		return err
 	}
 	if foundTrp {
-		return ErrTripleAlreadyExists
+		return store.wrapErr("AddTriple", &trp, "", ErrTripleAlreadyExists)
 	}
 	// Otherwise, add triple to store
 	return store.AddTripleUnchecked(trp)
@@ -161,45 +125,53 @@ func (store *BlazegraphStore) AddTriples(trps []Triple) error {
 
 // AddTripleUnchecked adds the given triple to the store. It does not error if the triple already exists.
 func (store *BlazegraphStore) AddTripleUnchecked(trp Triple) error {
+	if err := store.options.check(store, []Triple{trp}); err != nil {
+		return err
+	}
 	// Setup SPARQL insert query
-	ttlData := fmt.Sprintf("%s %s %s .", trp.Subject.String(), trp.Predicate.String(), trp.Object.String())
+	ttlData := sparqlTriple(trp)
 	sparqlReq := fmt.Sprintf("INSERT DATA { GRAPH <%s> { %s } }", store.uri, ttlData)
 	code, err := store.endpoint.DoSparqlUpdate(store.namespace, sparqlReq)
 	// Check response status
 	if err != nil {
-		return err
+		return store.wrapErr("AddTripleUnchecked", &trp, sparqlReq, err)
 	}
 	if code == http.StatusNotFound {
-		return fmt.Errorf("Namespace '%s' does not exist (HTTP %d)", store.namespace, http.StatusNotFound)
+		return store.wrapErr("AddTripleUnchecked", &trp, sparqlReq, fmt.Errorf("Namespace '%s' does not exist (HTTP %d)", store.namespace, http.StatusNotFound))
 	}
 	if code != http.StatusOK {
-		return fmt.Errorf("Failed to insert triple into graph '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code)
+		return store.wrapErr("AddTripleUnchecked", &trp, sparqlReq, fmt.Errorf("Failed to insert triple into graph '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code))
 	}
 	// We succeeded
+	store.options.observeTriples("blazegraph", 1, 0)
 	return nil
 }
 
 // AddTriplesUnchecked adds all the given triples to the store. It does not error if any of the triples already exists.
 func (store *BlazegraphStore) AddTriplesUnchecked(trps []Triple) error {
+	if err := store.options.check(store, trps); err != nil {
+		return err
+	}
 	// Convert triples to TTL
 	var ttlDataBuffer strings.Builder
 	for _, trp := range trps {
-		ttlDataBuffer.WriteString(fmt.Sprintf("%s %s %s .", trp.Subject.String(), trp.Predicate.String(), trp.Object.String()))
+		ttlDataBuffer.WriteString(sparqlTriple(trp))
 	}
 
 	sparqlReq := fmt.Sprintf("INSERT DATA { GRAPH <%s> { %s } }", store.uri, ttlDataBuffer.String())
 	code, err := store.endpoint.DoSparqlUpdate(store.namespace, sparqlReq)
 	// Check response status
 	if err != nil {
-		return err
+		return store.wrapErr("AddTriplesUnchecked", nil, sparqlReq, err)
 	}
 	if code == http.StatusNotFound {
-		return fmt.Errorf("Namespace '%s' does not exist (HTTP %d)", store.namespace, http.StatusNotFound)
+		return store.wrapErr("AddTriplesUnchecked", nil, sparqlReq, fmt.Errorf("Namespace '%s' does not exist (HTTP %d)", store.namespace, http.StatusNotFound))
 	}
 	if code != http.StatusOK {
-		return fmt.Errorf("Failed to insert triples into graph '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code)
+		return store.wrapErr("AddTriplesUnchecked", nil, sparqlReq, fmt.Errorf("Failed to insert triples into graph '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code))
 	}
 	// We succeeded
+	store.options.observeTriples("blazegraph", len(trps), 0)
 	return nil
 }
 
@@ -211,7 +183,7 @@ func (store *BlazegraphStore) DeleteTriple(trp Triple) error {
 		return err
 	}
 	if !foundTrp {
-		return ErrTripleDoesNotExist
+		return store.wrapErr("DeleteTriple", &trp, "", ErrTripleDoesNotExist)
 	}
 
 	// Otherwise, delete triple from store
@@ -244,20 +216,21 @@ func (store *BlazegraphStore) DeleteTriples(trps []Triple) error {
 // DeleteTripleUnchecked removes the given triple from the store. It does not error if the triple does not exist.
 func (store *BlazegraphStore) DeleteTripleUnchecked(trp Triple) error {
 	// Setup SPARQL deletion query
-	ttlData := fmt.Sprintf("%s %s %s .", trp.Subject.String(), trp.Predicate.String(), trp.Object.String())
+	ttlData := sparqlTriple(trp)
 	sparqlReq := fmt.Sprintf("DELETE DATA { GRAPH <%s> { %s } }", store.uri, ttlData)
 	code, err := store.endpoint.DoSparqlUpdate(store.namespace, sparqlReq)
 	// Check response status
 	if err != nil {
-		return err
+		return store.wrapErr("DeleteTripleUnchecked", &trp, sparqlReq, err)
 	}
 	if code == http.StatusNotFound {
 		return nil
 	}
 	if code != http.StatusOK {
-		return fmt.Errorf("Failed to delete triple from graph '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code)
+		return store.wrapErr("DeleteTripleUnchecked", &trp, sparqlReq, fmt.Errorf("Failed to delete triple from graph '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code))
 	}
 	// We succeeded
+	store.options.observeTriples("blazegraph", 0, 1)
 	return nil
 }
 
@@ -266,22 +239,23 @@ func (store *BlazegraphStore) DeleteTriplesUnchecked(trps []Triple) error {
 	// Convert triples to TTL
 	var ttlDataBuffer strings.Builder
 	for _, trp := range trps {
-		ttlDataBuffer.WriteString(fmt.Sprintf("%s %s %s .", trp.Subject.String(), trp.Predicate.String(), trp.Object.String()))
+		ttlDataBuffer.WriteString(sparqlTriple(trp))
 	}
 	// Fire SPARQL delete query for triples
 	sparqlReq := fmt.Sprintf("DELETE DATA { GRAPH <%s> { %s } }", store.uri, ttlDataBuffer.String())
 	code, err := store.endpoint.DoSparqlUpdate(store.namespace, sparqlReq)
 	// Check response status
 	if err != nil {
-		return err
+		return store.wrapErr("DeleteTriplesUnchecked", nil, sparqlReq, err)
 	}
 	if code == http.StatusNotFound {
 		return nil
 	}
 	if code != http.StatusOK {
-		return fmt.Errorf("Failed to delete triples from graph '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code)
+		return store.wrapErr("DeleteTriplesUnchecked", nil, sparqlReq, fmt.Errorf("Failed to delete triples from graph '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code))
 	}
 	// We succeeded
+	store.options.observeTriples("blazegraph", 0, len(trps))
 	return nil
 }
 
@@ -289,19 +263,19 @@ func (store *BlazegraphStore) DeleteTriplesUnchecked(trps []Triple) error {
 func (store *BlazegraphStore) Drop() error {
 	// Check if graph exists in the first place
 	if store.endpoint == nil {
-		return fmt.Errorf("Store was already dropped")
+		return store.wrapErr("Drop", nil, "", fmt.Errorf("Store was already dropped"))
 	}
 	sparqlReq := fmt.Sprintf("ASK WHERE { GRAPH <%s> { ?s ?p ?o } }", store.uri)
 	resSet, code, err := store.endpoint.DoSparqlJSONQuery(store.namespace, sparqlReq)
 	// Check response status
 	if err != nil {
-		return err
+		return store.wrapErr("Drop", nil, sparqlReq, err)
 	}
 	if code == http.StatusNotFound || (code == http.StatusOK && !resSet.Boolean) {
-		return fmt.Errorf("Graph '%s' does not exist on '%s", store.uri, store.namespace)
+		return store.wrapErr("Drop", nil, sparqlReq, fmt.Errorf("Graph '%s' does not exist on '%s", store.uri, store.namespace))
 	}
 	if code != http.StatusOK {
-		return fmt.Errorf("Failed to query for existence of '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code)
+		return store.wrapErr("Drop", nil, sparqlReq, fmt.Errorf("Failed to query for existence of '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code))
 	}
 
 	// Drop graph
@@ -309,13 +283,13 @@ func (store *BlazegraphStore) Drop() error {
 	code, err = store.endpoint.DoSparqlUpdate(store.namespace, sparqlReq)
 	// Check response status
 	if err != nil {
-		return err
+		return store.wrapErr("Drop", nil, sparqlReq, err)
 	}
 	if code == http.StatusNotFound {
-		return fmt.Errorf("Namespace '%s' does not exist (HTTP %d)", store.namespace, code)
+		return store.wrapErr("Drop", nil, sparqlReq, fmt.Errorf("Namespace '%s' does not exist (HTTP %d)", store.namespace, code))
 	}
 	if code != http.StatusOK {
-		return fmt.Errorf("Failed to delete graph '%s' on '%s' (HTTP %d)", store.uri, store.namespace, code)
+		return store.wrapErr("Drop", nil, sparqlReq, fmt.Errorf("Failed to delete graph '%s' on '%s' (HTTP %d)", store.uri, store.namespace, code))
 	}
 	store.uri = ""
 	store.namespace = ""
@@ -325,71 +299,29 @@ func (store *BlazegraphStore) Drop() error {
 
 // SerializeToTurtle writes the entire store into the writer in Turtle (TTL) format. If pretty is set to true, the TTL is pretty printed.
 func (store *BlazegraphStore) SerializeToTurtle(w io.Writer, pretty bool) error {
-	// Compile SPARQL construct query
-	sparqlReq := fmt.Sprintf("CONSTRUCT { ?s ?p ?o } FROM <%s> WHERE {  ?s ?p ?o . }", store.uri)
-	ttlBytes, code, err := store.endpoint.DoSparqlTurtleQuery(store.namespace, sparqlReq)
-	// Check response status
-	if err != nil {
-		return err
-	}
-	if code == http.StatusNotFound {
-		return fmt.Errorf("Namspace '%s' does not exist (HTTP %d)", store.namespace, http.StatusNotFound)
-	}
-	if code != http.StatusOK {
-		return fmt.Errorf("Failed to query for graph '%s' (HTTP %d)", store.uri, code)
-	}
-
-	// Write out returned TTL if we do not need to prettify it
-	if !pretty {
-		_, err := w.Write(ttlBytes)
-		return err
-	}
-
-	// Setup base prefix map
-	prefixMap := map[string]string{
-		"":     store.uri + "#",
-		"rdf":  "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
-		"rdfs": "http://www.w3.org/2000/01/rdf-schema#",
-		"owl":  "http://www.w3.org/2002/07/owl#",
-		"xsd":  "http://www.w3.org/2001/XMLSchema#",
-	}
-	// Find all imports
-	const OWLImports string = "http://www.w3.org/2002/07/owl#imports"
-	trps, err := store.GetAllMatches(NewResourceTerm(store.uri).String(), NewResourceTerm(OWLImports).String(), "")
-	if err != nil {
-		return err
-	}
-	importURIs := []string{}
-	for _, trp := range trps {
-		importURIs = append(importURIs, trp.Object.Value())
-	}
-	// Add imports to prefix map
-	for _, importURI := range importURIs {
-		abbr := importURI[strings.LastIndex(importURI, "/")+1:]
-		prefixMap[abbr] = importURI + "#"
-	}
-
-	// Convert TTL to string
-	ttlContent := string(ttlBytes)
-
-	// Setup Prefix block
-	ttlPrefixes := ""
-	for abbr, prefix := range prefixMap {
-		// Setup prefix entry
-		ttlPrefixes = fmt.Sprintf("%s@prefix %s: <%s> .\n", ttlPrefixes, abbr, prefix)
-		// Apply prefixes
-		var re = regexp.MustCompile(fmt.Sprintf(`\<%s(.+?)\>`, prefix))
-		ttlContent = re.ReplaceAllString(ttlContent, fmt.Sprintf(`%s:$1`, abbr))
-	}
-	// Pretty format triples
-	ttlContent = strings.Replace(ttlContent, " .", " .\n\n", -1)
-
-	// Append prefix block and base path
-	ttlContent = fmt.Sprintf("%s@base <%s> .\n\n%s", ttlPrefixes, store.uri, ttlContent)
+	return store.SerializeToTurtleWithOptions(w, pretty)
+}
 
-	// Write result
-	_, err = io.WriteString(w, ttlContent)
-	return err
+// SerializeToTurtleWithOptions writes the entire store into the writer in Turtle (TTL) format (see `SerializeToTurtle`). The
+// serialization can be configured with serialize options, e.g. to control the prefixes applied when pretty printing.
+func (store *BlazegraphStore) SerializeToTurtleWithOptions(w io.Writer, pretty bool, opts ...SerializeOption) error {
+	return newSerializeOptions(store.options.serializeOptions(opts)).writeTurtle(w, store, pretty, func() (string, error) {
+		// Compile SPARQL construct query
+		sparqlReq := fmt.Sprintf("CONSTRUCT { ?s ?p ?o } FROM <%s> WHERE {  ?s ?p ?o . }", store.uri)
+		ttlBytes, code, err := store.endpoint.DoSparqlTurtleQuery(store.namespace, sparqlReq)
+		// Check response status
+		if err != nil {
+			return "", err
+		}
+		if code == http.StatusNotFound {
+			return "", fmt.Errorf("Namspace '%s' does not exist (HTTP %d)", store.namespace, http.StatusNotFound)
+		}
+		if code != http.StatusOK {
+			return "", fmt.Errorf("Failed to query for graph '%s' (HTTP %d)", store.uri, code)
+		}
+		// Convert the stand-in IRIs back into blank nodes
+		return blazegraphBlankNodeIRIs.ReplaceAllString(string(ttlBytes), "_:$1"), nil
+	})
 }
 
 // Size returns the total number of triples in the store.
@@ -399,13 +331,13 @@ func (store *BlazegraphStore) Size() (int, error) {
 	resSet, code, err := store.endpoint.DoSparqlJSONQuery(store.namespace, sparqlReq)
 	// Check response status
 	if err != nil {
-		return 0, err
+		return 0, store.wrapErr("Size", nil, sparqlReq, err)
 	}
 	if code == http.StatusNotFound {
-		return 0, fmt.Errorf("Namspace '%s' does not exist (HTTP %d)", store.namespace, http.StatusNotFound)
+		return 0, store.wrapErr("Size", nil, sparqlReq, fmt.Errorf("Namspace '%s' does not exist (HTTP %d)", store.namespace, http.StatusNotFound))
 	}
 	if code != http.StatusOK {
-		return 0, fmt.Errorf("Failed to execute SELECT query on namespace '%s' (HTTP %d)", store.namespace, code)
+		return 0, store.wrapErr("Size", nil, sparqlReq, fmt.Errorf("Failed to execute SELECT query on namespace '%s' (HTTP %d)", store.namespace, code))
 	}
 	return strconv.Atoi(resSet.Results.Bindings[0]["n"].Value)
 }
@@ -414,31 +346,127 @@ func (store *BlazegraphStore) Size() (int, error) {
 // * Helper functions *
 // ********************
 
+// getMatches retrieves all triples that match the pattern with a SELECT query. The modifiers (e.g. ` LIMIT 10`) are appended to the
+// query, errors are reported for the given operation.
+func (store *BlazegraphStore) getMatches(op, subj, pred, obj, modifiers string) ([]Triple, error) {
+	// Construct SPARQL query
+	sparqlReq := store.matchQuery(subj, pred, obj) + modifiers
+
+	// Execute SPARQL query
+	resSet, code, err := store.endpoint.DoSparqlJSONQuery(store.namespace, sparqlReq)
+	if err != nil {
+		return nil, store.wrapPatternErr(op, subj, pred, obj, sparqlReq, err)
+	}
+	if code != http.StatusOK {
+		return nil, store.wrapPatternErr(op, subj, pred, obj, sparqlReq, fmt.Errorf("Received unexpected status code from SPARQL query (HTTP %d): %s", code, sparqlReq))
+	}
+	// We got a result set, iterate through bindings and parse corresponding triples
+	resTrps := []Triple{}
+	for _, trpBinding := range resSet.Results.Bindings {
+		resTrps = append(resTrps, matchTriple(trpBinding, subj, pred, obj))
+	}
+	return resTrps, nil
+}
+
+// matchQuery returns the SPARQL query of the triples of the store that match the pattern.
+func (store *BlazegraphStore) matchQuery(subj, pred, obj string) string {
+	// Parse pattern to query parameters
+	s := "?s"
+	p := "?p"
+	o := "?o"
+	if subj != "" {
+		s = sparqlTerm(Term(subj))
+	}
+	if pred != "" {
+		p = sparqlTerm(Term(pred))
+	}
+	if obj != "" {
+		o = sparqlTerm(Term(obj))
+	}
+	return fmt.Sprintf(`SELECT ?s ?p ?o WHERE { GRAPH <%s> { %s %s %s. } }`, store.uri, s, p, o)
+}
+
+// matchTriple returns the triple of the binding of a match query (see `matchQuery`) for the pattern.
+func matchTriple(trpBinding map[string]JSONResultSetBinding, subj, pred, obj string) Triple {
+	sTerm := Term(subj)
+	if subj == "" {
+		sTerm = binding2Term(trpBinding["s"])
+	}
+	pTerm := Term(pred)
+	if pred == "" {
+		pTerm = binding2Term(trpBinding["p"])
+	}
+	oTerm := Term(obj)
+	if obj == "" {
+		oTerm = binding2Term(trpBinding["o"])
+	}
+	return Triple{
+		Subject:   sTerm,
+		Predicate: pTerm,
+		Object:    oTerm,
+	}
+}
+
 func (store *BlazegraphStore) tripleExists(trp Triple) (bool, error) {
 	// Make query
-	sparqlReq := fmt.Sprintf("ASK WHERE { GRAPH <%s> { %s %s %s } }", store.uri, trp.Subject.String(), trp.Predicate.String(), trp.Object.String())
+	sparqlReq := fmt.Sprintf("ASK WHERE { GRAPH <%s> { %s } }", store.uri, sparqlTriple(trp))
 	resSet, code, err := store.endpoint.DoSparqlJSONQuery(store.namespace, sparqlReq)
 	// Check response status
 	if err != nil {
-		return false, err
+		return false, store.wrapErr("tripleExists", &trp, sparqlReq, err)
 	}
 	if code == http.StatusNotFound {
 		return false, nil
 	}
 	if code != http.StatusOK {
-		return false, fmt.Errorf("Failed to execute ASK query on namespace '%s' (HTTP %d)", store.namespace, code)
+		return false, store.wrapErr("tripleExists", &trp, sparqlReq, fmt.Errorf("Failed to execute ASK query on namespace '%s' (HTTP %d)", store.namespace, code))
 	}
 	return resSet.Boolean, nil
 }
 
+// blazegraphBlankNodePrefix is the prefix of the IRIs that stand in for blank nodes in Blazegraph. SPARQL cannot address stored blank
+// nodes by their label, so blank nodes are stored as these IRIs and converted back into blank nodes when they are read.
+const blazegraphBlankNodePrefix = "urn:ontograph:bnode:"
+
+// blazegraphBlankNodeIRIs matches the stand-in IRIs of blank nodes in Turtle data.
+var blazegraphBlankNodeIRIs = regexp.MustCompile(`<` + regexp.QuoteMeta(blazegraphBlankNodePrefix) + `([^>]+)>`)
+
+// sparqlTerm converts the term into its SPARQL representation. Blank nodes are replaced by their stand-in IRIs.
+func sparqlTerm(t Term) string {
+	if t.IsBlankNode() {
+		return NewResourceTerm(blazegraphBlankNodePrefix + t.Value()).String()
+	}
+	return t.String()
+}
+
+// sparqlTriple converts the triple into a SPARQL triple pattern.
+func sparqlTriple(trp Triple) string {
+	return fmt.Sprintf("%s %s %s .", sparqlTerm(trp.Subject), sparqlTerm(trp.Predicate), sparqlTerm(trp.Object))
+}
+
+// wrapErr wraps the error with the context of the store operation.
+func (store *BlazegraphStore) wrapErr(op string, trp *Triple, query string, err error) error {
+	return wrapStoreError(store, "blazegraph", op, trp, query, err)
+}
+
+// wrapPatternErr wraps the error with the context of the store operation on the triple pattern.
+func (store *BlazegraphStore) wrapPatternErr(op, subj, pred, obj, query string, err error) error {
+	return withStorePattern(store.wrapErr(op, nil, query, err), subj, pred, obj)
+}
+
 func binding2Term(binding JSONResultSetBinding) Term {
 	switch binding.Type {
 	case "uri":
+		if strings.HasPrefix(binding.Value, blazegraphBlankNodePrefix) {
+			return NewBlankNodeTerm(strings.TrimPrefix(binding.Value, blazegraphBlankNodePrefix))
+		}
 		return NewResourceTerm(binding.Value)
 	case "literal":
 		return NewLiteralTerm(binding.Value, binding.Lang, binding.DataType)
 	case "typed-literal":
 		return NewLiteralTerm(binding.Value, binding.Lang, binding.DataType)
+	case "bnode":
+		return Term("_:" + binding.Value)
 	default:
 		panic(fmt.Sprintf("Unknown JSON Result Set binding type '%s'", binding.Type))
 	}
@@ -163,7 +163,7 @@ var _ = Describe("BlazegraphStore", func() {
 					Expect(err).NotTo(HaveOccurred())
 					err = graph.AddTriple(*trp)
 					// Check error and make sure the store is unchanged
-					Expect(err).To(Equal(ErrTripleAlreadyExists))
+					Expect(err).To(MatchError(ErrTripleAlreadyExists))
 					trps, err := graph.GetAllTriples()
 					Expect(err).NotTo(HaveOccurred())
 					Expect(trps).To(ConsistOf(testTriples))
@@ -216,7 +216,7 @@ var _ = Describe("BlazegraphStore", func() {
 					Expect(err).NotTo(HaveOccurred())
 					err = graph.AddTriples([]Triple{*trp8, *trp9, *trp10})
 					// Check error and make sure the store is unchanged
-					Expect(err).To(Equal(ErrTripleAlreadyExists))
+					Expect(err).To(MatchError(ErrTripleAlreadyExists))
 					trps, err := graph.GetAllTriples()
 					Expect(err).NotTo(HaveOccurred())
 					Expect(trps).To(ConsistOf(testTriples))
@@ -273,7 +273,7 @@ var _ = Describe("BlazegraphStore", func() {
 					Expect(err).NotTo(HaveOccurred())
 					err = graph.DeleteTriple(*trp)
 					// Check error and make sure the store is unchanged
-					Expect(err).To(Equal(ErrTripleDoesNotExist))
+					Expect(err).To(MatchError(ErrTripleDoesNotExist))
 					trps, err := graph.GetAllTriples()
 					Expect(err).NotTo(HaveOccurred())
 					Expect(trps).To(ConsistOf(testTriples))
@@ -327,7 +327,7 @@ var _ = Describe("BlazegraphStore", func() {
 					Expect(err).NotTo(HaveOccurred())
 					err = graph.DeleteTriples([]Triple{*trp1, *trp4, *trp5})
 					// Check for error and that the store is left unchanged
-					Expect(err).To(Equal(ErrTripleDoesNotExist))
+					Expect(err).To(MatchError(ErrTripleDoesNotExist))
 					trps, err := graph.GetAllTriples()
 					Expect(err).NotTo(HaveOccurred())
 					Expect(trps).To(ConsistOf(testTriples))
@@ -394,9 +394,62 @@ var _ = Describe("BlazegraphStore", func() {
 		})
 	})
 
+	Describe("Serializing the graph store in sorted order", func() {
+		It("should generate the same TTL as the in-memory store", func() {
+			memGraph := NewMemoryStore(graphUri)
+			Expect(memGraph.AddTriples(testTriples)).To(Succeed())
+			var ttlContent, memTTLContent strings.Builder
+			Expect(graph.SerializeToTurtleWithOptions(&ttlContent, true, WithSortedOutput())).To(Succeed())
+			Expect(memGraph.SerializeToTurtleWithOptions(&memTTLContent, true, WithSortedOutput())).To(Succeed())
+			Expect(ttlContent.String()).To(Equal(memTTLContent.String()))
+		})
+	})
+
+	Describe("Storing blank nodes", func() {
+		It("should keep the labels of the blank nodes", func() {
+			trps := []Triple{
+				{Subject: NewResourceTerm(graphUri + "#a"), Predicate: NewResourceTerm(graphUri + "#rel-6"), Object: NewBlankNodeTerm("b1")},
+				{Subject: NewBlankNodeTerm("b1"), Predicate: NewResourceTerm(graphUri + "#rel-7"), Object: NewBlankNodeTerm("b2")},
+			}
+			Expect(graph.AddTriples(trps)).To(Succeed())
+			Expect(graph.GetAllMatches(NewBlankNodeTerm("b1").String(), "", "")).To(Equal([]Triple{trps[1]}))
+			Expect(graph.GetAllMatches("", "", NewBlankNodeTerm("b1").String())).To(Equal([]Triple{trps[0]}))
+			Expect(graph.DeleteTriple(trps[1])).To(Succeed())
+			Expect(graph.GetAllMatches(NewBlankNodeTerm("b1").String(), "", "")).To(BeEmpty())
+		})
+	})
+
 	Describe("Retrieving the size of the graph store", func() {
 		It("should return the expected number of triples", func() {
 			Expect(graph.Size()).To(Equal(len(testTriples)))
 		})
 	})
+
+	Describe("Retrieving property path targets", func() {
+		It("should return the targets of the translated path", func() {
+			targets, err := GetPathTargets(graph, NewResourceTerm(graphUri).String(), "<"+graphUri+"#rel-1>/<"+graphUri+"#rel-2>")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets).To(Equal([]Term{NewResourceTerm(graphUri + "#b")}))
+		})
+	})
+
+	Describe("Computing the transitive closure of a property", func() {
+		It("should return the asserted and the inferred triples", func() {
+			rel := NewResourceTerm(graphUri + "#rel-1")
+			Expect(graph.AddTriple(Triple{Subject: NewResourceTerm(graphUri + "#a"), Predicate: rel, Object: NewResourceTerm(graphUri + "#d")})).To(Succeed())
+			closure, err := TransitiveClosure(graph, graphUri+"#rel-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(closure).To(HaveLen(5))
+			Expect(closure).To(ContainElement(Triple{Subject: NewResourceTerm(graphUri), Predicate: rel, Object: NewResourceTerm(graphUri + "#d")}))
+		})
+	})
+
+	Describe("Retrieving filtered triples", func() {
+		It("should return the triples of all subjects that match the filter", func() {
+			filter := TripleFilter{}.OrWithObjectProperty(graphUri+"#rel-2", graphUri+"#b").OrWithDataPropertyContains(graphUri+"#rel-3", "it1")
+			trps, err := graph.GetFilteredTriples(filter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trps).To(ConsistOf(testTriples[3], testTriples[4], testTriples[5], testTriples[6]))
+		})
+	})
 })
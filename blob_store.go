@@ -0,0 +1,320 @@
+package ontograph
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// BlobURIPrefix is the URI prefix used for content-addressed blob references. The prefix is followed by the hex encoded SHA-256 hash of the blob content.
+const BlobURIPrefix string = "urn:sha256:"
+
+// BlobDatatypeProperty is the property that records the datatype of an externalized literal on its blob reference.
+const BlobDatatypeProperty string = "urn:ontograph:blobDatatype"
+
+// BlobLanguageProperty is the property that records the language tag of an externalized literal on its blob reference.
+const BlobLanguageProperty string = "urn:ontograph:blobLanguage"
+
+// BlobStore provides methods to store large binary payloads outside of a graph. Blobs are content-addressed by the hex encoded SHA-256 hash of their data.
+type BlobStore interface {
+	// Put should store the data read from the reader and return the hash of the content. Storing the same content twice should not error.
+	Put(r io.Reader) (string, error)
+	// Get should return a reader for the blob with the given hash. It should error with `ErrBlobNotFound` if the blob does not exist.
+	Get(hash string) (io.ReadCloser, error)
+	// Exists should return true if a blob with the given hash is stored.
+	Exists(hash string) (bool, error)
+	// Delete should remove the blob with the given hash. It should not error if the blob does not exist.
+	Delete(hash string) error
+}
+
+// NewBlobTerm creates a new resource term that references the blob with the given hash.
+func NewBlobTerm(hash string) Term {
+	return NewResourceTerm(BlobURIPrefix + hash)
+}
+
+// BlobHash returns the hash of the blob referenced by the term. If the term is not a blob reference, false is returned.
+func BlobHash(t Term) (string, bool) {
+	if !t.IsResource() || !strings.HasPrefix(t.Value(), BlobURIPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(t.Value(), BlobURIPrefix), true
+}
+
+// AddBlobTriple stores the data of the reader in the blob store and adds a triple to the graph that references the blob from the given subject and predicate. The reference triple is returned.
+func AddBlobTriple(graph GraphStore, blobs BlobStore, subj, pred Term, r io.Reader) (Triple, error) {
+	hash, err := blobs.Put(r)
+	if err != nil {
+		return Triple{}, err
+	}
+	trp := Triple{
+		Subject:   subj,
+		Predicate: pred,
+		Object:    NewBlobTerm(hash),
+	}
+	if err := graph.AddTripleUnchecked(trp); err != nil {
+		return Triple{}, err
+	}
+	return trp, nil
+}
+
+// ExternalizeLiteral moves the literal object of the triple into the blob store if its lexical value exceeds the given threshold in bytes.
+// The returned triple references the blob instead of the literal. The datatype and language of the literal are added to the graph as
+// triples on the blob reference (see `BlobDatatypeProperty` and `BlobLanguageProperty`), so `InternalizeLiteral` can restore the literal.
+// Triples with resources or small literals are returned unchanged, as are literals whose content is already referenced with another
+// datatype or language.
+func ExternalizeLiteral(graph GraphStore, blobs BlobStore, trp Triple, threshold int) (Triple, error) {
+	if !trp.Object.IsLiteral() {
+		return trp, nil
+	}
+	value := unescapeLiteral(trp.Object.Value())
+	if len(value) <= threshold {
+		return trp, nil
+	}
+	hash, err := blobs.Put(strings.NewReader(value))
+	if err != nil {
+		return trp, err
+	}
+	ref := NewBlobTerm(hash)
+	// Blobs are shared by all literals with the same content, so they must agree on datatype and language
+	literalTrps := blobLiteralTriples(ref, trp.Object)
+	current, err := graph.GetAllMatches(ref.String(), "", "")
+	if err != nil {
+		return trp, err
+	}
+	if !DiffTriples(literalTrps, current).IsEmpty() {
+		referenced, err := graph.GetFirstMatch("", "", ref.String())
+		if err != nil {
+			return trp, err
+		}
+		if len(current) > 0 || referenced != nil {
+			return trp, nil
+		}
+		if err := graph.AddTriplesUnchecked(literalTrps); err != nil {
+			return trp, err
+		}
+	}
+	trp.Object = ref
+	return trp, nil
+}
+
+// InternalizeLiteral restores the literal object of a triple that was externalized with `ExternalizeLiteral`. The literal is read from
+// the blob store and gets the datatype and language recorded in the graph. Triples that do not reference a blob are returned unchanged.
+func InternalizeLiteral(graph GraphStore, blobs BlobStore, trp Triple) (Triple, error) {
+	if _, ok := BlobHash(trp.Object); !ok {
+		return trp, nil
+	}
+	r, err := ResolveBlob(blobs, trp.Object)
+	if err != nil {
+		return trp, err
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return trp, err
+	}
+	literalTrps, err := graph.GetAllMatches(trp.Object.String(), "", "")
+	if err != nil {
+		return trp, err
+	}
+	language, datatype := "", ""
+	for _, literalTrp := range literalTrps {
+		switch literalTrp.Predicate {
+		case NewResourceTerm(BlobLanguageProperty):
+			language = literalTrp.Object.Value()
+		case NewResourceTerm(BlobDatatypeProperty):
+			datatype = literalTrp.Object.Value()
+		}
+	}
+	trp.Object = NewLiteralTerm(escapeLiteral(string(data)), language, datatype)
+	return trp, nil
+}
+
+// ResolveBlob returns a reader for the blob referenced by the given term. If the term is not a blob reference, it errors with `ErrNotABlobReference`.
+func ResolveBlob(blobs BlobStore, t Term) (io.ReadCloser, error) {
+	hash, ok := BlobHash(t)
+	if !ok {
+		return nil, ErrNotABlobReference
+	}
+	return blobs.Get(hash)
+}
+
+// *******************
+// * MemoryBlobStore *
+// *******************
+
+// MemoryBlobStore is an in-memory implementation of the blob store. It is mostly useful for testing and small payloads.
+type MemoryBlobStore struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+// NewMemoryBlobStore creates a new in-memory blob store.
+func NewMemoryBlobStore() *MemoryBlobStore {
+	return &MemoryBlobStore{
+		blobs: map[string][]byte{},
+	}
+}
+
+// Put stores the data read from the reader and returns the hash of the content.
+func (store *MemoryBlobStore) Put(r io.Reader) (string, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	store.mu.Lock()
+	store.blobs[hash] = data
+	store.mu.Unlock()
+	return hash, nil
+}
+
+// Get returns a reader for the blob with the given hash. If the blob does not exist, it errors with `ErrBlobNotFound`.
+func (store *MemoryBlobStore) Get(hash string) (io.ReadCloser, error) {
+	store.mu.RLock()
+	data, ok := store.blobs[hash]
+	store.mu.RUnlock()
+	if !ok {
+		return nil, ErrBlobNotFound
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+// Exists returns true if a blob with the given hash is stored.
+func (store *MemoryBlobStore) Exists(hash string) (bool, error) {
+	store.mu.RLock()
+	_, ok := store.blobs[hash]
+	store.mu.RUnlock()
+	return ok, nil
+}
+
+// Delete removes the blob with the given hash. It does not error if the blob does not exist.
+func (store *MemoryBlobStore) Delete(hash string) error {
+	store.mu.Lock()
+	delete(store.blobs, hash)
+	store.mu.Unlock()
+	return nil
+}
+
+// *****************
+// * FileBlobStore *
+// *****************
+
+// FileBlobStore is a file system implementation of the blob store. Each blob is stored as a single file named by its hash within the base directory.
+type FileBlobStore struct {
+	dir string
+}
+
+// NewFileBlobStore creates a new blob store in the given directory. The directory is created if it does not exist.
+func NewFileBlobStore(dir string) (*FileBlobStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileBlobStore{dir: dir}, nil
+}
+
+// Put stores the data read from the reader and returns the hash of the content. The data is streamed into a temporary file first, so the payload is never held in memory completely.
+func (store *FileBlobStore) Put(r io.Reader) (string, error) {
+	tmp, err := ioutil.TempFile(store.dir, "blob-*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	// Hash content while writing it to the temporary file
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), r); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	hash := hex.EncodeToString(h.Sum(nil))
+	// Move temporary file to its content address
+	if err := os.Rename(tmp.Name(), store.path(hash)); err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// Get returns a reader for the blob with the given hash. If the blob does not exist, it errors with `ErrBlobNotFound`.
+func (store *FileBlobStore) Get(hash string) (io.ReadCloser, error) {
+	if !isBlobHash(hash) {
+		return nil, ErrBlobNotFound
+	}
+	f, err := os.Open(store.path(hash))
+	if os.IsNotExist(err) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Exists returns true if a blob with the given hash is stored.
+func (store *FileBlobStore) Exists(hash string) (bool, error) {
+	if !isBlobHash(hash) {
+		return false, nil
+	}
+	_, err := os.Stat(store.path(hash))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Delete removes the blob with the given hash. It does not error if the blob does not exist.
+func (store *FileBlobStore) Delete(hash string) error {
+	if !isBlobHash(hash) {
+		return nil
+	}
+	err := os.Remove(store.path(hash))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// path returns the file path of the blob with the given hash.
+func (store *FileBlobStore) path(hash string) string {
+	return filepath.Join(store.dir, hash)
+}
+
+// blobLiteralTriples returns the triples that record the datatype and language of the literal on the blob reference.
+func blobLiteralTriples(ref, literal Term) []Triple {
+	trps := []Triple{}
+	if literal.Language() != "" {
+		trps = append(trps, Triple{Subject: ref, Predicate: NewResourceTerm(BlobLanguageProperty), Object: NewLiteralTerm(literal.Language(), "", "")})
+	}
+	if literal.Datatype() != "" {
+		trps = append(trps, Triple{Subject: ref, Predicate: NewResourceTerm(BlobDatatypeProperty), Object: NewResourceTerm(literal.Datatype())})
+	}
+	return trps
+}
+
+// isBlobHash checks if the given string is a valid hex encoded SHA-256 hash (this also guards against path traversal).
+func isBlobHash(hash string) bool {
+	if len(hash) != 2*sha256.Size {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrBlobNotFound is raised when a blob does not exist in the blob store.
+var ErrBlobNotFound error = errors.New("The requested blob does not exist")
+
+// ErrNotABlobReference is raised when a term is resolved as blob, but does not reference one.
+var ErrNotABlobReference error = errors.New("The term is not a blob reference")
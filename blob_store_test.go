@@ -0,0 +1,148 @@
+package ontograph_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/lithammer/shortuuid/v3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("BlobStore", func() {
+	var tmpDir string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "ontograph-blobs")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	testBlobStore := func(newStore func() BlobStore) {
+		It("should store and retrieve blobs by their content hash", func() {
+			blobs := newStore()
+			hash, err := blobs.Put(strings.NewReader("some large payload"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hash).To(HaveLen(64))
+			By("returning the same hash for the same content")
+			hash2, err := blobs.Put(strings.NewReader("some large payload"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hash2).To(Equal(hash))
+			By("returning the stored content")
+			r, err := blobs.Get(hash)
+			Expect(err).NotTo(HaveOccurred())
+			data, err := ioutil.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Close()).To(Succeed())
+			Expect(string(data)).To(Equal("some large payload"))
+			Expect(blobs.Exists(hash)).To(BeTrue())
+		})
+		It("should delete blobs", func() {
+			blobs := newStore()
+			hash, err := blobs.Put(strings.NewReader("to be deleted"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(blobs.Delete(hash)).To(Succeed())
+			Expect(blobs.Exists(hash)).To(BeFalse())
+			_, err = blobs.Get(hash)
+			Expect(err).To(Equal(ErrBlobNotFound))
+			By("not erroring when deleting a missing blob")
+			Expect(blobs.Delete(hash)).To(Succeed())
+		})
+	}
+
+	Describe("The in-memory blob store", func() {
+		testBlobStore(func() BlobStore { return NewMemoryBlobStore() })
+	})
+
+	Describe("The file blob store", func() {
+		testBlobStore(func() BlobStore {
+			blobs, err := NewFileBlobStore(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			return blobs
+		})
+	})
+
+	Describe("Externalizing literals", func() {
+		var graphUri string
+		var graph *MemoryStore
+		var blobs *MemoryBlobStore
+
+		BeforeEach(func() {
+			graphUri = fmt.Sprintf("https://www.ontograph.com/test-%s", shortuuid.New())
+			graph = NewMemoryStore(graphUri)
+			blobs = NewMemoryBlobStore()
+		})
+
+		It("should replace large literals with a blob reference", func() {
+			trp := Triple{
+				Subject:   NewResourceTerm(graphUri + "#a"),
+				Predicate: NewResourceTerm(graphUri + "#payload"),
+				Object:    NewLiteralTerm(strings.Repeat("x", 64), "", ""),
+			}
+			extTrp, err := ExternalizeLiteral(graph, blobs, trp, 32)
+			Expect(err).NotTo(HaveOccurred())
+			hash, ok := BlobHash(extTrp.Object)
+			Expect(ok).To(BeTrue())
+			r, err := ResolveBlob(blobs, extTrp.Object)
+			Expect(err).NotTo(HaveOccurred())
+			data, err := ioutil.ReadAll(r)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(strings.Repeat("x", 64)))
+			Expect(blobs.Exists(hash)).To(BeTrue())
+		})
+		It("should leave small literals unchanged", func() {
+			trp := Triple{
+				Subject:   NewResourceTerm(graphUri + "#a"),
+				Predicate: NewResourceTerm(graphUri + "#payload"),
+				Object:    NewLiteralTerm("small", "", ""),
+			}
+			extTrp, err := ExternalizeLiteral(graph, blobs, trp, 32)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(extTrp).To(Equal(trp))
+		})
+		It("should restore the original literal", func() {
+			for obj, content := range map[Term]string{
+				NewLiteralTerm(strings.Repeat(`say \"hi\"\n`, 8), "", ""): strings.Repeat("say \"hi\"\n", 8),
+				NewLiteralTerm(strings.Repeat("Hallo ", 8), "de", ""):     strings.Repeat("Hallo ", 8),
+				NewLiteralTerm(strings.Repeat("7", 40), "", XSDInteger):   strings.Repeat("7", 40),
+			} {
+				trp := Triple{Subject: NewResourceTerm(graphUri + "#a"), Predicate: NewResourceTerm(graphUri + "#payload"), Object: obj}
+				extTrp, err := ExternalizeLiteral(graph, blobs, trp, 32)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(graph.AddTriple(extTrp)).To(Succeed())
+				r, err := ResolveBlob(blobs, extTrp.Object)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.ReadAll(r)).To(Equal([]byte(content)))
+				Expect(InternalizeLiteral(graph, blobs, extTrp)).To(Equal(trp))
+			}
+		})
+		It("should keep literals whose content is referenced with another language", func() {
+			value := strings.Repeat("x", 64)
+			trp := Triple{Subject: NewResourceTerm(graphUri + "#a"), Predicate: NewResourceTerm(graphUri + "#payload"), Object: NewLiteralTerm(value, "en", "")}
+			extTrp, err := ExternalizeLiteral(graph, blobs, trp, 32)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(graph.AddTriple(extTrp)).To(Succeed())
+			trp.Object = NewLiteralTerm(value, "", "")
+			Expect(ExternalizeLiteral(graph, blobs, trp, 32)).To(Equal(trp))
+			trp.Object = NewLiteralTerm(value, "en", "")
+			Expect(ExternalizeLiteral(graph, blobs, trp, 32)).To(Equal(extTrp))
+		})
+		It("should add a reference triple to the graph", func() {
+			trp, err := AddBlobTriple(graph, blobs, NewResourceTerm(graphUri+"#a"), NewResourceTerm(graphUri+"#payload"), strings.NewReader("data"))
+			Expect(err).NotTo(HaveOccurred())
+			trps, err := graph.GetAllTriples()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trps).To(ConsistOf(trp))
+			_, err = ResolveBlob(blobs, NewResourceTerm(graphUri+"#a"))
+			Expect(err).To(Equal(ErrNotABlobReference))
+		})
+	})
+})
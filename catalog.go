@@ -0,0 +1,145 @@
+package ontograph
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Catalog maps ontology URIs to local files, like the XML catalogs of Protégé (`catalog-v001.xml`), so that imports can be resolved
+// in offline or CI environments. It is an import loader (see `OntologyGraph.ResolveImports`). With a cache, ontologies that are not
+// mapped are downloaded once and served from the cache directory afterwards.
+type Catalog struct {
+	mu       sync.RWMutex
+	files    map[string]string
+	cacheDir string
+	download ImportLoader
+}
+
+// A CatalogOption configures a catalog (see `NewCatalog`).
+type CatalogOption func(*Catalog)
+
+// WithCatalogCache caches the ontologies that are not mapped to local files in the directory. Missing ontologies are loaded with the
+// given loader (e.g. `NewHTTPImportLoader`), written to the directory in Turtle format and mapped in the catalog. The loader may be nil
+// to only serve ontologies that are already cached, e.g. on CI machines without network access.
+func WithCatalogCache(dir string, download ImportLoader) CatalogOption {
+	return func(c *Catalog) {
+		c.cacheDir = dir
+		c.download = download
+	}
+}
+
+// NewCatalog creates a new empty catalog.
+func NewCatalog(opts ...CatalogOption) *Catalog {
+	c := &Catalog{files: map[string]string{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ParseCatalogFile creates a new catalog from the XML catalog file at the given path (e.g. `catalog-v001.xml` of Protégé). The `uri`
+// entries (also within groups) are added to the catalog. Relative file paths are resolved against the directory of the catalog file.
+func ParseCatalogFile(path string, opts ...CatalogOption) (*Catalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var doc xmlCatalog
+	if err := xml.NewDecoder(f).Decode(&doc); err != nil {
+		return nil, err
+	}
+	c := NewCatalog(opts...)
+	for _, entry := range doc.entries() {
+		file := entry.URI
+		if u, err := url.Parse(file); err == nil && u.Scheme == "file" {
+			file = u.Path
+		}
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(path), file)
+		}
+		c.Add(entry.Name, file)
+	}
+	return c, nil
+}
+
+// Add maps the ontology URI to the file at the given path. An existing mapping is replaced.
+func (c *Catalog) Add(uri, path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.files[uri] = path
+}
+
+// Lookup returns the path of the file the ontology URI is mapped to. Ontologies in the cache directory are found as well. Returns
+// false if the ontology is not known to the catalog.
+func (c *Catalog) Lookup(uri string) (string, bool) {
+	c.mu.RLock()
+	path, ok := c.files[uri]
+	c.mu.RUnlock()
+	if ok || c.cacheDir == "" {
+		return path, ok
+	}
+	path = filepath.Join(c.cacheDir, cacheFileName(uri))
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// LoadImport parses the file the ontology URI is mapped to. If the ontology is not known, it is downloaded into the cache (see
+// `WithCatalogCache`). Errors with `ErrImportNotFound` if the ontology is neither mapped nor cached and cannot be downloaded.
+func (c *Catalog) LoadImport(ctx context.Context, uri string) (GraphStore, error) {
+	if path, ok := c.Lookup(uri); ok {
+		return ParseFile(path)
+	}
+	if c.cacheDir == "" || c.download == nil {
+		return nil, ErrImportNotFound
+	}
+	store, err := c.download.LoadImport(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(c.cacheDir, 0755); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(c.cacheDir, cacheFileName(uri))
+	if err := SerializeToFile(store, path, false); err != nil {
+		return nil, err
+	}
+	c.Add(uri, path)
+	return store, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// xmlCatalog is the structure of an OASIS XML catalog as written by Protégé.
+type xmlCatalog struct {
+	URIs   []xmlCatalogURI `xml:"uri"`
+	Groups []xmlCatalog    `xml:"group"`
+}
+
+// xmlCatalogURI maps the ontology URI `name` to the file `uri`.
+type xmlCatalogURI struct {
+	Name string `xml:"name,attr"`
+	URI  string `xml:"uri,attr"`
+}
+
+// entries returns the URI entries of the catalog and all its groups.
+func (doc xmlCatalog) entries() []xmlCatalogURI {
+	entries := append([]xmlCatalogURI{}, doc.URIs...)
+	for _, group := range doc.Groups {
+		entries = append(entries, group.entries()...)
+	}
+	return entries
+}
+
+// cacheFileName returns the name of the cache file for the ontology URI.
+func cacheFileName(uri string) string {
+	return url.QueryEscape(uri) + ".ttl"
+}
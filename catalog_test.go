@@ -0,0 +1,79 @@
+package ontograph_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Catalog", func() {
+	const coreTTL = `@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/core> a owl:Ontology .
+<http://example.com/core#Agent> a owl:Class .
+`
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "ontograph")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.MkdirAll(filepath.Join(dir, "imports"), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(dir, "imports", "core.ttl"), []byte(coreTTL), 0644)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should parse Protégé XML catalogs", func() {
+		Expect(ioutil.WriteFile(filepath.Join(dir, "catalog-v001.xml"), []byte(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<catalog prefer="public" xmlns="urn:oasis:names:tc:entity:xmlns:xml:catalog">
+    <group id="Folder Repository, directory=, recursive=true, Auto-Update=true, version=2" prefer="public" xml:base="">
+        <uri id="Automatically generated entry" name="http://example.com/core" uri="imports/core.ttl"/>
+    </group>
+    <uri name="http://example.com/other" uri="/abs/other.owl"/>
+</catalog>`), 0644)).To(Succeed())
+		catalog, err := ParseCatalogFile(filepath.Join(dir, "catalog-v001.xml"))
+		Expect(err).NotTo(HaveOccurred())
+		path, ok := catalog.Lookup("http://example.com/core")
+		Expect(ok).To(BeTrue())
+		Expect(path).To(Equal(filepath.Join(dir, "imports", "core.ttl")))
+		path, ok = catalog.Lookup("http://example.com/other")
+		Expect(ok).To(BeTrue())
+		Expect(path).To(Equal("/abs/other.owl"))
+		store, err := catalog.LoadImport(context.Background(), "http://example.com/core")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.GetURI()).To(Equal("http://example.com/core"))
+		_, err = catalog.LoadImport(context.Background(), "http://example.com/unknown")
+		Expect(errors.Is(err, ErrImportNotFound)).To(BeTrue())
+	})
+
+	It("should cache downloaded ontologies", func() {
+		downloads := 0
+		download := ImportLoaderFunc(func(ctx context.Context, uri string) (GraphStore, error) {
+			downloads++
+			return ParseFromTurtle(strings.NewReader(coreTTL))
+		})
+		cacheDir := filepath.Join(dir, "cache")
+		catalog := NewCatalog(WithCatalogCache(cacheDir, download))
+		for i := 0; i < 2; i++ {
+			store, err := catalog.LoadImport(context.Background(), "http://example.com/core")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store.Size()).To(Equal(2))
+		}
+		Expect(downloads).To(Equal(1))
+		// A new catalog without download finds the cached ontology offline
+		offline := NewCatalog(WithCatalogCache(cacheDir, nil))
+		store, err := offline.LoadImport(context.Background(), "http://example.com/core")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.GetURI()).To(Equal("http://example.com/core"))
+	})
+})
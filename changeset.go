@@ -0,0 +1,219 @@
+package ontograph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A Changeset is a recorded set of changes to a graph store that can be saved (see `WriteRDFPatch`), reviewed and replayed on another
+// store (see `Apply`). Deleted triples are applied before added triples. A triple is never both deleted and added.
+type Changeset struct {
+	Deleted []Triple
+	Added   []Triple
+}
+
+// IsEmpty returns true if the changeset contains no changes.
+func (cs *Changeset) IsEmpty() bool {
+	return len(cs.Deleted) == 0 && len(cs.Added) == 0
+}
+
+// Record adds the change of the event to the changeset, so that the changes of a store can be recorded by subscribing to it (see
+// `ObservableStore.Subscribe`). Adding a previously deleted triple or deleting a previously added triple replaces the earlier change.
+func (cs *Changeset) Record(event ChangeEvent) {
+	for _, trp := range event.Deleted {
+		cs.Added = removeTriple(cs.Added, trp)
+		cs.Deleted = append(removeTriple(cs.Deleted, trp), trp)
+	}
+	for _, trp := range event.Added {
+		cs.Deleted = removeTriple(cs.Deleted, trp)
+		cs.Added = append(removeTriple(cs.Added, trp), trp)
+	}
+}
+
+// Apply replays the changes on the store within a single transaction (see `Begin`). The changes are applied unchecked, so deleted
+// triples that do not exist and added triples that already exist are ignored.
+func (cs *Changeset) Apply(store GraphStore) error {
+	tx, err := Begin(store)
+	if err != nil {
+		return err
+	}
+	if err := tx.DeleteTriplesUnchecked(cs.Deleted); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.AddTriplesUnchecked(cs.Added); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// SparqlUpdate returns the changes as SPARQL update on the named graph with the given URI. Blank nodes are written as the stand-in IRIs
+// of the Blazegraph store, since SPARQL cannot address stored blank nodes by their label.
+func (cs *Changeset) SparqlUpdate(graphURI string) string {
+	return sparqlDataUpdate(graphURI, cs.Deleted, cs.Added)
+}
+
+// WriteRDFPatch writes the changes as a single transaction in RDF Patch format (https://afs.github.io/rdf-patch/) into the writer.
+func (cs *Changeset) WriteRDFPatch(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("TX .\n")
+	for _, trp := range cs.Deleted {
+		bw.WriteString("D " + nTriplesLine(trp))
+	}
+	for _, trp := range cs.Added {
+		bw.WriteString("A " + nTriplesLine(trp))
+	}
+	bw.WriteString("TC .\n")
+	return bw.Flush()
+}
+
+// ReadRDFPatch reads a changeset in RDF Patch format from the reader. Header and prefix rows are skipped, aborted transactions are
+// discarded. Terms must be given in N-Triples syntax and rows must not name a graph. Errors with `ErrInvalidPatch` on malformed rows.
+func ReadRDFPatch(r io.Reader) (*Changeset, error) {
+	cs := &Changeset{}
+	committed := &Changeset{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		row := strings.TrimSpace(scanner.Text())
+		if row == "" || strings.HasPrefix(row, "#") {
+			continue
+		}
+		code, rest := row, ""
+		if i := strings.IndexAny(row, " \t"); i >= 0 {
+			code, rest = row[:i], strings.TrimSpace(row[i:])
+		}
+		switch code {
+		case "H", "PA", "PD", "TX":
+			continue
+		case "TC":
+			committed.Record(ChangeEvent{Deleted: cs.Deleted, Added: cs.Added})
+			cs = &Changeset{}
+		case "TA":
+			cs = &Changeset{}
+		case "A", "D":
+			trp, err := parsePatchTriple(rest)
+			if err != nil {
+				return nil, fmt.Errorf("%w (line %d): %v", ErrInvalidPatch, line, err)
+			}
+			if code == "A" {
+				cs.Record(ChangeEvent{Added: []Triple{trp}})
+			} else {
+				cs.Record(ChangeEvent{Deleted: []Triple{trp}})
+			}
+		default:
+			return nil, fmt.Errorf("%w (line %d): Unknown row '%s'", ErrInvalidPatch, line, code)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	// Changes after the last transaction are treated as committed
+	committed.Record(ChangeEvent{Deleted: cs.Deleted, Added: cs.Added})
+	return committed, nil
+}
+
+// ProposeChanges runs fn on a copy of the ontology graph whose changes are recorded instead of applied, e.g. to propose an edit for
+// review. Returns the net changes, i.e. triples that are re-added or deleted without existing are left out.
+func (ont *OntologyGraph) ProposeChanges(fn func(tx *OntologyGraph) error) (*Changeset, error) {
+	tx := newBufferedTx(ont.graph, nil)
+	defer tx.Rollback()
+	if err := fn(ont.detached(tx)); err != nil {
+		return nil, err
+	}
+	cs := &Changeset{Deleted: []Triple{}, Added: []Triple{}}
+	for _, trp := range sortedTripleSet(tx.deleted) {
+		found, err := ont.graph.GetFirstMatch(trp.Subject.String(), trp.Predicate.String(), trp.Object.String())
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			cs.Deleted = append(cs.Deleted, trp)
+		}
+	}
+	for _, trp := range sortedTripleSet(tx.added) {
+		found, err := ont.graph.GetFirstMatch(trp.Subject.String(), trp.Predicate.String(), trp.Object.String())
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			cs.Added = append(cs.Added, trp)
+		}
+	}
+	return cs, nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrInvalidPatch is raised when RDF Patch data cannot be read.
+var ErrInvalidPatch error = errors.New("The patch is invalid")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// removeTriple removes all occurrences of the triple from the list.
+func removeTriple(trps []Triple, trp Triple) []Triple {
+	kept := trps[:0]
+	for _, other := range trps {
+		if other != trp {
+			kept = append(kept, other)
+		}
+	}
+	return kept
+}
+
+// sparqlDataUpdate returns a SPARQL update that deletes and adds the triples on the named graph. The update is empty if there are no
+// triples.
+func sparqlDataUpdate(graphURI string, deleted, added []Triple) string {
+	ops := []string{}
+	for _, change := range []struct {
+		op   string
+		trps []Triple
+	}{{"DELETE", deleted}, {"INSERT", added}} {
+		if len(change.trps) == 0 {
+			continue
+		}
+		var data strings.Builder
+		for _, trp := range change.trps {
+			data.WriteString(sparqlTriple(trp))
+		}
+		ops = append(ops, fmt.Sprintf("%s DATA { GRAPH <%s> { %s } }", change.op, graphURI, data.String()))
+	}
+	return strings.Join(ops, " ;\n")
+}
+
+// parsePatchTriple parses the subject, predicate and object of a patch row in N-Triples syntax, followed by the terminating dot. Like
+// the terms of triples, literals are not unescaped, so the object is taken as is up to the terminating dot.
+func parsePatchTriple(row string) (Triple, error) {
+	terms := []Term{}
+	for len(terms) < 2 {
+		row = strings.TrimLeft(row, " \t")
+		end := -1
+		if strings.HasPrefix(row, "<") {
+			end = strings.IndexByte(row, '>') + 1
+		} else if strings.HasPrefix(row, "_:") {
+			end = strings.IndexAny(row, " \t")
+		}
+		if end <= 0 {
+			return Triple{}, fmt.Errorf("%w: '%s'", ErrMalformedTerm, row)
+		}
+		terms = append(terms, Term(row[:end]))
+		row = row[end:]
+	}
+	row = strings.TrimSpace(row)
+	if !strings.HasSuffix(row, ".") {
+		return Triple{}, fmt.Errorf("Expected '.' at the end of the row")
+	}
+	trp := Triple{Subject: terms[0], Predicate: terms[1], Object: Term(strings.TrimSpace(strings.TrimSuffix(row, ".")))}
+	if issues := checkTriple(trp, "", func(string) bool { return true }); len(issues) > 0 {
+		return Triple{}, issues[0]
+	}
+	return trp, nil
+}
@@ -0,0 +1,89 @@
+package ontograph_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Changesets", func() {
+	const testUri = "http://example.com/onto"
+	labelTrp := func(name, label string) Triple {
+		return Triple{Subject: NewResourceTerm(testUri + "#" + name), Predicate: NewResourceTerm(RDFSLabel), Object: NewLiteralTerm(label, "en", "")}
+	}
+	var store *MemoryStore
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		var err error
+		store = NewMemoryStore(testUri)
+		ont, err = InitOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#A", Label: map[string]string{"en": "A"}})).To(Succeed())
+	})
+
+	It("should propose changes without applying them and replay them on another store", func() {
+		cs, err := ont.ProposeChanges(func(tx *OntologyGraph) error {
+			return tx.UpsertResource(&OntologyClass{URI: testUri + "#A", Label: map[string]string{"en": "New A"}})
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cs.Deleted).To(Equal([]Triple{labelTrp("A", "A")}))
+		Expect(cs.Added).To(Equal([]Triple{labelTrp("A", "New A")}))
+		class, err := ont.GetClass(testUri + "#A")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.Label["en"]).To(Equal("A"))
+
+		var buf bytes.Buffer
+		Expect(cs.WriteRDFPatch(&buf)).To(Succeed())
+		read, err := ReadRDFPatch(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(read).To(Equal(cs))
+
+		Expect(read.Apply(store)).To(Succeed())
+		class, err = ont.GetClass(testUri + "#A")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.Label["en"]).To(Equal("New A"))
+	})
+
+	It("should record the changes of an observable store", func() {
+		observed := NewObservableStore(NewMemoryStore(testUri))
+		cs := &Changeset{}
+		observed.Subscribe(cs.Record)
+		Expect(observed.AddTriples([]Triple{labelTrp("A", "A"), labelTrp("B", "B")})).To(Succeed())
+		Expect(observed.DeleteTriple(labelTrp("A", "A"))).To(Succeed())
+		Expect(cs.Added).To(Equal([]Triple{labelTrp("B", "B")}))
+		Expect(cs.Deleted).To(Equal([]Triple{labelTrp("A", "A")}))
+		Expect(cs.SparqlUpdate(testUri)).To(Equal(`DELETE DATA { GRAPH <http://example.com/onto> { <http://example.com/onto#A> <http://www.w3.org/2000/01/rdf-schema#label> "A"@en . } } ;
+INSERT DATA { GRAPH <http://example.com/onto> { <http://example.com/onto#B> <http://www.w3.org/2000/01/rdf-schema#label> "B"@en . } }`))
+	})
+
+	It("should read RDF patches with blank nodes and aborted transactions", func() {
+		cs, err := ReadRDFPatch(strings.NewReader(`H id <urn:uuid:1> .
+TX .
+A <http://example.com/onto#A> <http://example.com/onto#ref> _:b1 .
+A _:b1 <http://example.com/onto#value> "1"^^<http://www.w3.org/2001/XMLSchema#integer> .
+TC .
+TX .
+D <http://example.com/onto#A> <http://example.com/onto#ref> _:b1 .
+TA .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cs.Deleted).To(BeEmpty())
+		Expect(cs.Added).To(Equal([]Triple{
+			{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(testUri + "#ref"), Object: NewBlankNodeTerm("b1")},
+			{Subject: NewBlankNodeTerm("b1"), Predicate: NewResourceTerm(testUri + "#value"), Object: NewLiteralTerm("1", "", XSDInteger)},
+		}))
+
+		quoted := &Changeset{Added: []Triple{labelTrp("A", `Say "A" .`)}}
+		var buf bytes.Buffer
+		Expect(quoted.WriteRDFPatch(&buf)).To(Succeed())
+		Expect(ReadRDFPatch(&buf)).To(Equal(quoted))
+
+		_, err = ReadRDFPatch(strings.NewReader("A <http://example.com/onto#A> <http://example.com/onto#ref> .\n"))
+		Expect(err).To(MatchError(ErrInvalidPatch))
+	})
+})
@@ -0,0 +1,122 @@
+package ontograph
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// IngestCheckpointed adds the triples produced by the stream to the graph store in batches of the given size and records the progress
+// of the load with the given ID in the metadata graph after every batch. If an interrupted load is started again with the same ID, the
+// triples that were already processed are skipped and the load resumes with the next batch. Loads that completed are not repeated.
+// The stream must produce the triples in the same order on every call (e.g. by parsing the same file). Triples are added unchecked, so
+// a batch that was added before the progress could be recorded is not duplicated when it is added again. Returns the total number of
+// processed triples of the load.
+func IngestCheckpointed(store, metadata GraphStore, loadID string, stream func(fn func(Triple) error) error, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		return 0, errors.New("Batch size must be positive")
+	}
+	checkpoint := newLoadCheckpoint(metadata, loadID)
+	processed, completed, err := checkpoint.read()
+	if err != nil || completed {
+		return processed, err
+	}
+	// Skip the processed triples and add the remaining ones in batches
+	skip := processed
+	batch := make([]Triple, 0, batchSize)
+	flush := func() error {
+		if err := store.AddTriplesUnchecked(batch); err != nil {
+			return err
+		}
+		processed += len(batch)
+		batch = batch[:0]
+		return checkpoint.write(processed, false)
+	}
+	err = stream(func(trp Triple) error {
+		if skip > 0 {
+			skip--
+			return nil
+		}
+		batch = append(batch, trp)
+		if len(batch) < batchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return processed, err
+	}
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return processed, err
+		}
+	}
+	return processed, checkpoint.write(processed, true)
+}
+
+// LoadTurtleStreamCheckpointed parses the TTL data given in the reader and adds the triples to the graph store in checkpointed batches
+// (see `IngestCheckpointed`), so interrupted loads of large files resume where they left off. Returns the total number of processed
+// triples of the load.
+func LoadTurtleStreamCheckpointed(store, metadata GraphStore, loadID string, r io.Reader, batchSize int, opts ...ParseOption) (int, error) {
+	return IngestCheckpointed(store, metadata, loadID, func(fn func(Triple) error) error {
+		return ParseTurtleStream(r, fn, opts...)
+	}, batchSize)
+}
+
+// ResetCheckpoint removes the recorded progress of the load with the given ID from the metadata graph, so the next load with the ID
+// starts from the beginning.
+func ResetCheckpoint(metadata GraphStore, loadID string) error {
+	return metadata.DeleteAllMatches(newLoadCheckpoint(metadata, loadID).subject.String(), "", "")
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// loadCheckpoint records the progress of a load in the metadata graph. The terms are placed in the namespace of the metadata graph.
+type loadCheckpoint struct {
+	metadata  GraphStore
+	subject   Term
+	processed Term
+	completed Term
+}
+
+// newLoadCheckpoint creates the checkpoint of the load with the given ID.
+func newLoadCheckpoint(metadata GraphStore, loadID string) *loadCheckpoint {
+	ns := metadata.GetURI() + "#"
+	return &loadCheckpoint{
+		metadata:  metadata,
+		subject:   NewResourceTerm(ns + "load-" + url.PathEscape(loadID)),
+		processed: NewResourceTerm(ns + "processedTriples"),
+		completed: NewResourceTerm(ns + "completed"),
+	}
+}
+
+// read returns the number of processed triples and whether the load completed. Loads without checkpoint have not processed any triple.
+func (c *loadCheckpoint) read() (int, bool, error) {
+	trp, err := c.metadata.GetFirstMatch(c.subject.String(), c.processed.String(), "")
+	if err != nil || trp == nil {
+		return 0, false, err
+	}
+	processed, err := strconv.Atoi(trp.Object.Value())
+	if err != nil {
+		return 0, false, err
+	}
+	trp, err = c.metadata.GetFirstMatch(c.subject.String(), c.completed.String(), "")
+	if err != nil {
+		return 0, false, err
+	}
+	return processed, trp != nil && trp.Object.Value() == "true", nil
+}
+
+// write records the number of processed triples and whether the load completed.
+func (c *loadCheckpoint) write(processed int, completed bool) error {
+	if err := c.metadata.DeleteAllMatches(c.subject.String(), "", ""); err != nil {
+		return err
+	}
+	return c.metadata.AddTriples([]Triple{
+		{Subject: c.subject, Predicate: c.processed, Object: NewLiteralTerm(strconv.Itoa(processed), "", XSDInteger)},
+		{Subject: c.subject, Predicate: c.completed, Object: NewLiteralTerm(strconv.FormatBool(completed), "", XSDBoolean)},
+	})
+}
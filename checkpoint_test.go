@@ -0,0 +1,93 @@
+package ontograph_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+// countingStore counts the triples added to the wrapped store.
+type countingStore struct {
+	GraphStore
+	added int
+}
+
+func (store *countingStore) AddTriplesUnchecked(trps []Triple) error {
+	store.added += len(trps)
+	return store.GraphStore.AddTriplesUnchecked(trps)
+}
+
+var _ = Describe("Checkpointed ingestion", func() {
+	const uri = "http://example.com/onto"
+	var store *countingStore
+	var metadata *MemoryStore
+	var trps []Triple
+
+	BeforeEach(func() {
+		store = &countingStore{GraphStore: NewMemoryStore(uri)}
+		metadata = NewMemoryStore("http://example.com/loads")
+		trps = []Triple{}
+		for i := 0; i < 10; i++ {
+			trps = append(trps, Triple{Subject: NewResourceTerm(fmt.Sprintf("%s#i%d", uri, i)), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLNamedIndividual)})
+		}
+	})
+
+	// stream emits the triples and fails after the given number of triples (if positive)
+	stream := func(failAfter int) func(func(Triple) error) error {
+		return func(fn func(Triple) error) error {
+			for i, trp := range trps {
+				if i == failAfter {
+					return errors.New("connection lost")
+				}
+				if err := fn(trp); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	It("should resume interrupted loads after the last checkpoint", func() {
+		processed, err := IngestCheckpointed(store, metadata, "load-1", stream(7), 3)
+		Expect(err).To(MatchError("connection lost"))
+		Expect(processed).To(Equal(6))
+		Expect(store.added).To(Equal(6))
+
+		processed, err = IngestCheckpointed(store, metadata, "load-1", stream(-1), 3)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(processed).To(Equal(10))
+		Expect(store.added).To(Equal(10))
+		Expect(store.GetAllTriples()).To(ConsistOf(trps))
+	})
+
+	It("should not repeat completed loads unless reset", func() {
+		Expect(IngestCheckpointed(store, metadata, "load-1", stream(-1), 4)).To(Equal(10))
+		Expect(IngestCheckpointed(store, metadata, "load-1", stream(-1), 4)).To(Equal(10))
+		Expect(store.added).To(Equal(10))
+		Expect(IngestCheckpointed(store, metadata, "load-2", stream(-1), 4)).To(Equal(10))
+		Expect(store.added).To(Equal(20))
+		Expect(store.Size()).To(Equal(10))
+
+		Expect(ResetCheckpoint(metadata, "load-1")).To(Succeed())
+		Expect(metadata.Size()).To(Equal(2))
+		Expect(IngestCheckpointed(store, metadata, "load-1", stream(-1), 4)).To(Equal(10))
+		Expect(store.added).To(Equal(30))
+	})
+
+	It("should load TTL data with checkpoints", func() {
+		ttl := `@prefix : <http://example.com/onto#> .
+:a :rel :b .
+:b :rel :c .
+:c :rel :d .
+`
+		processed, err := LoadTurtleStreamCheckpointed(store, metadata, "ttl", strings.NewReader(ttl), 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(processed).To(Equal(3))
+		Expect(store.Size()).To(Equal(3))
+	})
+})
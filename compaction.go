@@ -0,0 +1,234 @@
+package ontograph
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/deiu/rdf2go"
+)
+
+// A CompactOption configures a compaction (see `Compact` and `EstimateReclaimable`).
+type CompactOption func(*compactOptions)
+
+// compactOptions holds the configuration compiled from a list of compact options.
+type compactOptions struct {
+	pruneBlankNodes bool
+}
+
+// WithBlankNodePruning additionally removes orphaned blank nodes, i.e. blank nodes that cannot be reached from any resource (e.g. the
+// remains of restrictions or lists whose owner was deleted).
+func WithBlankNodePruning() CompactOption {
+	return func(opts *compactOptions) {
+		opts.pruneBlankNodes = true
+	}
+}
+
+// newCompactOptions compiles the given list of options.
+func newCompactOptions(opts []CompactOption) compactOptions {
+	options := compactOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// CompactionStats describes the space that is (or can be) reclaimed by a compaction.
+type CompactionStats struct {
+	// Triples is the number of removed triples (e.g. of orphaned blank nodes).
+	Triples int
+	// Bytes is the estimated size of the removed triples in N-Triples format.
+	Bytes int
+}
+
+// A Compacter compacts its storage. It is implemented by graph stores that accumulate unused data over time.
+type Compacter interface {
+	// Compact should release unused storage and return the reclaimed space.
+	Compact(opts ...CompactOption) (CompactionStats, error)
+	// EstimateReclaimable should return the space that would be reclaimed by `Compact` with the same options without changing the store.
+	EstimateReclaimable(opts ...CompactOption) (CompactionStats, error)
+}
+
+// Compact releases unused storage of the graph store, e.g. for long-running deployments whose stores only ever grow. Stores that
+// implement `Compacter` compact themselves, for all other stores only orphaned blank nodes are pruned (if requested).
+func Compact(store GraphStore, opts ...CompactOption) (CompactionStats, error) {
+	if compacter, ok := store.(Compacter); ok {
+		return compacter.Compact(opts...)
+	}
+	if !newCompactOptions(opts).pruneBlankNodes {
+		return CompactionStats{}, nil
+	}
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return CompactionStats{}, err
+	}
+	orphaned := orphanedBlankNodeTriples(trps)
+	if err := store.DeleteTriplesUnchecked(orphaned); err != nil {
+		return CompactionStats{}, err
+	}
+	return newCompactionStats(orphaned), nil
+}
+
+// EstimateReclaimable returns the space that would be reclaimed by `Compact` with the same options without changing the store.
+func EstimateReclaimable(store GraphStore, opts ...CompactOption) (CompactionStats, error) {
+	if compacter, ok := store.(Compacter); ok {
+		return compacter.EstimateReclaimable(opts...)
+	}
+	if !newCompactOptions(opts).pruneBlankNodes {
+		return CompactionStats{}, nil
+	}
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return CompactionStats{}, err
+	}
+	return newCompactionStats(orphanedBlankNodeTriples(trps)), nil
+}
+
+// Compact rebuilds the triple index and the blank node labels, which releases the memory of deleted triples and blank nodes (Go maps
+// never shrink), and prunes orphaned blank nodes if requested.
+func (store *MemoryStore) Compact(opts ...CompactOption) (CompactionStats, error) {
+	orphaned := map[Term]bool{}
+	stats := CompactionStats{}
+	if newCompactOptions(opts).pruneBlankNodes {
+		trps, err := store.GetAllTriples()
+		if err != nil {
+			return CompactionStats{}, err
+		}
+		pruned := orphanedBlankNodeTriples(trps)
+		for _, trp := range pruned {
+			orphaned[trp.Subject] = true
+		}
+		stats = newCompactionStats(pruned)
+	}
+	g := rdf2go.NewGraph(store.graph.URI())
+	live := map[int]bool{}
+	for trp := range store.graph.IterTriples() {
+		if !orphaned[store.fromTerm(trp.Subject)] {
+			g.Add(trp)
+			for _, t := range []rdf2go.Term{trp.Subject, trp.Object} {
+				if bnode, ok := t.(*rdf2go.BlankNode); ok {
+					live[bnode.ID] = true
+				}
+			}
+		}
+	}
+	store.graph = g
+	store.labels = nil
+	// Rebuild the blank node maps, so the labels of deleted blank nodes are released as well
+	store.bnodeMu.Lock()
+	defer store.bnodeMu.Unlock()
+	if store.bnodeIDs != nil {
+		bnodeIDs, bnodeLabels := map[string]int{}, map[int]string{}
+		for id, label := range store.bnodeLabels {
+			if live[id] {
+				bnodeIDs[label] = id
+				bnodeLabels[id] = label
+			}
+		}
+		store.bnodeIDs, store.bnodeLabels = bnodeIDs, bnodeLabels
+	}
+	return stats, nil
+}
+
+// EstimateReclaimable returns the orphaned blank node triples that would be pruned by `Compact` (see `EstimateReclaimable`). The memory
+// of deleted triples is not included, since it cannot be determined.
+func (store *MemoryStore) EstimateReclaimable(opts ...CompactOption) (CompactionStats, error) {
+	if !newCompactOptions(opts).pruneBlankNodes {
+		return CompactionStats{}, nil
+	}
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return CompactionStats{}, err
+	}
+	return newCompactionStats(orphanedBlankNodeTriples(trps)), nil
+}
+
+// Compact prunes orphaned blank nodes from the graph if requested. Blazegraph does not expose the compaction of its journal (deleted
+// records and unused dictionary entries) via its REST API, which requires the offline `CompactJournalUtility` instead.
+func (store *BlazegraphStore) Compact(opts ...CompactOption) (CompactionStats, error) {
+	stats, err := store.EstimateReclaimable(opts...)
+	if err != nil || stats.Triples == 0 {
+		return stats, err
+	}
+	sparqlReq := fmt.Sprintf(`DELETE { GRAPH <%s> { ?b ?p ?o } } WHERE { %s }`, store.uri, store.orphanedBlankNodePattern())
+	code, err := store.endpoint.DoSparqlUpdate(store.namespace, sparqlReq)
+	if err != nil {
+		return CompactionStats{}, store.wrapErr("Compact", nil, sparqlReq, err)
+	}
+	if code != http.StatusOK {
+		return CompactionStats{}, store.wrapErr("Compact", nil, sparqlReq, fmt.Errorf("Failed to prune blank nodes of graph '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code))
+	}
+	return stats, nil
+}
+
+// EstimateReclaimable returns the orphaned blank node triples that would be pruned by `Compact` (see `EstimateReclaimable`).
+func (store *BlazegraphStore) EstimateReclaimable(opts ...CompactOption) (CompactionStats, error) {
+	if !newCompactOptions(opts).pruneBlankNodes {
+		return CompactionStats{}, nil
+	}
+	sparqlReq := fmt.Sprintf(`SELECT (COUNT(*) AS ?n) (SUM(STRLEN(STR(?p)) + STRLEN(STR(?o))) AS ?len) WHERE { %s }`, store.orphanedBlankNodePattern())
+	resSet, code, err := store.endpoint.DoSparqlJSONQuery(store.namespace, sparqlReq)
+	if err != nil {
+		return CompactionStats{}, store.wrapErr("EstimateReclaimable", nil, sparqlReq, err)
+	}
+	if code != http.StatusOK {
+		return CompactionStats{}, store.wrapErr("EstimateReclaimable", nil, sparqlReq, fmt.Errorf("Failed to execute SELECT query on namespace '%s' (HTTP %d)", store.namespace, code))
+	}
+	stats := CompactionStats{}
+	if len(resSet.Results.Bindings) > 0 {
+		stats.Triples, _ = strconv.Atoi(resSet.Results.Bindings[0]["n"].Value)
+		stats.Bytes, _ = strconv.Atoi(resSet.Results.Bindings[0]["len"].Value)
+	}
+	return stats, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// orphanedBlankNodePattern returns the SPARQL pattern that binds the triples `?b ?p ?o` of blank nodes `?b` that cannot be reached
+// from any resource of the graph.
+func (store *BlazegraphStore) orphanedBlankNodePattern() string {
+	isBlankNode := func(variable string) string {
+		return fmt.Sprintf(`(isBlank(%s) || STRSTARTS(STR(%s), "%s"))`, variable, variable, blazegraphBlankNodePrefix)
+	}
+	return fmt.Sprintf(`GRAPH <%s> { ?b ?p ?o . FILTER%s FILTER NOT EXISTS { ?r (<urn:ontograph:any>|!<urn:ontograph:any>)+ ?b . FILTER(!%s) } }`, store.uri, isBlankNode("?b"), isBlankNode("?r"))
+}
+
+// orphanedBlankNodeTriples returns the triples of blank nodes that cannot be reached from any resource subject.
+func orphanedBlankNodeTriples(trps []Triple) []Triple {
+	// Mark all blank nodes reachable from resources
+	bySubject := triplesBySubject(trps)
+	reachable := map[Term]bool{}
+	var visit func(t Term)
+	visit = func(t Term) {
+		for _, trp := range bySubject[t] {
+			if trp.Object.IsBlankNode() && !reachable[trp.Object] {
+				reachable[trp.Object] = true
+				visit(trp.Object)
+			}
+		}
+	}
+	for subj := range bySubject {
+		if !subj.IsBlankNode() {
+			visit(subj)
+		}
+	}
+	orphaned := []Triple{}
+	for _, trp := range trps {
+		if trp.Subject.IsBlankNode() && !reachable[trp.Subject] {
+			orphaned = append(orphaned, trp)
+		}
+	}
+	SortTriples(orphaned)
+	return orphaned
+}
+
+// newCompactionStats returns the statistics of removing the triples.
+func newCompactionStats(trps []Triple) CompactionStats {
+	stats := CompactionStats{Triples: len(trps)}
+	for _, trp := range trps {
+		stats.Bytes += len(nTriplesLine(trp))
+	}
+	return stats
+}
@@ -0,0 +1,66 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Compaction", func() {
+	var store *MemoryStore
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:A a owl:Class ;
+  :restriction _:r1 .
+_:r1 a owl:Restriction ;
+  owl:onProperty :p ;
+  :nested _:r2 .
+_:r2 :value 1 .
+_:o1 a owl:Restriction ;
+  :nested _:o2 .
+_:o2 :value 2 .
+`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should estimate and prune orphaned blank nodes", func() {
+		stats, err := EstimateReclaimable(store, WithBlankNodePruning())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats.Triples).To(Equal(3))
+		Expect(stats.Bytes).To(BeNumerically(">", 0))
+		Expect(store.Size()).To(Equal(10))
+
+		Expect(Compact(store, WithBlankNodePruning())).To(Equal(stats))
+		Expect(store.Size()).To(Equal(7))
+		Expect(store.GetAllMatches("", "<http://example.com/onto#value>", "")).To(HaveLen(1))
+		Expect(EstimateReclaimable(store, WithBlankNodePruning())).To(Equal(CompactionStats{}))
+	})
+
+	It("should keep the labels of remaining blank nodes", func() {
+		mem := NewMemoryStore("http://example.com/onto")
+		kept := Triple{Subject: NewBlankNodeTerm("kept"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLRestriction)}
+		deleted := Triple{Subject: NewResourceTerm("http://example.com/onto#A"), Predicate: NewResourceTerm(RDFSSubClassOf), Object: NewBlankNodeTerm("deleted")}
+		Expect(mem.AddTriples([]Triple{kept, deleted})).To(Succeed())
+		Expect(mem.DeleteTriple(deleted)).To(Succeed())
+		Expect(Compact(mem)).To(Equal(CompactionStats{}))
+		Expect(mem.GetAllTriples()).To(ConsistOf(kept))
+		Expect(mem.GetAllMatches(NewBlankNodeTerm("kept").String(), "", "")).To(ConsistOf(kept))
+		Expect(mem.AddTriple(deleted)).To(Succeed())
+		Expect(mem.GetAllTriples()).To(ConsistOf(kept, deleted))
+	})
+
+	It("should keep all triples without pruning", func() {
+		Expect(EstimateReclaimable(store)).To(Equal(CompactionStats{}))
+		Expect(Compact(store)).To(Equal(CompactionStats{}))
+		Expect(store.Size()).To(Equal(10))
+		Expect(store.AddTriple(Triple{Subject: NewResourceTerm("http://example.com/onto#B"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)})).To(Succeed())
+		Expect(store.Size()).To(Equal(11))
+	})
+})
@@ -0,0 +1,77 @@
+package ontograph_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Compression", func() {
+	const testUri = "http://example.com/onto"
+	testTriples := []Triple{
+		{Subject: NewResourceTerm(testUri), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLOntology)},
+		{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)},
+	}
+	var store *MemoryStore
+
+	BeforeEach(func() {
+		store = NewMemoryStore(testUri)
+		Expect(store.AddTriples(testTriples)).To(Succeed())
+	})
+
+	gzipped := func(data string) *bytes.Buffer {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, err := gz.Write([]byte(data))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gz.Close()).To(Succeed())
+		return &buf
+	}
+
+	It("should serialize compressed and parse it transparently", func() {
+		var buf bytes.Buffer
+		Expect(store.SerializeToTurtleWithOptions(&buf, true, WithGzip())).To(Succeed())
+		Expect(buf.Bytes()[:2]).To(Equal([]byte{0x1f, 0x8b}))
+		parsed, err := ParseFromTurtle(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.GetAllTriples()).To(ConsistOf(testTriples))
+	})
+
+	It("should decompress streamed and auto-detected data", func() {
+		ttl := "<http://example.com/onto#A> a <http://www.w3.org/2002/07/owl#Class> .\n"
+		count := 0
+		Expect(ParseTurtleStream(gzipped(ttl), func(Triple) error { count++; return nil })).To(Succeed())
+		Expect(count).To(Equal(1))
+		parsed, err := ParseGraph(gzipped(ttl), "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.Size()).To(Equal(1))
+	})
+
+	It("should compress and decompress CSV data", func() {
+		var buf bytes.Buffer
+		Expect(ExportCSV(store, &buf, CSVOptions{Gzip: true})).To(Succeed())
+		imported := NewMemoryStore(testUri)
+		Expect(ImportCSV(imported, &buf, DefaultCSVMapping(CSVOptions{}))).To(Equal(len(testTriples)))
+	})
+
+	It("should detect compression and format by file extension", func() {
+		dir, err := ioutil.TempDir("", "ontograph")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "onto.ttl.gz")
+		Expect(SerializeToFile(store, path, false)).To(Succeed())
+		data, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data[:2]).To(Equal([]byte{0x1f, 0x8b}))
+		parsed, err := ParseFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.GetAllTriples()).To(ConsistOf(testTriples))
+	})
+})
@@ -5,8 +5,13 @@ const (
 	OWLOntology                  string = "http://www.w3.org/2002/07/owl#Ontology"
 	OWLVersionInfo               string = "http://www.w3.org/2002/07/owl#versionInfo"
 	OWLImports                   string = "http://www.w3.org/2002/07/owl#imports"
+	OWLVersionIRI                string = "http://www.w3.org/2002/07/owl#versionIRI"
+	OWLPriorVersion              string = "http://www.w3.org/2002/07/owl#priorVersion"
+	OWLBackwardCompatibleWith    string = "http://www.w3.org/2002/07/owl#backwardCompatibleWith"
+	OWLIncompatibleWith          string = "http://www.w3.org/2002/07/owl#incompatibleWith"
 	OWLInverseOf                 string = "http://www.w3.org/2002/07/owl#inverseOf"
 	OWLClass                     string = "http://www.w3.org/2002/07/owl#Class"
+	OWLThing                     string = "http://www.w3.org/2002/07/owl#Thing"
 	OWLEquivalentClass           string = "http://www.w3.org/2002/07/owl#equivalentClass"
 	OWLDisjointWith              string = "http://www.w3.org/2002/07/owl#disjointWith"
 	OWLObjectProperty            string = "http://www.w3.org/2002/07/owl#ObjectProperty"
@@ -19,11 +24,42 @@ const (
 	OWLIrreflexiveProperty       string = "http://www.w3.org/2002/07/owl#IrreflexiveProperty"
 	OWLPropertyDisjointWith      string = "http://www.w3.org/2002/07/owl#propertyDisjointWith"
 	OWLEquivalentProperty        string = "http://www.w3.org/2002/07/owl#equivalentProperty"
+	OWLPropertyChainAxiom        string = "http://www.w3.org/2002/07/owl#propertyChainAxiom"
 	OWLDatatypeProperty          string = "http://www.w3.org/2002/07/owl#DatatypeProperty"
+	OWLAnnotationProperty        string = "http://www.w3.org/2002/07/owl#AnnotationProperty"
 	OWLNamedIndividual           string = "http://www.w3.org/2002/07/owl#NamedIndividual"
 	OWLSameAs                    string = "http://www.w3.org/2002/07/owl#sameAs"
+	OWLDeprecated                string = "http://www.w3.org/2002/07/owl#deprecated"
+	OWLRestriction               string = "http://www.w3.org/2002/07/owl#Restriction"
+	OWLOnProperty                string = "http://www.w3.org/2002/07/owl#onProperty"
+	OWLSomeValuesFrom            string = "http://www.w3.org/2002/07/owl#someValuesFrom"
+	OWLAllValuesFrom             string = "http://www.w3.org/2002/07/owl#allValuesFrom"
+	OWLHasValue                  string = "http://www.w3.org/2002/07/owl#hasValue"
+	OWLCardinality               string = "http://www.w3.org/2002/07/owl#cardinality"
+	OWLMinCardinality            string = "http://www.w3.org/2002/07/owl#minCardinality"
+	OWLMaxCardinality            string = "http://www.w3.org/2002/07/owl#maxCardinality"
+	OWLQualifiedCardinality      string = "http://www.w3.org/2002/07/owl#qualifiedCardinality"
+	OWLMinQualifiedCardinality   string = "http://www.w3.org/2002/07/owl#minQualifiedCardinality"
+	OWLMaxQualifiedCardinality   string = "http://www.w3.org/2002/07/owl#maxQualifiedCardinality"
+	OWLOnClass                   string = "http://www.w3.org/2002/07/owl#onClass"
+	OWLOnDataRange               string = "http://www.w3.org/2002/07/owl#onDataRange"
+	OWLUnionOf                   string = "http://www.w3.org/2002/07/owl#unionOf"
+	OWLIntersectionOf            string = "http://www.w3.org/2002/07/owl#intersectionOf"
+	OWLComplementOf              string = "http://www.w3.org/2002/07/owl#complementOf"
+	OWLAxiom                     string = "http://www.w3.org/2002/07/owl#Axiom"
+	OWLAnnotatedSource           string = "http://www.w3.org/2002/07/owl#annotatedSource"
+	OWLAnnotatedProperty         string = "http://www.w3.org/2002/07/owl#annotatedProperty"
+	OWLAnnotatedTarget           string = "http://www.w3.org/2002/07/owl#annotatedTarget"
 
-	RDFType string = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+	RDFType       string = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+	RDFLangString string = "http://www.w3.org/1999/02/22-rdf-syntax-ns#langString"
+	RDFFirst      string = "http://www.w3.org/1999/02/22-rdf-syntax-ns#first"
+	RDFRest       string = "http://www.w3.org/1999/02/22-rdf-syntax-ns#rest"
+	RDFNil        string = "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"
+	RDFStatement  string = "http://www.w3.org/1999/02/22-rdf-syntax-ns#Statement"
+	RDFSubject    string = "http://www.w3.org/1999/02/22-rdf-syntax-ns#subject"
+	RDFPredicate  string = "http://www.w3.org/1999/02/22-rdf-syntax-ns#predicate"
+	RDFObject     string = "http://www.w3.org/1999/02/22-rdf-syntax-ns#object"
 
 	RDFSComment       string = "http://www.w3.org/2000/01/rdf-schema#comment"
 	RDFSLabel         string = "http://www.w3.org/2000/01/rdf-schema#label"
@@ -32,15 +68,90 @@ const (
 	RDFSDomain        string = "http://www.w3.org/2000/01/rdf-schema#domain"
 	RDFSRange         string = "http://www.w3.org/2000/01/rdf-schema#range"
 	RDFSDatatype      string = "http://www.w3.org/2000/01/rdf-schema#Datatype"
+	RDFSLiteral       string = "http://www.w3.org/2000/01/rdf-schema#Literal"
 
-	XSDString   string = "http://www.w3.org/2001/XMLSchema#string"
-	XSDInteger  string = "http://www.w3.org/2001/XMLSchema#integer"
-	XSDDouble   string = "http://www.w3.org/2001/XMLSchema#double"
-	XSDDecimal  string = "http://www.w3.org/2001/XMLSchema#decimal"
-	XSDFloat    string = "http://www.w3.org/2001/XMLSchema#float"
-	XSDBoolean  string = "http://www.w3.org/2001/XMLSchema#boolean"
-	XSDDate     string = "http://www.w3.org/2001/XMLSchema#date"
-	XSDTime     string = "http://www.w3.org/2001/XMLSchema#time"
-	XSDDateTime string = "http://www.w3.org/2001/XMLSchema#dateTime"
-	XSDAnyURI   string = "http://www.w3.org/2001/XMLSchema#anyURI"
+	XSDString       string = "http://www.w3.org/2001/XMLSchema#string"
+	XSDInteger      string = "http://www.w3.org/2001/XMLSchema#integer"
+	XSDDouble       string = "http://www.w3.org/2001/XMLSchema#double"
+	XSDDecimal      string = "http://www.w3.org/2001/XMLSchema#decimal"
+	XSDFloat        string = "http://www.w3.org/2001/XMLSchema#float"
+	XSDBoolean      string = "http://www.w3.org/2001/XMLSchema#boolean"
+	XSDDate         string = "http://www.w3.org/2001/XMLSchema#date"
+	XSDTime         string = "http://www.w3.org/2001/XMLSchema#time"
+	XSDDateTime     string = "http://www.w3.org/2001/XMLSchema#dateTime"
+	XSDGYear        string = "http://www.w3.org/2001/XMLSchema#gYear"
+	XSDGYearMonth   string = "http://www.w3.org/2001/XMLSchema#gYearMonth"
+	XSDDuration     string = "http://www.w3.org/2001/XMLSchema#duration"
+	XSDBase64Binary string = "http://www.w3.org/2001/XMLSchema#base64Binary"
+	XSDHexBinary    string = "http://www.w3.org/2001/XMLSchema#hexBinary"
+	XSDAnyURI       string = "http://www.w3.org/2001/XMLSchema#anyURI"
+
+	XSDNonNegativeInteger string = "http://www.w3.org/2001/XMLSchema#nonNegativeInteger"
+	XSDPositiveInteger    string = "http://www.w3.org/2001/XMLSchema#positiveInteger"
+	XSDLong               string = "http://www.w3.org/2001/XMLSchema#long"
+	XSDInt                string = "http://www.w3.org/2001/XMLSchema#int"
+	XSDShort              string = "http://www.w3.org/2001/XMLSchema#short"
+	XSDByte               string = "http://www.w3.org/2001/XMLSchema#byte"
+	XSDUnsignedLong       string = "http://www.w3.org/2001/XMLSchema#unsignedLong"
+	XSDUnsignedInt        string = "http://www.w3.org/2001/XMLSchema#unsignedInt"
+	XSDUnsignedShort      string = "http://www.w3.org/2001/XMLSchema#unsignedShort"
+	XSDUnsignedByte       string = "http://www.w3.org/2001/XMLSchema#unsignedByte"
+)
+
+// Static URIs of the Shapes Constraint Language (SHACL)
+const (
+	SHNodeShape           string = "http://www.w3.org/ns/shacl#NodeShape"
+	SHTargetClass         string = "http://www.w3.org/ns/shacl#targetClass"
+	SHProperty            string = "http://www.w3.org/ns/shacl#property"
+	SHPath                string = "http://www.w3.org/ns/shacl#path"
+	SHName                string = "http://www.w3.org/ns/shacl#name"
+	SHMinCount            string = "http://www.w3.org/ns/shacl#minCount"
+	SHMaxCount            string = "http://www.w3.org/ns/shacl#maxCount"
+	SHDatatype            string = "http://www.w3.org/ns/shacl#datatype"
+	SHClass               string = "http://www.w3.org/ns/shacl#class"
+	SHPattern             string = "http://www.w3.org/ns/shacl#pattern"
+	SHFlags               string = "http://www.w3.org/ns/shacl#flags"
+	SHMinLength           string = "http://www.w3.org/ns/shacl#minLength"
+	SHMaxLength           string = "http://www.w3.org/ns/shacl#maxLength"
+	SHMinInclusive        string = "http://www.w3.org/ns/shacl#minInclusive"
+	SHMaxInclusive        string = "http://www.w3.org/ns/shacl#maxInclusive"
+	SHMinExclusive        string = "http://www.w3.org/ns/shacl#minExclusive"
+	SHMaxExclusive        string = "http://www.w3.org/ns/shacl#maxExclusive"
+	SHIn                  string = "http://www.w3.org/ns/shacl#in"
+	SHHasValue            string = "http://www.w3.org/ns/shacl#hasValue"
+	SHQualifiedValueShape string = "http://www.w3.org/ns/shacl#qualifiedValueShape"
+	SHQualifiedMinCount   string = "http://www.w3.org/ns/shacl#qualifiedMinCount"
+	SHQualifiedMaxCount   string = "http://www.w3.org/ns/shacl#qualifiedMaxCount"
+)
+
+// Static URIs of the DCMI Metadata Terms (Dublin Core)
+const (
+	DCTermsCreator     string = "http://purl.org/dc/terms/creator"
+	DCTermsPublisher   string = "http://purl.org/dc/terms/publisher"
+	DCTermsLicense     string = "http://purl.org/dc/terms/license"
+	DCTermsIssued      string = "http://purl.org/dc/terms/issued"
+	DCTermsModified    string = "http://purl.org/dc/terms/modified"
+	DCTermsDescription string = "http://purl.org/dc/terms/description"
+	DCTermsSource      string = "http://purl.org/dc/terms/source"
+)
+
+// Static URIs of the PROV Ontology (PROV-O)
+const (
+	PROVGeneratedAtTime string = "http://www.w3.org/ns/prov#generatedAtTime"
+)
+
+// Static URIs of the Simple Knowledge Organization System (SKOS)
+const (
+	SKOSConceptClass       string = "http://www.w3.org/2004/02/skos/core#Concept"
+	SKOSConceptSchemeClass string = "http://www.w3.org/2004/02/skos/core#ConceptScheme"
+	SKOSPrefLabel          string = "http://www.w3.org/2004/02/skos/core#prefLabel"
+	SKOSAltLabel           string = "http://www.w3.org/2004/02/skos/core#altLabel"
+	SKOSDefinition         string = "http://www.w3.org/2004/02/skos/core#definition"
+	SKOSNotation           string = "http://www.w3.org/2004/02/skos/core#notation"
+	SKOSBroader            string = "http://www.w3.org/2004/02/skos/core#broader"
+	SKOSNarrower           string = "http://www.w3.org/2004/02/skos/core#narrower"
+	SKOSRelated            string = "http://www.w3.org/2004/02/skos/core#related"
+	SKOSInScheme           string = "http://www.w3.org/2004/02/skos/core#inScheme"
+	SKOSHasTopConcept      string = "http://www.w3.org/2004/02/skos/core#hasTopConcept"
+	SKOSTopConceptOf       string = "http://www.w3.org/2004/02/skos/core#topConceptOf"
 )
@@ -0,0 +1,160 @@
+package ontograph
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// A Counter counts triples without retrieving them. It is implemented by graph stores that can evaluate aggregates natively.
+type Counter interface {
+	// CountMatches should return the number of triples that match the pattern. Empty strings in subject, predicate or object should be
+	// treated as wildcards.
+	CountMatches(subj, pred, obj string) (int, error)
+	// CountByObject should return the number of distinct subjects per object of the triples with the predicate.
+	CountByObject(pred string) (map[Term]int, error)
+}
+
+// CountMatches returns the number of triples in the store that match the pattern. Stores that implement `Counter` count the triples
+// themselves, for all other stores the matching triples are retrieved and counted.
+func CountMatches(store GraphStore, subj, pred, obj string) (int, error) {
+	if counter, ok := store.(Counter); ok {
+		return counter.CountMatches(subj, pred, obj)
+	}
+	trps, err := store.GetAllMatches(subj, pred, obj)
+	if err != nil {
+		return 0, err
+	}
+	return len(trps), nil
+}
+
+// CountByObject returns the number of distinct subjects per object of the triples with the predicate, e.g. the number of resources per
+// type for `rdf:type`. Stores that implement `Counter` count the subjects themselves, for all other stores the triples with the
+// predicate are retrieved and counted.
+func CountByObject(store GraphStore, pred string) (map[Term]int, error) {
+	if counter, ok := store.(Counter); ok {
+		return counter.CountByObject(pred)
+	}
+	return countByObject(store, pred)
+}
+
+// CountMatches returns the number of triples that match the pattern (see `CountMatches`).
+func (store *MemoryStore) CountMatches(subj, pred, obj string) (int, error) {
+	if subj == "" && pred == "" && obj == "" {
+		return store.graph.Len(), nil
+	}
+	trps, err := store.GetAllMatches(subj, pred, obj)
+	return len(trps), err
+}
+
+// CountByObject returns the number of distinct subjects per object of the triples with the predicate (see `CountByObject`).
+func (store *MemoryStore) CountByObject(pred string) (map[Term]int, error) {
+	return countByObject(store, pred)
+}
+
+// CountMatches returns the number of triples that match the pattern (see `CountMatches`). The triples are counted with a SPARQL COUNT
+// query by Blazegraph.
+func (store *BlazegraphStore) CountMatches(subj, pred, obj string) (int, error) {
+	// Parse pattern to query parameters
+	s := "?s"
+	p := "?p"
+	o := "?o"
+	if subj != "" {
+		s = sparqlTerm(Term(subj))
+	}
+	if pred != "" {
+		p = sparqlTerm(Term(pred))
+	}
+	if obj != "" {
+		o = sparqlTerm(Term(obj))
+	}
+	sparqlReq := fmt.Sprintf(`SELECT (COUNT(*) AS ?n) WHERE { GRAPH <%s> { %s %s %s . } }`, store.uri, s, p, o)
+	resSet, code, err := store.endpoint.DoSparqlJSONQuery(store.namespace, sparqlReq)
+	if err != nil {
+		return 0, store.wrapPatternErr("CountMatches", subj, pred, obj, sparqlReq, err)
+	}
+	if code != http.StatusOK {
+		return 0, store.wrapPatternErr("CountMatches", subj, pred, obj, sparqlReq, fmt.Errorf("Received unexpected status code from SPARQL query (HTTP %d): %s", code, sparqlReq))
+	}
+	return strconv.Atoi(resSet.Results.Bindings[0]["n"].Value)
+}
+
+// CountByObject returns the number of distinct subjects per object of the triples with the predicate (see `CountByObject`). The
+// subjects are counted with a grouped SPARQL COUNT query by Blazegraph.
+func (store *BlazegraphStore) CountByObject(pred string) (map[Term]int, error) {
+	sparqlReq := fmt.Sprintf(`SELECT ?o (COUNT(DISTINCT ?s) AS ?n) WHERE { GRAPH <%s> { ?s %s ?o . } } GROUP BY ?o`, store.uri, Term(pred).String())
+	resSet, code, err := store.endpoint.DoSparqlJSONQuery(store.namespace, sparqlReq)
+	if err != nil {
+		return nil, store.wrapErr("CountByObject", nil, sparqlReq, err)
+	}
+	if code != http.StatusOK {
+		return nil, store.wrapErr("CountByObject", nil, sparqlReq, fmt.Errorf("Received unexpected status code from SPARQL query (HTTP %d): %s", code, sparqlReq))
+	}
+	counts := map[Term]int{}
+	for _, binding := range resSet.Results.Bindings {
+		n, err := strconv.Atoi(binding["n"].Value)
+		if err != nil {
+			return nil, store.wrapErr("CountByObject", nil, sparqlReq, err)
+		}
+		counts[binding2Term(binding["o"])] = n
+	}
+	return counts, nil
+}
+
+// CountIndividuals returns the number of individuals that match the filters (see `GetIndividuals`) without retrieving the individuals
+// themselves. Unfiltered counts and filters with a single class or property value are counted by the store.
+func (ont *OntologyGraph) CountIndividuals(filters TripleFilter) (int, error) {
+	filters = ont.expandFilter(filters)
+	if len(filters) == 0 {
+		return CountMatches(ont.graph, "", NewResourceTerm(RDFType).String(), NewResourceTerm(OWLNamedIndividual).String())
+	}
+	if len(filters) == 1 && len(filters[0]) == 1 && isPlainFilterTriple(filters[0][0]) {
+		// Each subject matches the fully specified triple at most once
+		filterTrp := filters[0][0]
+		return CountMatches(ont.graph, "", filterTrp.Predicate.String(), filterTrp.Object.String())
+	}
+	uris, err := ont.getIndividualURIs(filters)
+	if err != nil {
+		return 0, err
+	}
+	return len(uris), nil
+}
+
+// CountByClass returns the number of individuals per class URI, e.g. for dashboards. Builtin RDF, RDFS and OWL types (like
+// `owl:NamedIndividual` or `owl:Class`) are not counted.
+func (ont *OntologyGraph) CountByClass() (map[string]int, error) {
+	counts, err := CountByObject(ont.graph, NewResourceTerm(RDFType).String())
+	if err != nil {
+		return nil, err
+	}
+	byClass := map[string]int{}
+	for class, n := range counts {
+		if class.IsResource() && !isBuiltinSchemaTerm(class.Value()) {
+			byClass[class.Value()] = n
+		}
+	}
+	return byClass, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// countByObject counts the distinct subjects per object of the triples with the predicate.
+func countByObject(store GraphStore, pred string) (map[Term]int, error) {
+	trps, err := store.GetAllMatches("", pred, "")
+	if err != nil {
+		return nil, err
+	}
+	// Graphs contain each triple once, so every triple adds a distinct subject
+	counts := map[Term]int{}
+	for _, trp := range trps {
+		counts[trp.Object]++
+	}
+	return counts, nil
+}
+
+// isPlainFilterTriple returns true if the filter triple has a predicate and a concrete object that is matched by the store.
+func isPlainFilterTriple(filterTrp FilterTriple) bool {
+	return filterTrp.Condition == nil && filterTrp.Subject == "" && filterTrp.Predicate != "" && filterTrp.Object != ""
+}
@@ -0,0 +1,60 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Counting", func() {
+	const ns = "http://example.com/onto#"
+	var store *MemoryStore
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:Person a owl:Class .
+:Employee a owl:Class .
+:alice a owl:NamedIndividual , :Person , :Employee ; :name "Alice" ; :age 31 .
+:bob a owl:NamedIndividual , :Person ; :name "Bob" ; :age 45 .
+:carol a owl:NamedIndividual , :Employee ; :name "Carol", "Caro" .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should count matching triples", func() {
+		Expect(CountMatches(store, "", "", "")).To(Equal(16))
+		Expect(CountMatches(store, "", NewResourceTerm(ns+"name").String(), "")).To(Equal(4))
+		Expect(CountByObject(store, NewResourceTerm(RDFType).String())).To(HaveKeyWithValue(NewResourceTerm(OWLClass), 2))
+	})
+
+	It("should count individuals like GetIndividuals retrieves them", func() {
+		filters := []TripleFilter{
+			nil,
+			TripleFilter{}.OrWithClass(ns + "Person"),
+			TripleFilter{}.OrWithClass(ns + "Person").AndWithClass(ns + "Employee"),
+			TripleFilter{}.OrWithClass(ns + "Person").OrWithClass(ns + "Employee"),
+			TripleFilter{}.OrWithDataPropertyAny(ns + "name"),
+			TripleFilter{}.OrWithDataPropertyContains(ns+"name", "o"),
+		}
+		for _, filter := range filters {
+			indivs, err := ont.GetIndividuals(filter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ont.CountIndividuals(filter)).To(Equal(len(indivs)))
+		}
+		Expect(ont.CountIndividuals(TripleFilter{}.OrWithClass(ns + "Person"))).To(Equal(2))
+		Expect(ont.CountIndividuals(TripleFilter{}.OrWithDataPropertyAny(ns + "name"))).To(Equal(3))
+	})
+
+	It("should count individuals by class", func() {
+		Expect(ont.CountByClass()).To(Equal(map[string]int{ns + "Person": 2, ns + "Employee": 2}))
+	})
+})
@@ -0,0 +1,246 @@
+package ontograph
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CSVQuoting controls when fields are enclosed in double quotes when writing CSV data.
+type CSVQuoting int
+
+const (
+	// QuoteMinimal only quotes fields that contain the separator, quotes, line breaks or leading spaces.
+	QuoteMinimal CSVQuoting = iota
+	// QuoteAll quotes every field.
+	QuoteAll
+	// QuoteNone never quotes fields, as it is common for TSV data. Fields must not contain the separator or line breaks.
+	QuoteNone
+)
+
+// CSVResourceType is the value of the type column for objects that are resources (if terms are exported as plain values).
+const CSVResourceType = "uri"
+
+// CSVOptions configures the CSV format used for triple import and export.
+type CSVOptions struct {
+	// Comma is the field separator. Defaults to ',' if not set (use '\t' for TSV).
+	Comma rune
+	// Quoting controls when fields are quoted. For import, QuoteNone disables the interpretation of quotes completely.
+	Quoting CSVQuoting
+	// Header declares that the first row contains the column names.
+	Header bool
+	// TermColumns writes the subject, predicate and object columns as terms in NTriple format. Otherwise the plain values are used and
+	// the object is described by an additional type column (datatype URI, `CSVResourceType` or empty for plain literals) and language column.
+	TermColumns bool
+	// Gzip compresses the exported data with gzip. Compressed data is always detected on import.
+	Gzip bool
+}
+
+// CSVMapping configures which columns of the CSV data contain the parts of the triples. Columns are referenced by their zero-based index.
+// Set the type or language column to a negative index if the data does not contain it.
+type CSVMapping struct {
+	CSVOptions
+	Subject   int
+	Predicate int
+	Object    int
+	Type      int
+	Language  int
+}
+
+// DefaultCSVMapping returns the mapping for the column layout written by `ExportCSV` with the given options.
+func DefaultCSVMapping(opts CSVOptions) CSVMapping {
+	mapping := CSVMapping{CSVOptions: opts, Subject: 0, Predicate: 1, Object: 2, Type: 3, Language: 4}
+	if opts.TermColumns {
+		mapping.Type, mapping.Language = -1, -1
+	}
+	return mapping
+}
+
+// ExportCSV writes all triples of the store as CSV data into the writer, one triple per row in canonical order. Depending on the options,
+// the columns are either `subject,predicate,object` in NTriple format or `subject,predicate,object,type,language` with plain values.
+func ExportCSV(store GraphStore, w io.Writer, opts CSVOptions) error {
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return err
+	}
+	SortTriples(trps)
+	var gz *gzip.Writer
+	if opts.Gzip {
+		gz = gzip.NewWriter(w)
+		w = gz
+	}
+	bw := bufio.NewWriter(w)
+	// Write header
+	if opts.Header {
+		header := []string{"subject", "predicate", "object"}
+		if !opts.TermColumns {
+			header = append(header, "type", "language")
+		}
+		if err := writeCSVRecord(bw, header, opts); err != nil {
+			return err
+		}
+	}
+	// Write triples
+	for _, trp := range trps {
+		record := []string{trp.Subject.String(), trp.Predicate.String(), trp.Object.String()}
+		if !opts.TermColumns {
+			objType := trp.Object.Datatype()
+			if trp.Object.IsResource() {
+				objType = CSVResourceType
+			}
+			record = []string{trp.Subject.Value(), trp.Predicate.Value(), trp.Object.Value(), objType, trp.Object.Language()}
+		}
+		if err := writeCSVRecord(bw, record, opts); err != nil {
+			return err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if gz != nil {
+		return gz.Close()
+	}
+	return nil
+}
+
+// ImportCSV reads the triples from the CSV data in the reader according to the mapping and adds them to the store. The triples are
+// added unchecked, so already existing triples do not cause an error. Gzip compressed data is decompressed transparently.
+// Returns the number of imported triples.
+func ImportCSV(store GraphStore, r io.Reader, mapping CSVMapping) (int, error) {
+	r, err := decompressReader(r)
+	if err != nil {
+		return 0, err
+	}
+	records, err := readCSVRecords(r, mapping.CSVOptions)
+	if err != nil {
+		return 0, err
+	}
+	if mapping.Header && len(records) > 0 {
+		records = records[1:]
+	}
+	// Convert records to triples
+	trps := make([]Triple, 0, len(records))
+	for i, record := range records {
+		trp, err := mapping.toTriple(record)
+		if err != nil {
+			row := i + 1
+			if mapping.Header {
+				row++
+			}
+			return 0, fmt.Errorf("Invalid triple in CSV row %d: %v", row, err)
+		}
+		trps = append(trps, *trp)
+	}
+	if err := store.AddTriplesUnchecked(trps); err != nil {
+		return 0, err
+	}
+	return len(trps), nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrCSVUnquotableField is raised when exporting without quoting and a field contains the separator or a line break.
+var ErrCSVUnquotableField error = errors.New("The field cannot be written without quoting")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// toTriple converts the CSV record into a triple according to the mapping.
+func (mapping CSVMapping) toTriple(record []string) (*Triple, error) {
+	column := func(idx int) (string, error) {
+		if idx < 0 {
+			return "", nil
+		}
+		if idx >= len(record) {
+			return "", fmt.Errorf("Column %d is missing", idx)
+		}
+		return record[idx], nil
+	}
+	subj, err := column(mapping.Subject)
+	if err != nil {
+		return nil, err
+	}
+	pred, err := column(mapping.Predicate)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := column(mapping.Object)
+	if err != nil {
+		return nil, err
+	}
+	// Terms can be used directly
+	if mapping.TermColumns {
+		return NewTriple(Term(subj), Term(pred), Term(obj))
+	}
+	// Build terms from plain values
+	objType, err := column(mapping.Type)
+	if err != nil {
+		return nil, err
+	}
+	lang, err := column(mapping.Language)
+	if err != nil {
+		return nil, err
+	}
+	objTerm := NewLiteralTerm(obj, lang, objType)
+	if objType == CSVResourceType {
+		objTerm = NewResourceTerm(obj)
+	}
+	return NewTriple(NewResourceTerm(subj), NewResourceTerm(pred), objTerm)
+}
+
+// csvComma returns the configured separator or the default ','.
+func csvComma(opts CSVOptions) rune {
+	if opts.Comma == 0 {
+		return ','
+	}
+	return opts.Comma
+}
+
+// writeCSVRecord writes a single record with the configured separator and quoting.
+func writeCSVRecord(w *bufio.Writer, record []string, opts CSVOptions) error {
+	comma := string(csvComma(opts))
+	for i, field := range record {
+		if i > 0 {
+			w.WriteString(comma)
+		}
+		special := strings.Contains(field, comma) || strings.ContainsAny(field, "\r\n")
+		switch {
+		case opts.Quoting == QuoteNone && special:
+			return ErrCSVUnquotableField
+		case opts.Quoting == QuoteAll || (opts.Quoting == QuoteMinimal && (special || strings.Contains(field, `"`) || strings.HasPrefix(field, " "))):
+			w.WriteString(`"` + strings.Replace(field, `"`, `""`, -1) + `"`)
+		default:
+			w.WriteString(field)
+		}
+	}
+	_, err := w.WriteString("\n")
+	return err
+}
+
+// readCSVRecords reads all records with the configured separator and quoting.
+func readCSVRecords(r io.Reader, opts CSVOptions) ([][]string, error) {
+	// Without quoting, lines can simply be split at the separator
+	if opts.Quoting == QuoteNone {
+		records := [][]string{}
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := strings.TrimSuffix(scanner.Text(), "\r")
+			if line == "" {
+				continue
+			}
+			records = append(records, strings.Split(line, string(csvComma(opts))))
+		}
+		return records, scanner.Err()
+	}
+	reader := csv.NewReader(r)
+	reader.Comma = csvComma(opts)
+	reader.FieldsPerRecord = -1
+	return reader.ReadAll()
+}
@@ -0,0 +1,80 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("CSV", func() {
+	const testUri = "http://example.com/onto"
+	testTriples := []Triple{
+		{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)},
+		{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(RDFSLabel), Object: NewLiteralTerm("Class A, the first", "en", "")},
+		{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(RDFSComment), Object: NewLiteralTerm("plain", "", "")},
+		{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(testUri + "#count"), Object: NewLiteralTerm("42", "", XSDInteger)},
+	}
+	var store *MemoryStore
+
+	BeforeEach(func() {
+		store = NewMemoryStore(testUri)
+		Expect(store.AddTriples(testTriples)).To(Succeed())
+	})
+
+	roundTrip := func(opts CSVOptions) string {
+		var out strings.Builder
+		Expect(ExportCSV(store, &out, opts)).To(Succeed())
+		imported := NewMemoryStore(testUri)
+		n, err := ImportCSV(imported, strings.NewReader(out.String()), DefaultCSVMapping(opts))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(len(testTriples)))
+		trps, err := imported.GetAllTriples()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trps).To(ConsistOf(testTriples))
+		return out.String()
+	}
+
+	It("should round trip plain values with minimal quoting", func() {
+		out := roundTrip(CSVOptions{Header: true})
+		Expect(out).To(HavePrefix("subject,predicate,object,type,language\n"))
+		Expect(out).To(ContainSubstring(`,"Class A, the first",,en` + "\n"))
+		Expect(out).To(ContainSubstring(",42,http://www.w3.org/2001/XMLSchema#integer,\n"))
+		Expect(out).To(ContainSubstring("," + OWLClass + ",uri,\n"))
+	})
+
+	It("should round trip terms as TSV without quoting", func() {
+		out := roundTrip(CSVOptions{Comma: '\t', Quoting: QuoteNone, TermColumns: true})
+		Expect(out).To(ContainSubstring("\t\"Class A, the first\"@en\n"))
+	})
+
+	It("should round trip with all fields quoted", func() {
+		out := roundTrip(CSVOptions{Quoting: QuoteAll, TermColumns: true})
+		Expect(out).To(ContainSubstring(`,"""42""^^<http://www.w3.org/2001/XMLSchema#integer>"` + "\n"))
+	})
+
+	It("should refuse unquotable fields", func() {
+		var out strings.Builder
+		Expect(ExportCSV(store, &out, CSVOptions{Quoting: QuoteNone})).To(MatchError(ErrCSVUnquotableField))
+	})
+
+	It("should import custom column layouts", func() {
+		data := "label;lang;resource;property\nHallo;de;http://example.com/onto#B;http://www.w3.org/2000/01/rdf-schema#label\n"
+		mapping := CSVMapping{CSVOptions: CSVOptions{Comma: ';', Header: true}, Subject: 2, Predicate: 3, Object: 0, Type: -1, Language: 1}
+		n, err := ImportCSV(store, strings.NewReader(data), mapping)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(1))
+		trp, err := store.GetFirstMatch(NewResourceTerm(testUri+"#B").String(), NewResourceTerm(RDFSLabel).String(), "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trp.Object).To(Equal(NewLiteralTerm("Hallo", "de", "")))
+	})
+
+	It("should report the row of invalid data", func() {
+		data := "subject,predicate,object\n<http://example.com/onto#B>,invalid,<http://example.com/onto#C>\n"
+		_, err := ImportCSV(store, strings.NewReader(data), DefaultCSVMapping(CSVOptions{Header: true, TermColumns: true}))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("row 2"))
+	})
+})
@@ -0,0 +1,50 @@
+package ontograph
+
+import (
+	"strings"
+)
+
+// A ResourceDescription contains all triples that mention a resource, e.g. for debugging tools and admin UIs.
+type ResourceDescription struct {
+	// URI is the URI of the described resource.
+	URI string
+	// Outgoing are the triples with the resource as subject.
+	Outgoing []Triple
+	// Incoming are the triples with the resource as object (i.e. the references from other resources).
+	Incoming []Triple
+}
+
+// String returns the description as dump with an outgoing and an incoming section of N-Triples.
+func (desc ResourceDescription) String() string {
+	var sb strings.Builder
+	sb.WriteString("# " + desc.URI + "\n# Outgoing\n")
+	for _, trp := range desc.Outgoing {
+		sb.WriteString(nTriplesLine(trp))
+	}
+	sb.WriteString("# Incoming\n")
+	for _, trp := range desc.Incoming {
+		sb.WriteString(nTriplesLine(trp))
+	}
+	return sb.String()
+}
+
+// DescribeResource returns all triples in which the resource appears as subject (outgoing) or as object (incoming). Both sections are
+// sorted. Triples of the resource with itself as object appear in both sections. Errors with `ErrResourceNotFound` if the resource
+// appears in no triple.
+func (ont *OntologyGraph) DescribeResource(uri string) (ResourceDescription, error) {
+	uri = ont.prefixes.Expand(uri)
+	outgoing, err := ont.graph.GetAllMatches(NewResourceTerm(uri).String(), "", "")
+	if err != nil {
+		return ResourceDescription{}, wrapResourceError("DescribeResource", uri, err)
+	}
+	incoming, err := ont.graph.GetAllMatches("", "", NewResourceTerm(uri).String())
+	if err != nil {
+		return ResourceDescription{}, wrapResourceError("DescribeResource", uri, err)
+	}
+	if len(outgoing) == 0 && len(incoming) == 0 {
+		return ResourceDescription{}, wrapResourceError("DescribeResource", uri, ErrResourceNotFound)
+	}
+	SortTriples(outgoing)
+	SortTriples(incoming)
+	return ResourceDescription{URI: uri, Outgoing: outgoing, Incoming: incoming}, nil
+}
@@ -0,0 +1,45 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Describing resources", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:alice a owl:NamedIndividual ; :knows :bob .
+:bob a owl:NamedIndividual ; :name "Bob" .
+:carol :knows :bob .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should group the triples of the resource into outgoing and incoming", func() {
+		desc, err := ont.DescribeResource(ns + "bob")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(desc.Outgoing).To(HaveLen(2))
+		Expect(desc.Incoming).To(Equal([]Triple{
+			{Subject: NewResourceTerm(ns + "alice"), Predicate: NewResourceTerm(ns + "knows"), Object: NewResourceTerm(ns + "bob")},
+			{Subject: NewResourceTerm(ns + "carol"), Predicate: NewResourceTerm(ns + "knows"), Object: NewResourceTerm(ns + "bob")},
+		}))
+		Expect(desc.String()).To(HavePrefix("# " + ns + "bob\n# Outgoing\n"))
+		Expect(desc.String()).To(HaveSuffix("# Incoming\n<" + ns + "alice> <" + ns + "knows> <" + ns + "bob> .\n<" + ns + "carol> <" + ns + "knows> <" + ns + "bob> .\n"))
+	})
+
+	It("should error for unknown resources", func() {
+		_, err := ont.DescribeResource(ns + "dave")
+		Expect(err).To(MatchError(ErrResourceNotFound))
+	})
+})
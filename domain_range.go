@@ -0,0 +1,184 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SetStrictDomainRange enables or disables the validation of individuals on upsert. If enabled, `UpsertResource` checks that the
+// individual conforms to the declared domains (`rdfs:domain`) of its properties and that the property values conform to the declared
+// ranges (`rdfs:range`), taking the class hierarchy into account. Object property targets must be typed with the range class (or one of
+// its subclasses) in the graph and literals must have the range datatype. Non-conforming individuals are rejected with a
+// `*DomainRangeError` that lists all violations.
+func (ont *OntologyGraph) SetStrictDomainRange(enabled bool) {
+	ont.strictTypes = enabled
+}
+
+// A DomainRangeViolation describes a property of an individual that does not conform to the domain or a value that does not conform to
+// the range of the property.
+type DomainRangeViolation struct {
+	// Property is the URI of the property.
+	Property string
+	// Value is the URI of the target or the value of the literal for range violations and empty for domain violations.
+	Value string
+	// Expected is the URI of the domain class, range class or range datatype.
+	Expected string
+	// Err is either `ErrDomainViolated` or `ErrRangeViolated`.
+	Err error
+}
+
+// DomainRangeError lists the domain and range violations of an individual (see `SetStrictDomainRange`). It matches the errors of its
+// violations with `errors.Is`.
+type DomainRangeError struct {
+	Violations []DomainRangeViolation
+}
+
+// Error returns the violations in a single line.
+func (e *DomainRangeError) Error() string {
+	msgs := []string{}
+	for _, v := range e.Violations {
+		if v.Err == ErrDomainViolated {
+			msgs = append(msgs, fmt.Sprintf("<%s> requires type <%s>", v.Property, v.Expected))
+		} else {
+			msgs = append(msgs, fmt.Sprintf("<%s> value '%s' is not of type <%s>", v.Property, v.Value, v.Expected))
+		}
+	}
+	return "The individual does not conform to the domains and ranges of its properties: " + strings.Join(msgs, "; ")
+}
+
+// Is returns true if one of the violations has the target error.
+func (e *DomainRangeError) Is(target error) bool {
+	for _, v := range e.Violations {
+		if v.Err == target {
+			return true
+		}
+	}
+	return false
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrDomainViolated is raised when an individual uses a property without being typed with the domain of the property.
+var ErrDomainViolated error = errors.New("The individual is not typed with the domain of the property")
+
+// ErrRangeViolated is raised when a property value is not typed with the range of the property.
+var ErrRangeViolated error = errors.New("The value is not typed with the range of the property")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// validateDomainsAndRanges checks the properties of the individual against the domains and ranges of the properties.
+func (ont *OntologyGraph) validateDomainsAndRanges(indiv *OntologyIndividual) error {
+	// Determine the classes of the individual including their superclasses
+	classes := map[string]bool{OWLThing: true}
+	for _, class := range indiv.Types {
+		superClasses, err := ont.GetSuperClasses(class, false)
+		if err != nil {
+			return err
+		}
+		classes[class] = true
+		for _, superClass := range superClasses {
+			classes[superClass] = true
+		}
+	}
+	violations := []DomainRangeViolation{}
+	checkDomains := func(prop string) error {
+		domains, err := ont.getDeclaredURIs(prop, RDFSDomain)
+		if err != nil {
+			return err
+		}
+		for _, domain := range domains {
+			if !classes[domain] {
+				violations = append(violations, DomainRangeViolation{Property: prop, Expected: domain, Err: ErrDomainViolated})
+			}
+		}
+		return nil
+	}
+	// Check the object properties against their domains and ranges
+	for _, prop := range sortedMapKeys(indiv.ObjectProperties) {
+		if err := checkDomains(prop); err != nil {
+			return err
+		}
+		ranges, err := ont.getDeclaredURIs(prop, RDFSRange)
+		if err != nil || len(ranges) == 0 {
+			return err
+		}
+		for _, target := range indiv.ObjectProperties[prop] {
+			targetClasses := classes
+			if target != indiv.URI {
+				types, err := GetPathTargets(ont.graph, uriTerm(target).String(), "a/<"+RDFSSubClassOf+">*")
+				if err != nil {
+					return err
+				}
+				targetClasses = map[string]bool{OWLThing: true}
+				for _, t := range types {
+					targetClasses[t.Value()] = true
+				}
+			}
+			for _, rng := range ranges {
+				if !targetClasses[rng] {
+					violations = append(violations, DomainRangeViolation{Property: prop, Value: target, Expected: rng, Err: ErrRangeViolated})
+				}
+			}
+		}
+	}
+	// Check the data properties against their domains and ranges
+	for _, prop := range sortedMapKeys(indiv.DataProperties) {
+		if err := checkDomains(prop); err != nil {
+			return err
+		}
+		ranges, err := ont.getDeclaredURIs(prop, RDFSRange)
+		if err != nil {
+			return err
+		}
+		for _, lit := range indiv.DataProperties[prop] {
+			for _, rng := range ranges {
+				if rng != RDFSLiteral && lit.Type().URI != rng {
+					violations = append(violations, DomainRangeViolation{Property: prop, Value: lit.Value(), Expected: rng, Err: ErrRangeViolated})
+				}
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return &DomainRangeError{Violations: violations}
+	}
+	return nil
+}
+
+// getDeclaredURIs returns the sorted URIs that the resource references with the predicate.
+func (ont *OntologyGraph) getDeclaredURIs(uri, pred string) ([]string, error) {
+	trps, err := ont.graph.GetAllMatches(NewResourceTerm(uri).String(), NewResourceTerm(pred).String(), "")
+	if err != nil {
+		return nil, err
+	}
+	uris := []string{}
+	for _, trp := range trps {
+		if trp.Object.IsResource() {
+			uris = append(uris, trp.Object.Value())
+		}
+	}
+	sort.Strings(uris)
+	return uris, nil
+}
+
+// sortedMapKeys returns the keys of the map in ascending order.
+func sortedMapKeys(m interface{}) []string {
+	keys := []string{}
+	switch m := m.(type) {
+	case map[string][]string:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string][]GenericLiteral:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
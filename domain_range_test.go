@@ -0,0 +1,88 @@
+package ontograph_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Strict domain and range validation", func() {
+	const ns = "http://example.com/onto#"
+	var store *MemoryStore
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.com/onto> a owl:Ontology .
+:Agent a owl:Class .
+:Person a owl:Class ; rdfs:subClassOf :Agent .
+:Employee a owl:Class ; rdfs:subClassOf :Person .
+:Company a owl:Class .
+:Startup a owl:Class ; rdfs:subClassOf :Company .
+:worksFor a owl:ObjectProperty ; rdfs:domain :Person ; rdfs:range :Company .
+:knows a owl:ObjectProperty ; rdfs:domain :Agent ; rdfs:range :Agent .
+:age a owl:DatatypeProperty ; rdfs:domain :Person ; rdfs:range xsd:integer .
+:note a owl:DatatypeProperty ; rdfs:range rdfs:Literal .
+:acme a :Startup .
+:bob a :Employee .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		ont.SetStrictDomainRange(true)
+	})
+
+	It("should accept individuals that conform via the class hierarchy", func() {
+		indiv := OntologyIndividual{
+			URI:              ns + "alice",
+			Types:            []string{OWLNamedIndividual, ns + "Employee"},
+			ObjectProperties: map[string][]string{ns + "worksFor": {ns + "acme"}, ns + "knows": {ns + "bob", ns + "alice"}},
+			DataProperties: map[string][]GenericLiteral{
+				ns + "age":  {*NewGenericLiteral(NewLiteralTerm("42", "", XSDInteger))},
+				ns + "note": {*NewGenericLiteral(NewLiteralTerm("likes tea", "en", ""))},
+			},
+		}
+		Expect(ont.UpsertResource(&indiv)).To(Succeed())
+		Expect(store.GetAllMatches(NewResourceTerm(ns+"alice").String(), "", "")).NotTo(BeEmpty())
+	})
+
+	It("should reject individuals that violate domains and ranges", func() {
+		indiv := OntologyIndividual{
+			URI:              ns + "acme2",
+			Types:            []string{OWLNamedIndividual, ns + "Company"},
+			ObjectProperties: map[string][]string{ns + "worksFor": {ns + "bob"}},
+			DataProperties:   map[string][]GenericLiteral{ns + "age": {*NewGenericLiteral(NewLiteralTerm("old", "", XSDString))}},
+		}
+		err := ont.UpsertResource(&indiv)
+		Expect(err).To(MatchError(ErrDomainViolated))
+		Expect(err).To(MatchError(ErrRangeViolated))
+		var drErr *DomainRangeError
+		Expect(errors.As(err, &drErr)).To(BeTrue())
+		Expect(drErr.Violations).To(Equal([]DomainRangeViolation{
+			{Property: ns + "worksFor", Expected: ns + "Person", Err: ErrDomainViolated},
+			{Property: ns + "worksFor", Value: ns + "bob", Expected: ns + "Company", Err: ErrRangeViolated},
+			{Property: ns + "age", Expected: ns + "Person", Err: ErrDomainViolated},
+			{Property: ns + "age", Value: "old", Expected: XSDInteger, Err: ErrRangeViolated},
+		}))
+		// Nothing is stored
+		Expect(store.GetAllMatches(NewResourceTerm(ns+"acme2").String(), "", "")).To(BeEmpty())
+	})
+
+	It("should not validate when strict mode is disabled", func() {
+		ont.SetStrictDomainRange(false)
+		indiv := OntologyIndividual{
+			URI:              ns + "acme2",
+			Types:            []string{OWLNamedIndividual, ns + "Company"},
+			ObjectProperties: map[string][]string{ns + "worksFor": {ns + "bob"}},
+		}
+		Expect(ont.UpsertResource(&indiv)).To(Succeed())
+	})
+})
@@ -0,0 +1,117 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// StoreError wraps an error raised by a graph store operation with the context in which it occurred. The wrapped error can be
+// checked with `errors.Is` (e.g. against `ErrTripleAlreadyExists`) and the context can be retrieved with `errors.As`.
+type StoreError struct {
+	// Op is the name of the failed store operation (e.g. `AddTriple`).
+	Op string
+	// Backend names the store implementation (e.g. `memory` or `blazegraph`).
+	Backend string
+	// Graph is the named graph URI of the store.
+	Graph string
+	// Triple is the triple the operation failed for (if any).
+	Triple *Triple
+	// Pattern is the triple pattern the operation failed for (if any). Empty terms of the pattern are wildcards.
+	Pattern *Triple
+	// Query is the SPARQL query that failed (if any).
+	Query string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the underlying error message prefixed with the context.
+func (e *StoreError) Error() string {
+	var msg strings.Builder
+	msg.WriteString(fmt.Sprintf("%s store: %s on graph <%s>", e.Backend, e.Op, e.Graph))
+	if e.Triple != nil {
+		msg.WriteString(fmt.Sprintf(" for triple %s %s %s", e.Triple.Subject, e.Triple.Predicate, e.Triple.Object))
+	}
+	if e.Pattern != nil {
+		terms := []string{}
+		for _, t := range []Term{e.Pattern.Subject, e.Pattern.Predicate, e.Pattern.Object} {
+			if t == "" {
+				t = "?"
+			}
+			terms = append(terms, t.String())
+		}
+		msg.WriteString(" for pattern " + strings.Join(terms, " "))
+	}
+	msg.WriteString(": ")
+	msg.WriteString(e.Err.Error())
+	return msg.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *StoreError) Unwrap() error {
+	return e.Err
+}
+
+// ResourceError wraps an error raised by an ontology graph operation with the URI of the resource it occurred for. The wrapped error
+// can be checked with `errors.Is` (e.g. against `ErrResourceNotFound`) and the context can be retrieved with `errors.As`.
+type ResourceError struct {
+	// Op is the name of the failed operation (e.g. `GetClass`).
+	Op string
+	// URI is the URI of the resource the operation failed for.
+	URI string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the underlying error message prefixed with the context.
+func (e *ResourceError) Error() string {
+	return fmt.Sprintf("%s <%s>: %s", e.Op, e.URI, e.Err.Error())
+}
+
+// Unwrap returns the underlying error.
+func (e *ResourceError) Unwrap() error {
+	return e.Err
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// wrapStoreError wraps the error with the store context. Returns nil if there is no error and leaves errors that already carry a
+// store context untouched, so that nested store calls do not stack the same context twice.
+func wrapStoreError(store GraphStore, backend, op string, trp *Triple, query string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var storeErr *StoreError
+	if errors.As(err, &storeErr) {
+		return err
+	}
+	if trp != nil {
+		trpCopy := *trp
+		trp = &trpCopy
+	}
+	return &StoreError{Op: op, Backend: backend, Graph: store.GetURI(), Triple: trp, Query: query, Err: err}
+}
+
+// wrapResourceError wraps the error with the resource context. Returns nil if there is no error and leaves errors that already carry
+// a resource context untouched.
+func wrapResourceError(op, uri string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var resErr *ResourceError
+	if errors.As(err, &resErr) {
+		return err
+	}
+	return &ResourceError{Op: op, URI: uri, Err: err}
+}
+
+// withStorePattern adds the triple pattern to the store context of the error, unless the context already has a pattern.
+func withStorePattern(err error, subj, pred, obj string) error {
+	var storeErr *StoreError
+	if errors.As(err, &storeErr) && storeErr.Pattern == nil {
+		storeErr.Pattern = &Triple{Subject: Term(subj), Predicate: Term(pred), Object: Term(obj)}
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+package ontograph_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+// failingStore is a memory store whose pattern matching fails with the error (if set).
+type failingStore struct {
+	*MemoryStore
+	err error
+}
+
+func (store *failingStore) GetAllMatches(subj, pred, obj string) ([]Triple, error) {
+	if store.err != nil {
+		return nil, store.err
+	}
+	return store.MemoryStore.GetAllMatches(subj, pred, obj)
+}
+
+var _ = Describe("Errors", func() {
+	const testUri = "http://example.com/onto"
+	testTriple := Triple{
+		Subject:   NewResourceTerm(testUri + "#A"),
+		Predicate: NewResourceTerm(RDFType),
+		Object:    NewResourceTerm(OWLClass),
+	}
+
+	It("should wrap store errors with the triple context", func() {
+		store := NewMemoryStore(testUri)
+		Expect(store.AddTriple(testTriple)).To(Succeed())
+		err := store.AddTriple(testTriple)
+		Expect(errors.Is(err, ErrTripleAlreadyExists)).To(BeTrue())
+		var storeErr *StoreError
+		Expect(errors.As(err, &storeErr)).To(BeTrue())
+		Expect(storeErr.Op).To(Equal("AddTriple"))
+		Expect(storeErr.Backend).To(Equal("memory"))
+		Expect(storeErr.Graph).To(Equal(testUri))
+		Expect(*storeErr.Triple).To(Equal(testTriple))
+		Expect(err.Error()).To(ContainSubstring(testTriple.Subject.String()))
+	})
+
+	It("should not stack store contexts of nested calls", func() {
+		store := NewMemoryStore(testUri)
+		err := store.DeleteTriples([]Triple{testTriple})
+		var storeErr *StoreError
+		Expect(errors.As(err, &storeErr)).To(BeTrue())
+		Expect(storeErr.Op).To(Equal("DeleteTriple"))
+		Expect(storeErr.Err).To(Equal(ErrTripleDoesNotExist))
+	})
+
+	It("should wrap ontology errors with the resource context", func() {
+		ont, err := InitOntologyGraph(NewMemoryStore(testUri))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ont.GetClass(testUri + "#Missing")
+		Expect(errors.Is(err, ErrResourceNotFound)).To(BeTrue())
+		var resErr *ResourceError
+		Expect(errors.As(err, &resErr)).To(BeTrue())
+		Expect(resErr.Op).To(Equal("GetClass"))
+		Expect(resErr.URI).To(Equal(testUri + "#Missing"))
+	})
+
+	It("should wrap failed store operations with the pattern and resource context", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		store := NewBlazegraphEndpoint(server.URL).NewBlazegraphStore(testUri, "test")
+		_, err := store.GetAllMatches(testTriple.Subject.String(), "", "")
+		var storeErr *StoreError
+		Expect(errors.As(err, &storeErr)).To(BeTrue())
+		Expect(storeErr.Op).To(Equal("GetAllMatches"))
+		Expect(*storeErr.Pattern).To(Equal(Triple{Subject: testTriple.Subject}))
+		Expect(err.Error()).To(ContainSubstring("for pattern <" + testUri + "#A> ? ?"))
+
+		// Ontology operations add the resource context
+		failing := &failingStore{MemoryStore: NewMemoryStore(testUri)}
+		ont, err := InitOntologyGraph(failing)
+		Expect(err).NotTo(HaveOccurred())
+		failing.err = errors.New("unavailable")
+		_, err = ont.GetIndividual(testUri + "#a")
+		var resErr *ResourceError
+		Expect(errors.As(err, &resErr)).To(BeTrue())
+		Expect(resErr.Op).To(Equal("GetIndividual"))
+		Expect(resErr.URI).To(Equal(testUri + "#a"))
+		Expect(errors.Is(err, failing.err)).To(BeTrue())
+	})
+})
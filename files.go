@@ -0,0 +1,52 @@
+package ontograph
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ParseFile creates a new memory store from the RDF file at the given path. The format is derived from the file extension (`.ttl`,
+// `.nt`, `.jsonld`, `.rdf`, `.owl`, `.xml` or the extension of a registered format) and sniffed from the data otherwise. A `.gz` suffix (e.g. `onto.ttl.gz`) is ignored
+// for the format, since gzip compressed data is decompressed transparently.
+func ParseFile(path string, opts ...ParseOption) (*MemoryStore, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseGraph(f, mediaTypeFromPath(path), opts...)
+}
+
+// SerializeToFile writes the entire store in Turtle (TTL) format into the file at the given path. If the path ends with `.gz`, the data
+// is compressed with gzip. An existing file is overwritten.
+func SerializeToFile(store GraphStore, path string, pretty bool, opts ...SerializeOption) error {
+	if strings.EqualFold(filepath.Ext(path), ".gz") {
+		opts = append(opts, WithGzip())
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := SerializeToTurtle(store, f, pretty, opts...); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// mediaTypeFromPath returns the media type for the extension of the path or the empty string if the extension is unknown.
+func mediaTypeFromPath(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".gz" {
+		ext = strings.ToLower(filepath.Ext(strings.TrimSuffix(path, filepath.Ext(path))))
+	}
+	if format, ok := lookupFormatByExtension(ext); ok {
+		return format.MediaType
+	}
+	return ""
+}
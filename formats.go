@@ -0,0 +1,231 @@
+package ontograph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/deiu/rdf2go"
+)
+
+// ParseFunc creates a new memory store from the RDF data in the reader. Implementations should respect the parse options.
+type ParseFunc func(r io.Reader, opts ...ParseOption) (*MemoryStore, error)
+
+// SerializeFunc writes all triples of the graph store into the writer. Implementations should respect the serialize options.
+type SerializeFunc func(w io.Writer, store GraphStore, opts ...SerializeOption) error
+
+// Format describes a RDF serialization format. Formats are registered with `RegisterFormat` and selected by media type in
+// `ParseGraph` and `Serialize` or by file extension in `ParseFile`. Parse or Serialize may be nil if the format can only be read or
+// only be written.
+type Format struct {
+	// MediaType is the canonical media type of the format, e.g. `application/ld+json`.
+	MediaType string
+	// Aliases are further media types that select the format, e.g. `application/json`.
+	Aliases []string
+	// Extensions are the file extensions (including the dot) of the format, e.g. `.jsonld`.
+	Extensions []string
+	// Parse reads data in the format.
+	Parse ParseFunc
+	// Serialize writes data in the format.
+	Serialize SerializeFunc
+}
+
+// RegisterFormat adds the format to the registry, so third parties can plug in new formats (e.g. HDT or custom formats). An already
+// registered format with the same media type, alias or extension is replaced for these. The built-in formats are Turtle, N-Triples,
+// JSON-LD and RDF/XML (read-only).
+func RegisterFormat(format Format) {
+	formats.Lock()
+	defer formats.Unlock()
+	formats.byMediaType[strings.ToLower(format.MediaType)] = format
+	for _, alias := range format.Aliases {
+		formats.byMediaType[strings.ToLower(alias)] = format
+	}
+	for _, ext := range format.Extensions {
+		formats.byExtension[strings.ToLower(ext)] = format
+	}
+}
+
+// LookupFormat returns the registered format for the media type (or one of its aliases). Parameters of the media type (like the charset)
+// are ignored. Returns false if no format is registered for the media type.
+func LookupFormat(mediaType string) (Format, bool) {
+	if parsed, _, err := mime.ParseMediaType(mediaType); err == nil {
+		mediaType = parsed
+	}
+	formats.RLock()
+	defer formats.RUnlock()
+	format, ok := formats.byMediaType[strings.ToLower(mediaType)]
+	return format, ok
+}
+
+// Serialize writes all triples of the graph store in the format registered for the media type into the writer. Errors with
+// `ErrUnsupportedFormat` if no format that can be written is registered for the media type.
+func Serialize(store GraphStore, w io.Writer, mediaType string, opts ...SerializeOption) error {
+	format, ok := LookupFormat(mediaType)
+	if !ok || format.Serialize == nil {
+		return fmt.Errorf("%w: '%s'", ErrUnsupportedFormat, mediaType)
+	}
+	return format.Serialize(w, store, opts...)
+}
+
+// Serialize writes the entire store in the format registered for the media type into the writer (see `Serialize`).
+func (store *MemoryStore) Serialize(w io.Writer, mediaType string, opts ...SerializeOption) error {
+	return Serialize(store, w, mediaType, opts...)
+}
+
+// Serialize writes the entire store in the format registered for the media type into the writer (see `Serialize`).
+func (store *BlazegraphStore) Serialize(w io.Writer, mediaType string, opts ...SerializeOption) error {
+	return Serialize(store, w, mediaType, opts...)
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// formats is the registry of the serialization formats.
+var formats = struct {
+	sync.RWMutex
+	byMediaType map[string]Format
+	byExtension map[string]Format
+}{
+	byMediaType: map[string]Format{},
+	byExtension: map[string]Format{},
+}
+
+func init() {
+	RegisterFormat(Format{
+		MediaType:  MediaTypeTurtle,
+		Aliases:    []string{"application/x-turtle"},
+		Extensions: []string{".ttl"},
+		Parse:      ParseFromTurtle,
+		Serialize: func(w io.Writer, store GraphStore, opts ...SerializeOption) error {
+			return SerializeToTurtle(store, w, true, opts...)
+		},
+	})
+	RegisterFormat(Format{
+		MediaType:  MediaTypeNTriples,
+		Extensions: []string{".nt"},
+		// N-Triples is a subset of Turtle
+		Parse:     ParseFromTurtle,
+		Serialize: serializeNTriples,
+	})
+	RegisterFormat(Format{
+		MediaType:  MediaTypeJSONLD,
+		Aliases:    []string{"application/json"},
+		Extensions: []string{".jsonld"},
+		Parse:      parseJSONLD,
+		Serialize:  serializeJSONLD,
+	})
+	RegisterFormat(Format{
+		MediaType:  MediaTypeRDFXML,
+		Aliases:    []string{"application/xml", "text/xml"},
+		Extensions: []string{".rdf", ".owl", ".xml"},
+		Parse:      parseRDFXMLStore,
+	})
+}
+
+// lookupFormatByExtension returns the registered format for the file extension (including the dot).
+func lookupFormatByExtension(ext string) (Format, bool) {
+	formats.RLock()
+	defer formats.RUnlock()
+	format, ok := formats.byExtension[strings.ToLower(ext)]
+	return format, ok
+}
+
+// parseJSONLD creates a new memory store from the JSON-LD data in the reader.
+func parseJSONLD(r io.Reader, opts ...ParseOption) (*MemoryStore, error) {
+	options := newParseOptions(opts)
+	r, err := decompressReader(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err := fixJSONLDIntegers(r)
+	if err != nil {
+		return nil, err
+	}
+	g := rdf2go.NewGraph(options.baseURI)
+	if err := g.Parse(bytes.NewReader(data), MediaTypeJSONLD); err != nil {
+		return nil, err
+	}
+	return newParsedMemoryStore(g, options)
+}
+
+// parseRDFXMLStore creates a new memory store from the RDF/XML data in the reader.
+func parseRDFXMLStore(r io.Reader, opts ...ParseOption) (*MemoryStore, error) {
+	options := newParseOptions(opts)
+	r, err := decompressReader(r)
+	if err != nil {
+		return nil, err
+	}
+	g := rdf2go.NewGraph(options.baseURI)
+	if err := parseRDFXML(r, g, options.baseURI); err != nil {
+		return nil, err
+	}
+	return newParsedMemoryStore(g, options)
+}
+
+// serializeNTriples writes the triples of the store as sorted N-Triples.
+func serializeNTriples(w io.Writer, store GraphStore, opts ...SerializeOption) error {
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return err
+	}
+	SortTriples(trps)
+	var sb strings.Builder
+	for _, trp := range trps {
+		sb.WriteString(nTriplesLine(trp))
+	}
+	return newSerializeOptions(opts).write(w, sb.String())
+}
+
+// serializeJSONLD writes the triples of the store as expanded JSON-LD document with one node object per subject (sorted by subject).
+func serializeJSONLD(w io.Writer, store GraphStore, opts ...SerializeOption) error {
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return err
+	}
+	SortTriples(trps)
+	nodes := []map[string]interface{}{}
+	for i, trp := range trps {
+		if i == 0 || trps[i-1].Subject != trp.Subject {
+			nodes = append(nodes, map[string]interface{}{"@id": jsonLDID(trp.Subject)})
+		}
+		node := nodes[len(nodes)-1]
+		values, _ := node[trp.Predicate.Value()].([]map[string]string)
+		node[trp.Predicate.Value()] = append(values, jsonLDValue(trp.Object))
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i]["@id"].(string) < nodes[j]["@id"].(string)
+	})
+	data, err := json.MarshalIndent(nodes, "", "  ")
+	if err != nil {
+		return err
+	}
+	return newSerializeOptions(opts).write(w, string(data)+"\n")
+}
+
+// jsonLDID returns the JSON-LD identifier of a resource or blank node.
+func jsonLDID(t Term) string {
+	if t.IsResource() {
+		return t.Value()
+	}
+	return t.String()
+}
+
+// jsonLDValue returns the JSON-LD value object of the term.
+func jsonLDValue(t Term) map[string]string {
+	if !t.IsLiteral() {
+		return map[string]string{"@id": jsonLDID(t)}
+	}
+	value := map[string]string{"@value": unescapeLiteral(t.Value())}
+	if t.Language() != "" {
+		value["@language"] = t.Language()
+	} else if t.Datatype() != "" {
+		value["@type"] = t.Datatype()
+	}
+	return value
+}
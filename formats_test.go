@@ -0,0 +1,107 @@
+package ontograph_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Format registry", func() {
+	const ttl = `@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:A a owl:Class ;
+  :label "Quote \" and newline\n"@en ;
+  :size 3 .
+`
+	var store *MemoryStore
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(ttl))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	roundTrip := func(mediaType string) {
+		var buf bytes.Buffer
+		Expect(store.Serialize(&buf, mediaType)).To(Succeed())
+		parsed, err := ParseGraph(&buf, mediaType)
+		Expect(err).NotTo(HaveOccurred())
+		expected, _ := store.GetAllTriples()
+		Expect(parsed.GetAllTriples()).To(ConsistOf(expected))
+	}
+
+	It("should serialize and parse the built-in formats", func() {
+		roundTrip(MediaTypeTurtle)
+		roundTrip(MediaTypeNTriples)
+		roundTrip(MediaTypeJSONLD)
+	})
+
+	It("should serialize JSON-LD as expanded document", func() {
+		var buf bytes.Buffer
+		Expect(store.Serialize(&buf, "application/ld+json; charset=utf-8")).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring(`"@value": "Quote \" and newline\n"`))
+		Expect(buf.String()).To(ContainSubstring(`"@language": "en"`))
+	})
+
+	It("should reject formats that cannot be written", func() {
+		Expect(store.Serialize(ioutil.Discard, MediaTypeRDFXML)).To(MatchError(ErrUnsupportedFormat))
+		Expect(store.Serialize(ioutil.Discard, "application/x-unknown")).To(MatchError(ErrUnsupportedFormat))
+	})
+
+	It("should plug in custom formats", func() {
+		// Lines of subject and object URIs related by a fixed predicate
+		RegisterFormat(Format{
+			MediaType:  "text/x-pairs",
+			Extensions: []string{".pairs"},
+			Parse: func(r io.Reader, opts ...ParseOption) (*MemoryStore, error) {
+				data, err := ioutil.ReadAll(r)
+				if err != nil {
+					return nil, err
+				}
+				var nt strings.Builder
+				for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+					fields := strings.Fields(line)
+					nt.WriteString(fmt.Sprintf("<%s> <http://example.com/onto#rel> <%s> .\n", fields[0], fields[1]))
+				}
+				return ParseFromTurtle(strings.NewReader(nt.String()), opts...)
+			},
+			Serialize: func(w io.Writer, store GraphStore, opts ...SerializeOption) error {
+				trps, err := store.GetAllMatches("", "<http://example.com/onto#rel>", "")
+				if err != nil {
+					return err
+				}
+				for _, trp := range trps {
+					fmt.Fprintf(w, "%s %s\n", trp.Subject.Value(), trp.Object.Value())
+				}
+				return nil
+			},
+		})
+		format, ok := LookupFormat("text/x-pairs")
+		Expect(ok).To(BeTrue())
+		Expect(format.Extensions).To(Equal([]string{".pairs"}))
+
+		parsed, err := ParseGraph(strings.NewReader("http://example.com/onto#a http://example.com/onto#b\n"), "text/x-pairs")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.Size()).To(Equal(1))
+		var buf bytes.Buffer
+		Expect(Serialize(parsed, &buf, "text/x-pairs")).To(Succeed())
+		Expect(buf.String()).To(Equal("http://example.com/onto#a http://example.com/onto#b\n"))
+	})
+
+	It("should apply serialize options to stores without native support", func() {
+		var native, generic bytes.Buffer
+		opts := []SerializeOption{WithSortedOutput(), WithPrefixes(PrefixMap{"ex": "http://example.com/onto#"})}
+		Expect(SerializeToTurtle(store, &native, true, opts...)).To(Succeed())
+		Expect(SerializeToTurtle(plainStore{store}, &generic, true, opts...)).To(Succeed())
+		Expect(generic.String()).To(Equal(native.String()))
+		Expect(generic.String()).To(ContainSubstring("ex:A"))
+	})
+})
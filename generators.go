@@ -0,0 +1,76 @@
+package ontograph
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lithammer/shortuuid/v3"
+)
+
+// An IDGenerator generates unique identifiers, e.g. for the local names of new resources or for blank node labels.
+type IDGenerator interface {
+	// NewID should return a new identifier that has not been returned before.
+	NewID() string
+}
+
+// A Clock provides the current time, e.g. for provenance timestamps.
+type Clock interface {
+	// Now should return the current time.
+	Now() time.Time
+}
+
+// DefaultIDGenerator is the ID generator used by ontology graphs unless another one is set. It generates random short UUIDs.
+var DefaultIDGenerator IDGenerator = ShortUUIDGenerator{}
+
+// DefaultClock is the clock used by ontology graphs unless another one is set. It returns the system time.
+var DefaultClock Clock = SystemClock{}
+
+// ********************
+// * Implementations *
+// ********************
+
+// ShortUUIDGenerator generates random, URL-safe short UUIDs.
+type ShortUUIDGenerator struct{}
+
+// NewID returns a new random short UUID.
+func (gen ShortUUIDGenerator) NewID() string {
+	return shortuuid.New()
+}
+
+// SequentialIDGenerator generates deterministic identifiers by appending an increasing counter to a prefix (e.g. `id1`, `id2`, ...).
+// It is intended for tests and golden files that must stay stable.
+type SequentialIDGenerator struct {
+	Prefix  string
+	mu      sync.Mutex
+	counter int
+}
+
+// NewSequentialIDGenerator creates a new sequential ID generator with the given prefix.
+func NewSequentialIDGenerator(prefix string) *SequentialIDGenerator {
+	return &SequentialIDGenerator{Prefix: prefix}
+}
+
+// NewID returns the next identifier in the sequence.
+func (gen *SequentialIDGenerator) NewID() string {
+	gen.mu.Lock()
+	defer gen.mu.Unlock()
+	gen.counter++
+	return fmt.Sprintf("%s%d", gen.Prefix, gen.counter)
+}
+
+// SystemClock returns the current system time.
+type SystemClock struct{}
+
+// Now returns the current system time.
+func (c SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock always returns the same point in time. It is intended for tests and golden files that must stay stable.
+type FixedClock time.Time
+
+// Now returns the fixed point in time.
+func (c FixedClock) Now() time.Time {
+	return time.Time(c)
+}
@@ -0,0 +1,74 @@
+package ontograph_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Generators", func() {
+	const testUri = "https://www.ontograph.com/test-generators"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		var err error
+		ont, err = InitOntologyGraph(NewMemoryStore(testUri))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should generate unique resource URIs by default", func() {
+		uri1, uri2 := ont.NewResourceURI(), ont.NewResourceURI()
+		Expect(uri1).To(HavePrefix(testUri + "#"))
+		Expect(uri1).NotTo(Equal(uri2))
+	})
+
+	It("should use an injected ID generator", func() {
+		ont.SetIDGenerator(NewSequentialIDGenerator("res"))
+		Expect(ont.NewResourceURI()).To(Equal(testUri + "#res1"))
+		Expect(ont.NewResourceURI()).To(Equal(testUri + "#res2"))
+	})
+
+	It("should label new blank nodes with the injected ID generator", func() {
+		build := func() []Triple {
+			store := NewMemoryStore(testUri)
+			ont, err := InitOntologyGraph(store)
+			Expect(err).NotTo(HaveOccurred())
+			ont.SetIDGenerator(NewSequentialIDGenerator("b"))
+			class := OntologyClass{
+				URI:          testUri + "#Parent",
+				Restrictions: []OntologyRestriction{{OnProperty: testUri + "#hasChild", SomeValuesFrom: testUri + "#Person"}},
+				EquivalentToExpressions: []ClassExpression{{UnionOf: []ClassExpression{
+					{Class: testUri + "#Mother"}, {Class: testUri + "#Father"},
+				}}},
+			}
+			Expect(ont.UpsertResource(&class)).To(Succeed())
+			axiom := Triple{Subject: NewResourceTerm(class.URI), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)}
+			Expect(ont.AnnotateAxiom(axiom, map[string][]GenericLiteral{RDFSComment: {XSDStringLiteral("Declared").Generic()}})).To(Succeed())
+			trps, err := store.GetAllTriples()
+			Expect(err).NotTo(HaveOccurred())
+			return trps
+		}
+		trps := build()
+		nodes := map[Term]bool{}
+		for _, trp := range trps {
+			if trp.Subject.IsBlankNode() {
+				nodes[trp.Subject] = true
+			}
+		}
+		// Restriction, union, two list nodes and the axiom
+		Expect(nodes).To(Equal(map[Term]bool{
+			NewBlankNodeTerm("b1"): true, NewBlankNodeTerm("b2"): true, NewBlankNodeTerm("b3"): true,
+			NewBlankNodeTerm("b4"): true, NewBlankNodeTerm("b5"): true,
+		}))
+		Expect(trps).To(ConsistOf(build()))
+	})
+
+	It("should use an injected clock", func() {
+		fixed := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		ont.SetClock(FixedClock(fixed))
+		Expect(ont.Now()).To(Equal(fixed))
+	})
+})
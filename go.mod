@@ -1,15 +1,26 @@
 module github.com/kahefi/ontograph
 
-go 1.14
+go 1.18
 
 require (
-	github.com/deiu/gon3 v0.0.0-20170627184619-f84eb1e0bd62 // indirect
+	github.com/deiu/gon3 v0.0.0-20170627184619-f84eb1e0bd62
 	github.com/deiu/rdf2go v0.0.0-20180504135839-3c24cc9e7afa
-	github.com/linkeddata/gojsonld v0.0.0-20170418210642-4f5db6791326 // indirect
 	github.com/lithammer/shortuuid v3.0.0+incompatible
 	github.com/lithammer/shortuuid/v3 v3.0.6
 	github.com/onsi/ginkgo v1.16.1
 	github.com/onsi/gomega v1.11.0
-	github.com/rychipman/easylex v0.0.0-20160129204217-49ee7767142f // indirect
 	github.com/teris-io/shortid v0.0.0-20201117134242-e59966efd125
 )
+
+require (
+	github.com/fsnotify/fsnotify v1.4.9 // indirect
+	github.com/google/uuid v1.2.0 // indirect
+	github.com/linkeddata/gojsonld v0.0.0-20170418210642-4f5db6791326 // indirect
+	github.com/nxadm/tail v1.4.8 // indirect
+	github.com/rychipman/easylex v0.0.0-20160129204217-49ee7767142f // indirect
+	golang.org/x/net v0.0.0-20201202161906-c7110b5ffcbb // indirect
+	golang.org/x/sys v0.0.0-20210112080510-489259a85091 // indirect
+	golang.org/x/text v0.3.3 // indirect
+	gopkg.in/tomb.v1 v1.0.0-20141024135613-dd632973f1e7 // indirect
+	gopkg.in/yaml.v2 v2.4.0 // indirect
+)
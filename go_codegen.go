@@ -0,0 +1,254 @@
+package ontograph
+
+import (
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// GoCodeOptions configures the Go code generation from an ontology graph.
+type GoCodeOptions struct {
+	// Package is the name of the generated Go package.
+	Package string
+	// Language selects the language of the comments used as doc comments of the generated types and fields.
+	Language string
+}
+
+// GenerateGoCode writes Go code for the classes and properties of the ontology into the writer, so application code can be kept in
+// sync with the ontology schema. It generates a `Class<Name>` constant for each class and a `Property<Name>` constant for each object
+// and data property, as well as a struct type per class with an `onto` tagged field for each property whose domain is the class or one
+// of its super classes (see `MarshalIndividual`). Functional properties become single valued fields, all others slices. Data property
+// ranges are mapped to the corresponding Go types (e.g. `xsd:integer` to `int` or `xsd:dateTime` to `time.Time`), object properties
+// to URI references. For each type, a `New<Name>` constructor, a `Get<Name>` function to load the type from an ontology graph and an
+// `Upsert` method are generated. Deprecated and anonymous resources are skipped and names are derived from the local names of the URIs.
+func (ont *OntologyGraph) GenerateGoCode(w io.Writer, opts GoCodeOptions) error {
+	classes, err := ont.GetClasses()
+	if err != nil {
+		return err
+	}
+	objProps, err := ont.GetObjectProperties()
+	if err != nil {
+		return err
+	}
+	dataProps, err := ont.GetDataProperties()
+	if err != nil {
+		return err
+	}
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = "ontology"
+	}
+
+	// Collect the properties as struct fields per domain
+	names := map[string]bool{}
+	fields := []goField{}
+	for _, prop := range objProps {
+		if !prop.IsDeprecated && !isAnonymousURI(prop.URI) {
+			fields = append(fields, goField{uri: prop.URI, goType: "string", isRef: true, isFunctional: prop.IsFunctional, domains: prop.Domains, comment: prop.Comment[opts.Language]})
+		}
+	}
+	for _, prop := range dataProps {
+		if !prop.IsDeprecated && !isAnonymousURI(prop.URI) {
+			goType := "string"
+			if len(prop.Ranges) == 1 && goLiteralTypes[prop.Ranges[0]] != "" {
+				goType = goLiteralTypes[prop.Ranges[0]]
+			}
+			fields = append(fields, goField{uri: prop.URI, goType: goType, isFunctional: prop.IsFunctional, domains: prop.Domains, comment: prop.Comment[opts.Language]})
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].uri < fields[j].uri })
+	for i := range fields {
+		fields[i].name = goIdentifier(localName(fields[i].uri), names, "Property")
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i].URI < classes[j].URI })
+	types := []goType{}
+	for _, class := range classes {
+		if class.IsDeprecated || isAnonymousURI(class.URI) {
+			continue
+		}
+		superClasses, err := ont.GetSuperClasses(class.URI, false)
+		if err != nil {
+			return err
+		}
+		isDomain := map[string]bool{class.URI: true}
+		for _, uri := range superClasses {
+			isDomain[uri] = true
+		}
+		t := goType{uri: class.URI, name: goIdentifier(localName(class.URI), names, "Class"), comment: class.Comment[opts.Language]}
+		for _, field := range fields {
+			for _, domain := range field.domains {
+				if isDomain[domain] {
+					t.fields = append(t.fields, field)
+					break
+				}
+			}
+		}
+		types = append(types, t)
+	}
+
+	// Write the source code
+	var src strings.Builder
+	fmt.Fprintf(&src, "// Code generated by ontograph from <%s>. DO NOT EDIT.\n\n", ont.GetURI())
+	fmt.Fprintf(&src, "package %s\n\n", pkg)
+	imports := map[string]bool{}
+	for _, t := range types {
+		for _, field := range t.fields {
+			if strings.HasPrefix(field.goType, "time.") {
+				imports["time"] = true
+			} else if strings.HasPrefix(field.goType, "*big.") {
+				imports["math/big"] = true
+			}
+		}
+	}
+	if len(types) > 0 {
+		// Standard library imports come first, separated from the ontograph import
+		src.WriteString("import (\n")
+		for _, path := range sortedKeys(imports) {
+			fmt.Fprintf(&src, "\t%q\n", path)
+		}
+		if len(imports) > 0 {
+			src.WriteString("\n")
+		}
+		src.WriteString("\t\"github.com/kahefi/ontograph\"\n)\n\n")
+	}
+	src.WriteString("// Class URIs\nconst (\n")
+	for _, t := range types {
+		fmt.Fprintf(&src, "\tClass%s = %q\n", t.name, t.uri)
+	}
+	src.WriteString(")\n\n// Property URIs\nconst (\n")
+	for _, field := range fields {
+		fmt.Fprintf(&src, "\tProperty%s = %q\n", field.name, field.uri)
+	}
+	src.WriteString(")\n")
+	for _, t := range types {
+		t.writeTo(&src)
+	}
+	formatted, err := format.Source([]byte(src.String()))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// goLiteralTypes maps the datatypes of data property ranges to the Go types of the generated fields.
+var goLiteralTypes = map[string]string{
+	XSDString:             "string",
+	XSDBoolean:            "bool",
+	XSDInteger:            "int",
+	XSDLong:               "int64",
+	XSDInt:                "int32",
+	XSDShort:              "int16",
+	XSDByte:               "int8",
+	XSDUnsignedLong:       "uint64",
+	XSDUnsignedInt:        "uint32",
+	XSDUnsignedShort:      "uint16",
+	XSDUnsignedByte:       "uint8",
+	XSDNonNegativeInteger: "uint64",
+	XSDPositiveInteger:    "uint64",
+	XSDDecimal:            "*big.Rat",
+	XSDFloat:              "float32",
+	XSDDouble:             "float64",
+	XSDDateTime:           "time.Time",
+	XSDDate:               "ontograph.XSDDateLiteral",
+	XSDTime:               "ontograph.XSDTimeLiteral",
+	XSDGYear:              "ontograph.XSDGYearLiteral",
+	XSDGYearMonth:         "ontograph.XSDGYearMonthLiteral",
+	XSDDuration:           "time.Duration",
+	XSDBase64Binary:       "[]byte",
+	XSDHexBinary:          "ontograph.XSDHexBinaryLiteral",
+	XSDAnyURI:             "ontograph.XSDAnyURILiteral",
+	RDFLangString:         "ontograph.LangStringLiteral",
+}
+
+// goField is a property that is generated as struct field.
+type goField struct {
+	uri          string
+	name         string
+	goType       string
+	isRef        bool
+	isFunctional bool
+	domains      []string
+	comment      string
+}
+
+// goType is a class that is generated as struct type.
+type goType struct {
+	uri     string
+	name    string
+	comment string
+	fields  []goField
+}
+
+// writeTo writes the struct type and its functions into the builder.
+func (t goType) writeTo(src *strings.Builder) {
+	fmt.Fprintf(src, "\n// %s represents the individuals of the class <%s>.", t.name, t.uri)
+	if t.comment != "" {
+		fmt.Fprintf(src, " %s", goComment(t.comment))
+	}
+	fmt.Fprintf(src, "\ntype %s struct {\n", t.name)
+	src.WriteString("\tURI string `onto:\"@id\"`\n")
+	src.WriteString("\tTypes []string `onto:\"@type\"`\n")
+	for _, field := range t.fields {
+		if field.comment != "" {
+			fmt.Fprintf(src, "\t// %s\n", goComment(field.comment))
+		}
+		goType, tag := "[]"+field.goType, field.uri
+		if field.isFunctional {
+			goType, tag = field.goType, tag+",omitempty"
+		}
+		if field.isRef {
+			tag += ",ref"
+		}
+		fmt.Fprintf(src, "\t%s %s `onto:%q`\n", field.name, goType, tag)
+	}
+	src.WriteString("}\n\n")
+	fmt.Fprintf(src, "// New%[1]s creates a new %[1]s with the given URI.\n", t.name)
+	fmt.Fprintf(src, "func New%[1]s(uri string) *%[1]s {\n\treturn &%[1]s{URI: uri, Types: []string{Class%[1]s}}\n}\n\n", t.name)
+	fmt.Fprintf(src, "// Get%[1]s retrieves the %[1]s with the given URI from the ontology graph.\n", t.name)
+	fmt.Fprintf(src, "func Get%[1]s(ont *ontograph.OntologyGraph, uri string) (*%[1]s, error) {\n", t.name)
+	fmt.Fprintf(src, "\tres := &%s{}\n\tif err := ont.GetStruct(uri, res); err != nil {\n\t\treturn nil, err\n\t}\n\treturn res, nil\n}\n\n", t.name)
+	fmt.Fprintf(src, "// Upsert inserts or updates the %s in the ontology graph.\n", t.name)
+	fmt.Fprintf(src, "func (res *%s) Upsert(ont *ontograph.OntologyGraph) error {\n\treturn ont.UpsertStruct(res)\n}\n", t.name)
+}
+
+// goIdentifier converts the local name into an exported Go identifier that is unique among the used names (which are updated). Names
+// already used or reserved for the given kind get a numeric suffix.
+func goIdentifier(name string, used map[string]bool, kind string) string {
+	var ident strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		ident.WriteRune(r)
+	}
+	base := ident.String()
+	if base == "" || !unicode.IsLetter([]rune(base)[0]) {
+		base = kind + base
+	}
+	reserved := map[string]bool{"URI": true, "Types": true}
+	result := base
+	for i := 2; used[kind+" "+result] || reserved[result]; i++ {
+		result = fmt.Sprintf("%s%d", base, i)
+	}
+	used[kind+" "+result] = true
+	return result
+}
+
+// goComment collapses the whitespace of the comment into a single line.
+func goComment(comment string) string {
+	return strings.Join(strings.Fields(comment), " ")
+}
@@ -0,0 +1,63 @@
+package ontograph_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Go code generation", func() {
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.com/onto> a owl:Ontology .
+:Agent a owl:Class .
+:Person a owl:Class ; rdfs:subClassOf :Agent ; rdfs:comment "A human being."@en .
+:Company a owl:Class .
+:Legacy a owl:Class ; owl:deprecated true .
+:worksFor a owl:ObjectProperty, owl:FunctionalProperty ; rdfs:domain :Person ; rdfs:range :Company .
+:knows a owl:ObjectProperty ; rdfs:domain :Agent .
+:has-name a owl:DatatypeProperty, owl:FunctionalProperty ; rdfs:domain :Agent ; rdfs:range xsd:string ; rdfs:comment "The name."@en .
+:born a owl:DatatypeProperty, owl:FunctionalProperty ; rdfs:domain :Person ; rdfs:range xsd:dateTime .
+:score a owl:DatatypeProperty ; rdfs:domain :Person ; rdfs:range xsd:decimal .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should generate constants, types and accessors", func() {
+		var src strings.Builder
+		Expect(ont.GenerateGoCode(&src, GoCodeOptions{Package: "people", Language: "en"})).To(Succeed())
+		code := src.String()
+		_, err := parser.ParseFile(token.NewFileSet(), "people.go", code, parser.ParseComments)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(code).To(HavePrefix("// Code generated by ontograph from <http://example.com/onto>. DO NOT EDIT.\n\npackage people\n"))
+		Expect(code).To(ContainSubstring("import (\n\t\"math/big\"\n\t\"time\"\n\n\t\"github.com/kahefi/ontograph\"\n)"))
+		Expect(code).NotTo(ContainSubstring("Legacy"))
+
+		// Compare the declarations independent of the alignment
+		code = strings.Join(strings.Fields(code), " ")
+		Expect(code).To(ContainSubstring(`ClassPerson = "http://example.com/onto#Person"`))
+		Expect(code).To(ContainSubstring(`PropertyHasName = "http://example.com/onto#has-name"`))
+		Expect(code).To(ContainSubstring("// Person represents the individuals of the class <http://example.com/onto#Person>. A human being. type Person struct {"))
+		Expect(code).To(ContainSubstring("type Agent struct { URI string `onto:\"@id\"` Types []string `onto:\"@type\"` // The name. HasName string `onto:\"http://example.com/onto#has-name,omitempty\"` Knows []string `onto:\"http://example.com/onto#knows,ref\"` }"))
+		Expect(code).To(ContainSubstring("Born time.Time `onto:\"http://example.com/onto#born,omitempty\"`"))
+		Expect(code).To(ContainSubstring("Score []*big.Rat `onto:\"http://example.com/onto#score\"`"))
+		Expect(code).To(ContainSubstring("WorksFor string `onto:\"http://example.com/onto#worksFor,omitempty,ref\"`"))
+		Expect(code).To(ContainSubstring("type Company struct { URI string `onto:\"@id\"` Types []string `onto:\"@type\"` }"))
+		Expect(code).To(ContainSubstring("func NewPerson(uri string) *Person { return &Person{URI: uri, Types: []string{ClassPerson}} }"))
+		Expect(code).To(ContainSubstring("func GetPerson(ont *ontograph.OntologyGraph, uri string) (*Person, error) {"))
+		Expect(code).To(ContainSubstring("func (res *Person) Upsert(ont *ontograph.OntologyGraph) error {"))
+	})
+})
@@ -0,0 +1,176 @@
+package ontograph
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+)
+
+// UpdateGoldenEnv is the environment variable that makes `CompareGolden` (re)write golden files from the actual graph instead of
+// comparing against them, e.g. `ONTOGRAPH_UPDATE_GOLDEN=1 go test ./...`.
+const UpdateGoldenEnv = "ONTOGRAPH_UPDATE_GOLDEN"
+
+// GoldenT is the subset of `testing.TB` (and `GinkgoT()`) used by `AssertGolden`.
+type GoldenT interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+}
+
+// TripleDiff lists the differences between an expected and an actual set of triples.
+type TripleDiff struct {
+	// Missing are the expected triples that are not in the actual set.
+	Missing []Triple
+	// Unexpected are the actual triples that are not in the expected set.
+	Unexpected []Triple
+}
+
+// IsEmpty returns true if both sets of triples are equal.
+func (d TripleDiff) IsEmpty() bool {
+	return len(d.Missing) == 0 && len(d.Unexpected) == 0
+}
+
+// String returns the differences line by line as N-Triples, prefixed with `-` for missing and `+` for unexpected triples.
+func (d TripleDiff) String() string {
+	var sb strings.Builder
+	for _, trp := range d.Missing {
+		sb.WriteString("- " + nTriplesLine(trp))
+	}
+	for _, trp := range d.Unexpected {
+		sb.WriteString("+ " + nTriplesLine(trp))
+	}
+	return sb.String()
+}
+
+// DiffTriples compares the expected with the actual triples. Both sets are canonicalized first (see `CanonicalTriples`), so the labels
+// of blank nodes do not cause differences as long as the graphs use them in the same way.
+func DiffTriples(expected, actual []Triple) TripleDiff {
+	expected, actual = CanonicalTriples(expected), CanonicalTriples(actual)
+	expSet := make(map[Triple]bool, len(expected))
+	for _, trp := range expected {
+		expSet[trp] = true
+	}
+	actSet := make(map[Triple]bool, len(actual))
+	for _, trp := range actual {
+		actSet[trp] = true
+	}
+	diff := TripleDiff{}
+	for _, trp := range expected {
+		if !actSet[trp] {
+			diff.Missing = append(diff.Missing, trp)
+		}
+	}
+	for _, trp := range actual {
+		if !expSet[trp] {
+			diff.Unexpected = append(diff.Unexpected, trp)
+		}
+	}
+	return diff
+}
+
+// CanonicalTriples returns a sorted copy of the triples with blank nodes relabeled as `_:b1`, `_:b2`, ... in order of their first
+// occurrence. The labels only depend on the non-blank terms of the triples, so the same graph yields the same triples regardless of the
+// blank node labels assigned by a parser.
+func CanonicalTriples(trps []Triple) []Triple {
+	canon := append([]Triple{}, trps...)
+	// Order by the triples with blank nodes masked, so that labels are assigned independent of their previous value
+	mask := func(t Term) Term {
+		if t.IsBlankNode() {
+			return "_:"
+		}
+		return t
+	}
+	sort.SliceStable(canon, func(i, j int) bool {
+		a := Triple{Subject: mask(canon[i].Subject), Predicate: canon[i].Predicate, Object: mask(canon[i].Object)}
+		b := Triple{Subject: mask(canon[j].Subject), Predicate: canon[j].Predicate, Object: mask(canon[j].Object)}
+		if a != b {
+			return nTriplesLine(a) < nTriplesLine(b)
+		}
+		return nTriplesLine(canon[i]) < nTriplesLine(canon[j])
+	})
+	labels := map[Term]Term{}
+	relabel := func(t Term) Term {
+		if !t.IsBlankNode() {
+			return t
+		}
+		if _, ok := labels[t]; !ok {
+			labels[t] = Term(fmt.Sprintf("_:b%d", len(labels)+1))
+		}
+		return labels[t]
+	}
+	for i := range canon {
+		canon[i].Subject = relabel(canon[i].Subject)
+		canon[i].Object = relabel(canon[i].Object)
+	}
+	SortTriples(canon)
+	return canon
+}
+
+// CompareGolden compares all triples of the store with the golden file at the given path, which contains the expected triples in
+// N-Triples format. Errors with `ErrGoldenMismatch` and a triple-level diff if the graphs differ. If the environment variable
+// `UpdateGoldenEnv` is set, the golden file is written with the canonical triples of the store instead.
+func CompareGolden(store GraphStore, path string) error {
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return err
+	}
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		var buf bytes.Buffer
+		for _, trp := range CanonicalTriples(trps) {
+			buf.WriteString(nTriplesLine(trp))
+		}
+		return ioutil.WriteFile(path, buf.Bytes(), 0644)
+	}
+	expected, err := readGolden(path)
+	if err != nil {
+		return fmt.Errorf("Failed to read golden file '%s' (set %s=1 to create it): %w", path, UpdateGoldenEnv, err)
+	}
+	if diff := DiffTriples(expected, trps); !diff.IsEmpty() {
+		return fmt.Errorf("%w '%s' (%d missing, %d unexpected):\n%s", ErrGoldenMismatch, path, len(diff.Missing), len(diff.Unexpected), diff)
+	}
+	return nil
+}
+
+// AssertGolden fails the test if the store does not match the golden file at the given path (see `CompareGolden`).
+func AssertGolden(t GoldenT, store GraphStore, path string) {
+	t.Helper()
+	if err := CompareGolden(store, path); err != nil {
+		t.Fatalf("%s", err)
+	}
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrGoldenMismatch is raised when a graph does not match its golden file.
+var ErrGoldenMismatch error = errors.New("The graph does not match the golden file")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// readGolden reads the triples of the golden file at the given path. An empty golden file (as written for an empty store) contains no
+// triples.
+func readGolden(path string) ([]Triple, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return []Triple{}, nil
+	}
+	golden, err := ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return golden.GetAllTriples()
+}
+
+// nTriplesLine formats the triple as a line in N-Triples format.
+func nTriplesLine(trp Triple) string {
+	return fmt.Sprintf("%s %s %s .\n", trp.Subject, trp.Predicate, trp.Object)
+}
@@ -0,0 +1,80 @@
+package ontograph_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Golden files", func() {
+	const testUri = "http://example.com/onto"
+	var dir, path string
+	var store *MemoryStore
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "ontograph")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(dir, "onto.nt")
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+<http://example.com/onto#A> a owl:Class ; owl:equivalentClass [ a owl:Restriction ] .
+`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Unsetenv(UpdateGoldenEnv)
+		os.RemoveAll(dir)
+	})
+
+	It("should write golden files and match them", func() {
+		Expect(CompareGolden(store, path)).NotTo(Succeed())
+		os.Setenv(UpdateGoldenEnv, "1")
+		Expect(CompareGolden(store, path)).To(Succeed())
+		os.Unsetenv(UpdateGoldenEnv)
+		data, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("_:b1"))
+		Expect(CompareGolden(store, path)).To(Succeed())
+		AssertGolden(GinkgoT(), store, path)
+	})
+
+	It("should match empty stores with empty golden files", func() {
+		empty := NewMemoryStore(testUri)
+		os.Setenv(UpdateGoldenEnv, "1")
+		Expect(CompareGolden(empty, path)).To(Succeed())
+		os.Unsetenv(UpdateGoldenEnv)
+		Expect(CompareGolden(empty, path)).To(Succeed())
+		Expect(ioutil.WriteFile(path, []byte("\n  \n"), 0644)).To(Succeed())
+		Expect(CompareGolden(empty, path)).To(Succeed())
+		err := CompareGolden(store, path)
+		Expect(err).To(MatchError(ErrGoldenMismatch))
+		Expect(err.Error()).To(ContainSubstring("(0 missing, 4 unexpected)"))
+	})
+
+	It("should report triple-level differences", func() {
+		os.Setenv(UpdateGoldenEnv, "1")
+		Expect(CompareGolden(store, path)).To(Succeed())
+		os.Unsetenv(UpdateGoldenEnv)
+		Expect(store.DeleteTriple(Triple{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)})).To(Succeed())
+		Expect(store.AddTriple(Triple{Subject: NewResourceTerm(testUri + "#B"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)})).To(Succeed())
+		err := CompareGolden(store, path)
+		Expect(err).To(MatchError(ErrGoldenMismatch))
+		Expect(err.Error()).To(ContainSubstring("(1 missing, 1 unexpected)"))
+		Expect(err.Error()).To(ContainSubstring("- <http://example.com/onto#A> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/2002/07/owl#Class> ."))
+		Expect(err.Error()).To(ContainSubstring("+ <http://example.com/onto#B> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/2002/07/owl#Class> ."))
+	})
+
+	It("should ignore blank node labels when diffing", func() {
+		expected := []Triple{{Subject: "_:x", Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)}}
+		actual := []Triple{{Subject: "_:n7", Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)}}
+		Expect(DiffTriples(expected, actual).IsEmpty()).To(BeTrue())
+	})
+})
@@ -0,0 +1,144 @@
+package ontograph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A HierarchyCycle is a cycle in the class or property hierarchy of an ontology.
+type HierarchyCycle struct {
+	// Property is the URI of the hierarchy property, i.e. `rdfs:subClassOf` or `rdfs:subPropertyOf`.
+	Property string
+	// Path are the URIs along the cycle, starting and ending with the smallest URI of the cycle.
+	Path []string
+}
+
+// String returns the cycle path in a human readable form.
+func (cycle HierarchyCycle) String() string {
+	uris := []string{}
+	for _, uri := range cycle.Path {
+		uris = append(uris, "<"+uri+">")
+	}
+	return fmt.Sprintf("<%s> cycle: %s", cycle.Property, strings.Join(uris, " -> "))
+}
+
+// FindHierarchyCycles finds the cycles in the `rdfs:subClassOf` and `rdfs:subPropertyOf` hierarchies of the ontology, e.g. cycles that
+// were introduced by a bad import (load the ontology from the union store returned by `ResolveImports` to check the imports closure).
+// One cycle is reported per strongly connected set of resources, which is the shortest cycle through its smallest URI. Trivial
+// cycles of a resource with itself are ignored. Cycles are sorted by property and path.
+func (ont *OntologyGraph) FindHierarchyCycles() ([]HierarchyCycle, error) {
+	cycles := []HierarchyCycle{}
+	for _, pred := range []string{RDFSSubClassOf, RDFSSubPropertyOf} {
+		trps, err := ont.graph.GetAllMatches("", NewResourceTerm(pred).String(), "")
+		if err != nil {
+			return nil, err
+		}
+		edges := map[string][]string{}
+		for _, trp := range trps {
+			if trp.Subject.IsResource() && trp.Object.IsResource() && trp.Subject != trp.Object {
+				edges[trp.Subject.Value()] = append(edges[trp.Subject.Value()], trp.Object.Value())
+			}
+		}
+		for _, targets := range edges {
+			sort.Strings(targets)
+		}
+		for _, component := range stronglyConnectedComponents(edges) {
+			if len(component) > 1 {
+				cycles = append(cycles, HierarchyCycle{Property: pred, Path: shortestCycle(component, edges)})
+			}
+		}
+	}
+	sort.SliceStable(cycles, func(i, j int) bool {
+		if cycles[i].Property != cycles[j].Property {
+			return cycles[i].Property < cycles[j].Property
+		}
+		return strings.Join(cycles[i].Path, " ") < strings.Join(cycles[j].Path, " ")
+	})
+	return cycles, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// stronglyConnectedComponents returns the strongly connected components of the graph given by the sorted edges (Tarjan's algorithm).
+func stronglyConnectedComponents(edges map[string][]string) [][]string {
+	index, lowLink := map[string]int{}, map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	components := [][]string{}
+	var visit func(node string)
+	visit = func(node string) {
+		index[node] = len(index)
+		lowLink[node] = index[node]
+		stack = append(stack, node)
+		onStack[node] = true
+		for _, next := range edges[node] {
+			if _, ok := index[next]; !ok {
+				visit(next)
+				if lowLink[next] < lowLink[node] {
+					lowLink[node] = lowLink[next]
+				}
+			} else if onStack[next] && index[next] < lowLink[node] {
+				lowLink[node] = index[next]
+			}
+		}
+		if lowLink[node] == index[node] {
+			component := []string{}
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == node {
+					break
+				}
+			}
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+	nodes := []string{}
+	for node := range edges {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		if _, ok := index[node]; !ok {
+			visit(node)
+		}
+	}
+	return components
+}
+
+// shortestCycle returns the shortest cycle through the smallest node of the sorted strongly connected component using a breadth-first
+// search within the component.
+func shortestCycle(component []string, edges map[string][]string) []string {
+	inComponent := map[string]bool{}
+	for _, node := range component {
+		inComponent[node] = true
+	}
+	start := component[0]
+	prev := map[string]string{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, next := range edges[node] {
+			if next == start {
+				// Walk back to the start to reconstruct the cycle
+				path := []string{start}
+				for n := node; n != start; n = prev[n] {
+					path = append([]string{n}, path...)
+				}
+				return append([]string{start}, path...)
+			}
+			if _, seen := prev[next]; !seen && inComponent[next] {
+				prev[next] = node
+				queue = append(queue, next)
+			}
+		}
+	}
+	return component
+}
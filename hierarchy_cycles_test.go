@@ -0,0 +1,61 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Finding hierarchy cycles", func() {
+	const ns = "http://example.com/onto#"
+
+	load := func(ttl string) *OntologyGraph {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.com/onto> a owl:Ontology .
+` + ttl))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err := LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		return ont
+	}
+
+	It("should report the cycle paths of classes and properties", func() {
+		ont := load(`:D rdfs:subClassOf :B .
+:B rdfs:subClassOf :C .
+:C rdfs:subClassOf :D , :E .
+:E rdfs:subClassOf :F .
+:X rdfs:subClassOf :X .
+:p rdfs:subPropertyOf :q .
+:q rdfs:subPropertyOf :p .
+`)
+		cycles, err := ont.FindHierarchyCycles()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cycles).To(Equal([]HierarchyCycle{
+			{Property: RDFSSubClassOf, Path: []string{ns + "B", ns + "C", ns + "D", ns + "B"}},
+			{Property: RDFSSubPropertyOf, Path: []string{ns + "p", ns + "q", ns + "p"}},
+		}))
+		Expect(cycles[1].String()).To(Equal("<" + RDFSSubPropertyOf + "> cycle: <" + ns + "p> -> <" + ns + "q> -> <" + ns + "p>"))
+	})
+
+	It("should report the shortest cycle through the smallest URI", func() {
+		ont := load(`:A rdfs:subClassOf :B , :C .
+:B rdfs:subClassOf :C .
+:C rdfs:subClassOf :A .
+`)
+		cycles, err := ont.FindHierarchyCycles()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cycles).To(Equal([]HierarchyCycle{{Property: RDFSSubClassOf, Path: []string{ns + "A", ns + "C", ns + "A"}}}))
+	})
+
+	It("should report nothing for acyclic hierarchies", func() {
+		ont := load(`:A rdfs:subClassOf :B , :C .
+:B rdfs:subClassOf :C .
+`)
+		Expect(ont.FindHierarchyCycles()).To(BeEmpty())
+	})
+})
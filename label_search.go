@@ -0,0 +1,329 @@
+package ontograph
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// A LabelMatch is a resource found by a label search (see `SearchLabels`).
+type LabelMatch struct {
+	URI      string
+	Label    string
+	Language string
+	// Score ranks the match, higher scores are better matches.
+	Score float64
+}
+
+// A SearchOption configures a label search (see `SearchLabels`).
+type SearchOption func(*searchOptions)
+
+// searchOptions holds the configuration compiled from a list of search options.
+type searchOptions struct {
+	limit    int
+	maxEdits int
+	langs    []string
+}
+
+// WithSearchLimit limits the number of returned matches, e.g. to the number of suggestions of an autocomplete box. Non-positive limits
+// are ignored.
+func WithSearchLimit(limit int) SearchOption {
+	return func(opts *searchOptions) {
+		if limit > 0 {
+			opts.limit = limit
+		}
+	}
+}
+
+// WithFuzzySearch lets the words of the query also match words of labels within the given number of edits (insertions, deletions or
+// substitutions of a character), so typos still find the resource. Fuzzy matches are ranked below exact and prefix matches.
+func WithFuzzySearch(maxEdits int) SearchOption {
+	return func(opts *searchOptions) {
+		opts.maxEdits = maxEdits
+	}
+}
+
+// WithSearchLanguages restricts the search to labels in the given languages (see `AnyLanguage`).
+func WithSearchLanguages(langs ...string) SearchOption {
+	return func(opts *searchOptions) {
+		opts.langs = langs
+	}
+}
+
+// newSearchOptions compiles the given list of options.
+func newSearchOptions(opts []SearchOption) searchOptions {
+	options := searchOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// A LabelSearcher searches labels natively. It is implemented by graph stores that maintain a full-text index.
+type LabelSearcher interface {
+	// SearchLabels should return the resources whose labels match the query (see `SearchLabels`).
+	SearchLabels(query string, opts ...SearchOption) ([]LabelMatch, error)
+}
+
+// SearchLabels searches the `rdfs:label` and `skos:prefLabel` labels of the store for the query, e.g. to feed an autocomplete box. A
+// label matches if every word of the query is a prefix of one of its words (case-insensitive). Matches are sorted by score: Whole words
+// rank above prefixes, and labels equal to the query rank first. Stores that implement `LabelSearcher` search with their own index, for
+// all other stores the labels are retrieved and indexed for every search.
+func SearchLabels(store GraphStore, query string, opts ...SearchOption) ([]LabelMatch, error) {
+	if searcher, ok := store.(LabelSearcher); ok {
+		return searcher.SearchLabels(query, opts...)
+	}
+	idx, err := buildLabelIndex(store)
+	if err != nil {
+		return nil, err
+	}
+	return idx.search(query, newSearchOptions(opts)), nil
+}
+
+// SearchLabels searches the labels of the ontology for the query (see `SearchLabels`).
+func (ont *OntologyGraph) SearchLabels(query string, opts ...SearchOption) ([]LabelMatch, error) {
+	return SearchLabels(ont.graph, query, opts...)
+}
+
+// SearchLabels searches the labels of the store for the query (see `SearchLabels`). The store keeps an inverted index of its labels in
+// memory, which is built on the first search and updated with every change.
+func (store *MemoryStore) SearchLabels(query string, opts ...SearchOption) ([]LabelMatch, error) {
+	if store.labels == nil {
+		idx, err := buildLabelIndex(store)
+		if err != nil {
+			return nil, err
+		}
+		store.labels = idx
+	}
+	return store.labels.search(query, newSearchOptions(opts)), nil
+}
+
+// SearchLabels searches the labels of the store for the query (see `SearchLabels`). The candidates are found with the full-text index of
+// Blazegraph (`bds:search`), which must be enabled for the namespace. Fuzzy searches are not supported by the index, so all labels are
+// retrieved and searched in-process instead.
+func (store *BlazegraphStore) SearchLabels(query string, opts ...SearchOption) ([]LabelMatch, error) {
+	options := newSearchOptions(opts)
+	words := searchWords(query)
+	if len(words) == 0 {
+		return []LabelMatch{}, nil
+	}
+	if options.maxEdits > 0 {
+		idx, err := buildLabelIndex(store)
+		if err != nil {
+			return nil, err
+		}
+		return idx.search(query, options), nil
+	}
+	search := strings.Join(words, "* ") + "*"
+	sparqlReq := fmt.Sprintf(`PREFIX bds: <http://www.bigdata.com/rdf/search#>
+SELECT ?s ?p ?o WHERE { ?o bds:search %q . ?o bds:matchAllTerms "true" . GRAPH <%s> { ?s ?p ?o . FILTER(?p IN (<%s>, <%s>)) } }`,
+		search, store.uri, RDFSLabel, SKOSPrefLabel)
+	resSet, code, err := store.endpoint.DoSparqlJSONQuery(store.namespace, sparqlReq)
+	if err != nil {
+		return nil, store.wrapErr("SearchLabels", nil, sparqlReq, err)
+	}
+	if code != http.StatusOK {
+		return nil, store.wrapErr("SearchLabels", nil, sparqlReq, fmt.Errorf("Received unexpected status code from SPARQL query (HTTP %d): %s", code, sparqlReq))
+	}
+	// Rank the candidates like the in-process search
+	idx := newLabelIndex()
+	for _, binding := range resSet.Results.Bindings {
+		idx.add(Triple{Subject: binding2Term(binding["s"]), Predicate: binding2Term(binding["p"]), Object: binding2Term(binding["o"])})
+	}
+	return idx.search(query, options), nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// labelEntry is an indexed label of a resource.
+type labelEntry struct {
+	subject   Term
+	predicate Term
+	label     Term
+}
+
+// labelIndex is an inverted index that maps the words of labels to the labels that contain them.
+type labelIndex struct {
+	words map[string]map[labelEntry]bool
+}
+
+// newLabelIndex creates an empty label index.
+func newLabelIndex() *labelIndex {
+	return &labelIndex{words: map[string]map[labelEntry]bool{}}
+}
+
+// buildLabelIndex indexes all labels of the store.
+func buildLabelIndex(store GraphStore) (*labelIndex, error) {
+	idx := newLabelIndex()
+	for _, pred := range []string{RDFSLabel, SKOSPrefLabel} {
+		trps, err := store.GetAllMatches("", NewResourceTerm(pred).String(), "")
+		if err != nil {
+			return nil, err
+		}
+		for _, trp := range trps {
+			idx.add(trp)
+		}
+	}
+	return idx, nil
+}
+
+// add indexes the label of the triple. Triples that are no labels are ignored. The index may be nil.
+func (idx *labelIndex) add(trp Triple) {
+	if idx == nil || !isLabelTriple(trp) {
+		return
+	}
+	entry := labelEntry{subject: trp.Subject, predicate: trp.Predicate, label: trp.Object}
+	for _, word := range searchWords(trp.Object.Value()) {
+		if idx.words[word] == nil {
+			idx.words[word] = map[labelEntry]bool{}
+		}
+		idx.words[word][entry] = true
+	}
+}
+
+// remove removes the label of the triple from the index. The index may be nil.
+func (idx *labelIndex) remove(trp Triple) {
+	if idx == nil || !isLabelTriple(trp) {
+		return
+	}
+	entry := labelEntry{subject: trp.Subject, predicate: trp.Predicate, label: trp.Object}
+	for _, word := range searchWords(trp.Object.Value()) {
+		delete(idx.words[word], entry)
+		if len(idx.words[word]) == 0 {
+			delete(idx.words, word)
+		}
+	}
+}
+
+// search returns the labels that match all words of the query, sorted by score, label and URI.
+func (idx *labelIndex) search(query string, options searchOptions) []LabelMatch {
+	matches := []LabelMatch{}
+	words := searchWords(query)
+	if len(words) == 0 {
+		return matches
+	}
+	// Score the labels for every word of the query, keeping the best score per label
+	scores := make([]map[labelEntry]float64, len(words))
+	for i, word := range words {
+		scores[i] = map[labelEntry]float64{}
+		for indexed, entries := range idx.words {
+			score := matchWord(word, indexed, options.maxEdits)
+			if score == 0 {
+				continue
+			}
+			for entry := range entries {
+				if score > scores[i][entry] {
+					scores[i][entry] = score
+				}
+			}
+		}
+	}
+	// Only keep labels that match all words, once per resource even if they are set with both predicates
+	seen := map[labelEntry]bool{}
+	for entry, score := range scores[0] {
+		unique := labelEntry{subject: entry.subject, label: entry.label}
+		if seen[unique] {
+			continue
+		}
+		matchesAll := true
+		for _, wordScores := range scores[1:] {
+			wordScore, ok := wordScores[entry]
+			matchesAll = matchesAll && ok
+			score += wordScore
+		}
+		lang := entry.label.Language()
+		if !matchesAll || !matchesLanguage(lang, options.langs) {
+			continue
+		}
+		if strings.Join(searchWords(entry.label.Value()), " ") == strings.Join(words, " ") {
+			score++
+		}
+		seen[unique] = true
+		matches = append(matches, LabelMatch{URI: uriOf(entry.subject), Label: entry.label.Value(), Language: lang, Score: score})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if matches[i].Label != matches[j].Label {
+			return matches[i].Label < matches[j].Label
+		}
+		if matches[i].URI != matches[j].URI {
+			return matches[i].URI < matches[j].URI
+		}
+		return matches[i].Language < matches[j].Language
+	})
+	if options.limit > 0 && len(matches) > options.limit {
+		matches = matches[:options.limit]
+	}
+	return matches
+}
+
+// isLabelTriple checks if the triple assigns a literal label with `rdfs:label` or `skos:prefLabel`.
+func isLabelTriple(trp Triple) bool {
+	return (trp.Predicate == NewResourceTerm(RDFSLabel) || trp.Predicate == NewResourceTerm(SKOSPrefLabel)) && trp.Object.IsLiteral()
+}
+
+// searchWords splits the text into lower case words of letters and digits.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// matchWord scores how the word of a query matches an indexed word: 1 for equal words, 0.75 if the query word is a prefix and 0.5 if
+// the indexed word (or its prefix of the same length) is within the maximum number of edits. Returns 0 if the words do not match.
+func matchWord(word, indexed string, maxEdits int) float64 {
+	switch {
+	case word == indexed:
+		return 1
+	case strings.HasPrefix(indexed, word):
+		return 0.75
+	case maxEdits > 0:
+		prefix := []rune(indexed)
+		if n := len([]rune(word)); len(prefix) > n {
+			prefix = prefix[:n]
+		}
+		if editDistance(word, indexed) <= maxEdits || editDistance(word, string(prefix)) <= maxEdits {
+			return 0.5
+		}
+	}
+	return 0
+}
+
+// editDistance returns the Levenshtein distance between the strings.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+// min3 returns the smallest of the three numbers.
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,93 @@
+package ontograph_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Label search", func() {
+	const testUri = "http://example.com/onto"
+	var store *MemoryStore
+	var ont *OntologyGraph
+
+	uris := func(matches []LabelMatch) []string {
+		result := []string{}
+		for _, match := range matches {
+			result = append(result, match.URI)
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		var err error
+		store = NewMemoryStore(testUri)
+		ont, err = InitOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#Car", Label: map[string]string{"en": "Car", "de": "Auto"}})).To(Succeed())
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#CargoShip", Label: map[string]string{"en": "Cargo ship"}})).To(Succeed())
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#SportsCar", Label: map[string]string{"en": "Sports car"}})).To(Succeed())
+	})
+
+	It("should find labels by word prefixes and rank whole words first", func() {
+		matches, err := ont.SearchLabels("car")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uris(matches)).To(Equal([]string{testUri + "#Car", testUri + "#SportsCar", testUri + "#CargoShip"}))
+		Expect(matches[0]).To(Equal(LabelMatch{URI: testUri + "#Car", Label: "Car", Language: "en", Score: 2}))
+
+		matches, err = ont.SearchLabels("sh car", WithSearchLimit(1))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uris(matches)).To(Equal([]string{testUri + "#CargoShip"}))
+
+		matches, err = ont.SearchLabels("au", WithSearchLanguages("en"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(BeEmpty())
+	})
+
+	It("should find labels with typos in fuzzy searches", func() {
+		matches, err := ont.SearchLabels("sprots")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(BeEmpty())
+		matches, err = SearchLabels(store, "sprots", WithFuzzySearch(2))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uris(matches)).To(Equal([]string{testUri + "#SportsCar"}))
+	})
+
+	It("should keep the index of memory stores up to date", func() {
+		matches, err := store.SearchLabels("truck")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(BeEmpty())
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#Truck", Label: map[string]string{"en": "Truck"}})).To(Succeed())
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#Car", Label: map[string]string{"en": "Automobile"}})).To(Succeed())
+		matches, err = store.SearchLabels("truck")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uris(matches)).To(Equal([]string{testUri + "#Truck"}))
+		matches, err = store.SearchLabels("auto")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(HaveLen(1))
+		Expect(matches[0].Label).To(Equal("Automobile"))
+	})
+
+	It("should search Blazegraph stores with the full-text index", func() {
+		var query string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).To(Succeed())
+			query = r.Form.Get("query")
+			w.Header().Set("Content-Type", "application/sparql-results+json")
+			w.Write([]byte(`{"head":{"vars":["s","p","o"]},"results":{"bindings":[
+				{"s":{"type":"uri","value":"http://example.com/onto#Car"},"p":{"type":"uri","value":"http://www.w3.org/2000/01/rdf-schema#label"},"o":{"type":"literal","value":"Car","xml:lang":"en"}},
+				{"s":{"type":"uri","value":"http://example.com/onto#CargoShip"},"p":{"type":"uri","value":"http://www.w3.org/2000/01/rdf-schema#label"},"o":{"type":"literal","value":"Cargo ship","xml:lang":"en"}}
+			]}}`))
+		}))
+		defer server.Close()
+		bgStore := NewBlazegraphEndpoint(server.URL).NewBlazegraphStore(testUri, "test")
+		matches, err := SearchLabels(bgStore, "Car")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uris(matches)).To(Equal([]string{testUri + "#Car", testUri + "#CargoShip"}))
+		Expect(query).To(ContainSubstring(`bds:search "car*"`))
+	})
+})
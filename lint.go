@@ -0,0 +1,288 @@
+package ontograph
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// LintSeverity is the severity of a lint finding.
+type LintSeverity int
+
+const (
+	// LintInfo marks findings that are merely hints.
+	LintInfo LintSeverity = iota
+	// LintWarning marks findings that should be fixed.
+	LintWarning
+	// LintError marks findings that must be fixed.
+	LintError
+)
+
+// String returns the name of the severity.
+func (s LintSeverity) String() string {
+	switch s {
+	case LintInfo:
+		return "info"
+	case LintWarning:
+		return "warning"
+	case LintError:
+		return "error"
+	}
+	return fmt.Sprintf("LintSeverity(%d)", int(s))
+}
+
+// A LintFinding is an issue that a lint rule found in an ontology.
+type LintFinding struct {
+	// Rule is the name of the rule that produced the finding.
+	Rule string `json:"rule"`
+	// Severity is the severity of the finding.
+	Severity LintSeverity `json:"severity"`
+	// Resource is the URI of the affected resource.
+	Resource string `json:"resource"`
+	// Message describes the issue.
+	Message string `json:"message"`
+}
+
+// String returns a human readable description of the finding.
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s [%s] <%s>: %s", f.Severity, f.Rule, f.Resource, f.Message)
+}
+
+// A LintRule checks an ontology for a particular kind of issue, e.g. missing labels.
+type LintRule interface {
+	// Name should return the unique name of the rule, which is used in the findings.
+	Name() string
+	// Check should return the findings of the rule for the ontology.
+	Check(ont *OntologyGraph) ([]LintFinding, error)
+}
+
+// NewLintRule creates a lint rule with the given name from the check function, e.g. to register custom rules with a `Linter`. The rule
+// name of the findings is set by the linter.
+func NewLintRule(name string, check func(ont *OntologyGraph) ([]LintFinding, error)) LintRule {
+	return &lintRuleFunc{name: name, check: check}
+}
+
+// A Linter runs a set of lint rules against ontologies.
+type Linter struct {
+	rules []LintRule
+}
+
+// NewLinter creates a linter with the given rules. Use `DefaultLintRules` to start with the built-in rules.
+func NewLinter(rules ...LintRule) *Linter {
+	return &Linter{rules: rules}
+}
+
+// Register adds the rules to the linter. Rules with the name of an already registered rule replace that rule.
+func (l *Linter) Register(rules ...LintRule) {
+	for _, rule := range rules {
+		replaced := false
+		for i, registered := range l.rules {
+			if registered.Name() == rule.Name() {
+				l.rules[i] = rule
+				replaced = true
+			}
+		}
+		if !replaced {
+			l.rules = append(l.rules, rule)
+		}
+	}
+}
+
+// Rules returns the names of the registered rules in the order they are run.
+func (l *Linter) Rules() []string {
+	names := []string{}
+	for _, rule := range l.rules {
+		names = append(names, rule.Name())
+	}
+	return names
+}
+
+// Run runs all registered rules against the ontology and returns their findings sorted by resource and rule. Errors if a rule errors.
+func (l *Linter) Run(ont *OntologyGraph) ([]LintFinding, error) {
+	findings := []LintFinding{}
+	for _, rule := range l.rules {
+		found, err := rule.Check(ont)
+		if err != nil {
+			return nil, fmt.Errorf("lint rule %s: %w", rule.Name(), err)
+		}
+		for _, f := range found {
+			f.Rule = rule.Name()
+			findings = append(findings, f)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Resource != findings[j].Resource {
+			return findings[i].Resource < findings[j].Resource
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+	return findings, nil
+}
+
+// DefaultLintRules returns the built-in lint rules: `missing-label` and `missing-comment` for classes and properties without
+// `rdfs:label` or `rdfs:comment`, `naming-convention` for classes whose local name is not UpperCamelCase and properties whose local name
+// is not lowerCamelCase, `unused-class` for classes that are never referenced, and `missing-domain-range` for object and data
+// properties without domain or range. The rules only check the non-deprecated resources that belong to the ontology.
+func DefaultLintRules() []LintRule {
+	return []LintRule{
+		NewLintRule("missing-label", lintMissingLabels),
+		NewLintRule("missing-comment", lintMissingComments),
+		NewLintRule("naming-convention", lintNamingConventions),
+		NewLintRule("unused-class", lintUnusedClasses),
+		NewLintRule("missing-domain-range", lintMissingDomainsAndRanges),
+	}
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// lintRuleFunc is a lint rule backed by a function.
+type lintRuleFunc struct {
+	name  string
+	check func(ont *OntologyGraph) ([]LintFinding, error)
+}
+
+// Name returns the name of the rule.
+func (rule *lintRuleFunc) Name() string {
+	return rule.name
+}
+
+// Check calls the check function.
+func (rule *lintRuleFunc) Check(ont *OntologyGraph) ([]LintFinding, error) {
+	return rule.check(ont)
+}
+
+// lintResource is a class or property of the ontology that is checked by the built-in rules.
+type lintResource struct {
+	uri     string
+	kind    string
+	label   map[string]string
+	comment map[string]string
+	domains []string
+	ranges  []string
+}
+
+// lintResources returns the non-deprecated classes and properties that belong to the ontology.
+func (ont *OntologyGraph) lintResources() ([]lintResource, error) {
+	resources := []lintResource{}
+	add := func(res lintResource, isDeprecated bool) {
+		if !isDeprecated && ont.isOwnURI(res.uri) && !uriTerm(res.uri).IsBlankNode() && !isAnonymousURI(res.uri) {
+			resources = append(resources, res)
+		}
+	}
+	classes, err := ont.GetClasses()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range classes {
+		add(lintResource{uri: c.URI, kind: "class", label: c.Label, comment: c.Comment}, c.IsDeprecated)
+	}
+	objProps, err := ont.GetObjectProperties()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range objProps {
+		add(lintResource{uri: p.URI, kind: "object property", label: p.Label, comment: p.Comment, domains: p.Domains, ranges: p.Ranges}, p.IsDeprecated)
+	}
+	dataProps, err := ont.GetDataProperties()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range dataProps {
+		add(lintResource{uri: p.URI, kind: "data property", label: p.Label, comment: p.Comment, domains: p.Domains, ranges: p.Ranges}, p.IsDeprecated)
+	}
+	annProps, err := ont.GetAnnotationProperties()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range annProps {
+		add(lintResource{uri: p.URI, kind: "annotation property", label: p.Label, comment: p.Comment}, p.IsDeprecated)
+	}
+	return resources, nil
+}
+
+// lintMissingLabels reports classes and properties without label.
+func lintMissingLabels(ont *OntologyGraph) ([]LintFinding, error) {
+	resources, err := ont.lintResources()
+	findings := []LintFinding{}
+	for _, res := range resources {
+		if len(res.label) == 0 {
+			findings = append(findings, LintFinding{Severity: LintWarning, Resource: res.uri, Message: "The " + res.kind + " has no label"})
+		}
+	}
+	return findings, err
+}
+
+// lintMissingComments reports classes and properties without comment.
+func lintMissingComments(ont *OntologyGraph) ([]LintFinding, error) {
+	resources, err := ont.lintResources()
+	findings := []LintFinding{}
+	for _, res := range resources {
+		if len(res.comment) == 0 {
+			findings = append(findings, LintFinding{Severity: LintInfo, Resource: res.uri, Message: "The " + res.kind + " has no comment"})
+		}
+	}
+	return findings, err
+}
+
+var (
+	upperCamelCase = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	lowerCamelCase = regexp.MustCompile(`^[a-z][A-Za-z0-9]*$`)
+)
+
+// lintNamingConventions reports classes whose local name is not UpperCamelCase and properties whose local name is not lowerCamelCase.
+func lintNamingConventions(ont *OntologyGraph) ([]LintFinding, error) {
+	resources, err := ont.lintResources()
+	findings := []LintFinding{}
+	for _, res := range resources {
+		name := localName(res.uri)
+		if res.kind == "class" && !upperCamelCase.MatchString(name) {
+			findings = append(findings, LintFinding{Severity: LintWarning, Resource: res.uri, Message: fmt.Sprintf("The class name '%s' is not UpperCamelCase", name)})
+		} else if res.kind != "class" && !lowerCamelCase.MatchString(name) {
+			findings = append(findings, LintFinding{Severity: LintWarning, Resource: res.uri, Message: fmt.Sprintf("The %s name '%s' is not lowerCamelCase", res.kind, name)})
+		}
+	}
+	return findings, err
+}
+
+// lintUnusedClasses reports classes that are not the object of any triple, i.e. that have no instances or subclasses and are not used
+// in any domain, range or class expression.
+func lintUnusedClasses(ont *OntologyGraph) ([]LintFinding, error) {
+	resources, err := ont.lintResources()
+	if err != nil {
+		return nil, err
+	}
+	findings := []LintFinding{}
+	for _, res := range resources {
+		if res.kind != "class" {
+			continue
+		}
+		trp, err := ont.graph.GetFirstMatch("", "", NewResourceTerm(res.uri).String())
+		if err != nil {
+			return nil, err
+		}
+		if trp == nil {
+			findings = append(findings, LintFinding{Severity: LintInfo, Resource: res.uri, Message: "The class is never used"})
+		}
+	}
+	return findings, nil
+}
+
+// lintMissingDomainsAndRanges reports object and data properties without domain or range.
+func lintMissingDomainsAndRanges(ont *OntologyGraph) ([]LintFinding, error) {
+	resources, err := ont.lintResources()
+	findings := []LintFinding{}
+	for _, res := range resources {
+		if res.kind != "object property" && res.kind != "data property" {
+			continue
+		}
+		if len(res.domains) == 0 {
+			findings = append(findings, LintFinding{Severity: LintWarning, Resource: res.uri, Message: "The " + res.kind + " has no domain"})
+		}
+		if len(res.ranges) == 0 {
+			findings = append(findings, LintFinding{Severity: LintWarning, Resource: res.uri, Message: "The " + res.kind + " has no range"})
+		}
+	}
+	return findings, err
+}
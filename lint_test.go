@@ -0,0 +1,79 @@
+package ontograph_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Linting ontologies", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.com/onto> a owl:Ontology .
+:Person a owl:Class ; rdfs:label "Person"@en ; rdfs:comment "A human."@en .
+:Company a owl:Class ; rdfs:label "Company"@en ; rdfs:comment "A business."@en .
+:old_thing a owl:Class ; rdfs:label "Old thing"@en ; rdfs:comment "Something old."@en .
+:Legacy a owl:Class ; owl:deprecated true .
+:worksFor a owl:ObjectProperty ; rdfs:label "works for"@en ; rdfs:comment "Employment."@en ; rdfs:domain :Person ; rdfs:range :Company .
+:Age a owl:DatatypeProperty ; rdfs:domain :Person .
+<http://example.com/other#Thing> a owl:Class .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should report the findings of the built-in rules", func() {
+		linter := NewLinter(DefaultLintRules()...)
+		Expect(linter.Rules()).To(Equal([]string{"missing-label", "missing-comment", "naming-convention", "unused-class", "missing-domain-range"}))
+		findings, err := linter.Run(ont)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(findings).To(Equal([]LintFinding{
+			{Rule: "missing-comment", Severity: LintInfo, Resource: ns + "Age", Message: "The data property has no comment"},
+			{Rule: "missing-domain-range", Severity: LintWarning, Resource: ns + "Age", Message: "The data property has no range"},
+			{Rule: "missing-label", Severity: LintWarning, Resource: ns + "Age", Message: "The data property has no label"},
+			{Rule: "naming-convention", Severity: LintWarning, Resource: ns + "Age", Message: "The data property name 'Age' is not lowerCamelCase"},
+			{Rule: "naming-convention", Severity: LintWarning, Resource: ns + "old_thing", Message: "The class name 'old_thing' is not UpperCamelCase"},
+			{Rule: "unused-class", Severity: LintInfo, Resource: ns + "old_thing", Message: "The class is never used"},
+		}))
+		Expect(findings[0].String()).To(Equal("info [missing-comment] <" + ns + "Age>: The data property has no comment"))
+	})
+
+	It("should run custom rules and replace rules by name", func() {
+		linter := NewLinter(DefaultLintRules()...)
+		linter.Register(
+			NewLintRule("missing-comment", func(ont *OntologyGraph) ([]LintFinding, error) { return nil, nil }),
+			NewLintRule("has-people", func(ont *OntologyGraph) ([]LintFinding, error) {
+				indivs, err := ont.GetIndividualsOfClass(ns+"Person", true)
+				if err != nil || len(indivs) > 0 {
+					return nil, err
+				}
+				return []LintFinding{{Severity: LintError, Resource: ns + "Person", Message: "No people"}}, nil
+			}),
+		)
+		Expect(linter.Rules()).To(HaveLen(6))
+		findings, err := linter.Run(ont)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(findings).To(ContainElement(LintFinding{Rule: "has-people", Severity: LintError, Resource: ns + "Person", Message: "No people"}))
+		for _, f := range findings {
+			Expect(f.Rule).NotTo(Equal("missing-comment"))
+		}
+	})
+
+	It("should fail if a rule fails", func() {
+		errBroken := errors.New("broken")
+		linter := NewLinter(NewLintRule("broken", func(ont *OntologyGraph) ([]LintFinding, error) { return nil, errBroken }))
+		_, err := linter.Run(ont)
+		Expect(err).To(MatchError(errBroken))
+	})
+})
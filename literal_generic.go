@@ -0,0 +1,60 @@
+package ontograph
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+// NativeLiteral is the set of Go types that can be wrapped in a `Literal`. Each type maps to one XSD datatype: strings to xsd:string,
+// booleans to xsd:boolean, `int` to xsd:integer, the sized integers to xsd:long, xsd:int, xsd:short, xsd:byte and their unsigned
+// counterparts, `float32` and `float64` to xsd:float and xsd:double, `time.Time` to xsd:dateTime, `time.Duration` to xsd:duration,
+// `[]byte` to xsd:base64Binary, `*big.Int` to xsd:integer and `*big.Rat` to xsd:decimal.
+type NativeLiteral interface {
+	string | bool | int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | float32 | float64 |
+		time.Time | time.Duration | []byte | *big.Int | *big.Rat
+}
+
+// Literal is a type-safe literal holding a value of a native Go type. It is an alternative to the per-type literals such as
+// `XSDLongLiteral`, which are kept for compatibility.
+type Literal[T NativeLiteral] struct {
+	Value T
+}
+
+// NewLiteral creates a new literal with the given value, e.g. `NewLiteral[int64](42)` for a xsd:long literal.
+func NewLiteral[T NativeLiteral](value T) Literal[T] {
+	return Literal[T]{Value: value}
+}
+
+// Datatype returns the URI of the XSD datatype of the literal.
+func (l Literal[T]) Datatype() string {
+	lit := l.Generic()
+	return lit.Type().URI
+}
+
+func (l Literal[T]) Generic() GenericLiteral {
+	lit, _ := marshalLiteral(reflect.ValueOf(l.Value))
+	return lit
+}
+
+// ToLiteral converts the generic literal into a literal of the native Go type. Errors with `ErrLiteralTypeMismatch` if the literal
+// does not have the datatype of the Go type (see `NativeLiteral`). With `WithNumericCoercion`, numeric values of other numeric or
+// string datatypes are converted as well.
+func ToLiteral[T NativeLiteral](l *GenericLiteral, opts ...LiteralOption) (Literal[T], error) {
+	options := literalOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	datatype := Literal[T]{}.Datatype()
+	uri := l.Type().URI
+	isNumeric := xsdNumericDatatypes[datatype]
+	if uri != datatype && !(isNumeric && options.coerceNumeric && (xsdNumericDatatypes[uri] || isStringDatatype(uri))) {
+		return Literal[T]{}, fmt.Errorf("%w: <%s> is no <%s>", ErrLiteralTypeMismatch, uri, datatype)
+	}
+	var value T
+	if err := unmarshalLiteral(l, reflect.ValueOf(&value).Elem()); err != nil {
+		return Literal[T]{}, err
+	}
+	return Literal[T]{Value: value}, nil
+}
@@ -0,0 +1,68 @@
+package ontograph_test
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Generic literals", func() {
+	term := func(l GenericLiteral) Term {
+		return l.Term()
+	}
+
+	It("should convert native values into literals of the corresponding datatype", func() {
+		Expect(term(NewLiteral[int64](42).Generic())).To(Equal(NewLiteralTerm("42", "", XSDLong)))
+		Expect(term(NewLiteral(42).Generic())).To(Equal(NewLiteralTerm("42", "", XSDInteger)))
+		Expect(term(NewLiteral[uint8](7).Generic())).To(Equal(NewLiteralTerm("7", "", XSDUnsignedByte)))
+		Expect(term(NewLiteral("hello").Generic())).To(Equal(NewLiteralTerm("hello", "", XSDString)))
+		Expect(term(NewLiteral(true).Generic())).To(Equal(NewLiteralTerm("true", "", XSDBoolean)))
+		Expect(term(NewLiteral(2.5).Generic())).To(Equal(NewLiteralTerm("2.5", "", XSDDouble)))
+		Expect(term(NewLiteral(90 * time.Minute).Generic())).To(Equal(NewLiteralTerm("PT1H30M", "", XSDDuration)))
+		Expect(term(NewLiteral([]byte("hi")).Generic())).To(Equal(NewLiteralTerm("aGk=", "", XSDBase64Binary)))
+		Expect(term(NewLiteral(big.NewRat(1, 4)).Generic())).To(Equal(NewLiteralTerm("0.25", "", XSDDecimal)))
+		Expect(NewLiteral[float32](1).Datatype()).To(Equal(XSDFloat))
+		Expect(NewLiteral(time.Now()).Datatype()).To(Equal(XSDDateTime))
+		Expect(NewLiteral[*big.Int](nil).Datatype()).To(Equal(XSDInteger))
+	})
+
+	It("should extract native values from generic literals", func() {
+		lit := NewLiteral[int32](-12).Generic()
+		i, err := ToLiteral[int32](&lit)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(i.Value).To(Equal(int32(-12)))
+
+		lit = *NewGenericLiteral(NewLiteralTerm("2021-03-04T05:06:07Z", "", XSDDateTime))
+		t, err := ToLiteral[time.Time](&lit)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(t.Value.Equal(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC))).To(BeTrue())
+
+		lit = *NewGenericLiteral(NewLiteralTerm("123456789012345678901234567890", "", XSDInteger))
+		b, err := ToLiteral[*big.Int](&lit)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b.Value.String()).To(Equal("123456789012345678901234567890"))
+	})
+
+	It("should reject literals of other datatypes unless numeric values are coerced", func() {
+		lit := NewLiteral[int64](42).Generic()
+		_, err := ToLiteral[int](&lit)
+		Expect(errors.Is(err, ErrLiteralTypeMismatch)).To(BeTrue())
+		_, err = ToLiteral[string](&lit)
+		Expect(errors.Is(err, ErrLiteralTypeMismatch)).To(BeTrue())
+
+		i, err := ToLiteral[int](&lit, WithNumericCoercion())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(i.Value).To(Equal(42))
+		lit = NewLiteral(300).Generic()
+		_, err = ToLiteral[int8](&lit, WithNumericCoercion())
+		Expect(err).To(HaveOccurred())
+		lit = NewLiteral(true).Generic()
+		_, err = ToLiteral[int](&lit, WithNumericCoercion())
+		Expect(errors.Is(err, ErrLiteralTypeMismatch)).To(BeTrue())
+	})
+})
@@ -5,11 +5,14 @@ import (
 	"io"
 
 	"bytes"
-	"regexp"
-	"strings"
 
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	rdf "github.com/deiu/gon3"
 	"github.com/deiu/rdf2go"
 )
 
@@ -17,46 +20,46 @@ import (
 type MemoryStore struct {
 	uri   string
 	graph *rdf2go.Graph
+	// bnodeIDs maps blank node labels that are not of the form `n<ID>` to the IDs of their rdf2go blank nodes, bnodeLabels maps back
+	bnodeIDs    map[string]int
+	bnodeLabels map[int]string
+	// bnodeMu guards the blank node maps, which are also written by reads (see `SyncStore`)
+	bnodeMu sync.RWMutex
+	options storeOptions
+	// labels indexes the labels for searches, it is built on the first search (see `SearchLabels`)
+	labels *labelIndex
 }
 
-// NewMemoryStore creates a new in-memory graph store.
-func NewMemoryStore(uri string) *MemoryStore {
+// NewMemoryStore creates a new in-memory graph store. The store can be configured with store options, e.g. to check added triples.
+func NewMemoryStore(uri string, opts ...StoreOption) *MemoryStore {
 	store := MemoryStore{
-		uri:   uri,
-		graph: rdf2go.NewGraph(""),
+		uri:     uri,
+		graph:   rdf2go.NewGraph(""),
+		options: newStoreOptions(opts),
 	}
 	return &store
 }
 
 // ParseFromTurtle creates a new memory store from the parsed TTL data given in the reader.
-func ParseFromTurtle(reader io.Reader) (*MemoryStore, error) {
-	// Create a new graph
-	g := rdf2go.NewGraph("")
-	// Parse graph
-	if err := g.Parse(reader, "text/turtle"); err != nil {
+// The import can be configured with parse options, e.g. to resolve relative URIs or rewrite base URIs.
+// Gzip compressed data is decompressed transparently.
+func ParseFromTurtle(reader io.Reader, opts ...ParseOption) (*MemoryStore, error) {
+	options := newParseOptions(opts)
+	// Decompress gzip data
+	reader, err := decompressReader(reader)
+	if err != nil {
 		return nil, err
 	}
-	// Find base URI
-	const RDFType string = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
-	const OWLOntology string = "http://www.w3.org/2002/07/owl#Ontology"
-	triple := g.One(nil, rdf2go.NewResource(RDFType), rdf2go.NewResource(OWLOntology))
-	if triple == nil {
-		// Use prefix from first triple as URI
-		triple = <-g.IterTriples()
-		if triple == nil {
-			return nil, errors.New("No triple found in reader data")
-		}
-	}
-	if triple == nil {
-		return nil, errors.New("invalid ontology: missing owl:Ontology object ")
+	// Parse graph (the Turtle parser of rdf2go maps all blank nodes to the same ID, so the parsed terms are converted here)
+	parsed, err := rdf.NewParser(options.baseURI).Parse(reader)
+	if err != nil {
+		return nil, err
 	}
-	subj := triple.Subject.String()
-	// Return new hive ontology
-	store := MemoryStore{
-		uri:   subj[1 : len(subj)-1],
-		graph: g,
+	g := rdf2go.NewGraph(options.baseURI)
+	for trp := range parsed.IterTriples() {
+		g.AddTriple(turtleTerm(trp.Subject), turtleTerm(trp.Predicate), turtleTerm(trp.Object))
 	}
-	return &store, nil
+	return newParsedMemoryStore(g, options)
 }
 
 // GetURI returns the named graph URI.
@@ -66,39 +69,41 @@ func (store *MemoryStore) GetURI() string {
 
 // GetFirstMatch retrieves the first triple that matches the pattern. Empty strings in subject, predicate or object are treated as wildcards.
 func (store *MemoryStore) GetFirstMatch(subj, pred, obj string) (*Triple, error) {
-	trp := store.graph.One(store.toTerm(subj), store.toTerm(pred), store.toTerm(obj))
+	defer store.options.observeQuery("memory", "query", time.Now(), nil)
+	trp := firstTriple(store.graph, store.toTerm(subj), store.toTerm(pred), store.toTerm(obj))
 	if trp == nil {
 		return nil, nil
 	}
 	triple := Triple{
-		Subject:   Term(trp.Subject.String()),
-		Predicate: Term(trp.Predicate.String()),
-		Object:    Term(trp.Object.String()),
+		Subject:   store.fromTerm(trp.Subject),
+		Predicate: store.fromTerm(trp.Predicate),
+		Object:    store.fromTerm(trp.Object),
 	}
 	return &triple, nil
 }
 
 // GetAllMatches retrieves all triples that match the pattern. Empty strings in subject, predicate or object are treated as wildcards.
 func (store *MemoryStore) GetAllMatches(subj, pred, obj string) ([]Triple, error) {
+	defer store.options.observeQuery("memory", "query", time.Now(), nil)
 	triples := []Triple{}
 	// If the triple pattern is a complete wildcard, return all triples
 	if subj == "" && pred == "" && obj == "" {
 		for trp := range store.graph.IterTriples() {
 			triples = append(triples, Triple{
-				Subject:   Term(trp.Subject.String()),
-				Predicate: Term(trp.Predicate.String()),
-				Object:    Term(trp.Object.String()),
+				Subject:   store.fromTerm(trp.Subject),
+				Predicate: store.fromTerm(trp.Predicate),
+				Object:    store.fromTerm(trp.Object),
 			})
 		}
 		return triples, nil
 	}
 
 	// Otherwise, find all occurrences using the `All` method
-	for _, trp := range store.graph.All(store.toTerm(subj), store.toTerm(pred), store.toTerm(obj)) {
+	for _, trp := range allTriples(store.graph, store.toTerm(subj), store.toTerm(pred), store.toTerm(obj)) {
 		triples = append(triples, Triple{
-			Subject:   Term(trp.Subject.String()),
-			Predicate: Term(trp.Predicate.String()),
-			Object:    Term(trp.Object.String()),
+			Subject:   store.fromTerm(trp.Subject),
+			Predicate: store.fromTerm(trp.Predicate),
+			Object:    store.fromTerm(trp.Object),
 		})
 	}
 	return triples, nil
@@ -123,13 +128,10 @@ func (store *MemoryStore) GetAllTriples() ([]Triple, error) {
 
 // AddTriple adds the given triple to the store. If the triple already exists, it errors with `ErrTripleAlreadyExists`.
 func (store *MemoryStore) AddTriple(trp Triple) error {
-	// Check if triple already exists
-	foundTrp := store.graph.One(store.toTerm(trp.Subject.String()), store.toTerm(trp.Predicate.String()), store.toTerm(trp.Object.String()))
-	if foundTrp != nil {
-		return ErrTripleAlreadyExists
+	if err := store.addTriple(trp); err != nil {
+		return err
 	}
-	// Otherwise, add triple to store
-	store.graph.AddTriple(store.toTerm(trp.Subject.String()), store.toTerm(trp.Predicate.String()), store.toTerm(trp.Object.String()))
+	store.options.observeTriples("memory", 1, 0)
 	return nil
 }
 
@@ -139,7 +141,7 @@ func (store *MemoryStore) AddTriples(trps []Triple) error {
 	// Add all triples in sequence
 	var err error
 	for _, trp := range trps {
-		err = store.AddTriple(trp)
+		err = store.addTriple(trp)
 		// Stop loop if there was an error
 		if err != nil {
 			break
@@ -147,12 +149,15 @@ func (store *MemoryStore) AddTriples(trps []Triple) error {
 		// Otherwise, remember added triple
 		addedTrps = append(addedTrps, trp)
 	}
-	// If there was an error, revoke the adding and return
+	// If there was an error, revoke the adding and return (the revoked triples are not reported as changes)
 	if err != nil {
-		_ = store.DeleteTriplesUnchecked(addedTrps)
+		for _, trp := range addedTrps {
+			_ = store.deleteTriple(trp)
+		}
 		return err
 	}
 	// All fine
+	store.options.observeTriples("memory", len(addedTrps), 0)
 	return nil
 }
 
@@ -160,7 +165,7 @@ func (store *MemoryStore) AddTriples(trps []Triple) error {
 func (store *MemoryStore) AddTripleUnchecked(trp Triple) error {
 	// Rdf2go will just add dplicate triples, so we have to check for existence eitherway and catch the conflict error
 	err := store.AddTriple(trp)
-	if err == ErrTripleAlreadyExists {
+	if errors.Is(err, ErrTripleAlreadyExists) {
 		return nil
 	}
 	return err
@@ -179,13 +184,10 @@ func (store *MemoryStore) AddTriplesUnchecked(trps []Triple) error {
 
 // DeleteTriple removes the given triple from the store. If the triple does not exist, it errors with `ErrTripleDoesNotExist`.
 func (store *MemoryStore) DeleteTriple(trp Triple) error {
-	// Check if triple exists
-	foundTrp := store.graph.One(store.toTerm(trp.Subject.String()), store.toTerm(trp.Predicate.String()), store.toTerm(trp.Object.String()))
-	if foundTrp == nil {
-		return ErrTripleDoesNotExist
+	if err := store.deleteTriple(trp); err != nil {
+		return err
 	}
-	// Delete triple from store
-	store.graph.Remove(foundTrp)
+	store.options.observeTriples("memory", 0, 1)
 	return nil
 }
 
@@ -195,7 +197,7 @@ func (store *MemoryStore) DeleteTriples(trps []Triple) error {
 	// Delete all triples in sequence
 	var err error
 	for _, trp := range trps {
-		err = store.DeleteTriple(trp)
+		err = store.deleteTriple(trp)
 		// Stop loop if there was an error
 		if err != nil {
 			break
@@ -203,20 +205,28 @@ func (store *MemoryStore) DeleteTriples(trps []Triple) error {
 		// Otherwise, remember deleted triple
 		deletedTrps = append(deletedTrps, trp)
 	}
-	// If there was an error, revoke the deletion and return
+	// If there was an error, revoke the deletion and return (the revoked triples are not reported as changes)
 	if err != nil {
-		_ = store.AddTriplesUnchecked(deletedTrps)
+		for _, trp := range deletedTrps {
+			_ = store.addTriple(trp)
+		}
 		return err
 	}
 	// All fine
+	store.options.observeTriples("memory", 0, len(deletedTrps))
 	return nil
 }
 
 // DeleteTripleUnchecked removes the given triple from the store. It does not error if the triple does not exist.
 func (store *MemoryStore) DeleteTripleUnchecked(trp Triple) error {
 	// We need to get the exact triple object from the store to remove it...
-	rdfTrp := store.graph.One(store.toTerm(trp.Subject.String()), store.toTerm(trp.Predicate.String()), store.toTerm(trp.Object.String()))
+	rdfTrp := firstTriple(store.graph, store.toTerm(trp.Subject.String()), store.toTerm(trp.Predicate.String()), store.toTerm(trp.Object.String()))
+	if rdfTrp == nil {
+		return nil
+	}
 	store.graph.Remove(rdfTrp)
+	store.labels.remove(trp)
+	store.options.observeTriples("memory", 0, 1)
 	return nil
 }
 
@@ -235,76 +245,164 @@ func (store *MemoryStore) DeleteTriplesUnchecked(trps []Triple) error {
 func (store *MemoryStore) Drop() error {
 	store.uri = ""
 	store.graph = nil
+	store.labels = nil
 	return nil
 }
 
 // SerializeToTurtle writes the entire store into the writer in Turtle (TTL) format. If pretty is set to true, the TTL is pretty printed.
 func (store *MemoryStore) SerializeToTurtle(w io.Writer, pretty bool) error {
-	// Use native serializer if not pretty
-	if !pretty {
-		return store.graph.Serialize(w, "text/turtle")
-	}
-
-	// Setup base prefix map
-	prefixMap := map[string]string{
-		"":     store.uri + "#",
-		"rdf":  "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
-		"rdfs": "http://www.w3.org/2000/01/rdf-schema#",
-		"owl":  "http://www.w3.org/2002/07/owl#",
-		"xsd":  "http://www.w3.org/2001/XMLSchema#",
-	}
-	// Find all imports
-	const OWLImports string = "http://www.w3.org/2002/07/owl#imports"
-	trps, err := store.GetAllMatches(NewResourceTerm(store.uri).String(), NewResourceTerm(OWLImports).String(), "")
-	if err != nil {
-		return err
+	return store.SerializeToTurtleWithOptions(w, pretty)
+}
+
+// SerializeToTurtleWithOptions writes the entire store into the writer in Turtle (TTL) format (see `SerializeToTurtle`). The
+// serialization can be configured with serialize options, e.g. to control the prefixes applied when pretty printing.
+func (store *MemoryStore) SerializeToTurtleWithOptions(w io.Writer, pretty bool, opts ...SerializeOption) error {
+	return newSerializeOptions(store.options.serializeOptions(opts)).writeTurtle(w, store, pretty, func() (string, error) {
+		ttlBytes := new(bytes.Buffer)
+		if err := store.graph.Serialize(ttlBytes, "text/turtle"); err != nil {
+			return "", err
+		}
+		return ttlBytes.String(), nil
+	})
+}
+
+// Size returns the total number of triples in the store.
+func (store *MemoryStore) Size() (int, error) {
+	return store.graph.Len(), nil
+
+}
+
+// Helper functions
+
+// newParsedMemoryStore creates a new memory store from the parsed graph. It applies the URI rewrites and checks of the parse options
+// and determines the URI of the store from the ontology definition (or the first triple if there is none).
+func newParsedMemoryStore(g *rdf2go.Graph, options parseOptions) (*MemoryStore, error) {
+	// Apply URI rewrites
+	if len(options.rewrites) > 0 {
+		rewritten := rdf2go.NewGraph(options.baseURI)
+		for trp := range g.IterTriples() {
+			rewritten.AddTriple(options.rewriteRDFTerm(trp.Subject), options.rewriteRDFTerm(trp.Predicate), options.rewriteRDFTerm(trp.Object))
+		}
+		g = rewritten
 	}
-	importURIs := []string{}
-	for _, trp := range trps {
-		importURIs = append(importURIs, trp.Object.Value())
+	// Find base URI
+	const RDFType string = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+	const OWLOntology string = "http://www.w3.org/2002/07/owl#Ontology"
+	triple := firstTriple(g, nil, rdf2go.NewResource(RDFType), rdf2go.NewResource(OWLOntology))
+	if triple == nil {
+		// Use prefix from first triple as URI
+		triple = firstTriple(g, nil, nil, nil)
+		if triple == nil {
+			return nil, errors.New("No triple found in reader data")
+		}
 	}
-	// Add imports to prefix map
-	for _, importURI := range importURIs {
-		abbr := importURI[strings.LastIndex(importURI, "/")+1:]
-		prefixMap[abbr] = importURI + "#"
+	if triple == nil {
+		return nil, errors.New("invalid ontology: missing owl:Ontology object ")
 	}
+	subj := triple.Subject.String()
+	// Return new hive ontology
+	store := MemoryStore{
+		uri:   subj[1 : len(subj)-1],
+		graph: g,
+	}
+	// Check parsed triples
+	if options.checksTriples() {
+		isDeclared := func(datatype string) bool {
+			return firstTriple(g, rdf2go.NewResource(datatype), rdf2go.NewResource(RDFType), rdf2go.NewResource(RDFSDatatype)) != nil
+		}
+		trps, _ := store.GetAllTriples()
+		SortTriples(trps)
+		for _, trp := range trps {
+			if err := handleTripleIssues(trp, checkTriple(trp, store.uri, isDeclared), options.strictness, options.warnings); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &store, nil
+}
 
-	// Serialize ontology into buffer
-	ttlBytes := new(bytes.Buffer)
-	err = store.graph.Serialize(ttlBytes, "text/turtle")
-	if err != nil {
+// addTriple adds the triple to the store like `AddTriple` without reporting it to the metrics hook.
+func (store *MemoryStore) addTriple(trp Triple) error {
+	if err := store.options.check(store, []Triple{trp}); err != nil {
 		return err
 	}
-	// Convert result to string
-	ttlContent := ttlBytes.String()
-
-	// Setup Prefix block
-	ttlPrefixes := ""
-	for abbr, prefix := range prefixMap {
-		// Setup prefix entry
-		ttlPrefixes = fmt.Sprintf("%s@prefix %s: <%s> .\n", ttlPrefixes, abbr, prefix)
-		// Apply prefixes
-		var re = regexp.MustCompile(fmt.Sprintf(`\<%s(.+?)\>`, prefix))
-		ttlContent = re.ReplaceAllString(ttlContent, fmt.Sprintf(`%s:$1`, abbr))
+	// Check if triple already exists
+	foundTrp := firstTriple(store.graph, store.toTerm(trp.Subject.String()), store.toTerm(trp.Predicate.String()), store.toTerm(trp.Object.String()))
+	if foundTrp != nil {
+		return wrapStoreError(store, "memory", "AddTriple", &trp, "", ErrTripleAlreadyExists)
 	}
-	// Pretty format triples
-	ttlContent = strings.Replace(ttlContent, " .", " .\n\n", -1)
-
-	// Append prefix block and base path
-	ttlContent = fmt.Sprintf("%s@base <%s> .\n\n%s", ttlPrefixes, store.uri, ttlContent)
+	// Otherwise, add triple to store
+	store.graph.AddTriple(store.toTerm(trp.Subject.String()), store.toTerm(trp.Predicate.String()), store.toTerm(trp.Object.String()))
+	store.labels.add(trp)
+	return nil
+}
 
-	// Write result
-	_, err = io.WriteString(w, ttlContent)
-	return err
+// deleteTriple removes the triple from the store like `DeleteTriple` without reporting it to the metrics hook.
+func (store *MemoryStore) deleteTriple(trp Triple) error {
+	// Check if triple exists
+	foundTrp := firstTriple(store.graph, store.toTerm(trp.Subject.String()), store.toTerm(trp.Predicate.String()), store.toTerm(trp.Object.String()))
+	if foundTrp == nil {
+		return wrapStoreError(store, "memory", "DeleteTriple", &trp, "", ErrTripleDoesNotExist)
+	}
+	// Delete triple from store
+	store.graph.Remove(foundTrp)
+	store.labels.remove(trp)
+	return nil
 }
 
-// Size returns the total number of triples in the store.
-func (store *MemoryStore) Size() (int, error) {
-	return store.graph.Len(), nil
+// turtleTerm converts the term of the Turtle parser into a rdf2go term. Blank nodes keep their distinct IDs.
+func turtleTerm(term rdf.Term) rdf2go.Term {
+	switch term := term.(type) {
+	case *rdf.BlankNode:
+		return rdf2go.NewBlankNode(term.Id)
+	case *rdf.Literal:
+		if len(term.LanguageTag) > 0 {
+			return rdf2go.NewLiteralWithLanguage(term.LexicalForm, term.LanguageTag)
+		}
+		if term.DatatypeIRI != nil && len(term.DatatypeIRI.RawValue()) > 0 {
+			return rdf2go.NewLiteralWithDatatype(term.LexicalForm, rdf2go.NewResource(term.DatatypeIRI.RawValue()))
+		}
+		return rdf2go.NewLiteral(term.RawValue())
+	case *rdf.IRI:
+		return rdf2go.NewResource(term.RawValue())
+	}
+	return nil
+}
 
+// firstTriple returns the first triple of the graph that matches the pattern (nil terms are wildcards), or nil if there is none. Unlike
+// `rdf2go.Graph.One`, it drains the iterator of the graph, so the goroutine of the iterator terminates instead of leaking and reading the
+// graph while it is changed.
+func firstTriple(g *rdf2go.Graph, s, p, o rdf2go.Term) *rdf2go.Triple {
+	if s != nil || p != nil || o != nil {
+		if trps := allTriples(g, s, p, o); len(trps) > 0 {
+			return trps[0]
+		}
+		return nil
+	}
+	var first *rdf2go.Triple
+	for trp := range g.IterTriples() {
+		if first == nil {
+			first = trp
+		}
+	}
+	return first
 }
 
-// Helper functions
+// allTriples returns all triples of the graph that match the pattern (nil terms are wildcards). Unlike `rdf2go.Graph.All`, it also
+// checks the object if only the subject and object are given.
+func allTriples(g *rdf2go.Graph, s, p, o rdf2go.Term) []*rdf2go.Triple {
+	trps := g.All(s, p, o)
+	if s == nil || p != nil || o == nil {
+		return trps
+	}
+	matching := []*rdf2go.Triple{}
+	for _, trp := range trps {
+		if trp.Object.Equal(o) {
+			matching = append(matching, trp)
+		}
+	}
+	return matching
+}
 
 // toTerm converts the given string term in NTriple format into a rdf2go term.
 func (store *MemoryStore) toTerm(term string) rdf2go.Term {
@@ -324,5 +422,38 @@ func (store *MemoryStore) toTerm(term string) rdf2go.Term {
 		}
 		return rdf2go.NewLiteral(t.Value())
 	}
+	if t.IsBlankNode() {
+		// Blank nodes of rdf2go are labeled `_:n<ID>`, other labels are mapped to new IDs
+		if id, err := strconv.Atoi(strings.TrimPrefix(term, "_:n")); err == nil && strings.HasPrefix(term, "_:n") {
+			return rdf2go.NewBlankNode(id)
+		}
+		store.bnodeMu.Lock()
+		defer store.bnodeMu.Unlock()
+		if store.bnodeIDs == nil {
+			store.bnodeIDs = map[string]int{}
+			store.bnodeLabels = map[int]string{}
+		}
+		id, ok := store.bnodeIDs[t.Value()]
+		if !ok {
+			blankNodeIDs.Lock()
+			id = blankNodeIDs.Int()
+			blankNodeIDs.Unlock()
+			store.bnodeIDs[t.Value()] = id
+			store.bnodeLabels[id] = t.Value()
+		}
+		return rdf2go.NewBlankNode(id)
+	}
 	panic(fmt.Sprintf("Invalid term '%s'", term))
 }
+
+// fromTerm converts the rdf2go term into a term in NTriple format. Blank nodes keep the label they were added with.
+func (store *MemoryStore) fromTerm(term rdf2go.Term) Term {
+	if bnode, ok := term.(*rdf2go.BlankNode); ok {
+		store.bnodeMu.RLock()
+		defer store.bnodeMu.RUnlock()
+		if label, ok := store.bnodeLabels[bnode.ID]; ok {
+			return NewBlankNodeTerm(label)
+		}
+	}
+	return Term(term.String())
+}
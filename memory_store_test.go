@@ -162,7 +162,7 @@ var _ = Describe("MemoryStore", func() {
 					Expect(err).NotTo(HaveOccurred())
 					err = graph.AddTriple(*trp)
 					// Check error and make sure the store is unchanged
-					Expect(err).To(Equal(ErrTripleAlreadyExists))
+					Expect(err).To(MatchError(ErrTripleAlreadyExists))
 					trps, err := graph.GetAllTriples()
 					Expect(err).NotTo(HaveOccurred())
 					Expect(trps).To(ConsistOf(testTriples))
@@ -215,7 +215,7 @@ var _ = Describe("MemoryStore", func() {
 					Expect(err).NotTo(HaveOccurred())
 					err = graph.AddTriples([]Triple{*trp8, *trp9, *trp10})
 					// Check error and make sure the store is unchanged
-					Expect(err).To(Equal(ErrTripleAlreadyExists))
+					Expect(err).To(MatchError(ErrTripleAlreadyExists))
 					trps, err := graph.GetAllTriples()
 					Expect(err).NotTo(HaveOccurred())
 					Expect(trps).To(ConsistOf(testTriples))
@@ -272,7 +272,7 @@ var _ = Describe("MemoryStore", func() {
 					Expect(err).NotTo(HaveOccurred())
 					err = graph.DeleteTriple(*trp)
 					// Check error and make sure the store is unchanged
-					Expect(err).To(Equal(ErrTripleDoesNotExist))
+					Expect(err).To(MatchError(ErrTripleDoesNotExist))
 					trps, err := graph.GetAllTriples()
 					Expect(err).NotTo(HaveOccurred())
 					Expect(trps).To(ConsistOf(testTriples))
@@ -326,7 +326,7 @@ var _ = Describe("MemoryStore", func() {
 					Expect(err).NotTo(HaveOccurred())
 					err = graph.DeleteTriples([]Triple{*trp1, *trp4, *trp5})
 					// Check for error and that the store is left unchanged
-					Expect(err).To(Equal(ErrTripleDoesNotExist))
+					Expect(err).To(MatchError(ErrTripleDoesNotExist))
 					trps, err := graph.GetAllTriples()
 					Expect(err).NotTo(HaveOccurred())
 					Expect(trps).To(ConsistOf(testTriples))
@@ -393,6 +393,103 @@ var _ = Describe("MemoryStore", func() {
 		})
 	})
 
+	Describe("Serializing the graph store in sorted order", func() {
+		It("should generate identical TTL regardless of insertion order", func() {
+			otherGraph := NewMemoryStore(graphUri)
+			for i := len(testTriples) - 1; i >= 0; i-- {
+				Expect(otherGraph.AddTriple(testTriples[i])).To(Succeed())
+			}
+			var ttlContent, otherTTLContent strings.Builder
+			Expect(graph.SerializeToTurtleWithOptions(&ttlContent, true, WithSortedOutput())).To(Succeed())
+			Expect(otherGraph.SerializeToTurtleWithOptions(&otherTTLContent, true, WithSortedOutput())).To(Succeed())
+			Expect(ttlContent.String()).To(Equal(otherTTLContent.String()))
+			By("loading the TTL back to a store")
+			loadedGraph, err := ParseFromTurtle(strings.NewReader(ttlContent.String()))
+			Expect(err).NotTo(HaveOccurred())
+			trps, err := loadedGraph.GetAllTriples()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trps).To(ConsistOf(testTriples))
+		})
+	})
+
+	Describe("Serializing the graph store with a custom prefix map", func() {
+		It("should only declare and apply the given prefixes", func() {
+			var ttlContent strings.Builder
+			err := graph.SerializeToTurtleWithOptions(&ttlContent, true, WithPrefixes(PrefixMap{"test": graphUri + "#"}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttlContent.String()).To(ContainSubstring(fmt.Sprintf("@prefix test: <%s#> .", graphUri)))
+			Expect(ttlContent.String()).To(ContainSubstring("test:rel-1"))
+			Expect(ttlContent.String()).NotTo(ContainSubstring("@prefix owl:"))
+			By("loading the TTL back to a store")
+			loadedGraph, err := ParseFromTurtle(strings.NewReader(ttlContent.String()))
+			Expect(err).NotTo(HaveOccurred())
+			trps, err := loadedGraph.GetAllTriples()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trps).To(ConsistOf(testTriples))
+		})
+	})
+
+	Describe("Loading from TTL with parse options", func() {
+		It("should resolve relative URIs against the given base URI", func() {
+			ttl := `<> a <http://www.w3.org/2002/07/owl#Ontology> . <#a> <#rel> <#b> .`
+			loadedGraph, err := ParseFromTurtle(strings.NewReader(ttl), WithBaseURI(graphUri))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loadedGraph.GetURI()).To(Equal(graphUri))
+			trp, err := loadedGraph.GetFirstMatch(NewResourceTerm(graphUri+"#a").String(), "", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trp).NotTo(BeNil())
+			Expect(trp.Object).To(Equal(NewResourceTerm(graphUri + "#b")))
+		})
+		It("should keep labeled blank nodes distinct", func() {
+			ttl := `<http://example.com/onto> a <http://www.w3.org/2002/07/owl#Ontology> .
+<http://example.com/onto#a> <http://example.com/onto#rel> _:x , _:y .
+_:x <http://example.com/onto#value> "1" .
+_:y <http://example.com/onto#value> "2" .`
+			loadedGraph, err := ParseFromTurtle(strings.NewReader(ttl))
+			Expect(err).NotTo(HaveOccurred())
+			trps, err := loadedGraph.GetAllMatches("", NewResourceTerm("http://example.com/onto#value").String(), "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trps).To(HaveLen(2))
+			Expect(trps[0].Subject).NotTo(Equal(trps[1].Subject))
+		})
+		It("should rewrite the source base URI to the target URI", func() {
+			err := graph.AddTriple(Triple{Subject: NewResourceTerm(graphUri), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLOntology)})
+			Expect(err).NotTo(HaveOccurred())
+			var ttlContent strings.Builder
+			err = graph.SerializeToTurtle(&ttlContent, false)
+			Expect(err).NotTo(HaveOccurred())
+			loadedGraph, err := ParseFromTurtle(strings.NewReader(ttlContent.String()), WithURIRewrite(graphUri, "https://www.ontograph.com/published"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loadedGraph.GetURI()).To(Equal("https://www.ontograph.com/published"))
+			trps, err := loadedGraph.GetAllTriples()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trps).To(HaveLen(len(testTriples) + 1))
+			Expect(trps).To(ContainElement(Triple{
+				Subject:   NewResourceTerm("https://www.ontograph.com/published#c"),
+				Predicate: NewResourceTerm("https://www.ontograph.com/published#rel-5"),
+				Object:    NewLiteralTerm("lit3", "", "https://www.ontograph.com/published#datatype"),
+			}))
+			for _, trp := range trps {
+				Expect(trp.Subject.Value()).NotTo(HavePrefix(graphUri))
+			}
+		})
+	})
+
+	Describe("Storing blank nodes", func() {
+		It("should keep the labels of the blank nodes", func() {
+			trps := []Triple{
+				{Subject: NewResourceTerm(graphUri + "#a"), Predicate: NewResourceTerm(graphUri + "#rel-6"), Object: NewBlankNodeTerm("b1")},
+				{Subject: NewBlankNodeTerm("b1"), Predicate: NewResourceTerm(graphUri + "#rel-7"), Object: NewBlankNodeTerm("b2")},
+			}
+			Expect(graph.AddTriples(trps)).To(Succeed())
+			Expect(graph.GetAllMatches(NewBlankNodeTerm("b1").String(), "", "")).To(Equal([]Triple{trps[1]}))
+			Expect(graph.GetAllMatches("", "", NewBlankNodeTerm("b1").String())).To(Equal([]Triple{trps[0]}))
+			Expect(graph.AddTriple(trps[1])).NotTo(Succeed())
+			Expect(graph.DeleteTriple(trps[1])).To(Succeed())
+			Expect(graph.GetAllMatches(NewBlankNodeTerm("b1").String(), "", "")).To(BeEmpty())
+		})
+	})
+
 	Describe("Retrieving the size of the graph store", func() {
 		It("should return the expected number of triples", func() {
 			Expect(graph.Size()).To(Equal(len(testTriples)))
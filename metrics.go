@@ -0,0 +1,186 @@
+package ontograph
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A MetricsHook receives the metrics of graph stores that are created with `WithMetrics`, e.g. to monitor the health of the stores of a
+// service. Hooks are called synchronously and must be safe for concurrent use. Use `Metrics` to collect the metrics in memory and expose
+// them in the Prometheus text format, or implement the hook to update the collectors of a metrics library.
+type MetricsHook interface {
+	// ObserveQuery is called after every query of a store with the backend of the store (`memory` or `blazegraph`), the kind of query
+	// (`query` for reads, `update` for changes and `insert` for bulk loads), its latency and its error (nil on success).
+	ObserveQuery(backend, kind string, latency time.Duration, err error)
+	// ObserveTriples is called after triples were added to or deleted from a store. Database stores cannot tell which triples of an
+	// unchecked change already existed (or did not exist), so they report all triples that were sent. Triples deleted by pattern (see
+	// `DeleteAllMatches`) are not reported by database stores.
+	ObserveTriples(backend string, added, deleted int)
+}
+
+// DefaultLatencyBuckets are the upper bounds of the latency histogram buckets of `Metrics` in seconds. They are the default buckets of
+// Prometheus.
+var DefaultLatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Metrics is a metrics hook that collects counters and latency histograms of graph stores in memory. It implements `http.Handler`, so
+// the metrics can be scraped by Prometheus. The following metrics are collected, labeled by backend and kind of query:
+//
+//	ontograph_queries_total            counter    queries executed
+//	ontograph_query_errors_total       counter    failed queries (the error rate is their ratio to the queries executed)
+//	ontograph_query_duration_seconds   histogram  query latency
+//	ontograph_triples_added_total      counter    triples added (labeled by backend only)
+//	ontograph_triples_deleted_total    counter    triples deleted (labeled by backend only)
+type Metrics struct {
+	mu      sync.Mutex
+	buckets []float64
+	queries map[queryMetricsKey]*queryMetrics
+	added   map[string]int
+	deleted map[string]int
+}
+
+// NewMetrics creates a new metrics collector whose latency histograms use the given bucket bounds in seconds. If no buckets are given,
+// `DefaultLatencyBuckets` are used.
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+	return &Metrics{
+		buckets: buckets,
+		queries: map[queryMetricsKey]*queryMetrics{},
+		added:   map[string]int{},
+		deleted: map[string]int{},
+	}
+}
+
+// ObserveQuery counts the query and records its latency.
+func (m *Metrics) ObserveQuery(backend, kind string, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := queryMetricsKey{backend: backend, kind: kind}
+	qm, ok := m.queries[key]
+	if !ok {
+		qm = &queryMetrics{buckets: make([]int, len(m.buckets))}
+		m.queries[key] = qm
+	}
+	qm.count++
+	if err != nil {
+		qm.errors++
+	}
+	seconds := latency.Seconds()
+	qm.sum += seconds
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			qm.buckets[i]++
+		}
+	}
+}
+
+// ObserveTriples counts the added and deleted triples.
+func (m *Metrics) ObserveTriples(backend string, added, deleted int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.added[backend] += added
+	m.deleted[backend] += deleted
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format into the writer.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var sb strings.Builder
+	keys := make([]queryMetricsKey, 0, len(m.queries))
+	for key := range m.queries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].backend != keys[j].backend {
+			return keys[i].backend < keys[j].backend
+		}
+		return keys[i].kind < keys[j].kind
+	})
+	writeMetricHeader(&sb, "ontograph_queries_total", "counter", "Number of queries executed by graph stores.")
+	for _, key := range keys {
+		fmt.Fprintf(&sb, "ontograph_queries_total{%s} %d\n", key.labels(), m.queries[key].count)
+	}
+	writeMetricHeader(&sb, "ontograph_query_errors_total", "counter", "Number of failed queries of graph stores.")
+	for _, key := range keys {
+		fmt.Fprintf(&sb, "ontograph_query_errors_total{%s} %d\n", key.labels(), m.queries[key].errors)
+	}
+	writeMetricHeader(&sb, "ontograph_query_duration_seconds", "histogram", "Latency of the queries of graph stores in seconds.")
+	for _, key := range keys {
+		qm := m.queries[key]
+		for i, bound := range m.buckets {
+			fmt.Fprintf(&sb, "ontograph_query_duration_seconds_bucket{%s,le=\"%s\"} %d\n", key.labels(), formatFloat(bound), qm.buckets[i])
+		}
+		fmt.Fprintf(&sb, "ontograph_query_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", key.labels(), qm.count)
+		fmt.Fprintf(&sb, "ontograph_query_duration_seconds_sum{%s} %s\n", key.labels(), formatFloat(qm.sum))
+		fmt.Fprintf(&sb, "ontograph_query_duration_seconds_count{%s} %d\n", key.labels(), qm.count)
+	}
+	writeMetricHeader(&sb, "ontograph_triples_added_total", "counter", "Number of triples added to graph stores.")
+	for _, backend := range sortedIntKeys(m.added) {
+		fmt.Fprintf(&sb, "ontograph_triples_added_total{backend=%q} %d\n", backend, m.added[backend])
+	}
+	writeMetricHeader(&sb, "ontograph_triples_deleted_total", "counter", "Number of triples deleted from graph stores.")
+	for _, backend := range sortedIntKeys(m.deleted) {
+		fmt.Fprintf(&sb, "ontograph_triples_deleted_total{backend=%q} %d\n", backend, m.deleted[backend])
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format as response, e.g. for a `/metrics` endpoint.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// queryMetricsKey identifies the metrics of a kind of query of a backend.
+type queryMetricsKey struct {
+	backend string
+	kind    string
+}
+
+// labels returns the Prometheus labels of the key.
+func (key queryMetricsKey) labels() string {
+	return fmt.Sprintf("backend=%q,kind=%q", key.backend, key.kind)
+}
+
+// queryMetrics holds the counters and the latency histogram of a kind of query. The buckets are cumulative.
+type queryMetrics struct {
+	count   int
+	errors  int
+	sum     float64
+	buckets []int
+}
+
+// writeMetricHeader writes the help and type lines of a metric.
+func writeMetricHeader(sb *strings.Builder, name, typ, help string) {
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// formatFloat formats the number like Prometheus does.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// sortedIntKeys returns the sorted keys of the map.
+func sortedIntKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
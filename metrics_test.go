@@ -0,0 +1,134 @@
+package ontograph_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+// exposition returns the metrics in the Prometheus text format.
+func exposition(metrics *Metrics) string {
+	var sb strings.Builder
+	Expect(metrics.WritePrometheus(&sb)).To(Succeed())
+	return sb.String()
+}
+
+var _ = Describe("Metrics", func() {
+	const testUri = "http://example.com/onto"
+	classTrp := func(name string) Triple {
+		return Triple{Subject: NewResourceTerm(testUri + "#" + name), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)}
+	}
+
+	It("should count the queries and changed triples of memory stores", func() {
+		metrics := NewMetrics()
+		store := NewMemoryStore(testUri, WithMetrics(metrics))
+		Expect(store.AddTriples([]Triple{classTrp("A"), classTrp("B")})).To(Succeed())
+		// Unchanged triples are not counted
+		Expect(store.AddTripleUnchecked(classTrp("A"))).To(Succeed())
+		Expect(store.DeleteTripleUnchecked(classTrp("C"))).To(Succeed())
+		Expect(store.DeleteTriple(classTrp("A"))).To(Succeed())
+		Expect(store.GetAllTriples()).To(HaveLen(1))
+		Expect(store.GetFirstMatch("", "", "")).NotTo(BeNil())
+
+		out := exposition(metrics)
+		Expect(out).To(ContainSubstring(`ontograph_queries_total{backend="memory",kind="query"} 2` + "\n"))
+		Expect(out).To(ContainSubstring(`ontograph_query_errors_total{backend="memory",kind="query"} 0` + "\n"))
+		Expect(out).To(ContainSubstring(`ontograph_query_duration_seconds_count{backend="memory",kind="query"} 2` + "\n"))
+		Expect(out).To(ContainSubstring(`ontograph_triples_added_total{backend="memory"} 2` + "\n"))
+		Expect(out).To(ContainSubstring(`ontograph_triples_deleted_total{backend="memory"} 1` + "\n"))
+	})
+
+	It("should not count the rolled back triples of failed batches", func() {
+		metrics := NewMetrics()
+		store := NewMemoryStore(testUri, WithMetrics(metrics))
+		Expect(store.AddTriple(classTrp("A"))).To(Succeed())
+		Expect(store.AddTriples([]Triple{classTrp("B"), classTrp("A")})).To(MatchError(ErrTripleAlreadyExists))
+		Expect(store.DeleteTriples([]Triple{classTrp("A"), classTrp("C")})).To(MatchError(ErrTripleDoesNotExist))
+		Expect(store.GetAllTriples()).To(ConsistOf(classTrp("A")))
+
+		out := exposition(metrics)
+		Expect(out).To(ContainSubstring(`ontograph_triples_added_total{backend="memory"} 1` + "\n"))
+		Expect(out).To(ContainSubstring(`ontograph_triples_deleted_total{backend="memory"} 0` + "\n"))
+	})
+
+	It("should not observe stores without metrics hook", func() {
+		metrics := NewMetrics()
+		store := NewMemoryStore(testUri)
+		Expect(store.AddTriple(classTrp("A"))).To(Succeed())
+		Expect(exposition(metrics)).NotTo(ContainSubstring("memory"))
+	})
+
+	It("should count the requests, errors and changed triples of Blazegraph stores", func() {
+		failing := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if failing {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			if strings.Contains(r.Header.Get("Accept"), "json") {
+				w.Header().Set("Content-Type", "application/sparql-results+json")
+				w.Write([]byte(`{"head":{"vars":["n"]},"results":{"bindings":[{"n":{"type":"literal","value":"3"}}]}}`))
+				return
+			}
+			w.Write([]byte(`<?xml version="1.0"?><data modified="1" milliseconds="1"/>`))
+		}))
+		defer server.Close()
+		metrics := NewMetrics()
+		ep := NewBlazegraphEndpoint(server.URL)
+		store := ep.NewBlazegraphStore(testUri, "test", WithMetrics(metrics))
+		Expect(store.AddTriplesUnchecked([]Triple{classTrp("A"), classTrp("B")})).To(Succeed())
+		Expect(store.DeleteTripleUnchecked(classTrp("A"))).To(Succeed())
+		Expect(store.Size()).To(Equal(3))
+		tx, err := store.Begin()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tx.AddTripleUnchecked(classTrp("C"))).To(Succeed())
+		Expect(tx.Commit()).To(Succeed())
+		failing = true
+		err = store.AddTripleUnchecked(classTrp("D"))
+		Expect(err).To(HaveOccurred())
+
+		out := exposition(metrics)
+		Expect(out).To(ContainSubstring(`ontograph_queries_total{backend="blazegraph",kind="query"} 1` + "\n"))
+		Expect(out).To(ContainSubstring(`ontograph_queries_total{backend="blazegraph",kind="update"} 4` + "\n"))
+		Expect(out).To(ContainSubstring(`ontograph_query_errors_total{backend="blazegraph",kind="update"} 1` + "\n"))
+		Expect(out).To(ContainSubstring(`ontograph_triples_added_total{backend="blazegraph"} 3` + "\n"))
+		Expect(out).To(ContainSubstring(`ontograph_triples_deleted_total{backend="blazegraph"} 1` + "\n"))
+
+		// The endpoint itself is not observed
+		_, err = ep.DoSparqlUpdate("test", "CLEAR ALL")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exposition(metrics)).To(ContainSubstring(`ontograph_queries_total{backend="blazegraph",kind="update"} 4` + "\n"))
+	})
+
+	It("should record the latency in histogram buckets", func() {
+		metrics := NewMetrics(1, 0.1)
+		metrics.ObserveQuery("memory", "query", 50*time.Millisecond, nil)
+		metrics.ObserveQuery("memory", "query", 500*time.Millisecond, errors.New("failed"))
+		metrics.ObserveQuery("memory", "query", 2*time.Second, nil)
+
+		out := exposition(metrics)
+		Expect(out).To(ContainSubstring("# TYPE ontograph_query_duration_seconds histogram\n"))
+		Expect(out).To(ContainSubstring(`ontograph_query_duration_seconds_bucket{backend="memory",kind="query",le="0.1"} 1` + "\n"))
+		Expect(out).To(ContainSubstring(`ontograph_query_duration_seconds_bucket{backend="memory",kind="query",le="1"} 2` + "\n"))
+		Expect(out).To(ContainSubstring(`ontograph_query_duration_seconds_bucket{backend="memory",kind="query",le="+Inf"} 3` + "\n"))
+		Expect(out).To(ContainSubstring(`ontograph_query_duration_seconds_sum{backend="memory",kind="query"} 2.55` + "\n"))
+		Expect(out).To(ContainSubstring(`ontograph_query_errors_total{backend="memory",kind="query"} 1` + "\n"))
+	})
+
+	It("should serve the metrics over HTTP", func() {
+		metrics := NewMetrics()
+		metrics.ObserveTriples("memory", 2, 0)
+		rec := httptest.NewRecorder()
+		metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Type")).To(HavePrefix("text/plain; version=0.0.4"))
+		Expect(rec.Body.String()).To(ContainSubstring(`ontograph_triples_added_total{backend="memory"} 2` + "\n"))
+	})
+})
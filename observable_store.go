@@ -0,0 +1,169 @@
+package ontograph
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// A ChangeEvent describes a change of a graph store. Added and Deleted hold the affected triples.
+type ChangeEvent struct {
+	Graph   string
+	Added   []Triple
+	Deleted []Triple
+}
+
+// ObservableStore wraps a graph store and notifies its subscribers about every change, e.g. to invalidate caches, update search
+// indexes or push changes to clients without polling. The subscribers are called synchronously after the change was applied to the
+// store. Failed operations are not reported. For the unchecked operations, the event holds all given triples, including triples that
+// already existed or did not exist. All reads are passed through.
+type ObservableStore struct {
+	GraphStore
+	subscribers *subscribers
+}
+
+// NewObservableStore wraps the given store into an observable store without subscribers.
+func NewObservableStore(store GraphStore) *ObservableStore {
+	return &ObservableStore{GraphStore: store, subscribers: &subscribers{fns: map[int]func(ChangeEvent){}}}
+}
+
+// Subscribe registers the function to be called on every change of the store. It returns a function to cancel the subscription.
+func (store *ObservableStore) Subscribe(fn func(ChangeEvent)) (unsubscribe func()) {
+	subs := store.subscribers
+	subs.mu.Lock()
+	defer subs.mu.Unlock()
+	id := subs.next
+	subs.next++
+	subs.fns[id] = fn
+	return func() {
+		subs.mu.Lock()
+		defer subs.mu.Unlock()
+		delete(subs.fns, id)
+	}
+}
+
+// WithContext returns a copy of the store bound to the context (see `StoreWithContext`). The copy shares the subscribers of the store.
+func (store *ObservableStore) WithContext(ctx context.Context) GraphStore {
+	return &ObservableStore{GraphStore: StoreWithContext(store.GraphStore, ctx), subscribers: store.subscribers}
+}
+
+// DeleteAllMatches removes all triples that match the pattern. The matching triples are retrieved beforehand to report them.
+func (store *ObservableStore) DeleteAllMatches(subj, pred, obj string) error {
+	trps, err := store.GraphStore.GetAllMatches(subj, pred, obj)
+	if err != nil {
+		return err
+	}
+	return store.notify(nil, trps, store.GraphStore.DeleteAllMatches(subj, pred, obj))
+}
+
+// AddTriple adds the triple to the store. If the triple already exists, it errors with `ErrTripleAlreadyExists`.
+func (store *ObservableStore) AddTriple(trp Triple) error {
+	return store.notify([]Triple{trp}, nil, store.GraphStore.AddTriple(trp))
+}
+
+// AddTriples adds all the triples to the store. If one of the triples already exists, it errors with `ErrTripleAlreadyExists`.
+func (store *ObservableStore) AddTriples(trps []Triple) error {
+	return store.notify(trps, nil, store.GraphStore.AddTriples(trps))
+}
+
+// AddTripleUnchecked adds the triple to the store. It does not error if the triple already exists.
+func (store *ObservableStore) AddTripleUnchecked(trp Triple) error {
+	return store.notify([]Triple{trp}, nil, store.GraphStore.AddTripleUnchecked(trp))
+}
+
+// AddTriplesUnchecked adds all the triples to the store. It does not error if any of the triples already exists.
+func (store *ObservableStore) AddTriplesUnchecked(trps []Triple) error {
+	return store.notify(trps, nil, store.GraphStore.AddTriplesUnchecked(trps))
+}
+
+// DeleteTriple removes the triple from the store.
+func (store *ObservableStore) DeleteTriple(trp Triple) error {
+	return store.notify(nil, []Triple{trp}, store.GraphStore.DeleteTriple(trp))
+}
+
+// DeleteTriples removes all the triples from the store.
+func (store *ObservableStore) DeleteTriples(trps []Triple) error {
+	return store.notify(nil, trps, store.GraphStore.DeleteTriples(trps))
+}
+
+// DeleteTripleUnchecked removes the triple from the store. It does not error if the triple does not exist.
+func (store *ObservableStore) DeleteTripleUnchecked(trp Triple) error {
+	return store.notify(nil, []Triple{trp}, store.GraphStore.DeleteTripleUnchecked(trp))
+}
+
+// DeleteTriplesUnchecked removes all the triples from the store. It does not error if any of the triples does not exist.
+func (store *ObservableStore) DeleteTriplesUnchecked(trps []Triple) error {
+	return store.notify(nil, trps, store.GraphStore.DeleteTriplesUnchecked(trps))
+}
+
+// Drop removes all triples from the store. All triples are retrieved beforehand to report them.
+func (store *ObservableStore) Drop() error {
+	trps, err := store.GraphStore.GetAllTriples()
+	if err != nil {
+		return err
+	}
+	// Dropping clears the URI of the store, so it is captured beforehand
+	uri := store.GetURI()
+	return store.notifyGraph(uri, nil, trps, store.GraphStore.Drop())
+}
+
+// Begin starts a new transaction on the store (see `Begin`). The changes of the transaction are committed with a transaction on the
+// wrapped store and reported as a single event.
+func (store *ObservableStore) Begin() (Tx, error) {
+	return newBufferedTx(store, func(deleted, added []Triple) error {
+		tx, err := Begin(store.GraphStore)
+		if err != nil {
+			return err
+		}
+		if err := tx.DeleteTriplesUnchecked(deleted); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := tx.AddTriplesUnchecked(added); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		return store.notify(added, deleted, tx.Commit())
+	}), nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// subscribers holds the subscriptions of an observable store.
+type subscribers struct {
+	mu   sync.RWMutex
+	next int
+	fns  map[int]func(ChangeEvent)
+}
+
+// notify calls the subscribers with the change unless the operation errored or nothing changed. The error is passed through.
+func (store *ObservableStore) notify(added, deleted []Triple, err error) error {
+	return store.notifyGraph(store.GetURI(), added, deleted, err)
+}
+
+// notifyGraph calls the subscribers with the change of the graph with the given URI like `notify`.
+func (store *ObservableStore) notifyGraph(graph string, added, deleted []Triple, err error) error {
+	if err != nil || len(added)+len(deleted) == 0 {
+		return err
+	}
+	event := ChangeEvent{Graph: graph, Added: added, Deleted: deleted}
+	subs := store.subscribers
+	subs.mu.RLock()
+	ids := make([]int, 0, len(subs.fns))
+	for id := range subs.fns {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	fns := make([]func(ChangeEvent), 0, len(ids))
+	for _, id := range ids {
+		fns = append(fns, subs.fns[id])
+	}
+	subs.mu.RUnlock()
+	// Call the subscribers without holding the lock, so they may subscribe or unsubscribe themselves
+	for _, fn := range fns {
+		fn(event)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package ontograph_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Observable stores", func() {
+	const testUri = "http://example.com/onto"
+	classTrp := func(name string) Triple {
+		return Triple{Subject: NewResourceTerm(testUri + "#" + name), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)}
+	}
+	var store *ObservableStore
+	var events []ChangeEvent
+
+	BeforeEach(func() {
+		store = NewObservableStore(NewMemoryStore(testUri))
+		events = []ChangeEvent{}
+		store.Subscribe(func(event ChangeEvent) {
+			events = append(events, event)
+		})
+	})
+
+	It("should notify subscribers about added and deleted triples", func() {
+		Expect(store.AddTriples([]Triple{classTrp("A"), classTrp("B")})).To(Succeed())
+		Expect(store.DeleteTriple(classTrp("A"))).To(Succeed())
+		Expect(store.DeleteAllMatches("", "", "")).To(Succeed())
+		Expect(events).To(Equal([]ChangeEvent{
+			{Graph: testUri, Added: []Triple{classTrp("A"), classTrp("B")}},
+			{Graph: testUri, Deleted: []Triple{classTrp("A")}},
+			{Graph: testUri, Deleted: []Triple{classTrp("B")}},
+		}))
+	})
+
+	It("should report the URI of dropped graphs", func() {
+		Expect(store.AddTriple(classTrp("A"))).To(Succeed())
+		Expect(store.Drop()).To(Succeed())
+		Expect(events).To(HaveLen(2))
+		Expect(events[1]).To(Equal(ChangeEvent{Graph: testUri, Deleted: []Triple{classTrp("A")}}))
+	})
+
+	It("should not notify about failed operations or after unsubscribing", func() {
+		Expect(store.AddTriple(classTrp("A"))).To(Succeed())
+		Expect(store.AddTriple(classTrp("A"))).To(MatchError(ErrTripleAlreadyExists))
+		Expect(events).To(HaveLen(1))
+
+		other := 0
+		unsubscribe := store.Subscribe(func(event ChangeEvent) { other++ })
+		Expect(store.AddTriple(classTrp("B"))).To(Succeed())
+		unsubscribe()
+		Expect(store.AddTriple(classTrp("C"))).To(Succeed())
+		Expect(other).To(Equal(1))
+		Expect(events).To(HaveLen(3))
+	})
+
+	It("should report committed transactions as a single event", func() {
+		ont, err := InitOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#A", Label: map[string]string{"en": "A"}})).To(Succeed())
+		events = []ChangeEvent{}
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#A", Label: map[string]string{"en": "B"}})).To(Succeed())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Added).To(ContainElement(Triple{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(RDFSLabel), Object: NewLiteralTerm("B", "en", "")}))
+		Expect(events[0].Deleted).To(ConsistOf(Triple{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(RDFSLabel), Object: NewLiteralTerm("A", "en", "")}))
+	})
+})
@@ -0,0 +1,279 @@
+package ontographtest
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/kahefi/ontograph"
+)
+
+// A Call is a recorded call of a `FakeGraphStore`.
+type Call struct {
+	// Method is the name of the called method, e.g. `AddTriple`.
+	Method string
+	// Args are the arguments of the call, except for writers and options.
+	Args []interface{}
+	// Err is the error returned by the call.
+	Err error
+}
+
+// FakeGraphStore is a graph store for unit tests that keeps its triples in memory, records all calls and can be scripted to fail or to
+// be slow, e.g. to test the error handling and timeouts of code built on ontograph without a database. It is safe for concurrent use.
+type FakeGraphStore struct {
+	store    ontograph.GraphStore
+	mu       sync.Mutex
+	calls    []Call
+	failures []failure
+	latency  map[string]time.Duration
+}
+
+// NewFakeGraphStore creates a new, empty fake graph store with the graph URI.
+func NewFakeGraphStore(uri string) *FakeGraphStore {
+	return &FakeGraphStore{
+		store:   ontograph.NewSyncStore(ontograph.NewMemoryStore(uri)),
+		latency: map[string]time.Duration{},
+	}
+}
+
+// Backend returns the store that holds the triples of the fake store, e.g. to add test data or inspect changes without recording calls.
+func (store *FakeGraphStore) Backend() ontograph.GraphStore {
+	return store.store
+}
+
+// FailOn lets all calls of the methods fail with the error, until the failures are cleared. If no methods are given, all methods fail.
+// Failing calls do not change the store.
+func (store *FakeGraphStore) FailOn(err error, methods ...string) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.failures = append(store.failures, failure{methods: methods, err: err, always: true})
+}
+
+// FailNext lets the next call of one of the methods fail with the error. If no methods are given, the next call of any method fails.
+// Failing calls do not change the store.
+func (store *FakeGraphStore) FailNext(err error, methods ...string) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.failures = append(store.failures, failure{methods: methods, err: err})
+}
+
+// ClearFailures removes all scripted failures.
+func (store *FakeGraphStore) ClearFailures() {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.failures = nil
+}
+
+// SetLatency delays all calls of the methods by the duration. If no methods are given, all methods are delayed. A zero duration removes
+// the delay.
+func (store *FakeGraphStore) SetLatency(d time.Duration, methods ...string) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(methods) == 0 {
+		methods = []string{""}
+	}
+	for _, method := range methods {
+		store.latency[method] = d
+	}
+}
+
+// Calls returns the recorded calls in the order they returned. If methods are given, only their calls are returned.
+func (store *FakeGraphStore) Calls(methods ...string) []Call {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	calls := []Call{}
+	for _, call := range store.calls {
+		if len(methods) == 0 || contains(methods, call.Method) {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// ResetCalls discards the recorded calls.
+func (store *FakeGraphStore) ResetCalls() {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.calls = nil
+}
+
+// GetURI returns the named graph URI. The call is recorded, but can neither fail nor be delayed.
+func (store *FakeGraphStore) GetURI() string {
+	store.record(Call{Method: "GetURI"})
+	return store.store.GetURI()
+}
+
+// GetFirstMatch retrieves the first triple that matches the pattern. Empty strings in subject, predicate or object are treated as
+// wildcards.
+func (store *FakeGraphStore) GetFirstMatch(subj, pred, obj string) (trp *ontograph.Triple, err error) {
+	err = store.call("GetFirstMatch", []interface{}{subj, pred, obj}, func() error {
+		trp, err = store.store.GetFirstMatch(subj, pred, obj)
+		return err
+	})
+	return trp, err
+}
+
+// GetAllMatches retrieves all triples that match the pattern. Empty strings in subject, predicate or object are treated as wildcards.
+func (store *FakeGraphStore) GetAllMatches(subj, pred, obj string) (trps []ontograph.Triple, err error) {
+	err = store.call("GetAllMatches", []interface{}{subj, pred, obj}, func() error {
+		trps, err = store.store.GetAllMatches(subj, pred, obj)
+		return err
+	})
+	return trps, err
+}
+
+// DeleteAllMatches removes all triples that match the pattern. Empty strings in subject, predicate or object are treated as wildcards.
+func (store *FakeGraphStore) DeleteAllMatches(subj, pred, obj string) error {
+	return store.call("DeleteAllMatches", []interface{}{subj, pred, obj}, func() error {
+		return store.store.DeleteAllMatches(subj, pred, obj)
+	})
+}
+
+// GetAllTriples returns all triples in the store.
+func (store *FakeGraphStore) GetAllTriples() (trps []ontograph.Triple, err error) {
+	err = store.call("GetAllTriples", nil, func() error {
+		trps, err = store.store.GetAllTriples()
+		return err
+	})
+	return trps, err
+}
+
+// AddTriple adds the triple to the store. If the triple already exists, it errors with `ErrTripleAlreadyExists`.
+func (store *FakeGraphStore) AddTriple(trp ontograph.Triple) error {
+	return store.call("AddTriple", []interface{}{trp}, func() error {
+		return store.store.AddTriple(trp)
+	})
+}
+
+// AddTriples adds all the triples to the store. If one of the triples already exists, it errors with `ErrTripleAlreadyExists`.
+func (store *FakeGraphStore) AddTriples(trps []ontograph.Triple) error {
+	return store.call("AddTriples", []interface{}{trps}, func() error {
+		return store.store.AddTriples(trps)
+	})
+}
+
+// AddTripleUnchecked adds the triple to the store. It does not error if the triple already exists.
+func (store *FakeGraphStore) AddTripleUnchecked(trp ontograph.Triple) error {
+	return store.call("AddTripleUnchecked", []interface{}{trp}, func() error {
+		return store.store.AddTripleUnchecked(trp)
+	})
+}
+
+// AddTriplesUnchecked adds all the triples to the store. It does not error if any of the triples already exists.
+func (store *FakeGraphStore) AddTriplesUnchecked(trps []ontograph.Triple) error {
+	return store.call("AddTriplesUnchecked", []interface{}{trps}, func() error {
+		return store.store.AddTriplesUnchecked(trps)
+	})
+}
+
+// DeleteTriple removes the triple from the store. If the triple does not exist, it errors with `ErrTripleDoesNotExist`.
+func (store *FakeGraphStore) DeleteTriple(trp ontograph.Triple) error {
+	return store.call("DeleteTriple", []interface{}{trp}, func() error {
+		return store.store.DeleteTriple(trp)
+	})
+}
+
+// DeleteTriples removes all the triples from the store. If one of the triples does not exist, it errors with `ErrTripleDoesNotExist`.
+func (store *FakeGraphStore) DeleteTriples(trps []ontograph.Triple) error {
+	return store.call("DeleteTriples", []interface{}{trps}, func() error {
+		return store.store.DeleteTriples(trps)
+	})
+}
+
+// DeleteTripleUnchecked removes the triple from the store. It does not error if the triple does not exist.
+func (store *FakeGraphStore) DeleteTripleUnchecked(trp ontograph.Triple) error {
+	return store.call("DeleteTripleUnchecked", []interface{}{trp}, func() error {
+		return store.store.DeleteTripleUnchecked(trp)
+	})
+}
+
+// DeleteTriplesUnchecked removes all the triples from the store. It does not error if any of the triples does not exist.
+func (store *FakeGraphStore) DeleteTriplesUnchecked(trps []ontograph.Triple) error {
+	return store.call("DeleteTriplesUnchecked", []interface{}{trps}, func() error {
+		return store.store.DeleteTriplesUnchecked(trps)
+	})
+}
+
+// Drop removes all triples from the store and renders it unusable.
+func (store *FakeGraphStore) Drop() error {
+	return store.call("Drop", nil, func() error {
+		return store.store.Drop()
+	})
+}
+
+// SerializeToTurtle writes the entire store into the writer in Turtle (TTL) format.
+func (store *FakeGraphStore) SerializeToTurtle(w io.Writer, pretty bool) error {
+	return store.call("SerializeToTurtle", []interface{}{pretty}, func() error {
+		return store.store.SerializeToTurtle(w, pretty)
+	})
+}
+
+// Size returns the total number of triples in the store.
+func (store *FakeGraphStore) Size() (size int, err error) {
+	err = store.call("Size", nil, func() error {
+		size, err = store.store.Size()
+		return err
+	})
+	return size, err
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// failure is a scripted failure of a fake store.
+type failure struct {
+	methods []string
+	err     error
+	// always is false if the failure only applies to the next call
+	always bool
+}
+
+// call runs fn as the method unless a failure is scripted for it, after the latency of the method. The call is recorded.
+func (store *FakeGraphStore) call(method string, args []interface{}, fn func() error) error {
+	latency, err := store.script(method)
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+	if err == nil {
+		err = fn()
+	}
+	store.record(Call{Method: method, Args: args, Err: err})
+	return err
+}
+
+// script returns the latency and the scripted error of the next call of the method. One-time failures are consumed.
+func (store *FakeGraphStore) script(method string) (time.Duration, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	latency, ok := store.latency[method]
+	if !ok {
+		latency = store.latency[""]
+	}
+	for i, f := range store.failures {
+		if len(f.methods) == 0 || contains(f.methods, method) {
+			if !f.always {
+				store.failures = append(store.failures[:i:i], store.failures[i+1:]...)
+			}
+			return latency, f.err
+		}
+	}
+	return latency, nil
+}
+
+// record appends the call to the recorded calls.
+func (store *FakeGraphStore) record(call Call) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.calls = append(store.calls, call)
+}
+
+// contains checks if the list contains the string.
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
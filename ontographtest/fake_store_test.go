@@ -0,0 +1,81 @@
+package ontographtest_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/kahefi/ontograph"
+	. "github.com/kahefi/ontograph/ontographtest"
+)
+
+var _ = DescribeGraphStoreTests("FakeGraphStore", func() (ontograph.GraphStore, error) {
+	return NewFakeGraphStore(testUri), nil
+})
+
+var _ = Describe("Fake graph store", func() {
+	errUnavailable := errors.New("unavailable")
+	classTrp := ontograph.Triple{
+		Subject:   ontograph.NewResourceTerm(testUri + "#A"),
+		Predicate: ontograph.NewResourceTerm(ontograph.RDFType),
+		Object:    ontograph.NewResourceTerm(ontograph.OWLClass),
+	}
+	var store *FakeGraphStore
+
+	BeforeEach(func() {
+		store = NewFakeGraphStore(testUri)
+	})
+
+	It("should record calls", func() {
+		Expect(store.AddTriple(classTrp)).To(Succeed())
+		Expect(store.GetAllMatches("", classTrp.Predicate.String(), "")).To(ConsistOf(classTrp))
+		Expect(store.Calls()).To(Equal([]Call{
+			{Method: "AddTriple", Args: []interface{}{classTrp}},
+			{Method: "GetAllMatches", Args: []interface{}{"", classTrp.Predicate.String(), ""}},
+		}))
+		Expect(store.Calls("AddTriple")).To(HaveLen(1))
+		store.ResetCalls()
+		Expect(store.Calls()).To(BeEmpty())
+		Expect(store.Backend().GetAllTriples()).To(ConsistOf(classTrp))
+		Expect(store.Calls()).To(BeEmpty())
+	})
+
+	It("should fail scripted calls", func() {
+		store.FailNext(errUnavailable, "AddTriple", "AddTripleUnchecked")
+		_, err := ontograph.InitOntologyGraph(store)
+		Expect(err).To(MatchError(errUnavailable))
+		Expect(store.Backend().GetAllTriples()).To(BeEmpty())
+		Expect(store.Calls("AddTripleUnchecked")).To(Equal([]Call{{Method: "AddTripleUnchecked", Args: []interface{}{ontograph.Triple{
+			Subject:   ontograph.NewResourceTerm(testUri),
+			Predicate: ontograph.NewResourceTerm(ontograph.RDFType),
+			Object:    ontograph.NewResourceTerm(ontograph.OWLOntology),
+		}}, Err: errUnavailable}}))
+		// The failure is consumed
+		_, err = ontograph.InitOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+
+		store.FailOn(errUnavailable)
+		_, err = ontograph.LoadOntologyGraph(store)
+		Expect(err).To(MatchError(errUnavailable))
+		_, err = store.GetAllTriples()
+		Expect(err).To(MatchError(errUnavailable))
+		store.ClearFailures()
+		Expect(ontograph.LoadOntologyGraph(store)).NotTo(BeNil())
+	})
+
+	It("should delay calls", func() {
+		store.SetLatency(20*time.Millisecond, "GetAllTriples")
+		start := time.Now()
+		Expect(store.AddTriple(classTrp)).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically("<", 20*time.Millisecond))
+		Expect(store.GetAllTriples()).To(HaveLen(1))
+		Expect(time.Since(start)).To(BeNumerically(">=", 20*time.Millisecond))
+
+		store.SetLatency(0, "GetAllTriples")
+		start = time.Now()
+		Expect(store.GetAllTriples()).To(HaveLen(1))
+		Expect(time.Since(start)).To(BeNumerically("<", 20*time.Millisecond))
+	})
+})
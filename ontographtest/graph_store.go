@@ -0,0 +1,209 @@
+package ontographtest
+
+import (
+	"bytes"
+	"errors"
+
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+// graphStoreTest is a test of the conformance suite. It runs against a new, empty store, which is dropped afterwards unless the test
+// drops it itself.
+type graphStoreTest struct {
+	name  string
+	run   func(g *WithT, store GraphStore)
+	drops bool
+}
+
+// graphStoreTests is the conformance suite of graph stores (see `RunGraphStoreTests`).
+var graphStoreTests = []graphStoreTest{
+	{name: "should have a graph URI", run: func(g *WithT, store GraphStore) {
+		g.Expect(store.GetURI()).NotTo(BeEmpty())
+		g.Expect(store.GetAllTriples()).To(BeEmpty())
+		g.Expect(store.Size()).To(Equal(0))
+	}},
+
+	{name: "should add and retrieve triples", run: func(g *WithT, store GraphStore) {
+		a, b := classTriple(store, "A"), classTriple(store, "B")
+		g.Expect(store.AddTriple(a)).To(Succeed())
+		g.Expect(store.AddTriples([]Triple{b})).To(Succeed())
+		g.Expect(store.GetAllTriples()).To(ConsistOf(a, b))
+		g.Expect(store.GetFirstMatch(a.Subject.String(), "", "")).To(Equal(&a))
+		g.Expect(store.Size()).To(Equal(2))
+	}},
+
+	{name: "should reject adding existing triples", run: func(g *WithT, store GraphStore) {
+		a, b := classTriple(store, "A"), classTriple(store, "B")
+		g.Expect(store.AddTriple(a)).To(Succeed())
+		g.Expect(errors.Is(store.AddTriple(a), ErrTripleAlreadyExists)).To(BeTrue(), "AddTriple should error with ErrTripleAlreadyExists")
+		// Adding several triples is all-or-nothing
+		g.Expect(errors.Is(store.AddTriples([]Triple{b, a}), ErrTripleAlreadyExists)).To(BeTrue(), "AddTriples should error with ErrTripleAlreadyExists")
+		g.Expect(store.GetAllTriples()).To(ConsistOf(a))
+	}},
+
+	{name: "should add existing triples unchecked", run: func(g *WithT, store GraphStore) {
+		a, b := classTriple(store, "A"), classTriple(store, "B")
+		g.Expect(store.AddTripleUnchecked(a)).To(Succeed())
+		g.Expect(store.AddTripleUnchecked(a)).To(Succeed())
+		g.Expect(store.AddTriplesUnchecked([]Triple{a, b})).To(Succeed())
+		g.Expect(store.GetAllTriples()).To(ConsistOf(a, b))
+	}},
+
+	{name: "should delete triples", run: func(g *WithT, store GraphStore) {
+		a, b, c := classTriple(store, "A"), classTriple(store, "B"), classTriple(store, "C")
+		g.Expect(store.AddTriples([]Triple{a, b, c})).To(Succeed())
+		g.Expect(store.DeleteTriple(a)).To(Succeed())
+		g.Expect(store.DeleteTriples([]Triple{b})).To(Succeed())
+		g.Expect(store.GetAllTriples()).To(ConsistOf(c))
+	}},
+
+	{name: "should reject deleting missing triples", run: func(g *WithT, store GraphStore) {
+		a, b := classTriple(store, "A"), classTriple(store, "B")
+		g.Expect(store.AddTriple(a)).To(Succeed())
+		g.Expect(errors.Is(store.DeleteTriple(b), ErrTripleDoesNotExist)).To(BeTrue(), "DeleteTriple should error with ErrTripleDoesNotExist")
+		// Deleting several triples is all-or-nothing
+		g.Expect(errors.Is(store.DeleteTriples([]Triple{a, b}), ErrTripleDoesNotExist)).To(BeTrue(), "DeleteTriples should error with ErrTripleDoesNotExist")
+		g.Expect(store.GetAllTriples()).To(ConsistOf(a))
+	}},
+
+	{name: "should delete missing triples unchecked", run: func(g *WithT, store GraphStore) {
+		a, b := classTriple(store, "A"), classTriple(store, "B")
+		g.Expect(store.AddTriple(a)).To(Succeed())
+		g.Expect(store.DeleteTripleUnchecked(b)).To(Succeed())
+		g.Expect(store.DeleteTriplesUnchecked([]Triple{a, b})).To(Succeed())
+		g.Expect(store.GetAllTriples()).To(BeEmpty())
+	}},
+
+	{name: "should match triple patterns with wildcards", run: func(g *WithT, store GraphStore) {
+		a, b := classTriple(store, "A"), classTriple(store, "B")
+		sub := Triple{Subject: b.Subject, Predicate: NewResourceTerm(RDFSSubClassOf), Object: a.Subject}
+		g.Expect(store.AddTriples([]Triple{a, b, sub})).To(Succeed())
+		for _, pattern := range []struct {
+			subj, pred, obj string
+			expected        []Triple
+		}{
+			{"", "", "", []Triple{a, b, sub}},
+			{b.Subject.String(), "", "", []Triple{b, sub}},
+			{"", a.Predicate.String(), "", []Triple{a, b}},
+			{"", "", a.Subject.String(), []Triple{sub}},
+			{b.Subject.String(), a.Predicate.String(), "", []Triple{b}},
+			{b.Subject.String(), "", a.Subject.String(), []Triple{sub}},
+			{"", a.Predicate.String(), a.Object.String(), []Triple{a, b}},
+			{a.Subject.String(), a.Predicate.String(), a.Object.String(), []Triple{a}},
+			{a.Subject.String(), sub.Predicate.String(), "", []Triple{}},
+		} {
+			g.Expect(store.GetAllMatches(pattern.subj, pattern.pred, pattern.obj)).To(ConsistOf(pattern.expected),
+				"GetAllMatches(%q, %q, %q)", pattern.subj, pattern.pred, pattern.obj)
+			first, err := store.GetFirstMatch(pattern.subj, pattern.pred, pattern.obj)
+			g.Expect(err).NotTo(HaveOccurred())
+			if len(pattern.expected) == 0 {
+				g.Expect(first).To(BeNil(), "GetFirstMatch(%q, %q, %q)", pattern.subj, pattern.pred, pattern.obj)
+			} else {
+				g.Expect(pattern.expected).To(ContainElement(*first), "GetFirstMatch(%q, %q, %q)", pattern.subj, pattern.pred, pattern.obj)
+			}
+		}
+	}},
+
+	{name: "should delete all matches of a pattern", run: func(g *WithT, store GraphStore) {
+		a, b := classTriple(store, "A"), classTriple(store, "B")
+		sub := Triple{Subject: b.Subject, Predicate: NewResourceTerm(RDFSSubClassOf), Object: a.Subject}
+		g.Expect(store.AddTriples([]Triple{a, b, sub})).To(Succeed())
+		g.Expect(store.DeleteAllMatches(b.Subject.String(), "", "")).To(Succeed())
+		g.Expect(store.GetAllTriples()).To(ConsistOf(a))
+		g.Expect(store.DeleteAllMatches("", "", "")).To(Succeed())
+		g.Expect(store.GetAllTriples()).To(BeEmpty())
+	}},
+
+	{name: "should keep literals", run: func(g *WithT, store GraphStore) {
+		subj := NewResourceTerm(store.GetURI() + "#A")
+		literals := []Term{
+			NewLiteralTerm("plain", "", ""),
+			NewLiteralTerm("Grüße", "de", ""),
+			NewLiteralTerm("42", "", XSDInteger),
+		}
+		for _, lit := range literals {
+			g.Expect(store.AddTriple(Triple{Subject: subj, Predicate: NewResourceTerm(RDFSLabel), Object: lit})).To(Succeed())
+		}
+		for _, lit := range literals {
+			g.Expect(store.GetAllMatches("", "", lit.String())).To(ConsistOf(Triple{Subject: subj, Predicate: NewResourceTerm(RDFSLabel), Object: lit}))
+		}
+	}},
+
+	{name: "should keep blank nodes", run: func(g *WithT, store GraphStore) {
+		a := classTriple(store, "A")
+		restriction := Triple{Subject: NewBlankNodeTerm("r1"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLRestriction)}
+		sub := Triple{Subject: a.Subject, Predicate: NewResourceTerm(RDFSSubClassOf), Object: restriction.Subject}
+		g.Expect(store.AddTriples([]Triple{a, restriction, sub})).To(Succeed())
+		restrictions, err := store.GetAllMatches("", restriction.Predicate.String(), restriction.Object.String())
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(restrictions).To(HaveLen(1))
+		g.Expect(restrictions[0].Subject.IsBlankNode()).To(BeTrue())
+		// The blank node may be relabeled, but must still be the same node
+		subs, err := store.GetAllMatches(a.Subject.String(), sub.Predicate.String(), "")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(subs).To(HaveLen(1))
+		g.Expect(subs[0].Object).To(Equal(restrictions[0].Subject))
+	}},
+
+	{name: "should serialize to Turtle", run: func(g *WithT, store GraphStore) {
+		a, b := classTriple(store, "A"), classTriple(store, "B")
+		label := Triple{Subject: a.Subject, Predicate: NewResourceTerm(RDFSLabel), Object: NewLiteralTerm("A", "en", "")}
+		g.Expect(store.AddTriples([]Triple{a, b, label})).To(Succeed())
+		for _, pretty := range []bool{false, true} {
+			buf := &bytes.Buffer{}
+			g.Expect(store.SerializeToTurtle(buf, pretty)).To(Succeed())
+			parsed, err := ParseFromTurtle(buf)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(parsed.GetAllTriples()).To(ConsistOf(a, b, label), "SerializeToTurtle(pretty=%t)", pretty)
+		}
+	}},
+
+	{name: "should count and iterate matches", run: func(g *WithT, store GraphStore) {
+		a, b := classTriple(store, "A"), classTriple(store, "B")
+		g.Expect(store.AddTriples([]Triple{a, b})).To(Succeed())
+		g.Expect(CountMatches(store, "", a.Predicate.String(), "")).To(Equal(2))
+		g.Expect(CountMatches(store, a.Subject.String(), "", "")).To(Equal(1))
+		iterated := []Triple{}
+		g.Expect(IterMatches(store, "", "", "", func(trp Triple) error {
+			iterated = append(iterated, trp)
+			return nil
+		})).To(Succeed())
+		g.Expect(iterated).To(ConsistOf(a, b))
+	}},
+
+	{name: "should commit and roll back transactions", run: func(g *WithT, store GraphStore) {
+		a, b := classTriple(store, "A"), classTriple(store, "B")
+		g.Expect(store.AddTriple(a)).To(Succeed())
+		tx, err := Begin(store)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(tx.DeleteTriple(a)).To(Succeed())
+		g.Expect(tx.AddTriple(b)).To(Succeed())
+		g.Expect(tx.GetAllTriples()).To(ConsistOf(b))
+		g.Expect(store.GetAllTriples()).To(ConsistOf(a))
+		g.Expect(tx.Rollback()).To(Succeed())
+		g.Expect(store.GetAllTriples()).To(ConsistOf(a))
+
+		tx, err = Begin(store)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(tx.DeleteTriple(a)).To(Succeed())
+		g.Expect(tx.AddTriple(b)).To(Succeed())
+		g.Expect(tx.Commit()).To(Succeed())
+		g.Expect(store.GetAllTriples()).To(ConsistOf(b))
+	}},
+
+	{name: "should drop the graph", run: func(g *WithT, store GraphStore) {
+		g.Expect(store.AddTriple(classTriple(store, "A"))).To(Succeed())
+		g.Expect(store.Drop()).To(Succeed())
+	}, drops: true},
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// classTriple returns the triple that declares a class with the name in the namespace of the store.
+func classTriple(store GraphStore, name string) Triple {
+	return Triple{Subject: NewResourceTerm(store.GetURI() + "#" + name), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)}
+}
@@ -0,0 +1,60 @@
+// Package ontographtest provides utilities for testing graph stores and code built on ontograph. Its conformance suite verifies that a
+// graph store implementation behaves like the stores of ontograph, so it can be used as backend of an `OntologyGraph`.
+package ontographtest
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+
+	"github.com/kahefi/ontograph"
+)
+
+// A StoreFactory creates a new, empty graph store. The conformance suite creates a store for every test and drops it afterwards.
+type StoreFactory func() (ontograph.GraphStore, error)
+
+// RunGraphStoreTests runs the conformance suite against the stores of the factory, every test as subtest of t, e.g.
+//
+//	func TestMyStore(t *testing.T) {
+//		ontographtest.RunGraphStoreTests(t, func() (ontograph.GraphStore, error) {
+//			return NewMyStore("http://example.com/test"), nil
+//		})
+//	}
+func RunGraphStoreTests(t *testing.T, factory StoreFactory) {
+	for _, test := range graphStoreTests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			store, err := factory()
+			if err != nil {
+				t.Fatalf("Creating the store failed: %v", err)
+			}
+			if !test.drops {
+				defer store.Drop()
+			}
+			test.run(gomega.NewWithT(t), store)
+		})
+	}
+}
+
+// DescribeGraphStoreTests registers the conformance suite as Ginkgo specs for the stores of the factory, e.g.
+//
+//	var _ = ontographtest.DescribeGraphStoreTests("MyStore", func() (ontograph.GraphStore, error) {
+//		return NewMyStore("http://example.com/test"), nil
+//	})
+func DescribeGraphStoreTests(text string, factory StoreFactory) bool {
+	return ginkgo.Describe(text, func() {
+		for _, test := range graphStoreTests {
+			test := test
+			ginkgo.It(test.name, func() {
+				g := gomega.NewWithT(ginkgo.GinkgoT())
+				store, err := factory()
+				g.Expect(err).NotTo(gomega.HaveOccurred(), "Creating the store failed")
+				if !test.drops {
+					defer store.Drop()
+				}
+				test.run(g, store)
+			})
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package ontographtest_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestOntographtest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Ontographtest Suite")
+}
@@ -0,0 +1,28 @@
+package ontographtest_test
+
+import (
+	"testing"
+
+	"github.com/kahefi/ontograph"
+	. "github.com/kahefi/ontograph/ontographtest"
+)
+
+const testUri = "http://example.com/onto"
+
+var _ = DescribeGraphStoreTests("MemoryStore", func() (ontograph.GraphStore, error) {
+	return ontograph.NewMemoryStore(testUri), nil
+})
+
+var _ = DescribeGraphStoreTests("SyncStore", func() (ontograph.GraphStore, error) {
+	return ontograph.NewSyncStore(ontograph.NewMemoryStore(testUri)), nil
+})
+
+var _ = DescribeGraphStoreTests("ObservableStore", func() (ontograph.GraphStore, error) {
+	return ontograph.NewObservableStore(ontograph.NewMemoryStore(testUri)), nil
+})
+
+func TestRunGraphStoreTests(t *testing.T) {
+	RunGraphStoreTests(t, func() (ontograph.GraphStore, error) {
+		return ontograph.NewCheckedStore(ontograph.NewMemoryStore(testUri), ontograph.Strict, nil), nil
+	})
+}
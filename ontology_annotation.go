@@ -0,0 +1,53 @@
+package ontograph
+
+// GetAnnotations returns the annotation assertions with literal values of the ontology header (e.g. `dcterms:creator`) keyed by
+// property URI. Labels, comments and the version are not included, since they are accessed with their own methods.
+func (ont *OntologyGraph) GetAnnotations() (map[string][]GenericLiteral, error) {
+	trps, err := ont.graph.GetAllMatches(NewResourceTerm(ont.GetURI()).String(), "", "")
+	if err != nil {
+		return nil, err
+	}
+	annotations := map[string][]GenericLiteral{}
+	for _, trp := range trps {
+		switch trp.Predicate.Value() {
+		case RDFSLabel, RDFSComment, OWLVersionInfo:
+			continue
+		}
+		if trp.Object.IsLiteral() {
+			annotations[trp.Predicate.Value()] = append(annotations[trp.Predicate.Value()], *NewGenericLiteral(trp.Object))
+		}
+	}
+	return annotations, nil
+}
+
+// SetAnnotation sets the values of the annotation property in the ontology header. All previous values of the property will be
+// deleted. If no value is given, the annotation is removed.
+func (ont *OntologyGraph) SetAnnotation(propertyURI string, values ...GenericLiteral) error {
+	propertyURI = ont.prefixes.Expand(propertyURI)
+	subj := NewResourceTerm(ont.GetURI())
+	// First delete all previous values
+	if err := ont.graph.DeleteAllMatches(subj.String(), NewResourceTerm(propertyURI).String(), ""); err != nil {
+		return err
+	}
+	// Set new values
+	return ont.graph.AddTriplesUnchecked(annotationTriples(subj, map[string][]GenericLiteral{propertyURI: values}))
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// annotationTriples converts the annotations of the subject into a set of triples.
+func annotationTriples(subj Term, annotations map[string][]GenericLiteral) []Triple {
+	trps := []Triple{}
+	for prop, values := range annotations {
+		for i := range values {
+			trps = append(trps, Triple{
+				Subject:   subj,
+				Predicate: NewResourceTerm(prop),
+				Object:    values[i].Term(),
+			})
+		}
+	}
+	return trps
+}
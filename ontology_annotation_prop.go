@@ -0,0 +1,81 @@
+package ontograph
+
+// An OntologyAnnotationProperty represents an annotation property from an ontology (e.g. `skos:definition`).
+type OntologyAnnotationProperty struct {
+	URI           string
+	SubPropertyOf []string
+	Domains       []string
+	Ranges        []string
+	IsDeprecated  bool
+	Label         map[string]string
+	Comment       map[string]string
+	// Annotations hold all other literal-valued annotations of the property, keyed by annotation property.
+	Annotations map[string][]GenericLiteral
+}
+
+// GetURI returns the URI of the annotation property.
+func (prop *OntologyAnnotationProperty) GetURI() string {
+	return prop.URI
+}
+
+// ToTriples converts the annotation property into a set of triples.
+func (prop *OntologyAnnotationProperty) ToTriples() []Triple {
+	trps := []Triple{}
+	subj := NewResourceTerm(prop.URI)
+
+	// Define property definition triple
+	trps = append(trps, Triple{
+		Subject:   subj,
+		Predicate: NewResourceTerm(RDFType),
+		Object:    NewResourceTerm(OWLAnnotationProperty),
+	})
+	// Add subPropertyOf triples
+	for _, uri := range prop.SubPropertyOf {
+		trps = append(trps, Triple{
+			Subject:   subj,
+			Predicate: NewResourceTerm(RDFSSubPropertyOf),
+			Object:    NewResourceTerm(uri),
+		})
+	}
+	// Add domain triples
+	for _, uri := range prop.Domains {
+		trps = append(trps, Triple{
+			Subject:   subj,
+			Predicate: NewResourceTerm(RDFSDomain),
+			Object:    NewResourceTerm(uri),
+		})
+	}
+	// Add range triples
+	for _, uri := range prop.Ranges {
+		trps = append(trps, Triple{
+			Subject:   subj,
+			Predicate: NewResourceTerm(RDFSRange),
+			Object:    NewResourceTerm(uri),
+		})
+	}
+
+	// Add deprecation flag
+	if prop.IsDeprecated {
+		trps = append(trps, deprecationTriple(subj))
+	}
+	// Add labels
+	for lang, label := range prop.Label {
+		trps = append(trps, Triple{
+			Subject:   subj,
+			Predicate: NewResourceTerm(RDFSLabel),
+			Object:    NewLiteralTerm(label, lang, ""),
+		})
+	}
+	// Add comments
+	for lang, comment := range prop.Comment {
+		trps = append(trps, Triple{
+			Subject:   subj,
+			Predicate: NewResourceTerm(RDFSComment),
+			Object:    NewLiteralTerm(comment, lang, ""),
+		})
+	}
+	// Add annotations
+	trps = append(trps, annotationTriples(subj, prop.Annotations)...)
+	// Done, return triples
+	return trps
+}
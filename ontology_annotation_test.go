@@ -0,0 +1,75 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Annotations", func() {
+	const ns = "http://example.com/onto#"
+	const skosDefinition = "http://www.w3.org/2004/02/skos/core#definition"
+	const dctermsCreator = "http://purl.org/dc/terms/creator"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix dcterms: <http://purl.org/dc/terms/> .
+<http://example.com/onto> a owl:Ontology ; rdfs:label "Example" ; dcterms:creator "Alice", "Bob" .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should round-trip annotations of resources", func() {
+		definition := *NewGenericLiteral(NewLiteralTerm("A person with children", "en", ""))
+		class := OntologyClass{URI: ns + "Parent", Annotations: map[string][]GenericLiteral{skosDefinition: {definition}}}
+		Expect(ont.UpsertResource(&class)).To(Succeed())
+		retClass, err := ont.GetClass(class.URI)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retClass.Annotations).To(Equal(class.Annotations))
+
+		prop := OntologyObjectProperty{URI: ns + "hasChild", Annotations: map[string][]GenericLiteral{skosDefinition: {definition}}}
+		Expect(ont.UpsertResource(&prop)).To(Succeed())
+		retProp, err := ont.GetObjectProperty(prop.URI)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retProp.Annotations).To(Equal(prop.Annotations))
+
+		dataProp := OntologyDataProperty{URI: ns + "age", Annotations: map[string][]GenericLiteral{dctermsCreator: {XSDStringLiteral("Alice").Generic()}}}
+		Expect(ont.UpsertResource(&dataProp)).To(Succeed())
+		retDataProp, err := ont.GetDataProperty(dataProp.URI)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retDataProp.Annotations).To(Equal(dataProp.Annotations))
+
+		datatype := OntologyDatatype{URI: ns + "Age", Annotations: map[string][]GenericLiteral{skosDefinition: {definition}}}
+		Expect(ont.UpsertResource(&datatype)).To(Succeed())
+		retDatatype, err := ont.GetDatatype(datatype.URI)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retDatatype.Annotations).To(Equal(datatype.Annotations))
+	})
+
+	It("should get and set annotations of the ontology header", func() {
+		annotations, err := ont.GetAnnotations()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotations).To(HaveLen(1))
+		Expect(annotations[dctermsCreator]).To(HaveLen(2))
+
+		Expect(ont.SetAnnotation(dctermsCreator, *NewGenericLiteral(NewLiteralTerm("Carol", "", "")))).To(Succeed())
+		Expect(ont.SetAnnotation(skosDefinition, *NewGenericLiteral(NewLiteralTerm("An example", "en", "")))).To(Succeed())
+		annotations, err = ont.GetAnnotations()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotations).To(Equal(map[string][]GenericLiteral{
+			dctermsCreator: {*NewGenericLiteral(NewLiteralTerm("Carol", "", ""))},
+			skosDefinition: {*NewGenericLiteral(NewLiteralTerm("An example", "en", ""))},
+		}))
+
+		Expect(ont.SetAnnotation(dctermsCreator)).To(Succeed())
+		Expect(ont.GetAnnotations()).NotTo(HaveKey(dctermsCreator))
+	})
+})
@@ -0,0 +1,70 @@
+package ontograph
+
+import (
+	"strings"
+)
+
+// SkolemPathSegment is the path segment of skolem URIs (RDF 1.1), which stand in for blank nodes with a globally unique URI.
+const SkolemPathSegment = "/.well-known/genid/"
+
+// NewAnonymousIndividual creates a new anonymous individual that is backed by a skolem URI within the namespace of the ontology (e.g.
+// `http://example.com/onto/.well-known/genid/<ID>`). Other than blank nodes, skolem URIs keep their identity when the ontology is
+// serialized and parsed again. The individual is not stored until it is upserted.
+func (ont *OntologyGraph) NewAnonymousIndividual() OntologyIndividual {
+	return OntologyIndividual{
+		URI:              ont.GetURI() + SkolemPathSegment + ont.idGen.NewID(),
+		Types:            []string{},
+		SameIndividualAs: []string{},
+		ObjectProperties: map[string][]string{},
+		DataProperties:   map[string][]GenericLiteral{},
+		Label:            map[string]string{},
+		Comment:          map[string]string{},
+	}
+}
+
+// GetAnonymousIndividuals retrieves the anonymous individuals that the resource with the specified URI points to with its object
+// properties (e.g. the address of a person that is modeled as blank node).
+func (ont *OntologyGraph) GetAnonymousIndividuals(uri string) ([]OntologyIndividual, error) {
+	uri = ont.prefixes.Expand(uri)
+	trps, err := ont.graph.GetAllMatches(uriTerm(uri).String(), "", "")
+	if err != nil {
+		return nil, err
+	}
+	SortTriples(trps)
+	indivs := []OntologyIndividual{}
+	visited := map[Term]bool{}
+	for _, trp := range trps {
+		target := trp.Object
+		if visited[target] || !(target.IsBlankNode() || (target.IsResource() && isAnonymousURI(target.Value()))) {
+			continue
+		}
+		visited[target] = true
+		targetURI := target.Value()
+		if target.IsBlankNode() {
+			targetURI = target.String()
+		}
+		indiv, err := ont.GetIndividual(targetURI)
+		if err != nil {
+			return indivs, err
+		}
+		indivs = append(indivs, indiv)
+	}
+	return indivs, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// uriTerm converts the URI of a resource into a term. Blank node labels (e.g. `_:b1`) are converted into blank node terms.
+func uriTerm(uri string) Term {
+	if t := Term(uri); t.IsBlankNode() {
+		return t
+	}
+	return NewResourceTerm(uri)
+}
+
+// isAnonymousURI returns true if the URI is a blank node label or a skolem URI.
+func isAnonymousURI(uri string) bool {
+	return Term(uri).IsBlankNode() || strings.Contains(uri, SkolemPathSegment)
+}
@@ -0,0 +1,77 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Anonymous individuals", func() {
+	const ns = "http://example.com/onto#"
+	var store *MemoryStore
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:alice a owl:NamedIndividual ; :hasAddress [ a :Address ; :city "Berlin" ] .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		ont.SetIDGenerator(NewSequentialIDGenerator("id"))
+	})
+
+	It("should retrieve anonymous individuals via the properties pointing to them", func() {
+		alice, err := ont.GetIndividual(ns + "alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(alice.ObjectProperties[ns+"hasAddress"]).To(HaveLen(1))
+		addrs, err := ont.GetAnonymousIndividuals(ns + "alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(addrs).To(HaveLen(1))
+		Expect(addrs[0].URI).To(Equal(alice.ObjectProperties[ns+"hasAddress"][0]))
+		Expect(addrs[0].IsAnonymous()).To(BeTrue())
+		Expect(addrs[0].Types).To(Equal([]string{ns + "Address"}))
+		Expect(addrs[0].DataProperties[ns+"city"]).To(Equal([]GenericLiteral{*NewGenericLiteral(NewLiteralTerm("Berlin", "", ""))}))
+	})
+
+	It("should create anonymous individuals with skolem URIs", func() {
+		addr := ont.NewAnonymousIndividual()
+		Expect(addr.URI).To(Equal("http://example.com/onto" + SkolemPathSegment + "id1"))
+		Expect(addr.IsAnonymous()).To(BeTrue())
+		addr.Types = append(addr.Types, ns+"Address")
+		addr.AddDataProperty(ns+"city", XSDStringLiteral("Paris").Generic())
+		Expect(ont.UpsertResource(&addr)).To(Succeed())
+		bob := OntologyIndividual{URI: ns + "bob", ObjectProperties: map[string][]string{ns + "hasAddress": {addr.URI}}}
+		Expect(ont.UpsertResource(&bob)).To(Succeed())
+
+		// Round-trip through serialization
+		var ttl strings.Builder
+		Expect(store.SerializeToTurtle(&ttl, true)).To(Succeed())
+		parsed, err := ParseFromTurtle(strings.NewReader(ttl.String()))
+		Expect(err).NotTo(HaveOccurred())
+		loaded, err := LoadOntologyGraph(parsed)
+		Expect(err).NotTo(HaveOccurred())
+		addrs, err := loaded.GetAnonymousIndividuals(ns + "bob")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(addrs).To(HaveLen(1))
+		Expect(addrs[0].URI).To(Equal(addr.URI))
+		Expect(addrs[0].DataProperties[ns+"city"]).To(Equal(addr.DataProperties[ns+"city"]))
+	})
+
+	It("should upsert anonymous individuals backed by blank nodes", func() {
+		addr := OntologyIndividual{URI: "_:addr", Types: []string{ns + "Address"}}
+		Expect(ont.UpsertResource(&addr)).To(Succeed())
+		retAddr, err := ont.GetIndividual("_:addr")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retAddr.Types).To(Equal([]string{ns + "Address"}))
+		Expect(ont.DeleteResource("_:addr")).To(Succeed())
+		_, err = ont.GetIndividual("_:addr")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,97 @@
+package ontograph
+
+// AnnotateAxiom adds annotations to a single axiom (e.g. a confidence score or the source of a `rdfs:subClassOf` statement). The axiom
+// is reified as `owl:Axiom` blank node with `owl:annotatedSource`, `owl:annotatedProperty` and `owl:annotatedTarget`, which is reused
+// if the axiom is already annotated. Errors with `ErrTripleDoesNotExist` if the axiom is not part of the graph.
+func (ont *OntologyGraph) AnnotateAxiom(axiom Triple, annotations map[string][]GenericLiteral) error {
+	axiom = ont.expandTriple(axiom)
+	match, err := ont.graph.GetFirstMatch(axiom.Subject.String(), axiom.Predicate.String(), axiom.Object.String())
+	if err != nil {
+		return err
+	}
+	if match == nil {
+		return wrapResourceError("AnnotateAxiom", axiom.Subject.Value(), ErrTripleDoesNotExist)
+	}
+	nodes, _, err := ont.getAxiomNodes(axiom)
+	if err != nil {
+		return err
+	}
+	trps := []Triple{}
+	var node Term
+	if len(nodes) > 0 {
+		node = nodes[0]
+	} else {
+		node = ont.newBlankNode()
+		trps = append(trps,
+			Triple{Subject: node, Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLAxiom)},
+			Triple{Subject: node, Predicate: NewResourceTerm(OWLAnnotatedSource), Object: axiom.Subject},
+			Triple{Subject: node, Predicate: NewResourceTerm(OWLAnnotatedProperty), Object: axiom.Predicate},
+			Triple{Subject: node, Predicate: NewResourceTerm(OWLAnnotatedTarget), Object: axiom.Object},
+		)
+	}
+	trps = append(trps, annotationTriples(node, annotations)...)
+	return ont.graph.AddTriplesUnchecked(trps)
+}
+
+// GetAxiomAnnotations returns the annotations of the axiom keyed by annotation property. If the axiom is not annotated, an empty map
+// is returned.
+func (ont *OntologyGraph) GetAxiomAnnotations(axiom Triple) (map[string][]GenericLiteral, error) {
+	axiom = ont.expandTriple(axiom)
+	nodes, nodeTrps, err := ont.getAxiomNodes(axiom)
+	if err != nil {
+		return nil, err
+	}
+	annotations := map[string][]GenericLiteral{}
+	for _, node := range nodes {
+		for _, trp := range nodeTrps[node] {
+			if trp.Object.IsLiteral() {
+				annotations[trp.Predicate.Value()] = append(annotations[trp.Predicate.Value()], *NewGenericLiteral(trp.Object))
+			}
+		}
+	}
+	return annotations, nil
+}
+
+// DeleteAxiomAnnotations removes all annotations of the axiom together with its reification. The axiom itself is kept.
+func (ont *OntologyGraph) DeleteAxiomAnnotations(axiom Triple) error {
+	axiom = ont.expandTriple(axiom)
+	nodes, nodeTrps, err := ont.getAxiomNodes(axiom)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if err := ont.graph.DeleteTriplesUnchecked(nodeTrps[node]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// getAxiomNodes returns the `owl:Axiom` blank nodes that reify the axiom together with the triples of these nodes grouped by subject.
+func (ont *OntologyGraph) getAxiomNodes(axiom Triple) ([]Term, map[Term][]Triple, error) {
+	sources, err := ont.graph.GetAllMatches("", NewResourceTerm(OWLAnnotatedSource).String(), axiom.Subject.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(sources) == 0 {
+		return []Term{}, map[Term][]Triple{}, nil
+	}
+	SortTriples(sources)
+	bySubject := map[Term][]Triple{}
+	nodes := []Term{}
+	for _, src := range sources {
+		trps, err := ont.graph.GetAllMatches(src.Subject.String(), "", "")
+		if err != nil {
+			return nil, nil, err
+		}
+		bySubject[src.Subject] = trps
+		if firstObject(trps, OWLAnnotatedProperty) == axiom.Predicate && firstObject(trps, OWLAnnotatedTarget) == axiom.Object {
+			nodes = append(nodes, src.Subject)
+		}
+	}
+	return nodes, bySubject, nil
+}
@@ -0,0 +1,65 @@
+package ontograph_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Axiom annotations", func() {
+	const ns = "http://example.com/onto#"
+	const confidence = "http://example.com/onto#confidence"
+	const dctermsSource = "http://purl.org/dc/terms/source"
+	var ont *OntologyGraph
+	var axiom Triple
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.com/onto> a owl:Ontology .
+:Parent a owl:Class ; rdfs:subClassOf :Person .
+:Person a owl:Class .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		axiom = Triple{Subject: NewResourceTerm(ns + "Parent"), Predicate: NewResourceTerm(RDFSSubClassOf), Object: NewResourceTerm(ns + "Person")}
+	})
+
+	It("should annotate axioms and read the annotations back", func() {
+		Expect(ont.GetAxiomAnnotations(axiom)).To(BeEmpty())
+		Expect(ont.AnnotateAxiom(axiom, map[string][]GenericLiteral{confidence: {XSDDecimalLiteral(0.9).Generic()}})).To(Succeed())
+		Expect(ont.AnnotateAxiom(axiom, map[string][]GenericLiteral{dctermsSource: {XSDStringLiteral("survey").Generic()}})).To(Succeed())
+
+		annotations, err := ont.GetAxiomAnnotations(axiom)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotations).To(Equal(map[string][]GenericLiteral{
+			confidence:    {XSDDecimalLiteral(0.9).Generic()},
+			dctermsSource: {XSDStringLiteral("survey").Generic()},
+		}))
+		// The axiom is reified only once
+		class, err := ont.GetClass(ns + "Parent")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.SubClassOf).To(Equal([]string{ns + "Person"}))
+	})
+
+	It("should delete the annotations of axioms", func() {
+		Expect(ont.AnnotateAxiom(axiom, map[string][]GenericLiteral{confidence: {XSDDecimalLiteral(0.9).Generic()}})).To(Succeed())
+		Expect(ont.DeleteAxiomAnnotations(axiom)).To(Succeed())
+		Expect(ont.GetAxiomAnnotations(axiom)).To(BeEmpty())
+		class, err := ont.GetClass(ns + "Parent")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.SubClassOf).To(Equal([]string{ns + "Person"}))
+	})
+
+	It("should not annotate unknown axioms", func() {
+		unknown := Triple{Subject: NewResourceTerm(ns + "Person"), Predicate: NewResourceTerm(RDFSSubClassOf), Object: NewResourceTerm(ns + "Parent")}
+		err := ont.AnnotateAxiom(unknown, map[string][]GenericLiteral{confidence: {XSDDecimalLiteral(0.1).Generic()}})
+		Expect(errors.Is(err, ErrTripleDoesNotExist)).To(BeTrue())
+	})
+})
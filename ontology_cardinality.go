@@ -0,0 +1,125 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// A CardinalityViolation describes an individual that has too few or too many values for a property that is restricted by a cardinality
+// restriction of one of its classes (see `OntologyGraph.ValidateCardinalities`).
+type CardinalityViolation struct {
+	// Individual is the URI of the offending individual.
+	Individual string
+	// Property is the URI of the restricted property.
+	Property string
+	// Class is the URI of the class that declares the restriction.
+	Class string
+	// Restriction is the violated restriction.
+	Restriction OntologyRestriction
+	// Count is the number of (qualifying) values of the individual for the property.
+	Count int
+	// Err describes the violation (`ErrMinCardinalityViolated`, `ErrMaxCardinalityViolated` or `ErrCardinalityViolated`).
+	Err error
+}
+
+// String returns a human readable description of the violation.
+func (v CardinalityViolation) String() string {
+	return fmt.Sprintf("<%s> has %d values for <%s> (restricted by <%s>): %s", v.Individual, v.Count, v.Property, v.Class, v.Err.Error())
+}
+
+// ValidateCardinalities checks the individuals of all classes (including the individuals of their subclasses) against the cardinality
+// restrictions of the classes and returns all violations, sorted by individual and property. Values of qualified cardinality
+// restrictions only count if the object is typed with the class of the restriction (or one of its subclasses) or if the literal has
+// the datatype of the restriction.
+func (ont *OntologyGraph) ValidateCardinalities() ([]CardinalityViolation, error) {
+	classes, err := ont.GetClasses()
+	if err != nil {
+		return nil, err
+	}
+	violations := []CardinalityViolation{}
+	for _, class := range classes {
+		for _, restr := range class.Restrictions {
+			if restr.Cardinality == nil && restr.MinCardinality == nil && restr.MaxCardinality == nil {
+				continue
+			}
+			indivs, err := ont.GetIndividualsOfClass(class.URI, true)
+			if err != nil {
+				return nil, wrapResourceError("ValidateCardinalities", class.URI, err)
+			}
+			for _, indiv := range indivs {
+				count, err := ont.countQualifiedValues(indiv, restr)
+				if err != nil {
+					return nil, wrapResourceError("ValidateCardinalities", indiv.URI, err)
+				}
+				violation := CardinalityViolation{Individual: indiv.URI, Property: restr.OnProperty, Class: class.URI, Restriction: restr, Count: count}
+				switch {
+				case restr.Cardinality != nil && count != *restr.Cardinality:
+					violation.Err = ErrCardinalityViolated
+				case restr.MinCardinality != nil && count < *restr.MinCardinality:
+					violation.Err = ErrMinCardinalityViolated
+				case restr.MaxCardinality != nil && count > *restr.MaxCardinality:
+					violation.Err = ErrMaxCardinalityViolated
+				default:
+					continue
+				}
+				violations = append(violations, violation)
+			}
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Individual != violations[j].Individual {
+			return violations[i].Individual < violations[j].Individual
+		}
+		return violations[i].Property < violations[j].Property
+	})
+	return violations, nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrCardinalityViolated is raised when an individual does not have exactly the number of values required by a cardinality restriction.
+var ErrCardinalityViolated error = errors.New("The number of values does not match the exact cardinality")
+
+// ErrMinCardinalityViolated is raised when an individual has fewer values than required by a minimum cardinality restriction.
+var ErrMinCardinalityViolated error = errors.New("The number of values is below the minimum cardinality")
+
+// ErrMaxCardinalityViolated is raised when an individual has more values than allowed by a maximum cardinality restriction.
+var ErrMaxCardinalityViolated error = errors.New("The number of values exceeds the maximum cardinality")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// countQualifiedValues counts the values of the individual for the property of the restriction that satisfy the qualification of the
+// restriction (if any).
+func (ont *OntologyGraph) countQualifiedValues(indiv OntologyIndividual, restr OntologyRestriction) (int, error) {
+	switch {
+	case restr.OnDataRange != "":
+		count := 0
+		for _, lit := range indiv.DataProperties[restr.OnProperty] {
+			if lit.Type().URI == restr.OnDataRange {
+				count++
+			}
+		}
+		return count, nil
+	case restr.OnClass != "":
+		count := 0
+		for _, obj := range indiv.ObjectProperties[restr.OnProperty] {
+			types, err := GetPathTargets(ont.graph, uriTerm(obj).String(), "a/<"+RDFSSubClassOf+">*")
+			if err != nil {
+				return 0, err
+			}
+			for _, t := range types {
+				if t == NewResourceTerm(restr.OnClass) {
+					count++
+					break
+				}
+			}
+		}
+		return count, nil
+	}
+	return len(indiv.ObjectProperties[restr.OnProperty]) + len(indiv.DataProperties[restr.OnProperty]), nil
+}
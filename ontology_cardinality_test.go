@@ -0,0 +1,59 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Validating cardinalities", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.com/onto> a owl:Ontology .
+:Wheel a owl:Class .
+:FrontWheel a owl:Class ; rdfs:subClassOf :Wheel .
+:Vehicle a owl:Class ; rdfs:subClassOf _:r1, _:r2 .
+_:r1 a owl:Restriction ; owl:onProperty :vin ; owl:cardinality "1"^^xsd:nonNegativeInteger .
+_:r2 a owl:Restriction ; owl:onProperty :owner ; owl:maxCardinality "1"^^xsd:nonNegativeInteger .
+:Car a owl:Class ; rdfs:subClassOf :Vehicle, _:r3 .
+_:r3 a owl:Restriction ; owl:onProperty :hasPart ; owl:minQualifiedCardinality "2"^^xsd:nonNegativeInteger ; owl:onClass :Wheel .
+:car1 a owl:NamedIndividual, :Car ; :vin "V1" ; :hasPart :w1, :w2, :seat .
+:car2 a owl:NamedIndividual, :Car ; :owner :alice, :bob ; :hasPart :w1, :seat .
+:bike a owl:NamedIndividual, :Vehicle ; :vin "V2", "V3" .
+:w1 a owl:NamedIndividual, :Wheel .
+:w2 a owl:NamedIndividual, :FrontWheel .
+:seat a owl:NamedIndividual .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should report violations per individual and property", func() {
+		violations, err := ont.ValidateCardinalities()
+		Expect(err).NotTo(HaveOccurred())
+		summary := []string{}
+		for _, v := range violations {
+			summary = append(summary, strings.TrimPrefix(v.Individual, ns)+" "+strings.TrimPrefix(v.Property, ns)+" "+v.Err.Error())
+		}
+		Expect(summary).To(Equal([]string{
+			"bike vin " + ErrCardinalityViolated.Error(),
+			"car2 hasPart " + ErrMinCardinalityViolated.Error(),
+			"car2 owner " + ErrMaxCardinalityViolated.Error(),
+			"car2 vin " + ErrCardinalityViolated.Error(),
+		}))
+		Expect(violations[1].Count).To(Equal(1))
+		Expect(violations[1].Class).To(Equal(ns + "Car"))
+		Expect(violations[1].Restriction.OnClass).To(Equal(ns + "Wheel"))
+		Expect(violations[2].String()).To(ContainSubstring("has 2 values"))
+	})
+})
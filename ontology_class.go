@@ -4,10 +4,17 @@ package ontograph
 type OntologyClass struct {
 	URI          string
 	EquivalentTo []string
-	SubClassOf   []string
-	DisjointWith []string
+	// EquivalentToExpressions are anonymous class expressions that are equivalent to the class (e.g. unions of classes).
+	EquivalentToExpressions []ClassExpression
+	SubClassOf              []string
+	DisjointWith            []string
+	// Restrictions are anonymous superclasses of the class (i.e. `rdfs:subClassOf` restrictions).
+	Restrictions []OntologyRestriction
+	IsDeprecated bool
 	Label        map[string]string
 	Comment      map[string]string
+	// Annotations hold all other literal-valued annotations of the class (e.g. `skos:definition`), keyed by annotation property.
+	Annotations map[string][]GenericLiteral
 }
 
 // GetURI returns the URI of the class.
@@ -33,6 +40,16 @@ func (class *OntologyClass) ToTriples() []Triple {
 			Object:    NewResourceTerm(uri),
 		})
 	}
+	// Add equivalentTo triples of class expressions
+	for i := range class.EquivalentToExpressions {
+		obj, exprTrps := class.EquivalentToExpressions[i].toTriples()
+		trps = append(trps, Triple{
+			Subject:   subj,
+			Predicate: NewResourceTerm(OWLEquivalentClass),
+			Object:    obj,
+		})
+		trps = append(trps, exprTrps...)
+	}
 	// Add subclassOf triples
 	for _, uri := range class.SubClassOf {
 		trps = append(trps, Triple{
@@ -41,7 +58,17 @@ func (class *OntologyClass) ToTriples() []Triple {
 			Object:    NewResourceTerm(uri),
 		})
 	}
-	// Add subclassOf triples
+	// Add restriction triples
+	for i := range class.Restrictions {
+		node := GenerateBlankNodeTerm()
+		trps = append(trps, Triple{
+			Subject:   subj,
+			Predicate: NewResourceTerm(RDFSSubClassOf),
+			Object:    node,
+		})
+		trps = append(trps, class.Restrictions[i].toTriples(node)...)
+	}
+	// Add disjointWith triples
 	for _, uri := range class.DisjointWith {
 		trps = append(trps, Triple{
 			Subject:   subj,
@@ -49,6 +76,10 @@ func (class *OntologyClass) ToTriples() []Triple {
 			Object:    NewResourceTerm(uri),
 		})
 	}
+	// Add deprecation flag
+	if class.IsDeprecated {
+		trps = append(trps, deprecationTriple(subj))
+	}
 	// Add labels
 	for lang, label := range class.Label {
 		trps = append(trps, Triple{
@@ -65,6 +96,8 @@ func (class *OntologyClass) ToTriples() []Triple {
 			Object:    NewLiteralTerm(comment, lang, ""),
 		})
 	}
+	// Add annotations
+	trps = append(trps, annotationTriples(subj, class.Annotations)...)
 	// Done, return triples
 	return trps
 }
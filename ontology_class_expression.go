@@ -0,0 +1,115 @@
+package ontograph
+
+// A ClassExpression represents a named class or an anonymous class expression (`owl:unionOf`, `owl:intersectionOf`, `owl:complementOf`
+// or a restriction). Exactly one of the fields should be set. Operands may be class expressions themselves.
+type ClassExpression struct {
+	Class          string
+	UnionOf        []ClassExpression
+	IntersectionOf []ClassExpression
+	ComplementOf   *ClassExpression
+	Restriction    *OntologyRestriction
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// toTriples converts the class expression into a set of triples and returns the term that represents the expression (the URI of named
+// classes or a new blank node).
+func (expr *ClassExpression) toTriples() (Term, []Triple) {
+	if expr.Class != "" {
+		return NewResourceTerm(expr.Class), nil
+	}
+	node := GenerateBlankNodeTerm()
+	if expr.Restriction != nil {
+		return node, expr.Restriction.toTriples(node)
+	}
+	trps := []Triple{{Subject: node, Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)}}
+	switch {
+	case expr.ComplementOf != nil:
+		operand, operandTrps := expr.ComplementOf.toTriples()
+		trps = append(trps, Triple{Subject: node, Predicate: NewResourceTerm(OWLComplementOf), Object: operand})
+		trps = append(trps, operandTrps...)
+	case expr.UnionOf != nil:
+		list, listTrps := classExpressionListTriples(expr.UnionOf)
+		trps = append(trps, Triple{Subject: node, Predicate: NewResourceTerm(OWLUnionOf), Object: list})
+		trps = append(trps, listTrps...)
+	case expr.IntersectionOf != nil:
+		list, listTrps := classExpressionListTriples(expr.IntersectionOf)
+		trps = append(trps, Triple{Subject: node, Predicate: NewResourceTerm(OWLIntersectionOf), Object: list})
+		trps = append(trps, listTrps...)
+	}
+	return node, trps
+}
+
+// classExpressionListTriples converts the class expressions into a RDF list and returns the head of the list.
+func classExpressionListTriples(exprs []ClassExpression) (Term, []Triple) {
+	items := []Term{}
+	trps := []Triple{}
+	for i := range exprs {
+		item, itemTrps := exprs[i].toTriples()
+		items = append(items, item)
+		trps = append(trps, itemTrps...)
+	}
+	head, listTrps := rdfListTriples(items)
+	return head, append(listTrps, trps...)
+}
+
+// rdfListTriples converts the items into a RDF list of new blank nodes and returns the head of the list.
+func rdfListTriples(items []Term) (Term, []Triple) {
+	head := NewResourceTerm(RDFNil)
+	trps := []Triple{}
+	// Build list from the back
+	for i := len(items) - 1; i >= 0; i-- {
+		node := GenerateBlankNodeTerm()
+		trps = append(trps,
+			Triple{Subject: node, Predicate: NewResourceTerm(RDFFirst), Object: items[i]},
+			Triple{Subject: node, Predicate: NewResourceTerm(RDFRest), Object: head},
+		)
+		head = node
+	}
+	return head, trps
+}
+
+// classExpressionFromTerm parses the class expression represented by the term. Returns false if the term is no valid class expression.
+func classExpressionFromTerm(t Term, blankNodeTrps map[Term][]Triple) (ClassExpression, bool) {
+	return parseClassExpression(t, blankNodeTrps, map[Term]bool{})
+}
+
+// parseClassExpression parses the class expression represented by the term. Visited blank nodes are tracked to stop on cycles.
+func parseClassExpression(t Term, blankNodeTrps map[Term][]Triple, visited map[Term]bool) (ClassExpression, bool) {
+	if t.IsResource() {
+		return ClassExpression{Class: t.Value()}, true
+	}
+	if !t.IsBlankNode() || visited[t] {
+		return ClassExpression{}, false
+	}
+	visited[t] = true
+	trps := blankNodeTrps[t]
+	if restr, ok := restrictionFromTriples(trps); ok {
+		return ClassExpression{Restriction: &restr}, true
+	}
+	if operand := firstObject(trps, OWLComplementOf); operand != "" {
+		expr, ok := parseClassExpression(operand, blankNodeTrps, visited)
+		return ClassExpression{ComplementOf: &expr}, ok
+	}
+	for _, pred := range []string{OWLUnionOf, OWLIntersectionOf} {
+		list := firstObject(trps, pred)
+		if list == "" {
+			continue
+		}
+		operands := []ClassExpression{}
+		for _, item := range rdfList(list, blankNodeTrps) {
+			expr, ok := parseClassExpression(item, blankNodeTrps, visited)
+			if !ok {
+				return ClassExpression{}, false
+			}
+			operands = append(operands, expr)
+		}
+		if pred == OWLUnionOf {
+			return ClassExpression{UnionOf: operands}, true
+		}
+		return ClassExpression{IntersectionOf: operands}, true
+	}
+	return ClassExpression{}, false
+}
@@ -0,0 +1,70 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Class expressions", func() {
+	const ns = "http://example.com/onto#"
+	var store *MemoryStore
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+<http://example.com/onto> a owl:Ontology .
+:Parent a owl:Class ; owl:equivalentClass _:u .
+_:u a owl:Class ; owl:unionOf _:l1 .
+_:l1 rdf:first :Mother ; rdf:rest _:l2 .
+_:l2 rdf:first :Father ; rdf:rest rdf:nil .
+:Childless a owl:Class ; owl:equivalentClass _:c .
+_:c a owl:Class ; owl:complementOf _:r .
+_:r a owl:Restriction ; owl:onProperty :hasChild ; owl:someValuesFrom owl:Thing .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should parse unions and complements", func() {
+		class, err := ont.GetClass(ns + "Parent")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.EquivalentTo).To(BeEmpty())
+		Expect(class.EquivalentToExpressions).To(Equal([]ClassExpression{{UnionOf: []ClassExpression{{Class: ns + "Mother"}, {Class: ns + "Father"}}}}))
+
+		class, err = ont.GetClass(ns + "Childless")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.EquivalentToExpressions).To(Equal([]ClassExpression{{ComplementOf: &ClassExpression{
+			Restriction: &OntologyRestriction{OnProperty: ns + "hasChild", SomeValuesFrom: "http://www.w3.org/2002/07/owl#Thing"},
+		}}}))
+	})
+
+	It("should round-trip nested class expressions", func() {
+		class := OntologyClass{
+			URI:          ns + "WorkingParent",
+			EquivalentTo: []string{ns + "Breadwinner"},
+			EquivalentToExpressions: []ClassExpression{{IntersectionOf: []ClassExpression{
+				{Class: ns + "Employee"},
+				{UnionOf: []ClassExpression{{Class: ns + "Mother"}, {Class: ns + "Father"}}},
+				{ComplementOf: &ClassExpression{Class: ns + "Retiree"}},
+			}}},
+		}
+		Expect(ont.UpsertResource(&class)).To(Succeed())
+		retClass, err := ont.GetClass(class.URI)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retClass.EquivalentTo).To(Equal(class.EquivalentTo))
+		Expect(retClass.EquivalentToExpressions).To(Equal(class.EquivalentToExpressions))
+
+		// The blank nodes of the expressions are deleted with the class
+		size, _ := store.Size()
+		Expect(ont.DeleteResource(class.URI)).To(Succeed())
+		Expect(store.Size()).To(Equal(size - 19))
+	})
+})
@@ -0,0 +1,83 @@
+package ontograph
+
+// GetSubClasses returns the sorted URIs of the classes that specialize the class (`rdfs:subClassOf`). If `direct` is true, only the
+// direct subclasses are returned, otherwise the hierarchy is traversed transitively.
+func (ont *OntologyGraph) GetSubClasses(uri string, direct bool) ([]string, error) {
+	uri = ont.prefixes.Expand(uri)
+	return ont.getPathURIs(uri, hierarchyPath("^", RDFSSubClassOf, direct))
+}
+
+// GetSuperClasses returns the sorted URIs of the named classes that the class specializes (`rdfs:subClassOf`). If `direct` is true,
+// only the direct superclasses are returned, otherwise the hierarchy is traversed transitively.
+func (ont *OntologyGraph) GetSuperClasses(uri string, direct bool) ([]string, error) {
+	uri = ont.prefixes.Expand(uri)
+	return ont.getPathURIs(uri, hierarchyPath("", RDFSSubClassOf, direct))
+}
+
+// GetIndividualsOfClass retrieves the individuals that have the class as type. If `includeSubclasses` is true, the individuals of all
+// (transitive) subclasses are included as well, e.g. querying for `Vehicle` also returns the instances of `Car`.
+func (ont *OntologyGraph) GetIndividualsOfClass(uri string, includeSubclasses bool) ([]OntologyIndividual, error) {
+	uri = ont.prefixes.Expand(uri)
+	filter := TripleFilter{}.OrWithClass(uri)
+	if includeSubclasses {
+		subClasses, err := ont.GetSubClasses(uri, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, subClass := range subClasses {
+			filter = filter.OrWithClass(subClass)
+		}
+	}
+	return ont.GetIndividuals(filter)
+}
+
+// GetInferredTypes returns the sorted URIs of the named classes that the individual is an instance of according to the RDFS entailment
+// of the asserted triples: the asserted types, the domains of the properties used by the individual, the ranges of the object
+// properties that point to the individual (including the domains and ranges of their super properties) and all superclasses of these
+// classes. This answers questions such as "is this thing a Sensor?" without materializing the inferences in the graph.
+func (ont *OntologyGraph) GetInferredTypes(uri string) ([]string, error) {
+	uri = ont.prefixes.Expand(uri)
+	closure := "/" + NewResourceTerm(RDFSSubClassOf).String() + "*"
+	superProps := NewResourceTerm(RDFSSubPropertyOf).String() + "*/"
+	subj := NewResourceTerm(uri).String()
+	types := map[string]bool{}
+	addTypes := func(start, path string) error {
+		targets, err := GetPathTargets(ont.graph, start, path)
+		if err != nil {
+			return err
+		}
+		for _, t := range targets {
+			if t.IsResource() && !isAnonymousURI(t.Value()) {
+				types[t.Value()] = true
+			}
+		}
+		return nil
+	}
+	// Collect the asserted types with their superclasses
+	if err := addTypes(subj, "a"+closure); err != nil {
+		return nil, err
+	}
+	// Collect the domains of the used properties and the ranges of the properties pointing to the individual
+	for _, usage := range []struct {
+		subj, obj, axiom string
+	}{
+		{subj, "", RDFSDomain},
+		{"", subj, RDFSRange},
+	} {
+		trps, err := ont.graph.GetAllMatches(usage.subj, "", usage.obj)
+		if err != nil {
+			return nil, err
+		}
+		visited := map[Term]bool{NewResourceTerm(RDFType): true}
+		for _, trp := range trps {
+			if visited[trp.Predicate] {
+				continue
+			}
+			visited[trp.Predicate] = true
+			if err := addTypes(trp.Predicate.String(), superProps+NewResourceTerm(usage.axiom).String()+closure); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return sortedKeys(types), nil
+}
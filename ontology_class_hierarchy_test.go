@@ -0,0 +1,85 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Class hierarchy", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.com/onto> a owl:Ontology .
+:Vehicle a owl:Class .
+:Car a owl:Class ; rdfs:subClassOf :Vehicle .
+:SportsCar a owl:Class ; rdfs:subClassOf :Car .
+:Bike a owl:Class ; rdfs:subClassOf :Vehicle .
+:cart a owl:NamedIndividual, :Vehicle .
+:beetle a owl:NamedIndividual, :Car .
+:ferrari a owl:NamedIndividual, :SportsCar, :Car .
+:bmx a owl:NamedIndividual, :Bike .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should traverse the class hierarchy", func() {
+		Expect(ont.GetSubClasses(ns+"Vehicle", true)).To(Equal([]string{ns + "Bike", ns + "Car"}))
+		Expect(ont.GetSubClasses(ns+"Vehicle", false)).To(Equal([]string{ns + "Bike", ns + "Car", ns + "SportsCar"}))
+		Expect(ont.GetSuperClasses(ns+"SportsCar", false)).To(Equal([]string{ns + "Car", ns + "Vehicle"}))
+	})
+
+	It("should retrieve the individuals of a class with or without subclasses", func() {
+		uris := func(indivs []OntologyIndividual) []string {
+			res := []string{}
+			for _, indiv := range indivs {
+				res = append(res, indiv.URI)
+			}
+			return res
+		}
+		indivs, err := ont.GetIndividualsOfClass(ns+"Vehicle", false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uris(indivs)).To(ConsistOf(ns + "cart"))
+
+		indivs, err = ont.GetIndividualsOfClass(ns+"Vehicle", true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uris(indivs)).To(ConsistOf(ns+"cart", ns+"beetle", ns+"ferrari", ns+"bmx"))
+
+		indivs, err = ont.GetIndividualsOfClass(ns+"Car", true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uris(indivs)).To(ConsistOf(ns+"beetle", ns+"ferrari"))
+	})
+	It("should infer types from the class hierarchy, domains and ranges", func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.com/onto> a owl:Ontology .
+:Device a owl:Class .
+:Sensor a owl:Class ; rdfs:subClassOf :Device .
+:Location a owl:Class .
+:Room a owl:Class ; rdfs:subClassOf :Location .
+:observes a owl:ObjectProperty ; rdfs:domain :Sensor .
+:measuresTemperature a owl:ObjectProperty ; rdfs:subPropertyOf :observes .
+:locatedIn a owl:ObjectProperty ; rdfs:range :Room .
+:serial a owl:DatatypeProperty ; rdfs:domain :Device .
+:t1 a owl:NamedIndividual ; :measuresTemperature :kitchen ; :locatedIn :kitchen ; :serial "42" .
+:kitchen a owl:NamedIndividual .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ont.GetInferredTypes(ns + "t1")).To(Equal([]string{ns + "Device", ns + "Sensor", OWLNamedIndividual}))
+		Expect(ont.GetInferredTypes(ns + "kitchen")).To(Equal([]string{ns + "Location", ns + "Room", OWLNamedIndividual}))
+		Expect(ont.GetInferredTypes(ns + "unknown")).To(BeEmpty())
+	})
+})
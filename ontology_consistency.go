@@ -0,0 +1,211 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// An Inconsistency describes a violation of an axiom of the ontology by asserted triples (see `OntologyGraph.CheckConsistency`).
+type Inconsistency struct {
+	// Resource is the URI of the offending individual.
+	Resource string
+	// Err describes the violated axiom (e.g. `ErrDisjointClassesViolated`).
+	Err error
+	// Triples are the offending triples.
+	Triples []Triple
+}
+
+// String returns a human readable description of the inconsistency.
+func (inc Inconsistency) String() string {
+	return fmt.Sprintf("<%s>: %s", inc.Resource, inc.Err.Error())
+}
+
+// CheckConsistency checks the individuals of the ontology against the disjointness and property characteristic axioms and returns all
+// violations, sorted by resource. It finds individuals that are typed with disjoint classes (`owl:disjointWith`, also via asserted
+// superclasses), that relate to the same resource via disjoint properties (`owl:propertyDisjointWith`), that relate to each other via an
+// asymmetric property (`owl:AsymmetricProperty`) or that relate to themselves via an irreflexive property (`owl:IrreflexiveProperty`).
+// This is no replacement for a reasoner, but catches common modelling errors in instance data cheaply.
+func (ont *OntologyGraph) CheckConsistency() ([]Inconsistency, error) {
+	incs := []Inconsistency{}
+	for _, check := range []func() ([]Inconsistency, error){
+		ont.checkDisjointClasses,
+		ont.checkDisjointProperties,
+		ont.checkAsymmetricProperties,
+		ont.checkIrreflexiveProperties,
+	} {
+		found, err := check()
+		if err != nil {
+			return nil, err
+		}
+		incs = append(incs, found...)
+	}
+	sort.SliceStable(incs, func(i, j int) bool {
+		return incs[i].Resource < incs[j].Resource
+	})
+	return incs, nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrDisjointClassesViolated is raised when an individual is typed with disjoint classes.
+var ErrDisjointClassesViolated error = errors.New("The individual is typed with disjoint classes")
+
+// ErrDisjointPropertiesViolated is raised when an individual relates to the same resource via disjoint properties.
+var ErrDisjointPropertiesViolated error = errors.New("The individual relates to the same resource via disjoint properties")
+
+// ErrAsymmetricPropertyViolated is raised when two individuals relate to each other via an asymmetric property.
+var ErrAsymmetricPropertyViolated error = errors.New("The individuals relate to each other via an asymmetric property")
+
+// ErrIrreflexivePropertyViolated is raised when an individual relates to itself via an irreflexive property.
+var ErrIrreflexivePropertyViolated error = errors.New("The individual relates to itself via an irreflexive property")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// checkDisjointClasses finds individuals that are typed with disjoint classes directly or via asserted superclasses.
+func (ont *OntologyGraph) checkDisjointClasses() ([]Inconsistency, error) {
+	disjoint, err := ont.graph.GetAllMatches("", NewResourceTerm(OWLDisjointWith).String(), "")
+	if err != nil || len(disjoint) == 0 {
+		return nil, err
+	}
+	subClassOf, err := ont.graph.GetAllMatches("", NewResourceTerm(RDFSSubClassOf).String(), "")
+	if err != nil {
+		return nil, err
+	}
+	superClasses := map[Term][]Term{}
+	for _, trp := range subClassOf {
+		superClasses[trp.Subject] = append(superClasses[trp.Subject], trp.Object)
+	}
+	// Determine the asserted type triples that make each individual an instance of each class
+	typeTrps, err := ont.graph.GetAllMatches("", NewResourceTerm(RDFType).String(), "")
+	if err != nil {
+		return nil, err
+	}
+	SortTriples(typeTrps)
+	instanceOf := map[Term]map[Term]Triple{}
+	for _, trp := range typeTrps {
+		if instanceOf[trp.Subject] == nil {
+			instanceOf[trp.Subject] = map[Term]Triple{}
+		}
+		visited := map[Term]bool{}
+		queue := []Term{trp.Object}
+		for len(queue) > 0 {
+			class := queue[0]
+			queue = queue[1:]
+			if visited[class] {
+				continue
+			}
+			visited[class] = true
+			if _, ok := instanceOf[trp.Subject][class]; !ok {
+				instanceOf[trp.Subject][class] = trp
+			}
+			queue = append(queue, superClasses[class]...)
+		}
+	}
+	// Check every disjointness axiom against every individual
+	incs := []Inconsistency{}
+	reported := map[[3]Term]bool{}
+	SortTriples(disjoint)
+	for _, axiom := range disjoint {
+		for _, trp := range typeTrps {
+			indiv := trp.Subject
+			trp1, ok1 := instanceOf[indiv][axiom.Subject]
+			trp2, ok2 := instanceOf[indiv][axiom.Object]
+			key := [3]Term{indiv, axiom.Subject, axiom.Object}
+			if !ok1 || !ok2 || reported[key] {
+				continue
+			}
+			reported[key] = true
+			offending := []Triple{trp1}
+			if trp2 != trp1 {
+				offending = append(offending, trp2)
+			}
+			incs = append(incs, Inconsistency{Resource: uriOf(indiv), Err: ErrDisjointClassesViolated, Triples: append(offending, axiom)})
+		}
+	}
+	return incs, nil
+}
+
+// checkDisjointProperties finds individuals that relate to the same resource via disjoint properties.
+func (ont *OntologyGraph) checkDisjointProperties() ([]Inconsistency, error) {
+	disjoint, err := ont.graph.GetAllMatches("", NewResourceTerm(OWLPropertyDisjointWith).String(), "")
+	if err != nil {
+		return nil, err
+	}
+	SortTriples(disjoint)
+	incs := []Inconsistency{}
+	for _, axiom := range disjoint {
+		trps, err := ont.graph.GetAllMatches("", axiom.Subject.String(), "")
+		if err != nil {
+			return nil, err
+		}
+		SortTriples(trps)
+		for _, trp := range trps {
+			other := Triple{Subject: trp.Subject, Predicate: axiom.Object, Object: trp.Object}
+			match, err := ont.graph.GetFirstMatch(other.Subject.String(), other.Predicate.String(), other.Object.String())
+			if err != nil {
+				return nil, err
+			}
+			if match != nil {
+				incs = append(incs, Inconsistency{Resource: uriOf(trp.Subject), Err: ErrDisjointPropertiesViolated, Triples: []Triple{trp, other, axiom}})
+			}
+		}
+	}
+	return incs, nil
+}
+
+// checkAsymmetricProperties finds pairs of individuals that relate to each other via an asymmetric property. Each pair is reported once.
+func (ont *OntologyGraph) checkAsymmetricProperties() ([]Inconsistency, error) {
+	incs := []Inconsistency{}
+	err := ont.forEachPropertyTriple(OWLAsymmetricProperty, func(trp Triple, byPair map[[2]Term]bool) {
+		if byPair[[2]Term{trp.Object, trp.Subject}] && trp.Subject.String() <= trp.Object.String() {
+			inverse := Triple{Subject: trp.Object, Predicate: trp.Predicate, Object: trp.Subject}
+			offending := []Triple{trp}
+			if inverse != trp {
+				offending = append(offending, inverse)
+			}
+			incs = append(incs, Inconsistency{Resource: uriOf(trp.Subject), Err: ErrAsymmetricPropertyViolated, Triples: offending})
+		}
+	})
+	return incs, err
+}
+
+// checkIrreflexiveProperties finds individuals that relate to themselves via an irreflexive property.
+func (ont *OntologyGraph) checkIrreflexiveProperties() ([]Inconsistency, error) {
+	incs := []Inconsistency{}
+	err := ont.forEachPropertyTriple(OWLIrreflexiveProperty, func(trp Triple, byPair map[[2]Term]bool) {
+		if trp.Subject == trp.Object {
+			incs = append(incs, Inconsistency{Resource: uriOf(trp.Subject), Err: ErrIrreflexivePropertyViolated, Triples: []Triple{trp}})
+		}
+	})
+	return incs, err
+}
+
+// forEachPropertyTriple calls the function for every triple (in sorted order) of every property with the given characteristic. The
+// function also receives the subject-object pairs of all triples of the property.
+func (ont *OntologyGraph) forEachPropertyTriple(characteristic string, fn func(trp Triple, byPair map[[2]Term]bool)) error {
+	props, err := ont.graph.GetAllMatches("", NewResourceTerm(RDFType).String(), NewResourceTerm(characteristic).String())
+	if err != nil {
+		return err
+	}
+	SortTriples(props)
+	for _, prop := range props {
+		trps, err := ont.graph.GetAllMatches("", prop.Subject.String(), "")
+		if err != nil {
+			return err
+		}
+		SortTriples(trps)
+		byPair := map[[2]Term]bool{}
+		for _, trp := range trps {
+			byPair[[2]Term{trp.Subject, trp.Object}] = true
+		}
+		for _, trp := range trps {
+			fn(trp, byPair)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Checking consistency", func() {
+	const ns = "http://example.com/onto#"
+	const header = `@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.com/onto> a owl:Ontology .
+:Animal a owl:Class ; owl:disjointWith :Plant .
+:Plant a owl:Class .
+:Cat a owl:Class ; rdfs:subClassOf :Animal .
+:likes a owl:ObjectProperty ; owl:propertyDisjointWith :hates .
+:hates a owl:ObjectProperty .
+:parentOf a owl:ObjectProperty, owl:AsymmetricProperty, owl:IrreflexiveProperty .
+`
+	load := func(ttl string) *OntologyGraph {
+		store, err := ParseFromTurtle(strings.NewReader(header + ttl))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err := LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		return ont
+	}
+	trp := func(subj, pred, obj string) Triple {
+		return Triple{Subject: NewResourceTerm(subj), Predicate: NewResourceTerm(pred), Object: NewResourceTerm(obj)}
+	}
+
+	It("should find no inconsistencies in consistent data", func() {
+		ont := load(`:tom a :Cat ; :likes :jerry ; :parentOf :kitty .
+:jerry a :Animal .
+:rose a :Plant .
+`)
+		Expect(ont.CheckConsistency()).To(BeEmpty())
+	})
+
+	It("should find individuals typed with disjoint classes", func() {
+		ont := load(`:tom a :Cat, :Plant .`)
+		incs, err := ont.CheckConsistency()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(incs).To(HaveLen(1))
+		Expect(incs[0].Resource).To(Equal(ns + "tom"))
+		Expect(incs[0].Err).To(Equal(ErrDisjointClassesViolated))
+		Expect(incs[0].Triples).To(Equal([]Triple{
+			trp(ns+"tom", RDFType, ns+"Cat"),
+			trp(ns+"tom", RDFType, ns+"Plant"),
+			trp(ns+"Animal", OWLDisjointWith, ns+"Plant"),
+		}))
+		Expect(incs[0].String()).To(ContainSubstring("disjoint classes"))
+	})
+
+	It("should find violations of property disjointness and characteristics", func() {
+		ont := load(`:tom :likes :jerry ; :hates :jerry ; :parentOf :kitty, :tom .
+:kitty :parentOf :tom .
+`)
+		incs, err := ont.CheckConsistency()
+		Expect(err).NotTo(HaveOccurred())
+		errs := []error{}
+		for _, inc := range incs {
+			Expect(inc.Resource).To(BeElementOf(ns+"kitty", ns+"tom"))
+			errs = append(errs, inc.Err)
+		}
+		Expect(errs).To(ConsistOf(
+			ErrDisjointPropertiesViolated,
+			ErrAsymmetricPropertyViolated,
+			ErrAsymmetricPropertyViolated,
+			ErrIrreflexivePropertyViolated,
+		))
+		Expect(incs[0].Resource).To(Equal(ns + "kitty"))
+		Expect(incs[0].Triples).To(Equal([]Triple{trp(ns+"kitty", ns+"parentOf", ns+"tom"), trp(ns+"tom", ns+"parentOf", ns+"kitty")}))
+	})
+})
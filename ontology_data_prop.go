@@ -9,8 +9,11 @@ type OntologyDataProperty struct {
 	Ranges        []string
 	DisjointWith  []string
 	IsFunctional  bool
+	IsDeprecated  bool
 	Label         map[string]string
 	Comment       map[string]string
+	// Annotations hold all other literal-valued annotations of the property, keyed by annotation property.
+	Annotations map[string][]GenericLiteral
 }
 
 // GetURI returns the URI of the data property.
@@ -79,6 +82,10 @@ func (prop *OntologyDataProperty) ToTriples() []Triple {
 		})
 	}
 
+	// Add deprecation flag
+	if prop.IsDeprecated {
+		trps = append(trps, deprecationTriple(subj))
+	}
 	// Add labels
 	for lang, label := range prop.Label {
 		trps = append(trps, Triple{
@@ -95,6 +102,8 @@ func (prop *OntologyDataProperty) ToTriples() []Triple {
 			Object:    NewLiteralTerm(comment, lang, ""),
 		})
 	}
+	// Add annotations
+	trps = append(trps, annotationTriples(subj, prop.Annotations)...)
 	// Done, return triples
 	return trps
 }
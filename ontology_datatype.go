@@ -5,6 +5,8 @@ type OntologyDatatype struct {
 	URI     string
 	Label   map[string]string
 	Comment map[string]string
+	// Annotations hold all other literal-valued annotations of the datatype, keyed by annotation property.
+	Annotations map[string][]GenericLiteral
 }
 
 // GetURI returns the URI of the data type.
@@ -40,6 +42,8 @@ func (dt *OntologyDatatype) ToTriples() []Triple {
 			Object:    NewLiteralTerm(comment, lang, ""),
 		})
 	}
+	// Add annotations
+	trps = append(trps, annotationTriples(subj, dt.Annotations)...)
 	// Done, return triples
 	return trps
 }
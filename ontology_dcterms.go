@@ -0,0 +1,175 @@
+package ontograph
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GetCreators returns the creators of the ontology (`dcterms:creator`), sorted. Creators can be given as URIs of agents or as names.
+func (ont *OntologyGraph) GetCreators() ([]string, error) {
+	return ont.getHeaderValues(DCTermsCreator)
+}
+
+// SetCreators sets the creators of the ontology, replacing the previous ones. Absolute URIs (e.g. an ORCID) are stored as references,
+// all other values as plain literals.
+func (ont *OntologyGraph) SetCreators(creators ...string) error {
+	return ont.setHeaderValues(DCTermsCreator, creators...)
+}
+
+// GetPublishers returns the publishers of the ontology (`dcterms:publisher`), sorted. Publishers can be given as URIs of agents or as
+// names.
+func (ont *OntologyGraph) GetPublishers() ([]string, error) {
+	return ont.getHeaderValues(DCTermsPublisher)
+}
+
+// SetPublishers sets the publishers of the ontology, replacing the previous ones. Absolute URIs are stored as references, all other
+// values as plain literals.
+func (ont *OntologyGraph) SetPublishers(publishers ...string) error {
+	return ont.setHeaderValues(DCTermsPublisher, publishers...)
+}
+
+// GetLicense returns the license of the ontology (`dcterms:license`), usually the URI of the license document. If no license is set,
+// the empty string is returned.
+func (ont *OntologyGraph) GetLicense() (string, error) {
+	values, err := ont.getHeaderValues(DCTermsLicense)
+	if err != nil || len(values) == 0 {
+		return "", err
+	}
+	return values[0], nil
+}
+
+// SetLicense sets the license of the ontology, e.g. `https://creativecommons.org/licenses/by/4.0/`. A previous license will be
+// replaced. If `license` is empty, the license is removed.
+func (ont *OntologyGraph) SetLicense(license string) error {
+	if license == "" {
+		return ont.setHeaderValues(DCTermsLicense)
+	}
+	return ont.setHeaderValues(DCTermsLicense, license)
+}
+
+// GetIssued returns the date of formal issuance of the ontology (`dcterms:issued`). If no date is set, the zero time is returned.
+func (ont *OntologyGraph) GetIssued() (time.Time, error) {
+	return ont.getHeaderDate(DCTermsIssued)
+}
+
+// SetIssued sets the date of formal issuance of the ontology as `xsd:date`. A previous date will be replaced. If the time is zero, the
+// date is removed.
+func (ont *OntologyGraph) SetIssued(t time.Time) error {
+	return ont.setHeaderDate(DCTermsIssued, t)
+}
+
+// GetModified returns the date on which the ontology was last changed (`dcterms:modified`). If no date is set, the zero time is
+// returned.
+func (ont *OntologyGraph) GetModified() (time.Time, error) {
+	return ont.getHeaderDate(DCTermsModified)
+}
+
+// SetModified sets the date on which the ontology was last changed as `xsd:date`. A previous date will be replaced. If the time is zero,
+// the date is removed.
+func (ont *OntologyGraph) SetModified(t time.Time) error {
+	return ont.setHeaderDate(DCTermsModified, t)
+}
+
+// GetDescription returns the description of the ontology (`dcterms:description`) for the specified language code. If no description is
+// set, the empty string is returned.
+func (ont *OntologyGraph) GetDescription(lang string) (string, error) {
+	trps, err := ont.graph.GetAllMatches(NewResourceTerm(ont.GetURI()).String(), NewResourceTerm(DCTermsDescription).String(), "")
+	if err != nil {
+		return "", err
+	}
+	for _, trp := range trps {
+		if trp.Object.IsLiteral() && trp.Object.Language() == lang {
+			return trp.Object.Value(), nil
+		}
+	}
+	return "", nil
+}
+
+// SetDescription sets the description of the ontology for the specified language code. Any previous description for the language will
+// be removed. If `description` is empty, the description for the language code will be removed.
+func (ont *OntologyGraph) SetDescription(description, lang string) error {
+	trps, err := ont.graph.GetAllMatches(NewResourceTerm(ont.GetURI()).String(), NewResourceTerm(DCTermsDescription).String(), "")
+	if err != nil {
+		return err
+	}
+	objs := []Term{}
+	for _, trp := range trps {
+		if !trp.Object.IsLiteral() || trp.Object.Language() != lang {
+			objs = append(objs, trp.Object)
+		}
+	}
+	if description != "" {
+		objs = append(objs, NewLiteralTerm(description, lang, ""))
+	}
+	return ont.setHeaderTerms(DCTermsDescription, objs...)
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// getHeaderValues returns the sorted URIs and literal values that the ontology header references with the predicate.
+func (ont *OntologyGraph) getHeaderValues(pred string) ([]string, error) {
+	trps, err := ont.graph.GetAllMatches(NewResourceTerm(ont.GetURI()).String(), NewResourceTerm(pred).String(), "")
+	if err != nil {
+		return nil, err
+	}
+	return headerValues(trps, pred), nil
+}
+
+// headerValues returns the sorted URIs and literal values of the header triples with the predicate.
+func headerValues(trps []Triple, pred string) []string {
+	trps = headerTriples(trps, pred)
+	values := []string{}
+	for _, trp := range trps {
+		if !trp.Object.IsBlankNode() {
+			values = append(values, trp.Object.Value())
+		}
+	}
+	return values
+}
+
+// setHeaderValues replaces the values that the ontology header references with the predicate. Absolute URIs are stored as references,
+// all other values as plain literals.
+func (ont *OntologyGraph) setHeaderValues(pred string, values ...string) error {
+	objs := []Term{}
+	for _, value := range values {
+		if u, err := url.Parse(value); err == nil && u.IsAbs() && !strings.ContainsAny(value, " \t\n") {
+			objs = append(objs, NewResourceTerm(value))
+		} else {
+			objs = append(objs, NewLiteralTerm(value, "", ""))
+		}
+	}
+	return ont.setHeaderTerms(pred, objs...)
+}
+
+// getHeaderDate returns the date that the ontology header references with the predicate. Dates (`xsd:date`) and timestamps
+// (`xsd:dateTime`) are supported.
+func (ont *OntologyGraph) getHeaderDate(pred string) (time.Time, error) {
+	trps, err := ont.graph.GetAllMatches(NewResourceTerm(ont.GetURI()).String(), NewResourceTerm(pred).String(), "")
+	if err != nil {
+		return time.Time{}, err
+	}
+	return headerDate(trps, pred)
+}
+
+// headerDate returns the first date of the header triples with the predicate. If there is none, the zero time is returned.
+func headerDate(trps []Triple, pred string) (time.Time, error) {
+	trps = headerTriples(trps, pred)
+	if len(trps) == 0 {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, trps[0].Object.Value()); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", trps[0].Object.Value())
+}
+
+// setHeaderDate replaces the date that the ontology header references with the predicate. The zero time removes the date.
+func (ont *OntologyGraph) setHeaderDate(pred string, t time.Time) error {
+	if t.IsZero() {
+		return ont.setHeaderTerms(pred)
+	}
+	return ont.setHeaderTerms(pred, NewLiteralTerm(t.Format("2006-01-02"), "", XSDDate))
+}
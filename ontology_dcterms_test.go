@@ -0,0 +1,69 @@
+package ontograph_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Dublin Core metadata", func() {
+	var ont *OntologyGraph
+	var store *MemoryStore
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix dcterms: <http://purl.org/dc/terms/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.com/onto> a owl:Ontology ;
+	dcterms:creator "Jane Doe", <https://orcid.org/0000-0002-1825-0097> ;
+	dcterms:license <https://creativecommons.org/licenses/by/4.0/> ;
+	dcterms:issued "2020-03-01"^^xsd:date ;
+	dcterms:modified "2021-04-05T10:00:00Z"^^xsd:dateTime ;
+	dcterms:description "An example ontology"@en, "Eine Beispielontologie"@de .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should read the metadata of the ontology header", func() {
+		Expect(ont.GetCreators()).To(Equal([]string{"Jane Doe", "https://orcid.org/0000-0002-1825-0097"}))
+		Expect(ont.GetPublishers()).To(BeEmpty())
+		Expect(ont.GetLicense()).To(Equal("https://creativecommons.org/licenses/by/4.0/"))
+		Expect(ont.GetIssued()).To(Equal(time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)))
+		Expect(ont.GetModified()).To(Equal(time.Date(2021, 4, 5, 10, 0, 0, 0, time.UTC)))
+		Expect(ont.GetDescription("de")).To(Equal("Eine Beispielontologie"))
+		Expect(ont.GetDescription("fr")).To(BeEmpty())
+	})
+
+	It("should write the metadata of the ontology header", func() {
+		Expect(ont.SetCreators("John Doe")).To(Succeed())
+		Expect(ont.SetPublishers("https://example.com/org", "mailto:info@example.com")).To(Succeed())
+		Expect(ont.SetLicense("")).To(Succeed())
+		Expect(ont.SetIssued(time.Time{})).To(Succeed())
+		Expect(ont.SetModified(time.Date(2022, 1, 2, 15, 4, 5, 0, time.UTC))).To(Succeed())
+		Expect(ont.SetDescription("Ein Beispiel", "de")).To(Succeed())
+		Expect(ont.SetDescription("", "en")).To(Succeed())
+
+		Expect(ont.GetCreators()).To(Equal([]string{"John Doe"}))
+		Expect(ont.GetPublishers()).To(Equal([]string{"https://example.com/org", "mailto:info@example.com"}))
+		Expect(ont.GetLicense()).To(BeEmpty())
+		Expect(ont.GetIssued()).To(BeZero())
+		Expect(ont.GetModified()).To(Equal(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)))
+		Expect(ont.GetDescription("de")).To(Equal("Ein Beispiel"))
+		Expect(ont.GetDescription("en")).To(BeEmpty())
+
+		subj := NewResourceTerm("http://example.com/onto").String()
+		trp, err := store.GetFirstMatch(subj, NewResourceTerm(DCTermsPublisher).String(), NewResourceTerm("https://example.com/org").String())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trp).NotTo(BeNil())
+		trp, err = store.GetFirstMatch(subj, NewResourceTerm(DCTermsModified).String(), "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trp.Object).To(Equal(NewLiteralTerm("2022-01-02", "", XSDDate)))
+	})
+})
@@ -0,0 +1,62 @@
+package ontograph
+
+// A DeleteOption configures how a resource is deleted (see `DeleteResourceChecked`).
+type DeleteOption func(*deleteOptions)
+
+// deleteOptions holds the configuration compiled from a list of delete options.
+type deleteOptions struct {
+	failOnReferences bool
+	keepReferences   bool
+}
+
+// WithFailOnReferences lets the deletion fail with `ErrResourceReferenced` if other resources still reference the resource. Nothing is
+// deleted in this case.
+func WithFailOnReferences() DeleteOption {
+	return func(opts *deleteOptions) {
+		opts.failOnReferences = true
+	}
+}
+
+// WithKeepReferences only deletes the triples of the resource itself (and its blank nodes) and keeps the triples of other resources
+// that reference it.
+func WithKeepReferences() DeleteOption {
+	return func(opts *deleteOptions) {
+		opts.keepReferences = true
+	}
+}
+
+// newDeleteOptions compiles the given list of options.
+func newDeleteOptions(opts []DeleteOption) deleteOptions {
+	options := deleteOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// DeleteResourceChecked removes the resource from the graph like `DeleteResource`, but reports the sorted triples of other resources
+// that reference it. By default, these references are deleted as well, which can be changed with the delete options.
+func (ont *OntologyGraph) DeleteResourceChecked(uri string, opts ...DeleteOption) ([]Triple, error) {
+	uri = ont.prefixes.Expand(uri)
+	options := newDeleteOptions(opts)
+	// Collect references from other resources
+	matches, err := ont.graph.GetAllMatches("", "", uriTerm(uri).String())
+	if err != nil {
+		return nil, err
+	}
+	refs := []Triple{}
+	for _, trp := range matches {
+		if trp.Subject != uriTerm(uri) {
+			refs = append(refs, trp)
+		}
+	}
+	SortTriples(refs)
+	if options.failOnReferences && len(refs) > 0 {
+		return refs, wrapResourceError("DeleteResourceChecked", uri, ErrResourceReferenced)
+	}
+	// Delete the resource and its references (if requested)
+	if options.keepReferences {
+		return refs, ont.deleteSubjectTriples(uri)
+	}
+	return refs, ont.DeleteResource(uri)
+}
@@ -0,0 +1,59 @@
+package ontograph_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Checked deletion", func() {
+	const ns = "http://example.com/onto#"
+	var store *MemoryStore
+	var ont *OntologyGraph
+	var ref Triple
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:alice a owl:NamedIndividual ; :knows :bob .
+:bob a owl:NamedIndividual ; :knows :bob .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		ref = Triple{Subject: NewResourceTerm(ns + "alice"), Predicate: NewResourceTerm(ns + "knows"), Object: NewResourceTerm(ns + "bob")}
+	})
+
+	It("should report and delete the references", func() {
+		refs, err := ont.DeleteResourceChecked(ns + "bob")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refs).To(Equal([]Triple{ref}))
+		Expect(store.GetAllMatches(ref.Subject.String(), ref.Predicate.String(), "")).To(BeEmpty())
+	})
+
+	It("should fail on references if requested", func() {
+		refs, err := ont.DeleteResourceChecked(ns+"bob", WithFailOnReferences())
+		Expect(errors.Is(err, ErrResourceReferenced)).To(BeTrue())
+		Expect(refs).To(Equal([]Triple{ref}))
+		Expect(ont.GetIndividual(ns + "bob")).NotTo(BeZero())
+
+		refs, err = ont.DeleteResourceChecked(ns+"alice", WithFailOnReferences())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refs).To(BeEmpty())
+	})
+
+	It("should keep the references if requested", func() {
+		refs, err := ont.DeleteResourceChecked(ns+"bob", WithKeepReferences())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refs).To(Equal([]Triple{ref}))
+		_, err = ont.GetIndividual(ns + "bob")
+		Expect(errors.Is(err, ErrResourceNotFound)).To(BeTrue())
+		Expect(store.GetAllMatches(ref.Subject.String(), ref.Predicate.String(), "")).To(Equal([]Triple{ref}))
+	})
+})
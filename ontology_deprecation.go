@@ -0,0 +1,44 @@
+package ontograph
+
+import (
+	"sort"
+)
+
+// GetDeprecatedResources returns the sorted URIs of all resources that are marked as deprecated (`owl:deprecated true`), so consumers
+// can warn about the usage of deprecated vocabulary.
+func (ont *OntologyGraph) GetDeprecatedResources() ([]string, error) {
+	trps, err := ont.graph.GetAllMatches("", NewResourceTerm(OWLDeprecated).String(), "")
+	if err != nil {
+		return nil, err
+	}
+	uris := []string{}
+	for _, trp := range trps {
+		if trp.Subject.IsResource() && isTrueLiteral(trp.Object) {
+			uris = append(uris, trp.Subject.Value())
+		}
+	}
+	sort.Strings(uris)
+	return uris, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// deprecationTriple returns the triple that marks the subject as deprecated.
+func deprecationTriple(subj Term) Triple {
+	return Triple{
+		Subject:   subj,
+		Predicate: NewResourceTerm(OWLDeprecated),
+		Object:    NewLiteralTerm("true", "", XSDBoolean),
+	}
+}
+
+// isTrueLiteral returns true if the term is a boolean literal with value true (`true` or `1`).
+func isTrueLiteral(t Term) bool {
+	if !t.IsLiteral() {
+		return false
+	}
+	value := t.Value()
+	return value == "true" || (value == "1" && t.Datatype() == XSDBoolean)
+}
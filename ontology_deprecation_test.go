@@ -0,0 +1,56 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Deprecation", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:OldClass a owl:Class ; owl:deprecated true .
+:NewClass a owl:Class ; owl:deprecated false .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should round-trip deprecation flags", func() {
+		prop := OntologyObjectProperty{URI: ns + "oldProp", IsDeprecated: true}
+		Expect(ont.UpsertResource(&prop)).To(Succeed())
+		dataProp := OntologyDataProperty{URI: ns + "oldValue", IsDeprecated: true}
+		Expect(ont.UpsertResource(&dataProp)).To(Succeed())
+		indiv := OntologyIndividual{URI: ns + "oldIndividual", IsDeprecated: true}
+		Expect(ont.UpsertResource(&indiv)).To(Succeed())
+
+		class, err := ont.GetClass(ns + "OldClass")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.IsDeprecated).To(BeTrue())
+		Expect(class.Annotations).To(BeEmpty())
+		class, err = ont.GetClass(ns + "NewClass")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.IsDeprecated).To(BeFalse())
+		retProp, err := ont.GetObjectProperty(prop.URI)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retProp.IsDeprecated).To(BeTrue())
+		retDataProp, err := ont.GetDataProperty(dataProp.URI)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retDataProp.IsDeprecated).To(BeTrue())
+		retIndiv, err := ont.GetIndividual(indiv.URI)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retIndiv.IsDeprecated).To(BeTrue())
+		Expect(retIndiv.DataProperties).To(BeEmpty())
+
+		Expect(ont.GetDeprecatedResources()).To(Equal([]string{ns + "OldClass", ns + "oldIndividual", ns + "oldProp", ns + "oldValue"}))
+	})
+})
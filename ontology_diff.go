@@ -0,0 +1,146 @@
+package ontograph
+
+import (
+	"sort"
+	"strings"
+)
+
+// ResourceDiff lists the differences of a single resource between two ontologies. The triples include the blank nodes of the resource
+// (e.g. restrictions) with canonical labels (see `CanonicalTriples`).
+type ResourceDiff struct {
+	URI string
+	// Removed are the triples of the resource that are only in the old ontology.
+	Removed []Triple
+	// Added are the triples of the resource that are only in the new ontology.
+	Added []Triple
+}
+
+// OntologyDiff lists the resources that differ between two ontologies (see `DiffOntologies`). All lists are sorted by URI.
+type OntologyDiff struct {
+	// Added are the resources that are only in the new ontology.
+	Added []ResourceDiff
+	// Removed are the resources that are only in the old ontology.
+	Removed []ResourceDiff
+	// Changed are the resources that are in both ontologies but with different triples.
+	Changed []ResourceDiff
+}
+
+// IsEmpty returns true if both ontologies are equal.
+func (d OntologyDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Triples returns the differences of all resources as one triple diff, with the removed triples as missing and the added triples as
+// unexpected ones.
+func (d OntologyDiff) Triples() TripleDiff {
+	diff := TripleDiff{}
+	for _, res := range append(append(append([]ResourceDiff{}, d.Removed...), d.Changed...), d.Added...) {
+		diff.Missing = append(diff.Missing, res.Removed...)
+		diff.Unexpected = append(diff.Unexpected, res.Added...)
+	}
+	return diff
+}
+
+// String returns a summary of the differences line by line, with the URIs of added resources prefixed with `+`, of removed resources
+// with `-` and of changed resources with `~`. This is meant for review workflows and release notes.
+func (d OntologyDiff) String() string {
+	var sb strings.Builder
+	for _, res := range d.Added {
+		sb.WriteString("+ " + res.URI + "\n")
+	}
+	for _, res := range d.Removed {
+		sb.WriteString("- " + res.URI + "\n")
+	}
+	for _, res := range d.Changed {
+		sb.WriteString("~ " + res.URI + "\n")
+	}
+	return sb.String()
+}
+
+// DiffOntologies compares the old with the new ontology resource by resource. Blank nodes are compared by their position within the
+// resources that reach them, not by their labels, so re-parsing an unchanged ontology yields an empty diff. Blank nodes that are not
+// reachable from any named resource are compared as one resource with an empty URI.
+func DiffOntologies(old, new *OntologyGraph) (OntologyDiff, error) {
+	return DiffStores(old.graph, new.graph)
+}
+
+// DiffStores compares the triples of the old with the new store resource by resource (see `DiffOntologies`).
+func DiffStores(old, new GraphStore) (OntologyDiff, error) {
+	oldTrps, err := old.GetAllTriples()
+	if err != nil {
+		return OntologyDiff{}, err
+	}
+	newTrps, err := new.GetAllTriples()
+	if err != nil {
+		return OntologyDiff{}, err
+	}
+	oldRes, oldOrphans := groupResourceTriples(oldTrps)
+	newRes, newOrphans := groupResourceTriples(newTrps)
+	if len(oldOrphans) > 0 || len(newOrphans) > 0 {
+		oldRes[""], newRes[""] = oldOrphans, newOrphans
+	}
+	// Compare the resources of both stores
+	uris := map[string]bool{}
+	for uri := range oldRes {
+		uris[uri] = true
+	}
+	for uri := range newRes {
+		uris[uri] = true
+	}
+	diff := OntologyDiff{}
+	for _, uri := range sortedKeys(uris) {
+		trpDiff := DiffTriples(oldRes[uri], newRes[uri])
+		if trpDiff.IsEmpty() {
+			continue
+		}
+		res := ResourceDiff{URI: uri, Removed: trpDiff.Missing, Added: trpDiff.Unexpected}
+		switch {
+		case len(oldRes[uri]) == 0:
+			diff.Added = append(diff.Added, res)
+		case len(newRes[uri]) == 0:
+			diff.Removed = append(diff.Removed, res)
+		default:
+			diff.Changed = append(diff.Changed, res)
+		}
+	}
+	return diff, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// groupResourceTriples groups the triples by the URIs of their named subjects. The triples of blank nodes are added to the group of every
+// named subject that reaches them. The triples of blank nodes that are not reachable from any named subject are returned as orphans.
+func groupResourceTriples(trps []Triple) (map[string][]Triple, []Triple) {
+	bySubject := triplesBySubject(trps)
+	groups := map[string][]Triple{}
+	reached := map[Term]bool{}
+	for subj, subjTrps := range bySubject {
+		if subj.IsBlankNode() {
+			continue
+		}
+		closure := blankNodeClosure(subjTrps, bySubject)
+		for _, trp := range closure {
+			reached[trp.Subject] = true
+		}
+		groups[subj.Value()] = append(append([]Triple{}, subjTrps...), closure...)
+	}
+	orphans := []Triple{}
+	for subj, subjTrps := range bySubject {
+		if subj.IsBlankNode() && !reached[subj] {
+			orphans = append(orphans, subjTrps...)
+		}
+	}
+	return groups, orphans
+}
+
+// sortedKeys returns the keys of the set in ascending order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
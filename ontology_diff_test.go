@@ -0,0 +1,81 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Diffing ontologies", func() {
+	const ns = "http://example.com/onto#"
+	const header = `@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.com/onto> a owl:Ontology .
+`
+	var old *OntologyGraph
+
+	load := func(ttl string) *OntologyGraph {
+		store, err := ParseFromTurtle(strings.NewReader(header + ttl))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err := LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		return ont
+	}
+
+	BeforeEach(func() {
+		old = load(`:Person a owl:Class .
+:Parent a owl:Class ; rdfs:subClassOf :Person, [ a owl:Restriction ; owl:onProperty :hasChild ; owl:someValuesFrom :Person ] .
+:hasChild a owl:ObjectProperty .
+`)
+	})
+
+	It("should yield an empty diff for equal ontologies with different blank node labels", func() {
+		diff, err := DiffOntologies(old, load(`:Person a owl:Class .
+:Parent a owl:Class ; rdfs:subClassOf :Person, [ a owl:Restriction ; owl:onProperty :hasChild ; owl:someValuesFrom :Person ] .
+:hasChild a owl:ObjectProperty .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.IsEmpty()).To(BeTrue())
+		Expect(diff.String()).To(BeEmpty())
+	})
+
+	It("should list added, removed and changed resources", func() {
+		diff, err := DiffOntologies(old, load(`:Person a owl:Class ; rdfs:label "Person" .
+:Parent a owl:Class ; rdfs:subClassOf :Person, [ a owl:Restriction ; owl:onProperty :hasChild ; owl:allValuesFrom :Person ] .
+:Child a owl:Class .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.Added).To(HaveLen(1))
+		Expect(diff.Added[0].URI).To(Equal(ns + "Child"))
+		Expect(diff.Added[0].Added).To(HaveLen(1))
+		Expect(diff.Removed).To(HaveLen(1))
+		Expect(diff.Removed[0].URI).To(Equal(ns + "hasChild"))
+		Expect(diff.Removed[0].Removed).To(HaveLen(1))
+		Expect(diff.Changed).To(HaveLen(2))
+		Expect(diff.Changed[0].URI).To(Equal(ns + "Parent"))
+		Expect(diff.Changed[0].Removed).To(HaveLen(1))
+		Expect(diff.Changed[0].Removed[0].Predicate).To(Equal(NewResourceTerm(OWLSomeValuesFrom)))
+		Expect(diff.Changed[0].Added).To(HaveLen(1))
+		Expect(diff.Changed[0].Added[0].Predicate).To(Equal(NewResourceTerm(OWLAllValuesFrom)))
+		Expect(diff.Changed[1].URI).To(Equal(ns + "Person"))
+		Expect(diff.Changed[1].Removed).To(BeEmpty())
+		Expect(diff.Changed[1].Added).To(HaveLen(1))
+		Expect(diff.String()).To(Equal("+ " + ns + "Child\n- " + ns + "hasChild\n~ " + ns + "Parent\n~ " + ns + "Person\n"))
+		Expect(diff.Triples().Missing).To(HaveLen(2))
+		Expect(diff.Triples().Unexpected).To(HaveLen(3))
+	})
+
+	It("should compare stores", func() {
+		store, err := ParseFromTurtle(strings.NewReader(header + `:Person a owl:Class .`))
+		Expect(err).NotTo(HaveOccurred())
+		diff, err := DiffStores(store, NewMemoryStore("http://example.com/onto"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.Removed).To(HaveLen(2))
+		Expect(diff.Removed[0].URI).To(Equal("http://example.com/onto"))
+		Expect(diff.Removed[1].URI).To(Equal(ns + "Person"))
+	})
+})
@@ -0,0 +1,122 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DOTOptions configures the GraphViz DOT export of an ontology graph.
+type DOTOptions struct {
+	// IncludeIndividuals adds the individuals as nodes with dashed edges to their classes.
+	IncludeIndividuals bool
+	// IncludeObjectProperties adds edges between individuals for their object properties (only if individuals are included).
+	IncludeObjectProperties bool
+	// Language selects the language of the labels used for nodes and edges. If no label exists for the language, the local name of the URI is used.
+	Language string
+}
+
+// ExportDOT writes the structure of the ontology into the writer as GraphViz DOT graph. Classes are drawn as boxes with
+// edges to their super classes. Optionally, individuals and their object property relations are drawn as well.
+// The output is sorted, so the same ontology always yields the same graph.
+func (ont *OntologyGraph) ExportDOT(w io.Writer, opts DOTOptions) error {
+	var dot strings.Builder
+	dot.WriteString("digraph ontology {\n")
+	dot.WriteString("  rankdir=BT;\n")
+
+	// Add class nodes and subclass edges
+	trps, err := ont.graph.GetAllMatches("", NewResourceTerm(RDFType).String(), NewResourceTerm(OWLClass).String())
+	if err != nil {
+		return err
+	}
+	classURIs := []string{}
+	for _, trp := range trps {
+		classURIs = append(classURIs, trp.Subject.Value())
+	}
+	sort.Strings(classURIs)
+	for _, uri := range classURIs {
+		class, err := ont.GetClass(uri)
+		if err != nil {
+			return err
+		}
+		dot.WriteString(fmt.Sprintf("  %s [shape=box, label=%s];\n", dotID(uri), dotID(dotLabel(uri, class.Label, opts.Language))))
+		parents := append([]string{}, class.SubClassOf...)
+		sort.Strings(parents)
+		for _, parent := range parents {
+			dot.WriteString(fmt.Sprintf("  %s -> %s [arrowhead=empty];\n", dotID(uri), dotID(parent)))
+		}
+	}
+
+	// Add individuals with their types and relations
+	if opts.IncludeIndividuals {
+		indivs, err := ont.GetIndividuals(nil)
+		if err != nil {
+			return err
+		}
+		sort.Slice(indivs, func(i, j int) bool { return indivs[i].URI < indivs[j].URI })
+		propLabels := map[string]string{}
+		for _, indiv := range indivs {
+			dot.WriteString(fmt.Sprintf("  %s [shape=ellipse, label=%s];\n", dotID(indiv.URI), dotID(dotLabel(indiv.URI, indiv.Label, opts.Language))))
+			types := append([]string{}, indiv.Types...)
+			sort.Strings(types)
+			for _, typ := range types {
+				dot.WriteString(fmt.Sprintf("  %s -> %s [style=dashed];\n", dotID(indiv.URI), dotID(typ)))
+			}
+			if !opts.IncludeObjectProperties {
+				continue
+			}
+			props := []string{}
+			for prop := range indiv.ObjectProperties {
+				props = append(props, prop)
+			}
+			sort.Strings(props)
+			for _, prop := range props {
+				// Lookup property label once
+				if _, ok := propLabels[prop]; !ok {
+					propLabels[prop] = localName(prop)
+					if objProp, err := ont.GetObjectProperty(prop); err == nil {
+						propLabels[prop] = dotLabel(prop, objProp.Label, opts.Language)
+					} else if !errors.Is(err, ErrResourceNotFound) {
+						return err
+					}
+				}
+				targets := append([]string{}, indiv.ObjectProperties[prop]...)
+				sort.Strings(targets)
+				for _, target := range targets {
+					dot.WriteString(fmt.Sprintf("  %s -> %s [label=%s];\n", dotID(indiv.URI), dotID(target), dotID(propLabels[prop])))
+				}
+			}
+		}
+	}
+
+	dot.WriteString("}\n")
+	_, err = io.WriteString(w, dot.String())
+	return err
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// dotID quotes the string as DOT identifier.
+func dotID(s string) string {
+	return `"` + strings.Replace(strings.Replace(s, `\`, `\\`, -1), `"`, `\"`, -1) + `"`
+}
+
+// dotLabel returns the label in the given language or the local name of the URI if there is no such label.
+func dotLabel(uri string, labels map[string]string, lang string) string {
+	if label, ok := labels[lang]; ok && label != "" {
+		return label
+	}
+	return localName(uri)
+}
+
+// localName returns the local name of the URI, i.e. the part after the fragment separator `#` or the last `/`.
+func localName(uri string) string {
+	if i := strings.LastIndex(uri, "#"); i >= 0 {
+		return uri[i+1:]
+	}
+	return uri[strings.LastIndex(uri, "/")+1:]
+}
@@ -2,14 +2,31 @@ package ontograph
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"sync"
+	"time"
 )
 
 // An OntologyGraph represents an ontology backed by a grapg store using a higher abstraction level.
+//
+// An ontology graph is safe for concurrent use if its graph store is (see `SyncStore`), but it must be configured (e.g. with
+// `SetIDGenerator` or `SetTBoxCache`) before it is shared. Methods that change several triples are only atomic within a `Transaction`.
 type OntologyGraph struct {
-	graph   GraphStore
-	label   map[string]string
-	comment map[string]string
+	graph GraphStore
+	// mu guards the label and comment maps and is shared by all copies of the ontology (e.g. bound to a context)
+	mu          *sync.RWMutex
+	label       map[string]string
+	comment     map[string]string
+	tbox        *tboxCache
+	idGen       IDGenerator
+	clock       Clock
+	warnings    WarningCollector
+	signingKey  []byte
+	autoDeclare bool
+	strictTypes bool
+	prefixes    *Prefixes
+	uriMatcher  URIMatcher
 }
 
 // InitOntologyGraph initializes a new ontology on the given graph store as backend and adds
@@ -18,16 +35,12 @@ type OntologyGraph struct {
 // otherwise).
 func InitOntologyGraph(graph GraphStore) (*OntologyGraph, error) {
 	// Check if ontology already exists
-	trp, err := graph.GetFirstMatch(
-		NewResourceTerm(graph.GetURI()).String(),
-		NewResourceTerm(RDFType).String(),
-		NewResourceTerm(OWLOntology).String(),
-	)
+	exists, err := OntologyExists(graph)
 	if err != nil {
 		return nil, err
 	}
-	if trp != nil {
-		return nil, ErrOntologyAlreadyExists
+	if exists {
+		return nil, wrapResourceError("InitOntologyGraph", graph.GetURI(), ErrOntologyAlreadyExists)
 	}
 	// Add ontology definition triples
 	err = graph.AddTripleUnchecked(Triple{
@@ -40,9 +53,13 @@ func InitOntologyGraph(graph GraphStore) (*OntologyGraph, error) {
 	}
 	// Success
 	ont := OntologyGraph{
-		graph:   graph,
-		label:   map[string]string{},
-		comment: map[string]string{},
+		graph:      graph,
+		mu:         &sync.RWMutex{},
+		label:      map[string]string{},
+		comment:    map[string]string{},
+		idGen:      DefaultIDGenerator,
+		clock:      DefaultClock,
+		uriMatcher: DefaultURIMatcher,
 	}
 	return &ont, nil
 }
@@ -52,22 +69,22 @@ func InitOntologyGraph(graph GraphStore) (*OntologyGraph, error) {
 // otherwise to create them).
 func LoadOntologyGraph(graph GraphStore) (*OntologyGraph, error) {
 	// Check if ontology does exists
-	trp, err := graph.GetFirstMatch(
-		NewResourceTerm(graph.GetURI()).String(),
-		NewResourceTerm(RDFType).String(),
-		NewResourceTerm(OWLOntology).String(),
-	)
+	exists, err := OntologyExists(graph)
 	if err != nil {
 		return nil, err
 	}
-	if trp == nil {
-		return nil, ErrOntologyNotFound
+	if !exists {
+		return nil, wrapResourceError("LoadOntologyGraph", graph.GetURI(), ErrOntologyNotFound)
 	}
 	// Success
 	ont := OntologyGraph{
-		graph:   graph,
-		label:   map[string]string{},
-		comment: map[string]string{},
+		graph:      graph,
+		mu:         &sync.RWMutex{},
+		label:      map[string]string{},
+		comment:    map[string]string{},
+		idGen:      DefaultIDGenerator,
+		clock:      DefaultClock,
+		uriMatcher: DefaultURIMatcher,
 	}
 
 	// Retrieve labels (if available)
@@ -98,11 +115,58 @@ func LoadOntologyGraph(graph GraphStore) (*OntologyGraph, error) {
 	return &ont, nil
 }
 
+// OntologyExists checks if the graph store contains the ontology definition of its graph URI, i.e. if the ontology can be loaded with
+// `LoadOntologyGraph` rather than initialized with `InitOntologyGraph`.
+func OntologyExists(graph GraphStore) (bool, error) {
+	trp, err := graph.GetFirstMatch(
+		NewResourceTerm(graph.GetURI()).String(),
+		NewResourceTerm(RDFType).String(),
+		NewResourceTerm(OWLOntology).String(),
+	)
+	if err != nil {
+		return false, err
+	}
+	return trp != nil, nil
+}
+
 // GetURI returns the URI of the ontology
 func (ont *OntologyGraph) GetURI() string {
 	return ont.graph.GetURI()
 }
 
+// SetIDGenerator sets the generator used for new identifiers, i.e. URIs of new resources and labels of new blank nodes (e.g. of
+// restrictions, class expressions or axiom annotations). Use a deterministic generator like `SequentialIDGenerator` in tests to keep the
+// generated data stable. Its IDs must not collide with the blank node labels already in the graph (e.g. `n<ID>` of parsed data).
+func (ont *OntologyGraph) SetIDGenerator(gen IDGenerator) {
+	ont.idGen = gen
+}
+
+// SetClock sets the clock used for timestamps. Use a `FixedClock` in tests to keep the generated data stable.
+func (ont *OntologyGraph) SetClock(clock Clock) {
+	ont.clock = clock
+}
+
+// SetWarningCollector sets the collector that receives warnings about non-fatal issues of upserts (e.g. dropped references or the
+// usage of deprecated resources). Set to nil to disable the warnings again.
+func (ont *OntologyGraph) SetWarningCollector(warnings WarningCollector) {
+	ont.warnings = warnings
+}
+
+// NewResourceURI generates a new unique URI within the namespace of the ontology (see `SetURIMatcher`).
+func (ont *OntologyGraph) NewResourceURI() string {
+	return ont.uriMatcher.ResourceURI(ont.GetURI(), ont.idGen.NewID())
+}
+
+// newBlankNode creates a term for a new blank node with a label drawn from the ID generator of the ontology.
+func (ont *OntologyGraph) newBlankNode() Term {
+	return NewBlankNodeTerm(ont.idGen.NewID())
+}
+
+// Now returns the current time according to the clock of the ontology.
+func (ont *OntologyGraph) Now() time.Time {
+	return ont.clock.Now()
+}
+
 // GetVersion returns the version set for this ontology. If not version is set, the empty string is returned.
 func (ont *OntologyGraph) GetVersion() (string, error) {
 	trp, err := ont.graph.GetFirstMatch(
@@ -161,6 +225,7 @@ func (ont *OntologyGraph) GetImports() ([]string, error) {
 
 // AddImport adds an ontology to the list of imports in the ontology.
 func (ont *OntologyGraph) AddImport(uri string) error {
+	uri = ont.prefixes.Expand(uri)
 	// Get triples with import predicate
 	return ont.graph.AddTriple(Triple{
 		Subject:   NewResourceTerm(ont.GetURI()),
@@ -169,10 +234,29 @@ func (ont *OntologyGraph) AddImport(uri string) error {
 	})
 }
 
+// RemoveImport removes an ontology from the list of imports in the ontology. Errors with `ErrTripleDoesNotExist` if the ontology is
+// not imported.
+func (ont *OntologyGraph) RemoveImport(uri string) error {
+	uri = ont.prefixes.Expand(uri)
+	return ont.graph.DeleteTriple(Triple{
+		Subject:   NewResourceTerm(ont.GetURI()),
+		Predicate: NewResourceTerm(OWLImports),
+		Object:    NewResourceTerm(uri),
+	})
+}
+
+// SetImports replaces the list of imports in the ontology. An empty list removes all imports.
+func (ont *OntologyGraph) SetImports(uris []string) error {
+	uris = ont.expandURIs(uris)
+	return ont.setHeaderURIs(OWLImports, uris...)
+}
+
 // SetLabel sets the ontology label for the specified language code.
 // Any previous set label for the language will be removed.
 // If `label` is empty, the label for the language code will be removed.
 func (ont *OntologyGraph) SetLabel(label, lang string) error {
+	ont.mu.Lock()
+	defer ont.mu.Unlock()
 	// Check if previous label must be removed
 	if val, ok := ont.label[lang]; ok {
 		if err := ont.graph.DeleteTripleUnchecked(Triple{
@@ -200,15 +284,20 @@ func (ont *OntologyGraph) SetLabel(label, lang string) error {
 	return nil
 }
 
-// GetLabel retrieves the ontology label for the specified language code.
-func (ont *OntologyGraph) GetLabel(lang string) string {
-	return ont.label[lang]
+// GetLabel retrieves the ontology label for the first of the specified language codes that has a label, so a fallback chain like
+// `GetLabel("de", "en", AnyLanguage)` prefers German, then English and then any other label (see `AnyLanguage`).
+func (ont *OntologyGraph) GetLabel(langs ...string) string {
+	ont.mu.RLock()
+	defer ont.mu.RUnlock()
+	return pickLanguage(ont.label, langs)
 }
 
 // SetComment sets the ontology comment for the specified language code.
 // Any previous set comment for the language will be removed.
 // If `comment` is empty, the comment for the language code will be removed.
 func (ont *OntologyGraph) SetComment(comment, lang string) error {
+	ont.mu.Lock()
+	defer ont.mu.Unlock()
 	// Check if previous comment must be removed
 	if val, ok := ont.comment[lang]; ok {
 		if err := ont.graph.DeleteTripleUnchecked(Triple{
@@ -236,9 +325,11 @@ func (ont *OntologyGraph) SetComment(comment, lang string) error {
 	return nil
 }
 
-// GetComment retrieves the ontology comment for the specified language code.
-func (ont *OntologyGraph) GetComment(lang string) string {
-	return ont.comment[lang]
+// GetComment retrieves the ontology comment for the first of the specified language codes that has a comment (see `GetLabel`).
+func (ont *OntologyGraph) GetComment(langs ...string) string {
+	ont.mu.RLock()
+	defer ont.mu.RUnlock()
+	return pickLanguage(ont.comment, langs)
 }
 
 // // AddClass adds the given class to the ontology.
@@ -263,48 +354,97 @@ func (ont *OntologyGraph) GetComment(lang string) string {
 // }
 
 // UpsertResource stores the given resource into the graph.
-// Any already stored version of the resources will be deleted.
+// Any already stored version of the resources will be deleted. The resource is replaced within a transaction (see `Transaction`), so
+// it is not left half-written if the update fails.
 func (ont *OntologyGraph) UpsertResource(resource OntologyResource) error {
-	uri := resource.GetURI()
-	if uri[:strings.LastIndex(uri, "#")] != ont.graph.GetURI() {
-		return ErrResourceDoesNotBelongToGraph
+	return ont.Transaction(func(tx *OntologyGraph) error {
+		return tx.upsertResource(resource)
+	})
+}
+
+// upsertResource replaces the stored version of the resource with the given one.
+func (ont *OntologyGraph) upsertResource(resource OntologyResource) error {
+	uri := ont.prefixes.Expand(resource.GetURI())
+	if !ont.isOwnURI(uri) {
+		return wrapResourceError("UpsertResource", uri, ErrResourceDoesNotBelongToGraph)
 	}
-	if err := ont.DeleteResource(resource.GetURI()); err != nil {
-		return err
+	trps := ont.relabelBlankNodes(uriTerm(uri), ont.expandTriples(resource.ToTriples()))
+	indiv, isIndiv := resource.(*OntologyIndividual)
+	if isIndiv && ont.prefixes != nil {
+		// Check and declare the referenced terms of the individual with their full URIs
+		expanded := individualFromTriples(uri, trps)
+		indiv = &expanded
 	}
-	return ont.graph.AddTriplesUnchecked(resource.ToTriples())
+	if isIndiv && ont.strictTypes {
+		if err := ont.validateDomainsAndRanges(indiv); err != nil {
+			return wrapResourceError("UpsertResource", uri, err)
+		}
+	}
+	if ont.warnings != nil {
+		if err := ont.warnUpsert(uri, trps); err != nil {
+			return err
+		}
+	}
+	if err := ont.DeleteResource(uri); err != nil {
+		return wrapResourceError("UpsertResource", uri, err)
+	}
+	if isIndiv && ont.autoDeclare {
+		if err := ont.declareReferencedTerms(indiv); err != nil {
+			return wrapResourceError("UpsertResource", uri, err)
+		}
+	}
+	return wrapResourceError("UpsertResource", uri, ont.graph.AddTriplesUnchecked(trps))
 }
 
-// DeleteResource removes the resource and all its references from the graph.
+// DeleteResource removes the resource and all its references from the graph. Blank nodes of the resource (e.g. restrictions or
+// anonymous individuals backed by blank nodes) are removed as well.
 func (ont *OntologyGraph) DeleteResource(uri string) error {
-	// First delete all triples which have the URI as subject
-	err := ont.graph.DeleteAllMatches(NewResourceTerm(uri).String(), "", "")
-	if err != nil {
-		return err
+	uri = ont.prefixes.Expand(uri)
+	// First delete all triples which have the URI or one of its blank nodes as subject
+	if err := ont.deleteSubjectTriples(uri); err != nil {
+		return wrapResourceError("DeleteResource", uri, err)
 	}
 	// Second delete all triples that reference the URI in their object
-	return ont.graph.DeleteAllMatches("", "", NewResourceTerm(uri).String())
+	return wrapResourceError("DeleteResource", uri, ont.graph.DeleteAllMatches("", "", uriTerm(uri).String()))
 }
 
 // GetClass retrieves the class with the specified URI from the graph.
 func (ont *OntologyGraph) GetClass(uri string) (OntologyClass, error) {
+	uri = ont.prefixes.Expand(uri)
 	// Retrieve all relevant triples
 	trps, err := ont.graph.GetAllMatches(NewResourceTerm(uri).String(), "", "")
 	if err != nil {
-		return OntologyClass{}, err
+		return OntologyClass{}, wrapResourceError("GetClass", uri, err)
 	}
 	// Parse triples into the class structure
 	class := OntologyClass{
-		URI:          "",
-		EquivalentTo: []string{},
-		SubClassOf:   []string{},
-		DisjointWith: []string{},
-		Label:        map[string]string{},
-		Comment:      map[string]string{},
+		URI:                     "",
+		EquivalentTo:            []string{},
+		EquivalentToExpressions: []ClassExpression{},
+		SubClassOf:              []string{},
+		DisjointWith:            []string{},
+		Restrictions:            []OntologyRestriction{},
+		Label:                   map[string]string{},
+		Comment:                 map[string]string{},
+		Annotations:             map[string][]GenericLiteral{},
+	}
+	blankNodeTrps, err := ont.getBlankNodeTriples(trps)
+	if err != nil {
+		return OntologyClass{}, err
 	}
 	for _, trp := range trps {
 		if trp.Predicate == NewResourceTerm(RDFType) && trp.Object == NewResourceTerm(OWLClass) {
 			class.URI = uri
+		} else if trp.Predicate == NewResourceTerm(OWLDeprecated) {
+			class.IsDeprecated = isTrueLiteral(trp.Object)
+		} else if trp.Predicate == NewResourceTerm(RDFSSubClassOf) && trp.Object.IsBlankNode() {
+			if restr, ok := restrictionFromTriples(blankNodeTrps[trp.Object]); ok {
+				class.Restrictions = append(class.Restrictions, restr)
+			}
+		} else if trp.Predicate == NewResourceTerm(OWLEquivalentClass) && trp.Object.IsBlankNode() {
+			if expr, ok := classExpressionFromTerm(trp.Object, blankNodeTrps); ok {
+				class.EquivalentToExpressions = append(class.EquivalentToExpressions, expr)
+			}
 		} else if trp.Predicate == NewResourceTerm(OWLEquivalentClass) {
 			class.EquivalentTo = append(class.EquivalentTo, trp.Object.Value())
 		} else if trp.Predicate == NewResourceTerm(RDFSSubClassOf) {
@@ -315,21 +455,24 @@ func (ont *OntologyGraph) GetClass(uri string) (OntologyClass, error) {
 			class.Label[trp.Object.Language()] = trp.Object.Value()
 		} else if trp.Predicate == NewResourceTerm(RDFSComment) {
 			class.Comment[trp.Object.Language()] = trp.Object.Value()
+		} else if trp.Object.IsLiteral() {
+			class.Annotations[trp.Predicate.Value()] = append(class.Annotations[trp.Predicate.Value()], *NewGenericLiteral(trp.Object))
 		}
 	}
 	// If no URI was set, the requested URI is not a class
 	if class.URI == "" {
-		return OntologyClass{}, ErrResourceNotFound
+		return OntologyClass{}, wrapResourceError("GetClass", uri, ErrResourceNotFound)
 	}
 	return class, nil
 }
 
 // GetObjectProperty retrieves the object property with the specified URI from the graph.
 func (ont *OntologyGraph) GetObjectProperty(uri string) (OntologyObjectProperty, error) {
+	uri = ont.prefixes.Expand(uri)
 	// Retrieve all relevant triples
 	trps, err := ont.graph.GetAllMatches(NewResourceTerm(uri).String(), "", "")
 	if err != nil {
-		return OntologyObjectProperty{}, err
+		return OntologyObjectProperty{}, wrapResourceError("GetObjectProperty", uri, err)
 	}
 	// Parse triples into the object property structure
 	prop := OntologyObjectProperty{
@@ -340,6 +483,7 @@ func (ont *OntologyGraph) GetObjectProperty(uri string) (OntologyObjectProperty,
 		Domains:             []string{},
 		Ranges:              []string{},
 		DisjointWith:        []string{},
+		PropertyChains:      [][]string{},
 		IsFunctional:        false,
 		IsInverseFunctional: false,
 		IsTransitive:        false,
@@ -349,10 +493,17 @@ func (ont *OntologyGraph) GetObjectProperty(uri string) (OntologyObjectProperty,
 		IsIrreflexive:       false,
 		Label:               map[string]string{},
 		Comment:             map[string]string{},
+		Annotations:         map[string][]GenericLiteral{},
+	}
+	blankNodeTrps, err := ont.getBlankNodeTriples(trps)
+	if err != nil {
+		return OntologyObjectProperty{}, err
 	}
 	for _, trp := range trps {
 		if trp.Predicate == NewResourceTerm(RDFType) && trp.Object == NewResourceTerm(OWLObjectProperty) {
 			prop.URI = uri
+		} else if trp.Predicate == NewResourceTerm(OWLDeprecated) {
+			prop.IsDeprecated = isTrueLiteral(trp.Object)
 		} else if trp.Predicate == NewResourceTerm(OWLEquivalentProperty) {
 			prop.EquivalentTo = append(prop.EquivalentTo, trp.Object.Value())
 		} else if trp.Predicate == NewResourceTerm(RDFSSubPropertyOf) {
@@ -363,6 +514,12 @@ func (ont *OntologyGraph) GetObjectProperty(uri string) (OntologyObjectProperty,
 			prop.Domains = append(prop.Domains, trp.Object.Value())
 		} else if trp.Predicate == NewResourceTerm(RDFSRange) {
 			prop.Ranges = append(prop.Ranges, trp.Object.Value())
+		} else if trp.Predicate == NewResourceTerm(OWLPropertyChainAxiom) {
+			chain := []string{}
+			for _, item := range rdfList(trp.Object, blankNodeTrps) {
+				chain = append(chain, item.Value())
+			}
+			prop.PropertyChains = append(prop.PropertyChains, chain)
 		} else if trp.Predicate == NewResourceTerm(OWLPropertyDisjointWith) {
 			prop.DisjointWith = append(prop.DisjointWith, trp.Object.Value())
 		} else if trp.Predicate == NewResourceTerm(RDFType) && trp.Object == NewResourceTerm(OWLFunctionalProperty) {
@@ -383,21 +540,24 @@ func (ont *OntologyGraph) GetObjectProperty(uri string) (OntologyObjectProperty,
 			prop.Label[trp.Object.Language()] = trp.Object.Value()
 		} else if trp.Predicate == NewResourceTerm(RDFSComment) {
 			prop.Comment[trp.Object.Language()] = trp.Object.Value()
+		} else if trp.Object.IsLiteral() {
+			prop.Annotations[trp.Predicate.Value()] = append(prop.Annotations[trp.Predicate.Value()], *NewGenericLiteral(trp.Object))
 		}
 	}
 	// If no URI was set, the requested URI is not an object property
 	if prop.URI == "" {
-		return OntologyObjectProperty{}, ErrResourceNotFound
+		return OntologyObjectProperty{}, wrapResourceError("GetObjectProperty", uri, ErrResourceNotFound)
 	}
 	return prop, nil
 }
 
 // GetDataProperty retrieves the data property with the specified URI from the graph.
 func (ont *OntologyGraph) GetDataProperty(uri string) (OntologyDataProperty, error) {
+	uri = ont.prefixes.Expand(uri)
 	// Retrieve all relevant triples
 	trps, err := ont.graph.GetAllMatches(NewResourceTerm(uri).String(), "", "")
 	if err != nil {
-		return OntologyDataProperty{}, err
+		return OntologyDataProperty{}, wrapResourceError("GetDataProperty", uri, err)
 	}
 	// Parse triples into the object property structure
 	prop := OntologyDataProperty{
@@ -410,10 +570,13 @@ func (ont *OntologyGraph) GetDataProperty(uri string) (OntologyDataProperty, err
 		IsFunctional:  false,
 		Label:         map[string]string{},
 		Comment:       map[string]string{},
+		Annotations:   map[string][]GenericLiteral{},
 	}
 	for _, trp := range trps {
 		if trp.Predicate == NewResourceTerm(RDFType) && trp.Object == NewResourceTerm(OWLDatatypeProperty) {
 			prop.URI = uri
+		} else if trp.Predicate == NewResourceTerm(OWLDeprecated) {
+			prop.IsDeprecated = isTrueLiteral(trp.Object)
 		} else if trp.Predicate == NewResourceTerm(OWLEquivalentProperty) {
 			prop.EquivalentTo = append(prop.EquivalentTo, trp.Object.Value())
 		} else if trp.Predicate == NewResourceTerm(RDFSSubPropertyOf) {
@@ -430,27 +593,31 @@ func (ont *OntologyGraph) GetDataProperty(uri string) (OntologyDataProperty, err
 			prop.Label[trp.Object.Language()] = trp.Object.Value()
 		} else if trp.Predicate == NewResourceTerm(RDFSComment) {
 			prop.Comment[trp.Object.Language()] = trp.Object.Value()
+		} else if trp.Object.IsLiteral() {
+			prop.Annotations[trp.Predicate.Value()] = append(prop.Annotations[trp.Predicate.Value()], *NewGenericLiteral(trp.Object))
 		}
 	}
 	// If no URI was set, the requested URI is not an object property
 	if prop.URI == "" {
-		return OntologyDataProperty{}, ErrResourceNotFound
+		return OntologyDataProperty{}, wrapResourceError("GetDataProperty", uri, ErrResourceNotFound)
 	}
 	return prop, nil
 }
 
 // GetDatatype retrieves the datatype with the specified URI from the graph.
 func (ont *OntologyGraph) GetDatatype(uri string) (OntologyDatatype, error) {
+	uri = ont.prefixes.Expand(uri)
 	// Retrieve all relevant triples
 	trps, err := ont.graph.GetAllMatches(NewResourceTerm(uri).String(), "", "")
 	if err != nil {
-		return OntologyDatatype{}, err
+		return OntologyDatatype{}, wrapResourceError("GetDatatype", uri, err)
 	}
 	// Parse triples into the object property structure
 	prop := OntologyDatatype{
-		URI:     "",
-		Label:   map[string]string{},
-		Comment: map[string]string{},
+		URI:         "",
+		Label:       map[string]string{},
+		Comment:     map[string]string{},
+		Annotations: map[string][]GenericLiteral{},
 	}
 	for _, trp := range trps {
 		if trp.Predicate == NewResourceTerm(RDFType) && trp.Object == NewResourceTerm(RDFSDatatype) {
@@ -459,56 +626,75 @@ func (ont *OntologyGraph) GetDatatype(uri string) (OntologyDatatype, error) {
 			prop.Label[trp.Object.Language()] = trp.Object.Value()
 		} else if trp.Predicate == NewResourceTerm(RDFSComment) {
 			prop.Comment[trp.Object.Language()] = trp.Object.Value()
+		} else if trp.Object.IsLiteral() {
+			prop.Annotations[trp.Predicate.Value()] = append(prop.Annotations[trp.Predicate.Value()], *NewGenericLiteral(trp.Object))
 		}
 	}
 	// If no URI was set, the requested URI is not an object property
 	if prop.URI == "" {
-		return OntologyDatatype{}, ErrResourceNotFound
+		return OntologyDatatype{}, wrapResourceError("GetDatatype", uri, ErrResourceNotFound)
 	}
 	return prop, nil
 }
 
-// GetIndividual retrieves the individual with the specified URI from the graph.
-func (ont *OntologyGraph) GetIndividual(uri string) (OntologyIndividual, error) {
+// GetAnnotationProperty retrieves the annotation property with the specified URI from the graph.
+func (ont *OntologyGraph) GetAnnotationProperty(uri string) (OntologyAnnotationProperty, error) {
+	uri = ont.prefixes.Expand(uri)
 	// Retrieve all relevant triples
 	trps, err := ont.graph.GetAllMatches(NewResourceTerm(uri).String(), "", "")
 	if err != nil {
-		return OntologyIndividual{}, err
+		return OntologyAnnotationProperty{}, wrapResourceError("GetAnnotationProperty", uri, err)
 	}
-	// Parse triples into the individual structure
-	indiv := OntologyIndividual{
-		URI:              "",
-		Types:            []string{},
-		SameIndividualAs: []string{},
-		ObjectProperties: map[string][]string{},
-		DataProperties:   map[string][]GenericLiteral{},
-		Label:            map[string]string{},
-		Comment:          map[string]string{},
+	// Parse triples into the annotation property structure
+	prop := OntologyAnnotationProperty{
+		URI:           "",
+		SubPropertyOf: []string{},
+		Domains:       []string{},
+		Ranges:        []string{},
+		Label:         map[string]string{},
+		Comment:       map[string]string{},
+		Annotations:   map[string][]GenericLiteral{},
 	}
 	for _, trp := range trps {
-		if trp.Predicate == NewResourceTerm(RDFType) && trp.Object == NewResourceTerm(OWLNamedIndividual) {
-			indiv.URI = uri
-		} else if trp.Predicate == NewResourceTerm(RDFType) {
-			indiv.Types = append(indiv.Types, trp.Object.Value())
-		} else if trp.Predicate == NewResourceTerm(OWLSameAs) {
-			indiv.SameIndividualAs = append(indiv.SameIndividualAs, trp.Object.Value())
+		if trp.Predicate == NewResourceTerm(RDFType) && trp.Object == NewResourceTerm(OWLAnnotationProperty) {
+			prop.URI = uri
+		} else if trp.Predicate == NewResourceTerm(OWLDeprecated) {
+			prop.IsDeprecated = isTrueLiteral(trp.Object)
+		} else if trp.Predicate == NewResourceTerm(RDFSSubPropertyOf) {
+			prop.SubPropertyOf = append(prop.SubPropertyOf, trp.Object.Value())
+		} else if trp.Predicate == NewResourceTerm(RDFSDomain) {
+			prop.Domains = append(prop.Domains, trp.Object.Value())
+		} else if trp.Predicate == NewResourceTerm(RDFSRange) {
+			prop.Ranges = append(prop.Ranges, trp.Object.Value())
 		} else if trp.Predicate == NewResourceTerm(RDFSLabel) {
-			indiv.Label[trp.Object.Language()] = trp.Object.Value()
+			prop.Label[trp.Object.Language()] = trp.Object.Value()
 		} else if trp.Predicate == NewResourceTerm(RDFSComment) {
-			indiv.Comment[trp.Object.Language()] = trp.Object.Value()
-		} else {
-			obj := trp.Object
-			prop := trp.Predicate.Value()
-			if obj.IsResource() {
-				indiv.ObjectProperties[prop] = append(indiv.ObjectProperties[prop], obj.Value())
-			} else if obj.IsLiteral() {
-				indiv.DataProperties[prop] = append(indiv.DataProperties[prop], *NewGenericLiteral(obj))
-			}
+			prop.Comment[trp.Object.Language()] = trp.Object.Value()
+		} else if trp.Object.IsLiteral() {
+			prop.Annotations[trp.Predicate.Value()] = append(prop.Annotations[trp.Predicate.Value()], *NewGenericLiteral(trp.Object))
 		}
 	}
+	// If no URI was set, the requested URI is not an annotation property
+	if prop.URI == "" {
+		return OntologyAnnotationProperty{}, wrapResourceError("GetAnnotationProperty", uri, ErrResourceNotFound)
+	}
+	return prop, nil
+}
+
+// GetIndividual retrieves the individual with the specified URI from the graph. Anonymous individuals are retrieved by their blank
+// node label (e.g. `_:b1`) or skolem URI.
+func (ont *OntologyGraph) GetIndividual(uri string) (OntologyIndividual, error) {
+	uri = ont.prefixes.Expand(uri)
+	// Retrieve all relevant triples
+	trps, err := ont.graph.GetAllMatches(uriTerm(uri).String(), "", "")
+	if err != nil {
+		return OntologyIndividual{}, wrapResourceError("GetIndividual", uri, err)
+	}
+	// Parse triples into the individual structure
+	indiv := individualFromTriples(uri, trps)
 	// If no URI was set, the requested URI is not an individual
 	if indiv.URI == "" {
-		return OntologyIndividual{}, ErrResourceNotFound
+		return OntologyIndividual{}, wrapResourceError("GetIndividual", uri, ErrResourceNotFound)
 	}
 	return indiv, nil
 }
@@ -528,76 +714,18 @@ func (ont *OntologyGraph) GetIndividual(uri string) (OntologyIndividual, error)
 //	indivs, err := ont.GetIndividuals(filter)
 // `
 // will retrieve all individuals that have either class1 and class2 or class1 and class3.
-// TODO: Add filter parameter to GetAllMatches in order to improve performance.
+// Data property filters may also match any value or values that match a regular expression or contain a substring
+// (e.g. `filter.AndWithDataPropertyContains("name", "Ann")`). Errors with `ErrInvalidFilter` if a regular expression is malformed.
+// Stores that implement `FilterMatcher` evaluate the whole filter and return the triples of all matches in a single round-trip.
 func (ont *OntologyGraph) GetIndividuals(filters TripleFilter) ([]OntologyIndividual, error) {
-	candidates := []string{}
-	if filters == nil || len(filters) == 0 {
-		// Add all individuals as candidates if no filter was supplied
-		trps, err := ont.graph.GetAllMatches("", NewResourceTerm(RDFType).String(), NewResourceTerm(OWLNamedIndividual).String())
-		if err != nil {
-			return nil, err
-		}
-		for _, trp := range trps {
-			candidates = append(candidates, trp.Subject.Value())
-		}
-	} else {
-		// Apply all filter triples in OR fashion
-		for _, filterTrps := range filters {
-			// Create AND-candidate pool
-			var andCandidates []string = nil
-			for _, filterTrp := range filterTrps {
-				trps, err := ont.graph.GetAllMatches(filterTrp.Subject.String(), filterTrp.Predicate.String(), filterTrp.Object.String())
-				if err != nil {
-					return nil, err
-				}
-				// If its the first set of matches, initialize AND-candidate pool
-				if andCandidates == nil {
-					andCandidates = []string{}
-					for _, trp := range trps {
-						andCandidates = append(andCandidates, trp.Subject.Value())
-					}
-				} else {
-					// Otherwise, intersect results with the current AND-candidates
-					newCandidates := []string{}
-					for _, trp := range trps {
-						cand := trp.Subject.Value()
-						found := false
-						for _, current := range andCandidates {
-							if current == cand {
-								found = true
-								break
-							}
-						}
-						// If candidate was found in the AND-candidate pool, we can keep it
-						if found {
-							newCandidates = append(newCandidates, cand)
-						}
-					}
-					// Updated AND-candidate pool
-					andCandidates = newCandidates
-				}
-				// Shortcut AND-evaluation if the pool is empty
-				if len(andCandidates) == 0 {
-					break
-				}
-			}
-			// Add all AND-candidates to OR-list (if not already present)
-			for _, cand := range andCandidates {
-				duplicate := false
-				for _, c := range candidates {
-					if c == cand {
-						duplicate = true
-						break
-					}
-				}
-				if !duplicate {
-					candidates = append(candidates, cand)
-				}
-			}
-		}
-
+	filters = ont.expandFilter(filters)
+	if matcher, ok := ont.graph.(FilterMatcher); ok {
+		return ont.getFilteredIndividuals(matcher, filters)
+	}
+	candidates, err := ont.getIndividualURIs(filters)
+	if err != nil {
+		return nil, err
 	}
-
 	// Load all individuals
 	indivs := []OntologyIndividual{}
 	for _, uri := range candidates {
@@ -618,7 +746,7 @@ func (ont *OntologyGraph) GetIndividuals(filters TripleFilter) ([]OntologyIndivi
 
 // TripleFilter represents a triple filtering structure where the inner list filters
 // in AND fashion and the outer list in OR fashion.
-type TripleFilter [][]Triple
+type TripleFilter [][]FilterTriple
 
 // OrWithClass returns a generic triple filter that returns all
 // individuals that have the given class. The class filter is appended
@@ -629,7 +757,7 @@ func (filter TripleFilter) OrWithClass(classURI string) TripleFilter {
 		Predicate: NewResourceTerm(RDFType),
 		Object:    NewResourceTerm(classURI),
 	}
-	filter = append(filter, []Triple{filterTrp})
+	filter = append(filter, []FilterTriple{{Triple: filterTrp}})
 
 	return filter
 }
@@ -645,9 +773,9 @@ func (filter TripleFilter) AndWithClass(classURI string) TripleFilter {
 	}
 	// Append to last OR filter in the list
 	if len(filter) == 0 {
-		filter = append(filter, []Triple{})
+		filter = append(filter, []FilterTriple{})
 	}
-	filter[len(filter)-1] = append(filter[len(filter)-1], filterTrp)
+	filter[len(filter)-1] = append(filter[len(filter)-1], FilterTriple{Triple: filterTrp})
 
 	return filter
 }
@@ -661,7 +789,7 @@ func (filter TripleFilter) OrWithObjectProperty(propertyURI, objectURI string) T
 		Predicate: NewResourceTerm(propertyURI),
 		Object:    NewResourceTerm(objectURI),
 	}
-	filter = append(filter, []Triple{filterTrp})
+	filter = append(filter, []FilterTriple{{Triple: filterTrp}})
 	return filter
 }
 
@@ -676,9 +804,9 @@ func (filter TripleFilter) AndWithObjectProperty(propertyURI, objectURI string)
 	}
 	// Append to last OR filter in the list
 	if len(filter) == 0 {
-		filter = append(filter, []Triple{})
+		filter = append(filter, []FilterTriple{})
 	}
-	filter[len(filter)-1] = append(filter[len(filter)-1], filterTrp)
+	filter[len(filter)-1] = append(filter[len(filter)-1], FilterTriple{Triple: filterTrp})
 
 	return filter
 }
@@ -692,7 +820,7 @@ func (filter TripleFilter) OrWithDataProperty(propertyURI string, literal Generi
 		Predicate: NewResourceTerm(propertyURI),
 		Object:    literal.Term(),
 	}
-	filter = append(filter, []Triple{filterTrp})
+	filter = append(filter, []FilterTriple{{Triple: filterTrp}})
 	return filter
 }
 
@@ -707,9 +835,9 @@ func (filter TripleFilter) AndWithDataProperty(propertyURI string, literal Gener
 	}
 	// Append to last OR filter in the list
 	if len(filter) == 0 {
-		filter = append(filter, []Triple{})
+		filter = append(filter, []FilterTriple{})
 	}
-	filter[len(filter)-1] = append(filter[len(filter)-1], filterTrp)
+	filter[len(filter)-1] = append(filter[len(filter)-1], FilterTriple{Triple: filterTrp})
 
 	return filter
 }
@@ -727,5 +855,295 @@ var ErrOntologyAlreadyExists error = errors.New("The requested ontology already
 // ErrResourceNotFound is raised on conflict errors when a triple already exists (i.e. adding triples).
 var ErrResourceNotFound error = errors.New("The requested ontology resource does not exist in the graph")
 
+// ErrResourceAlreadyExists is raised when a resource is attempted to be created with a URI that is already used in the graph.
+var ErrResourceAlreadyExists error = errors.New("The resource already exists in the graph")
+
+// ErrResourceReferenced is raised when a resource is attempted to be deleted, but other resources still reference it.
+var ErrResourceReferenced error = errors.New("The resource is still referenced by other resources")
+
+// ErrMergeConflict is raised when a resource is defined differently in the destination and a source ontology of a merge.
+var ErrMergeConflict error = errors.New("The resource is defined differently in the merged ontologies")
+
 // ErrResourceDoesNotBelongToGraph is raised when a resource is attempted to be added to the graph, but their base URIs do not match.
 var ErrResourceDoesNotBelongToGraph error = errors.New("The URI of the resource does not match the URI of the graph")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// warnUpsert reports the references to the resource that will be dropped by the upsert and the deprecated resources the new triples refer to.
+func (ont *OntologyGraph) warnUpsert(uri string, trps []Triple) error {
+	// Report references from other resources, which are deleted together with the old version of the resource
+	refs, err := ont.graph.GetAllMatches("", "", uriTerm(uri).String())
+	if err != nil {
+		return err
+	}
+	SortTriples(refs)
+	for _, ref := range refs {
+		if ref.Subject != uriTerm(uri) {
+			ont.warnings.Warn(Warning{Triple: ref, Err: ErrDroppedTriple})
+		}
+	}
+	// Report references to deprecated resources
+	deprecated := map[Term]bool{}
+	for _, trp := range trps {
+		for _, t := range []Term{trp.Predicate, trp.Object} {
+			if !t.IsResource() {
+				continue
+			}
+			if _, ok := deprecated[t]; !ok {
+				match, err := ont.graph.GetFirstMatch(t.String(), NewResourceTerm(OWLDeprecated).String(), NewLiteralTerm("true", "", XSDBoolean).String())
+				if err != nil {
+					return err
+				}
+				deprecated[t] = match != nil
+			}
+			if deprecated[t] {
+				ont.warnings.Warn(Warning{Triple: trp, Err: fmt.Errorf("%w: %s", ErrDeprecatedTerm, t)})
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// deleteSubjectTriples removes all triples which have the URI or one of its blank nodes as subject.
+func (ont *OntologyGraph) deleteSubjectTriples(uri string) error {
+	trps, err := ont.graph.GetAllMatches(uriTerm(uri).String(), "", "")
+	if err != nil {
+		return err
+	}
+	blankNodeTrps, err := ont.getBlankNodeTriples(trps)
+	if err != nil {
+		return err
+	}
+	if err := ont.graph.DeleteTriplesUnchecked(blankNodeClosure(trps, blankNodeTrps)); err != nil {
+		return err
+	}
+	return ont.graph.DeleteAllMatches(uriTerm(uri).String(), "", "")
+}
+
+// isOwnURI returns true if the URI belongs to the namespace of the ontology according to its URI matcher. Blank node labels of anonymous
+// resources belong to every ontology.
+func (ont *OntologyGraph) isOwnURI(uri string) bool {
+	if uriTerm(uri).IsBlankNode() || strings.HasPrefix(uri, ont.GetURI()+SkolemPathSegment) {
+		return true
+	}
+	return ont.uriMatcher.MatchesURI(ont.GetURI(), uri)
+}
+
+// getIndividualURIs returns the URIs of the individuals that match the filters (see `GetIndividuals`).
+func (ont *OntologyGraph) getIndividualURIs(filters TripleFilter) ([]string, error) {
+	candidates := []string{}
+	if filters == nil || len(filters) == 0 {
+		// Add all individuals as candidates if no filter was supplied
+		trps, err := ont.graph.GetAllMatches("", NewResourceTerm(RDFType).String(), NewResourceTerm(OWLNamedIndividual).String())
+		if err != nil {
+			return nil, err
+		}
+		for _, trp := range trps {
+			candidates = append(candidates, trp.Subject.Value())
+		}
+	} else {
+		// Apply all filter triples in OR fashion
+		for _, filterTrps := range filters {
+			// Create AND-candidate pool
+			var andCandidates []string = nil
+			for _, filterTrp := range filterTrps {
+				trps, err := ont.getFilterMatches(filterTrp)
+				if err != nil {
+					return nil, err
+				}
+				// If its the first set of matches, initialize AND-candidate pool
+				if andCandidates == nil {
+					andCandidates = []string{}
+					for _, trp := range trps {
+						andCandidates = append(andCandidates, trp.Subject.Value())
+					}
+				} else {
+					// Otherwise, intersect results with the current AND-candidates
+					newCandidates := []string{}
+					for _, trp := range trps {
+						cand := trp.Subject.Value()
+						found := false
+						for _, current := range andCandidates {
+							if current == cand {
+								found = true
+								break
+							}
+						}
+						// If candidate was found in the AND-candidate pool, we can keep it
+						if found {
+							newCandidates = append(newCandidates, cand)
+						}
+					}
+					// Updated AND-candidate pool
+					andCandidates = newCandidates
+				}
+				// Shortcut AND-evaluation if the pool is empty
+				if len(andCandidates) == 0 {
+					break
+				}
+			}
+			// Add all AND-candidates to OR-list (if not already present)
+			for _, cand := range andCandidates {
+				duplicate := false
+				for _, c := range candidates {
+					if c == cand {
+						duplicate = true
+						break
+					}
+				}
+				if !duplicate {
+					candidates = append(candidates, cand)
+				}
+			}
+		}
+
+	}
+	return candidates, nil
+}
+
+// individualFromTriples parses the triples of the resource into the individual structure. The URI of the individual remains empty if
+// the resource is not typed as named individual (or has no triples at all if it is anonymous).
+func individualFromTriples(uri string, trps []Triple) OntologyIndividual {
+	indiv := OntologyIndividual{
+		URI:              "",
+		Types:            []string{},
+		SameIndividualAs: []string{},
+		ObjectProperties: map[string][]string{},
+		DataProperties:   map[string][]GenericLiteral{},
+		Label:            map[string]string{},
+		Comment:          map[string]string{},
+	}
+	if isAnonymousURI(uri) && len(trps) > 0 {
+		indiv.URI = uri
+	}
+	for _, trp := range trps {
+		if trp.Predicate == NewResourceTerm(RDFType) && trp.Object == NewResourceTerm(OWLNamedIndividual) {
+			indiv.URI = uri
+		} else if trp.Predicate == NewResourceTerm(OWLDeprecated) {
+			indiv.IsDeprecated = isTrueLiteral(trp.Object)
+		} else if trp.Predicate == NewResourceTerm(RDFType) {
+			indiv.Types = append(indiv.Types, trp.Object.Value())
+		} else if trp.Predicate == NewResourceTerm(OWLSameAs) {
+			indiv.SameIndividualAs = append(indiv.SameIndividualAs, trp.Object.Value())
+		} else if trp.Predicate == NewResourceTerm(RDFSLabel) {
+			indiv.Label[trp.Object.Language()] = trp.Object.Value()
+		} else if trp.Predicate == NewResourceTerm(RDFSComment) {
+			indiv.Comment[trp.Object.Language()] = trp.Object.Value()
+		} else {
+			obj := trp.Object
+			prop := trp.Predicate.Value()
+			if obj.IsResource() {
+				indiv.ObjectProperties[prop] = append(indiv.ObjectProperties[prop], obj.Value())
+			} else if obj.IsBlankNode() {
+				indiv.ObjectProperties[prop] = append(indiv.ObjectProperties[prop], obj.String())
+			} else if obj.IsLiteral() {
+				indiv.DataProperties[prop] = append(indiv.DataProperties[prop], *NewGenericLiteral(obj))
+			}
+		}
+	}
+	return indiv
+}
+
+// getFilteredIndividuals retrieves the individuals that match the filters with a single request to the filter matcher of the store.
+func (ont *OntologyGraph) getFilteredIndividuals(matcher FilterMatcher, filters TripleFilter) ([]OntologyIndividual, error) {
+	trps, err := matcher.GetFilteredTriples(filters)
+	if err != nil {
+		return nil, err
+	}
+	// Group triples by subject (in order of their first appearance)
+	subjects := []Term{}
+	bySubject := map[Term][]Triple{}
+	for _, trp := range trps {
+		if _, ok := bySubject[trp.Subject]; !ok {
+			subjects = append(subjects, trp.Subject)
+		}
+		bySubject[trp.Subject] = append(bySubject[trp.Subject], trp)
+	}
+	indivs := []OntologyIndividual{}
+	for _, subj := range subjects {
+		indiv := individualFromTriples(subj.Value(), bySubject[subj])
+		if indiv.URI == "" {
+			return indivs, wrapResourceError("GetIndividual", subj.Value(), ErrResourceNotFound)
+		}
+		indivs = append(indivs, indiv)
+	}
+	return indivs, nil
+}
+
+// relabelBlankNodes relabels the blank nodes that are described by the triples of a resource (i.e. its blank node subjects, like
+// restrictions) with new blank nodes of the ontology (see `newBlankNode`), since resources create them with random labels. The
+// resource itself keeps its term, even if it is a blank node.
+func (ont *OntologyGraph) relabelBlankNodes(resource Term, trps []Triple) []Triple {
+	labels := map[Term]Term{}
+	for _, trp := range trps {
+		if trp.Subject.IsBlankNode() && trp.Subject != resource {
+			labels[trp.Subject] = ""
+		}
+	}
+	if len(labels) == 0 {
+		return trps
+	}
+	relabel := func(t Term) Term {
+		label, ok := labels[t]
+		if !ok {
+			return t
+		}
+		if label == "" {
+			label = ont.newBlankNode()
+			labels[t] = label
+		}
+		return label
+	}
+	relabeled := make([]Triple, len(trps))
+	for i, trp := range trps {
+		relabeled[i] = Triple{Subject: relabel(trp.Subject), Predicate: trp.Predicate, Object: relabel(trp.Object)}
+	}
+	return relabeled
+}
+
+// getBlankNodeTriples returns the triples of all blank nodes that are reachable from the triples via blank node objects, grouped by
+// subject. The blank nodes are queried level by level, so only the reachable ones are retrieved from the store.
+func (ont *OntologyGraph) getBlankNodeTriples(trps []Triple) (map[Term][]Triple, error) {
+	bySubject := map[Term][]Triple{}
+	level := trps
+	for len(level) > 0 {
+		next := []Triple{}
+		for _, trp := range level {
+			if !trp.Object.IsBlankNode() {
+				continue
+			}
+			if _, ok := bySubject[trp.Object]; ok {
+				continue
+			}
+			nodeTrps, err := ont.graph.GetAllMatches(trp.Object.String(), "", "")
+			if err != nil {
+				return nil, err
+			}
+			bySubject[trp.Object] = nodeTrps
+			next = append(next, nodeTrps...)
+		}
+		level = next
+	}
+	return bySubject, nil
+}
+
+// blankNodeClosure returns the triples of all blank nodes that are reachable from the triples via blank node objects.
+func blankNodeClosure(trps []Triple, blankNodeTrps map[Term][]Triple) []Triple {
+	closure := []Triple{}
+	visited := map[Term]bool{}
+	queue := append([]Triple{}, trps...)
+	for len(queue) > 0 {
+		trp := queue[0]
+		queue = queue[1:]
+		if !trp.Object.IsBlankNode() || visited[trp.Object] {
+			continue
+		}
+		visited[trp.Object] = true
+		closure = append(closure, blankNodeTrps[trp.Object]...)
+		queue = append(queue, blankNodeTrps[trp.Object]...)
+	}
+	return closure
+}
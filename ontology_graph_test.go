@@ -2,6 +2,7 @@ package ontograph_test
 
 import (
     "fmt"
+    "strings"
 
     "github.com/lithammer/shortuuid"
     . "github.com/onsi/ginkgo"
@@ -173,6 +174,36 @@ var _ = Describe("OntologyGraph", func() {
         })
     })
 
+    Describe("Removing and replacing imports of the ontology", func() {
+        BeforeEach(func() {
+            Expect(ont.AddImport("http://abc-1.com")).To(Succeed())
+            Expect(ont.AddImport("http://abc-2.com")).To(Succeed())
+        })
+        It("should remove the URI from the list of imports in the ontology", func() {
+            err := ont.RemoveImport("http://abc-1.com")
+            Expect(err).NotTo(HaveOccurred())
+            uris, err := ont.GetImports()
+            Expect(err).NotTo(HaveOccurred())
+            Expect(uris).To(ConsistOf("http://abc-2.com"))
+        })
+        It("should fail to remove an URI that is not imported", func() {
+            err := ont.RemoveImport("http://abc-3.com")
+            Expect(err).To(HaveOccurred())
+        })
+        It("should replace the list of imports in the ontology", func() {
+            err := ont.SetImports([]string{"http://abc-2.com", "http://abc-3.com"})
+            Expect(err).NotTo(HaveOccurred())
+            uris, err := ont.GetImports()
+            Expect(err).NotTo(HaveOccurred())
+            Expect(uris).To(ConsistOf("http://abc-2.com", "http://abc-3.com"))
+            err = ont.SetImports(nil)
+            Expect(err).NotTo(HaveOccurred())
+            uris, err = ont.GetImports()
+            Expect(err).NotTo(HaveOccurred())
+            Expect(uris).To(BeEmpty())
+        })
+    })
+
     Describe("Adding and retrieving an ontology class", func() {
         When("the class belongs to the graph", func() {
             It("should successfully add the class to the store", func() {
@@ -205,10 +236,10 @@ var _ = Describe("OntologyGraph", func() {
                 }
                 err := ont.UpsertResource(&class)
                 By("raising the expected error")
-                Expect(err).To(Equal(ErrResourceDoesNotBelongToGraph))
+                Expect(err).To(MatchError(ErrResourceDoesNotBelongToGraph))
                 By("not having stored the class")
                 _, err = ont.GetClass(class.URI)
-                Expect(err).To(Equal(ErrResourceNotFound))
+                Expect(err).To(MatchError(ErrResourceNotFound))
             })
         })
     })
@@ -265,10 +296,10 @@ var _ = Describe("OntologyGraph", func() {
                 }
                 err := ont.UpsertResource(&prop)
                 By("raising the expected error")
-                Expect(err).To(Equal(ErrResourceDoesNotBelongToGraph))
+                Expect(err).To(MatchError(ErrResourceDoesNotBelongToGraph))
                 By("not having stored the object property")
                 _, err = ont.GetObjectProperty(prop.URI)
-                Expect(err).To(Equal(ErrResourceNotFound))
+                Expect(err).To(MatchError(ErrResourceNotFound))
             })
         })
     })
@@ -311,10 +342,10 @@ var _ = Describe("OntologyGraph", func() {
                 }
                 err := ont.UpsertResource(&prop)
                 By("raising the expected error")
-                Expect(err).To(Equal(ErrResourceDoesNotBelongToGraph))
+                Expect(err).To(MatchError(ErrResourceDoesNotBelongToGraph))
                 By("not having stored the object property")
                 _, err = ont.GetObjectProperty(prop.URI)
-                Expect(err).To(Equal(ErrResourceNotFound))
+                Expect(err).To(MatchError(ErrResourceNotFound))
             })
         })
     })
@@ -357,10 +388,10 @@ var _ = Describe("OntologyGraph", func() {
                 }
                 err := ont.UpsertResource(&prop)
                 By("raising the expected error")
-                Expect(err).To(Equal(ErrResourceDoesNotBelongToGraph))
+                Expect(err).To(MatchError(ErrResourceDoesNotBelongToGraph))
                 By("not having stored the data property")
                 _, err = ont.GetObjectProperty(prop.URI)
-                Expect(err).To(Equal(ErrResourceNotFound))
+                Expect(err).To(MatchError(ErrResourceNotFound))
             })
         })
     })
@@ -391,10 +422,10 @@ var _ = Describe("OntologyGraph", func() {
                 }
                 err := ont.UpsertResource(&datatype)
                 By("raising the expected error")
-                Expect(err).To(Equal(ErrResourceDoesNotBelongToGraph))
+                Expect(err).To(MatchError(ErrResourceDoesNotBelongToGraph))
                 By("not having stored the datatype")
                 _, err = ont.GetDatatype(datatype.URI)
-                Expect(err).To(Equal(ErrResourceNotFound))
+                Expect(err).To(MatchError(ErrResourceNotFound))
             })
         })
     })
@@ -430,10 +461,10 @@ var _ = Describe("OntologyGraph", func() {
                 }
                 err := ont.UpsertResource(&indiv)
                 By("raising the expected error")
-                Expect(err).To(Equal(ErrResourceDoesNotBelongToGraph))
+                Expect(err).To(MatchError(ErrResourceDoesNotBelongToGraph))
                 By("not having stored the individual")
                 _, err = ont.GetIndividual(indiv.URI)
-                Expect(err).To(Equal(ErrResourceNotFound))
+                Expect(err).To(MatchError(ErrResourceNotFound))
             })
         })
     })
@@ -603,6 +634,41 @@ var _ = Describe("OntologyGraph", func() {
                 checkIndividuals(indivs[0], indiv3)
             })
         })
+        When("filtered by data property values", func() {
+            It("should match any value of the property", func() {
+                filter = filter.AndWithDataPropertyAny("http://abc.com#dataprop1")
+                filter = filter.OrWithDataPropertyAny("http://abc.com#dataprop2")
+                indivs, err := ont.GetIndividuals(filter)
+                Expect(err).NotTo(HaveOccurred())
+                Expect(len(indivs)).To(Equal(2))
+            })
+            It("should match substrings and regular expressions of literal values", func() {
+                indivs, err := ont.GetIndividuals(filter.AndWithDataPropertyContains("http://abc.com#dataprop1", "string lit"))
+                Expect(err).NotTo(HaveOccurred())
+                Expect(len(indivs)).To(Equal(1))
+                Expect(indivs[0].URI).To(Equal(indiv1.URI))
+                indivs, err = ont.GetIndividuals(TripleFilter{}.AndWithDataPropertyRegex("http://abc.com#dataprop2", "^4[0-9]$"))
+                Expect(err).NotTo(HaveOccurred())
+                Expect(len(indivs)).To(Equal(1))
+                Expect(indivs[0].URI).To(Equal(indiv3.URI))
+                indivs, err = ont.GetIndividuals(TripleFilter{}.AndWithDataPropertyRegex("http://abc.com#dataprop1", "(?i)^some").AndWithClass("http://abc.com#type2"))
+                Expect(err).NotTo(HaveOccurred())
+                Expect(indivs).To(BeEmpty())
+            })
+            It("should match numeric ranges", func() {
+                indivs, err := ont.GetIndividuals(filter.AndWithDataPropertyRange("http://abc.com#dataprop2", XSDIntegerLiteral(40).Generic(), XSDDecimalLiteral(42.0).Generic()))
+                Expect(err).NotTo(HaveOccurred())
+                Expect(len(indivs)).To(Equal(1))
+                Expect(indivs[0].URI).To(Equal(indiv3.URI))
+                indivs, err = ont.GetIndividuals(TripleFilter{}.AndWithDataPropertyRange("http://abc.com#dataprop2", XSDIntegerLiteral(43).Generic(), GenericLiteral{}))
+                Expect(err).NotTo(HaveOccurred())
+                Expect(indivs).To(BeEmpty())
+            })
+            It("should error on malformed regular expressions", func() {
+                _, err := ont.GetIndividuals(filter.AndWithDataPropertyRegex("http://abc.com#dataprop1", "(unclosed"))
+                Expect(err).To(MatchError(ErrInvalidFilter))
+            })
+        })
         When("filtered by a chain of classes and properties", func() {
             It("should return the expected individuals only", func() {
                 filter = filter.AndWithClass("http://abc.com#type2")
@@ -627,4 +693,34 @@ var _ = Describe("OntologyGraph", func() {
             })
         })
     })
+
+    Describe("Exporting the ontology as DOT graph", func() {
+        BeforeEach(func() {
+            parent := OntologyClass{URI: testUri + "#parent", Label: map[string]string{"en": "Parent"}}
+            child := OntologyClass{URI: testUri + "#child", SubClassOf: []string{parent.URI}}
+            prop := OntologyObjectProperty{URI: testUri + "#knows", Label: map[string]string{"en": "knows"}}
+            indiv1 := OntologyIndividual{URI: testUri + "#indiv1", Types: []string{child.URI}}
+            indiv1.AddObjectProperty(prop.URI, testUri+"#indiv2")
+            indiv2 := OntologyIndividual{URI: testUri + "#indiv2", Types: []string{parent.URI}}
+            // Note: upserting a resource removes references to it, so the relation target is added first
+            for _, res := range []OntologyResource{&parent, &child, &prop, &indiv2, &indiv1} {
+                Expect(ont.UpsertResource(res)).To(Succeed())
+            }
+        })
+        It("should contain the classes and subclass edges", func() {
+            var dot strings.Builder
+            Expect(ont.ExportDOT(&dot, DOTOptions{Language: "en"})).To(Succeed())
+            Expect(dot.String()).To(HavePrefix("digraph ontology {"))
+            Expect(dot.String()).To(ContainSubstring(fmt.Sprintf(`"%s#parent" [shape=box, label="Parent"];`, testUri)))
+            Expect(dot.String()).To(ContainSubstring(fmt.Sprintf(`"%s#child" [shape=box, label="child"];`, testUri)))
+            Expect(dot.String()).To(ContainSubstring(fmt.Sprintf(`"%s#child" -> "%s#parent" [arrowhead=empty];`, testUri, testUri)))
+            Expect(dot.String()).NotTo(ContainSubstring("indiv1"))
+        })
+        It("should contain the individuals and their relations if requested", func() {
+            var dot strings.Builder
+            Expect(ont.ExportDOT(&dot, DOTOptions{IncludeIndividuals: true, IncludeObjectProperties: true, Language: "en"})).To(Succeed())
+            Expect(dot.String()).To(ContainSubstring(fmt.Sprintf(`"%s#indiv1" -> "%s#child" [style=dashed];`, testUri, testUri)))
+            Expect(dot.String()).To(ContainSubstring(fmt.Sprintf(`"%s#indiv1" -> "%s#indiv2" [label="knows"];`, testUri, testUri)))
+        })
+    })
 })
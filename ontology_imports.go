@@ -0,0 +1,137 @@
+package ontograph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// ImportLoader loads the ontology with the given URI, e.g. to resolve `owl:imports` (see `OntologyGraph.ResolveImports`). Loaders
+// should error with `ErrImportNotFound` if they do not know the ontology, so that they can be chained with `ChainImportLoaders`.
+type ImportLoader interface {
+	LoadImport(ctx context.Context, uri string) (GraphStore, error)
+}
+
+// ImportLoaderFunc adapts a function to the import loader interface, e.g. to load imports from a custom source.
+type ImportLoaderFunc func(ctx context.Context, uri string) (GraphStore, error)
+
+// LoadImport calls the function.
+func (f ImportLoaderFunc) LoadImport(ctx context.Context, uri string) (GraphStore, error) {
+	return f(ctx, uri)
+}
+
+// NewHTTPImportLoader returns an import loader that dereferences the ontology URI via HTTP. The RDF formats are requested via content
+// negotiation and the response is parsed according to its content type (see `ParseGraph`). The client is optional and defaults to
+// `http.DefaultClient`.
+func NewHTTPImportLoader(client *http.Client) ImportLoader {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return ImportLoaderFunc(func(ctx context.Context, uri string) (GraphStore, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", rdfAcceptHeader)
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		switch {
+		case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+			return nil, ErrImportNotFound
+		case resp.StatusCode < 200 || resp.StatusCode > 299:
+			return nil, fmt.Errorf("Unexpected response status '%s'", resp.Status)
+		}
+		return ParseGraph(resp.Body, resp.Header.Get("Content-Type"), WithBaseURI(uri))
+	})
+}
+
+// NewDirImportLoader returns an import loader that reads ontologies from the files in the directory. A file matches an ontology URI if
+// its name with or without extension equals the last path segment of the URI, e.g. `dir/pizza.owl` for `http://example.com/pizza`.
+func NewDirImportLoader(dir string) ImportLoader {
+	return ImportLoaderFunc(func(ctx context.Context, uri string) (GraphStore, error) {
+		name := strings.TrimRight(uri, "/#")
+		name = name[strings.LastIndex(name, "/")+1:]
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			base := strings.TrimSuffix(f.Name(), ".gz")
+			base = strings.TrimSuffix(base, filepath.Ext(base))
+			if !f.IsDir() && name != "" && (f.Name() == name || base == name) {
+				return ParseFile(filepath.Join(dir, f.Name()))
+			}
+		}
+		return nil, ErrImportNotFound
+	})
+}
+
+// ChainImportLoaders returns an import loader that tries the loaders in the given order until one of them knows the ontology, e.g. a
+// local directory before the web.
+func ChainImportLoaders(loaders ...ImportLoader) ImportLoader {
+	return ImportLoaderFunc(func(ctx context.Context, uri string) (GraphStore, error) {
+		for _, loader := range loaders {
+			store, err := loader.LoadImport(ctx, uri)
+			if !errors.Is(err, ErrImportNotFound) {
+				return store, err
+			}
+		}
+		return nil, ErrImportNotFound
+	})
+}
+
+// ResolveImports loads the ontologies imported by the ontology directly or transitively via `owl:imports` with the loader into companion
+// stores. It returns the imports closure as union of the graph store of the ontology and the companion stores, which can be queried like
+// a single store (e.g. with `LoadOntologyGraph`). Each ontology is only loaded once, so cyclic imports are fine. Errors if an import
+// cannot be loaded or the context is done.
+func (ont *OntologyGraph) ResolveImports(ctx context.Context, loader ImportLoader) (*UnionStore, error) {
+	imports := []GraphStore{}
+	visited := map[string]bool{ont.GetURI(): true}
+	queue := []GraphStore{ont.graph}
+	for len(queue) > 0 {
+		trps, err := queue[0].GetAllMatches(NewResourceTerm(queue[0].GetURI()).String(), NewResourceTerm(OWLImports).String(), "")
+		if err != nil {
+			return nil, err
+		}
+		queue = queue[1:]
+		for _, trp := range trps {
+			uri := trp.Object.Value()
+			if visited[uri] {
+				continue
+			}
+			visited[uri] = true
+			if err := ctx.Err(); err != nil {
+				return nil, wrapResourceError("ResolveImports", uri, err)
+			}
+			imported, err := loader.LoadImport(ctx, uri)
+			if err != nil {
+				return nil, wrapResourceError("ResolveImports", uri, err)
+			}
+			visited[imported.GetURI()] = true
+			imports = append(imports, imported)
+			queue = append(queue, imported)
+		}
+	}
+	return NewUnionStore(ont.graph, imports...), nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrImportNotFound is raised when an import loader does not know the requested ontology.
+var ErrImportNotFound error = errors.New("The imported ontology could not be found")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// rdfAcceptHeader requests the built-in RDF formats, preferring Turtle.
+const rdfAcceptHeader = "text/turtle, application/rdf+xml;q=0.9, application/ld+json;q=0.8, application/n-triples;q=0.7"
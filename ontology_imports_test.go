@@ -0,0 +1,112 @@
+package ontograph_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Resolving imports", func() {
+	const ttlPrefixes = `@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+`
+	var ont *OntologyGraph
+	var loaded []string
+	var loader ImportLoader
+
+	BeforeEach(func() {
+		ontologies := map[string]string{
+			"http://example.com/base": `<http://example.com/base> a owl:Ontology ; owl:imports <http://example.com/app> .
+<http://example.com/base#Thing> a owl:Class .`,
+			"http://example.com/core": `<http://example.com/core> a owl:Ontology ; owl:imports <http://example.com/base> .
+<http://example.com/core#Agent> a owl:Class ; rdfs:subClassOf <http://example.com/base#Thing> .`,
+		}
+		loaded = []string{}
+		loader = ImportLoaderFunc(func(ctx context.Context, uri string) (GraphStore, error) {
+			loaded = append(loaded, uri)
+			ttl, ok := ontologies[uri]
+			if !ok {
+				return nil, ErrImportNotFound
+			}
+			return ParseFromTurtle(strings.NewReader(ttlPrefixes + ttl))
+		})
+		store, err := ParseFromTurtle(strings.NewReader(ttlPrefixes + `<http://example.com/app> a owl:Ontology ; owl:imports <http://example.com/core> .
+<http://example.com/app#Person> a owl:Class ; rdfs:subClassOf <http://example.com/core#Agent> .`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should load the transitive imports closure as union", func() {
+		union, err := ont.ResolveImports(context.Background(), loader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(Equal([]string{"http://example.com/core", "http://example.com/base"}))
+		Expect(union.GetURI()).To(Equal("http://example.com/app"))
+		Expect(union.Stores()).To(HaveLen(3))
+		trp, err := union.GetFirstMatch(NewResourceTerm("http://example.com/base#Thing").String(), "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trp).NotTo(BeNil())
+		size, err := union.Size()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(size).To(Equal(11))
+	})
+
+	It("should fail if an import cannot be loaded", func() {
+		Expect(ont.AddImport("http://example.com/missing")).To(Succeed())
+		_, err := ont.ResolveImports(context.Background(), loader)
+		Expect(errors.Is(err, ErrImportNotFound)).To(BeTrue())
+		var resErr *ResourceError
+		Expect(errors.As(err, &resErr)).To(BeTrue())
+		Expect(resErr.URI).To(Equal("http://example.com/missing"))
+	})
+
+	It("should stop when the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := ont.ResolveImports(ctx, loader)
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		Expect(loaded).To(BeEmpty())
+	})
+
+	It("should load imports via HTTP with content negotiation", func() {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/core" {
+				http.NotFound(w, r)
+				return
+			}
+			Expect(r.Header.Get("Accept")).To(ContainSubstring(MediaTypeTurtle))
+			w.Header().Set("Content-Type", MediaTypeTurtle+"; charset=utf-8")
+			w.Write([]byte(ttlPrefixes + `<> a owl:Ontology .
+<#Agent> a owl:Class .`))
+		}))
+		defer srv.Close()
+		httpLoader := NewHTTPImportLoader(srv.Client())
+		store, err := httpLoader.LoadImport(context.Background(), srv.URL+"/core")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.GetURI()).To(Equal(srv.URL + "/core"))
+		_, err = httpLoader.LoadImport(context.Background(), srv.URL+"/other")
+		Expect(errors.Is(err, ErrImportNotFound)).To(BeTrue())
+	})
+
+	It("should load imports from a directory and chain loaders", func() {
+		dir, err := ioutil.TempDir("", "ontograph")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		Expect(ioutil.WriteFile(filepath.Join(dir, "core.ttl"), []byte(ttlPrefixes+`<http://example.com/core> a owl:Ontology .
+<http://example.com/core#Agent> a owl:Class .`), 0644)).To(Succeed())
+		union, err := ont.ResolveImports(context.Background(), ChainImportLoaders(NewDirImportLoader(dir), loader))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(BeEmpty())
+		Expect(union.Stores()).To(HaveLen(2))
+	})
+})
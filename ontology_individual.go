@@ -1,5 +1,9 @@
 package ontograph
 
+import (
+	"strings"
+)
+
 // An OntologyIndividual represents an individual from an ontology.
 type OntologyIndividual struct {
 	URI              string
@@ -7,6 +11,7 @@ type OntologyIndividual struct {
 	SameIndividualAs []string
 	ObjectProperties map[string][]string
 	DataProperties   map[string][]GenericLiteral
+	IsDeprecated     bool
 	Label            map[string]string
 	Comment          map[string]string
 }
@@ -16,6 +21,12 @@ func (indiv *OntologyIndividual) GetURI() string {
 	return indiv.URI
 }
 
+// IsAnonymous returns true if the individual is an anonymous individual, i.e. its URI is a blank node label (e.g. `_:b1`) or a skolem
+// URI (see `NewAnonymousIndividual`).
+func (indiv *OntologyIndividual) IsAnonymous() bool {
+	return isAnonymousURI(indiv.URI)
+}
+
 func (indiv *OntologyIndividual) AddObjectProperty(prop, target string) {
 	if indiv.ObjectProperties == nil {
 		indiv.ObjectProperties = map[string][]string{}
@@ -30,17 +41,43 @@ func (indiv *OntologyIndividual) AddDataProperty(prop string, target GenericLite
 	indiv.DataProperties[prop] = append(indiv.DataProperties[prop], target)
 }
 
+// AddLangString adds a language-tagged string value for the data property. If the language is empty, a plain string is added.
+func (indiv *OntologyIndividual) AddLangString(prop, value, lang string) {
+	indiv.AddDataProperty(prop, LangStringLiteral{Value: value, Lang: lang}.Generic())
+}
+
+// GetLangString returns the string value of the data property in the first matching language of the fallback chain. Each language
+// falls back to its less specific tags (e.g. `de-AT` to `de`) before the next language is tried, and untagged strings are matched
+// last. Language tags are compared case-insensitively. Returns false if no value matches.
+func (indiv *OntologyIndividual) GetLangString(prop string, langs ...string) (LangStringLiteral, bool) {
+	for _, lang := range languageFallbacks(langs) {
+		for _, lit := range indiv.DataProperties[prop] {
+			matches := strings.EqualFold(lit.Language(), lang)
+			if lang == "" {
+				// Only plain strings match the untagged fallback
+				matches = lit.Type().URI == "" || lit.Type().URI == XSDString
+			}
+			if matches {
+				return LangStringLiteral{Value: lit.Value(), Lang: lit.Language()}, true
+			}
+		}
+	}
+	return LangStringLiteral{}, false
+}
+
 // ToTriples converts the individual into a set of triples.
 func (indiv *OntologyIndividual) ToTriples() []Triple {
 	trps := []Triple{}
-	subj := NewResourceTerm(indiv.URI)
+	subj := uriTerm(indiv.URI)
 
-	// Define individual definition triple
-	trps = append(trps, Triple{
-		Subject:   subj,
-		Predicate: NewResourceTerm(RDFType),
-		Object:    NewResourceTerm(OWLNamedIndividual),
-	})
+	// Define individual definition triple (anonymous individuals are not named)
+	if !indiv.IsAnonymous() {
+		trps = append(trps, Triple{
+			Subject:   subj,
+			Predicate: NewResourceTerm(RDFType),
+			Object:    NewResourceTerm(OWLNamedIndividual),
+		})
+	}
 
 	// Add type triples
 	for _, uri := range indiv.Types {
@@ -55,7 +92,7 @@ func (indiv *OntologyIndividual) ToTriples() []Triple {
 		trps = append(trps, Triple{
 			Subject:   subj,
 			Predicate: NewResourceTerm(OWLSameAs),
-			Object:    NewResourceTerm(uri),
+			Object:    uriTerm(uri),
 		})
 	}
 
@@ -65,7 +102,7 @@ func (indiv *OntologyIndividual) ToTriples() []Triple {
 			trps = append(trps, Triple{
 				Subject:   subj,
 				Predicate: NewResourceTerm(propUri),
-				Object:    NewResourceTerm(uri),
+				Object:    uriTerm(uri),
 			})
 		}
 	}
@@ -80,6 +117,10 @@ func (indiv *OntologyIndividual) ToTriples() []Triple {
 		}
 	}
 
+	// Add deprecation flag
+	if indiv.IsDeprecated {
+		trps = append(trps, deprecationTriple(subj))
+	}
 	// Add labels
 	for lang, label := range indiv.Label {
 		trps = append(trps, Triple{
@@ -99,3 +140,31 @@ func (indiv *OntologyIndividual) ToTriples() []Triple {
 	// Done, return triples
 	return trps
 }
+
+// ********************
+// * Helper functions *
+// ********************
+
+// languageFallbacks expands the languages into the fallback chain of language tags, e.g. `[de-AT en]` into `[de-AT de en ""]`.
+func languageFallbacks(langs []string) []string {
+	chain := []string{}
+	seen := map[string]bool{}
+	add := func(lang string) {
+		if !seen[strings.ToLower(lang)] {
+			seen[strings.ToLower(lang)] = true
+			chain = append(chain, lang)
+		}
+	}
+	for _, lang := range langs {
+		for lang != "" {
+			add(lang)
+			pos := strings.LastIndex(lang, "-")
+			if pos < 0 {
+				break
+			}
+			lang = lang[:pos]
+		}
+	}
+	add("")
+	return chain
+}
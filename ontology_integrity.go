@@ -0,0 +1,101 @@
+package ontograph
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A DanglingReference is a reference from a resource to a URI that is not defined in the graph or its imports.
+type DanglingReference struct {
+	// Resource is the URI of the referencing resource.
+	Resource string
+	// Property is the URI of the referencing property, e.g. an object property, `rdf:type`, `rdfs:domain` or `rdfs:range`.
+	Property string
+	// Target is the undefined URI.
+	Target string
+}
+
+// String returns a human readable description of the dangling reference.
+func (ref DanglingReference) String() string {
+	return fmt.Sprintf("<%s> <%s> <%s>: undefined target", ref.Resource, ref.Property, ref.Target)
+}
+
+// IntegrityReport lists the referential integrity issues of an ontology (see `OntologyGraph.CheckIntegrity`).
+type IntegrityReport struct {
+	// DanglingReferences are the references to undefined URIs, sorted by resource, property and target.
+	DanglingReferences []DanglingReference
+	// UntypedResources are the URIs of the named resources without any `rdf:type`, sorted by URI.
+	UntypedResources []string
+}
+
+// IsEmpty checks whether no integrity issues were found.
+func (report IntegrityReport) IsEmpty() bool {
+	return len(report.DanglingReferences) == 0 && len(report.UntypedResources) == 0
+}
+
+// CheckIntegrity checks the referential integrity of the ontology against the graph and the given imports, e.g. the union store returned
+// by `ResolveImports`. It reports object property targets, types, domains and ranges that reference URIs which are not the subject of
+// any triple in the graph or its imports, as well as named resources (such as individuals) that have no type. Terms of the RDF, RDFS,
+// OWL and XML Schema vocabularies are always defined and blank nodes are ignored.
+func (ont *OntologyGraph) CheckIntegrity(imports ...GraphStore) (IntegrityReport, error) {
+	report := IntegrityReport{DanglingReferences: []DanglingReference{}, UntypedResources: []string{}}
+	trps, err := ont.graph.GetAllTriples()
+	if err != nil {
+		return report, err
+	}
+	// Collect the defined and typed subjects and the object properties of the graph and its imports
+	defined, typed := map[Term]bool{}, map[Term]bool{}
+	objProps := map[Term]bool{}
+	collect := func(trps []Triple) {
+		for _, trp := range trps {
+			defined[trp.Subject] = true
+			if trp.Predicate == NewResourceTerm(RDFType) {
+				typed[trp.Subject] = true
+				if trp.Object == NewResourceTerm(OWLObjectProperty) {
+					objProps[trp.Subject] = true
+				}
+			}
+		}
+	}
+	collect(trps)
+	for _, imported := range imports {
+		importedTrps, err := imported.GetAllTriples()
+		if err != nil {
+			return report, err
+		}
+		collect(importedTrps)
+	}
+
+	// Find references to undefined URIs and untyped resources
+	untyped := map[string]bool{}
+	for _, trp := range trps {
+		if trp.Subject.IsResource() && !typed[trp.Subject] && !isAnonymousURI(trp.Subject.Value()) {
+			untyped[trp.Subject.Value()] = true
+		}
+		isReference := objProps[trp.Predicate]
+		switch trp.Predicate {
+		case NewResourceTerm(RDFType), NewResourceTerm(RDFSDomain), NewResourceTerm(RDFSRange):
+			isReference = true
+		}
+		if !isReference || !trp.Object.IsResource() || defined[trp.Object] || isBuiltinSchemaTerm(trp.Object.Value()) {
+			continue
+		}
+		report.DanglingReferences = append(report.DanglingReferences, DanglingReference{
+			Resource: trp.Subject.Value(),
+			Property: trp.Predicate.Value(),
+			Target:   trp.Object.Value(),
+		})
+	}
+	sort.Slice(report.DanglingReferences, func(i, j int) bool {
+		a, b := report.DanglingReferences[i], report.DanglingReferences[j]
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		if a.Property != b.Property {
+			return a.Property < b.Property
+		}
+		return a.Target < b.Target
+	})
+	report.UntypedResources = sortedKeys(untyped)
+	return report, nil
+}
@@ -0,0 +1,78 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Checking referential integrity", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+	var imported *MemoryStore
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix base: <http://example.com/base#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.com/onto> a owl:Ontology ; owl:imports <http://example.com/base> .
+:Person a owl:Class ; rdfs:subClassOf base:Agent .
+:worksFor a owl:ObjectProperty ; rdfs:domain :Person ; rdfs:range :Company .
+:age a owl:DatatypeProperty ; rdfs:domain base:Agent ; rdfs:range xsd:integer .
+:alice a :Person , :Employee ; :worksFor :acme , base:initech ; :age 42 .
+:bob :worksFor :alice ; rdfs:label "Bob" .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		imported, err = ParseFromTurtle(strings.NewReader(`@prefix base: <http://example.com/base#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/base> a owl:Ontology .
+base:Agent a owl:Class .
+base:initech a owl:NamedIndividual .
+`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should report dangling references and untyped resources", func() {
+		report, err := ont.CheckIntegrity(imported)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.IsEmpty()).To(BeFalse())
+		Expect(report.DanglingReferences).To(Equal([]DanglingReference{
+			{Resource: ns + "alice", Property: ns + "worksFor", Target: ns + "acme"},
+			{Resource: ns + "alice", Property: RDFType, Target: ns + "Employee"},
+			{Resource: ns + "worksFor", Property: RDFSRange, Target: ns + "Company"},
+		}))
+		Expect(report.UntypedResources).To(Equal([]string{ns + "bob"}))
+	})
+
+	It("should report references into missing imports", func() {
+		report, err := ont.CheckIntegrity()
+		Expect(err).NotTo(HaveOccurred())
+		targets := []string{}
+		for _, ref := range report.DanglingReferences {
+			targets = append(targets, ref.Target)
+		}
+		Expect(targets).To(ContainElements("http://example.com/base#initech", "http://example.com/base#Agent"))
+	})
+
+	It("should report nothing for a consistent graph", func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:Person a owl:Class .
+:alice a :Person .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		report, err := ont.CheckIntegrity()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.IsEmpty()).To(BeTrue())
+	})
+})
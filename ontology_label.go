@@ -0,0 +1,69 @@
+package ontograph
+
+import (
+	"sort"
+	"strings"
+)
+
+// AnyLanguage can be given as language code in label lookups to match labels of any language. If several labels match, the label
+// without language tag is preferred, followed by the labels in the alphabetical order of their language codes.
+const AnyLanguage = "*"
+
+// FindByLabel returns the URIs of all resources that have the label as `rdfs:label` or `skos:prefLabel`, e.g. to resolve human names
+// given by users to resources. Labels are compared case-insensitively. If language codes are given, only labels in one of the languages
+// match (see `AnyLanguage`), otherwise labels of any language match. The URIs are sorted and unique.
+func (ont *OntologyGraph) FindByLabel(label string, langs ...string) ([]string, error) {
+	found := map[string]bool{}
+	for _, pred := range []string{RDFSLabel, SKOSPrefLabel} {
+		trps, err := ont.graph.GetAllMatches("", NewResourceTerm(pred).String(), "")
+		if err != nil {
+			return nil, err
+		}
+		for _, trp := range trps {
+			if trp.Object.IsLiteral() && strings.EqualFold(trp.Object.Value(), label) && matchesLanguage(trp.Object.Language(), langs) {
+				found[uriOf(trp.Subject)] = true
+			}
+		}
+	}
+	return sortedKeys(found), nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// matchesLanguage checks if the language code is one of the given codes (see `AnyLanguage`). If no codes are given, all languages match.
+func matchesLanguage(lang string, langs []string) bool {
+	if len(langs) == 0 {
+		return true
+	}
+	for _, l := range langs {
+		if l == AnyLanguage || l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// pickLanguage returns the value for the first of the language codes that has a value in the map (see `AnyLanguage`). If no language
+// matches, the empty string is returned.
+func pickLanguage(values map[string]string, langs []string) string {
+	for _, lang := range langs {
+		if lang != AnyLanguage {
+			if val, ok := values[lang]; ok {
+				return val
+			}
+			continue
+		}
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		// The empty language code sorts first, so untagged values are preferred
+		sort.Strings(keys)
+		if len(keys) > 0 {
+			return values[keys[0]]
+		}
+	}
+	return ""
+}
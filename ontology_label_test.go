@@ -0,0 +1,39 @@
+package ontograph_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Label lookup", func() {
+	const testUri = "http://example.com/onto"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		var err error
+		ont, err = InitOntologyGraph(NewMemoryStore(testUri))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#Person", Label: map[string]string{"en": "Person", "de": "Person"}})).To(Succeed())
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#Car", Label: map[string]string{"en": "Car", "de": "Auto"}})).To(Succeed())
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#Vehicle", Label: map[string]string{"": "car"}})).To(Succeed())
+	})
+
+	It("should find resources by label", func() {
+		Expect(ont.FindByLabel("car")).To(Equal([]string{testUri + "#Car", testUri + "#Vehicle"}))
+		Expect(ont.FindByLabel("Car", "en")).To(Equal([]string{testUri + "#Car"}))
+		Expect(ont.FindByLabel("auto", "fr", AnyLanguage)).To(Equal([]string{testUri + "#Car"}))
+		Expect(ont.FindByLabel("Auto", "en")).To(BeEmpty())
+	})
+
+	It("should fall back through the given languages", func() {
+		Expect(ont.SetLabel("Fahrzeuge", "de")).To(Succeed())
+		Expect(ont.SetLabel("Vehicles", "en")).To(Succeed())
+		Expect(ont.GetLabel("fr", "en")).To(Equal("Vehicles"))
+		Expect(ont.GetLabel("fr")).To(BeEmpty())
+		Expect(ont.GetLabel("fr", AnyLanguage)).To(Equal("Fahrzeuge"))
+		Expect(ont.SetComment("Cars and more", "")).To(Succeed())
+		Expect(ont.GetComment("en", AnyLanguage)).To(Equal("Cars and more"))
+	})
+})
@@ -1,9 +1,15 @@
 package ontograph
 
 import (
+    "encoding/base64"
+    "encoding/hex"
     "errors"
     "fmt"
+    "math"
+    "math/big"
+    "regexp"
     "strconv"
+    "strings"
     "time"
 )
 
@@ -14,12 +20,16 @@ type GenericLiteral struct {
     datatype OntologyDatatype
 }
 
-// NewGenericLiteral creates a new generic literal from the given term.
+// NewGenericLiteral creates a new generic literal from the given term. Language-tagged literals have the datatype rdf:langString.
 func NewGenericLiteral(t Term) *GenericLiteral {
+    datatype := t.Datatype()
+    if t.Language() != "" {
+        datatype = RDFLangString
+    }
     return &GenericLiteral{
         value: t,
         datatype: OntologyDatatype{
-            URI: t.Datatype(),
+            URI: datatype,
         },
     }
 }
@@ -34,6 +44,11 @@ func (l *GenericLiteral) Type() OntologyDatatype {
     return l.datatype
 }
 
+// Language returns the language tag of the literal. Will be the empty string if the literal is not language-tagged.
+func (l *GenericLiteral) Language() string {
+    return l.value.Language()
+}
+
 // Value returns a string representation of the value of the literal.
 func (l *GenericLiteral) Value() string {
     return l.value.Value()
@@ -48,6 +63,167 @@ func (l *GenericLiteral) String() string {
 // ErrLiteralTypeMismatch is raised when a generic literal is attempted to be converted into a specific literal of a certain datatype, but the datatype does not match.
 var ErrLiteralTypeMismatch error = errors.New("The literal is not of the expected type")
 
+// ErrInvalidLexicalForm is raised when the value of a literal is not a valid lexical form of its datatype.
+var ErrInvalidLexicalForm error = errors.New("The value is not a valid lexical form of the datatype")
+
+// ErrDurationNotRepresentable is raised when a xsd:duration literal cannot be converted into a `time.Duration`.
+var ErrDurationNotRepresentable error = errors.New("The duration cannot be represented as time.Duration")
+
+// ErrLiteralsNotComparable is raised when two literals are compared whose values are not comparable, e.g. a number and a string.
+var ErrLiteralsNotComparable error = errors.New("The literals are not comparable")
+
+// A LiteralOption configures the conversion of generic literals into specific literals.
+type LiteralOption func(*literalOptions)
+
+// WithNumericCoercion makes the numeric conversions lenient: literals of any numeric datatype as well as plain and xsd:string literals
+// with a numeric value are accepted, as long as the value fits into the target type. Conversions into integer types require integral
+// values (e.g. `"42.0"^^xsd:decimal` converts into 42, but `"4.2"^^xsd:decimal` does not), conversions into floating point types may
+// round the value.
+func WithNumericCoercion() LiteralOption {
+    return func(opts *literalOptions) {
+        opts.coerceNumeric = true
+    }
+}
+
+// Compare compares the values of the literals and returns -1, 0 or +1 if the literal is less than, equal to or greater than the other
+// literal. Numeric literals of any numeric datatype are compared by value (exactly for integers and decimals), booleans with false
+// before true, strings (plain or xsd:string) and language-tagged strings of the same language lexically, and date and time literals
+// (xsd:dateTime, xsd:date, xsd:time, xsd:gYear and xsd:gYearMonth) of the same datatype chronologically. All other combinations, NaN
+// and invalid values error with `ErrLiteralsNotComparable`.
+func (l *GenericLiteral) Compare(other *GenericLiteral) (int, error) {
+    a, b := l.Type().URI, other.Type().URI
+    switch {
+    case xsdNumericDatatypes[a] && xsdNumericDatatypes[b]:
+        return compareNumericLiterals(l, other)
+    case isStringDatatype(a) && isStringDatatype(b):
+        return strings.Compare(l.Value(), other.Value()), nil
+    case a == RDFLangString && b == RDFLangString && strings.EqualFold(l.Language(), other.Language()):
+        return strings.Compare(l.Value(), other.Value()), nil
+    case a == XSDBoolean && b == XSDBoolean:
+        x, err1 := l.ToXSDBoolean()
+        y, err2 := other.ToXSDBoolean()
+        if err1 != nil || err2 != nil {
+            return 0, ErrLiteralsNotComparable
+        }
+        if x == y {
+            return 0, nil
+        } else if !x {
+            return -1, nil
+        }
+        return 1, nil
+    case a == b && xsdTemporalLayouts[a] != "":
+        x, err1 := l.parseTemporal(a, xsdTemporalLayouts[a])
+        y, err2 := other.parseTemporal(b, xsdTemporalLayouts[b])
+        if err1 != nil || err2 != nil {
+            return 0, ErrLiteralsNotComparable
+        }
+        if x.Before(y) {
+            return -1, nil
+        } else if x.After(y) {
+            return 1, nil
+        }
+        return 0, nil
+    }
+    return 0, ErrLiteralsNotComparable
+}
+
+// Validate checks the value of the literal against the lexical space and value space of its XSD datatype, e.g. that a xsd:byte is an
+// integer between -128 and 127. Literals of other datatypes (e.g. strings or custom datatypes) are always valid. Errors with
+// `ErrInvalidLexicalForm` if the value is invalid.
+func (l *GenericLiteral) Validate() error {
+    _, err := l.Canonicalize()
+    return err
+}
+
+// Canonicalize returns the literal with the canonical lexical form of its value (e.g. `"01"^^xsd:integer` becomes `"1"^^xsd:integer`
+// and `"1"^^xsd:boolean` becomes `"true"^^xsd:boolean`). Date and time values with timezone are normalized to UTC, language tags to
+// lower case. Literals of other datatypes are returned unchanged. Errors with `ErrInvalidLexicalForm` if the value is invalid.
+func (l *GenericLiteral) Canonicalize() (GenericLiteral, error) {
+    datatype := l.Type().URI
+    value := strings.TrimSpace(l.Value())
+    invalid := func(err error) (GenericLiteral, error) {
+        if errors.Is(err, ErrInvalidLexicalForm) {
+            return GenericLiteral{}, fmt.Errorf("%w: '%s' is no valid <%s>", err, l.Value(), datatype)
+        }
+        return GenericLiteral{}, fmt.Errorf("%w: '%s' is no valid <%s>: %v", ErrInvalidLexicalForm, l.Value(), datatype, err)
+    }
+    canonical := func(value string) (GenericLiteral, error) {
+        return *NewGenericLiteral(NewLiteralTerm(value, "", datatype)), nil
+    }
+    switch {
+    case xsdIntegerDatatypes[datatype]:
+        val, err := l.ToXSDBigInteger()
+        if err != nil {
+            return invalid(err)
+        }
+        if err := checkIntegerRange(datatype, val.Int); err != nil {
+            return invalid(err)
+        }
+        return canonical(val.String())
+    case datatype == XSDDecimal:
+        val, err := l.ToXSDBigDecimal()
+        if err != nil {
+            return invalid(err)
+        }
+        return canonical(formatXSDDecimal(val.Rat))
+    case datatype == XSDFloat || datatype == XSDDouble:
+        bitSize := 64
+        if datatype == XSDFloat {
+            bitSize = 32
+        }
+        val, err := strconv.ParseFloat(value, bitSize)
+        if err != nil || !xsdFloatPattern.MatchString(value) {
+            return invalid(ErrInvalidLexicalForm)
+        }
+        return canonical(formatXSDFloat(val, bitSize))
+    case datatype == XSDBoolean:
+        switch value {
+        case "true", "1":
+            return canonical("true")
+        case "false", "0":
+            return canonical("false")
+        }
+        return invalid(ErrInvalidLexicalForm)
+    case xsdTemporalLayouts[datatype] != "":
+        val, err := l.parseTemporal(datatype, xsdTemporalLayouts[datatype])
+        if err != nil {
+            return invalid(err)
+        }
+        layout := xsdTemporalLayouts[datatype]
+        if datatype == XSDDateTime || datatype == XSDTime {
+            layout += ".999999999"
+        }
+        if !xsdTimezonePattern.MatchString(value) {
+            return canonical(val.Format(layout))
+        }
+        if datatype == XSDDateTime || datatype == XSDTime {
+            val = val.UTC()
+        }
+        return canonical(val.Format(layout + "Z07:00"))
+    case datatype == XSDDuration:
+        val, err := l.ToXSDDuration()
+        if err != nil {
+            return invalid(err)
+        }
+        return canonical(val.String())
+    case datatype == XSDBase64Binary:
+        val, err := l.ToXSDBase64Binary()
+        if err != nil {
+            return invalid(err)
+        }
+        return val.Generic(), nil
+    case datatype == XSDHexBinary:
+        val, err := l.ToXSDHexBinary()
+        if err != nil {
+            return invalid(err)
+        }
+        return val.Generic(), nil
+    case datatype == RDFLangString:
+        return *NewGenericLiteral(NewLiteralTerm(l.Value(), strings.ToLower(l.Language()), "")), nil
+    }
+    return *l, nil
+}
+
 // **************
 // * xsd:string *
 // **************
@@ -80,6 +256,13 @@ func (l XSDIntegerLiteral) Generic() GenericLiteral {
     return *NewGenericLiteral(t)
 }
 
+// ToXSDInteger parses the literal into a xsd:integer literal. If the literal is not of type xsd:integer, an `ErrLiteralTypeMismatch` is returned.
+// Values that do not fit into an `int` are rejected (see `ToXSDBigInteger` for unbounded values).
+func (l *GenericLiteral) ToXSDInteger(opts ...LiteralOption) (XSDIntegerLiteral, error) {
+    val, err := l.parseInt(XSDInteger, strconv.IntSize, opts)
+    return XSDIntegerLiteral(val), err
+}
+
 // ***************
 // * xsd:decimal *
 // ***************
@@ -92,13 +275,14 @@ func (l XSDDecimalLiteral) Generic() GenericLiteral {
 }
 
 // ToXSDDecimalLiteral parses the literal into a xsd:decimal literal. If the literal is not a number, an `ErrLiteralTypeMismatch` is returned.
-func (l *GenericLiteral) ToXSDDecimal() (XSDDecimalLiteral, error) {
+func (l *GenericLiteral) ToXSDDecimal(opts ...LiteralOption) (XSDDecimalLiteral, error) {
     // Check for type mismatch
-    if l.Type().URI != XSDDecimal {
-        return 0, ErrLiteralTypeMismatch
+    value, err := l.numericValue(XSDDecimal, opts)
+    if err != nil {
+        return 0, err
     }
     // Parse literal
-    val, err := strconv.ParseFloat(l.Value(), 64)
+    val, err := strconv.ParseFloat(value, 64)
     if err != nil {
         return 0, err
     }
@@ -176,3 +360,799 @@ func (l *GenericLiteral) ToXSDDateTime() (XSDDateTimeLiteral, error) {
     }
     return XSDDateTimeLiteral(t), nil
 }
+
+// ************
+// * xsd:long *
+// ************
+
+type XSDLongLiteral int64
+
+func (l XSDLongLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(strconv.FormatInt(int64(l), 10), "", XSDLong)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDLong parses the literal into a xsd:long literal. If the literal is not of type xsd:long, an `ErrLiteralTypeMismatch` is returned.
+func (l *GenericLiteral) ToXSDLong(opts ...LiteralOption) (XSDLongLiteral, error) {
+    val, err := l.parseInt(XSDLong, 64, opts)
+    return XSDLongLiteral(val), err
+}
+
+// ***********
+// * xsd:int *
+// ***********
+
+type XSDIntLiteral int32
+
+func (l XSDIntLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(strconv.FormatInt(int64(l), 10), "", XSDInt)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDInt parses the literal into a xsd:int literal. If the literal is not of type xsd:int, an `ErrLiteralTypeMismatch` is returned.
+func (l *GenericLiteral) ToXSDInt(opts ...LiteralOption) (XSDIntLiteral, error) {
+    val, err := l.parseInt(XSDInt, 32, opts)
+    return XSDIntLiteral(val), err
+}
+
+// *************
+// * xsd:short *
+// *************
+
+type XSDShortLiteral int16
+
+func (l XSDShortLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(strconv.FormatInt(int64(l), 10), "", XSDShort)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDShort parses the literal into a xsd:short literal. If the literal is not of type xsd:short, an `ErrLiteralTypeMismatch` is returned.
+func (l *GenericLiteral) ToXSDShort(opts ...LiteralOption) (XSDShortLiteral, error) {
+    val, err := l.parseInt(XSDShort, 16, opts)
+    return XSDShortLiteral(val), err
+}
+
+// ************
+// * xsd:byte *
+// ************
+
+type XSDByteLiteral int8
+
+func (l XSDByteLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(strconv.FormatInt(int64(l), 10), "", XSDByte)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDByte parses the literal into a xsd:byte literal. If the literal is not of type xsd:byte, an `ErrLiteralTypeMismatch` is returned.
+func (l *GenericLiteral) ToXSDByte(opts ...LiteralOption) (XSDByteLiteral, error) {
+    val, err := l.parseInt(XSDByte, 8, opts)
+    return XSDByteLiteral(val), err
+}
+
+// ********************
+// * xsd:unsignedLong *
+// ********************
+
+type XSDUnsignedLongLiteral uint64
+
+func (l XSDUnsignedLongLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(strconv.FormatUint(uint64(l), 10), "", XSDUnsignedLong)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDUnsignedLong parses the literal into a xsd:unsignedLong literal. If the literal is not of type xsd:unsignedLong, an `ErrLiteralTypeMismatch` is returned.
+func (l *GenericLiteral) ToXSDUnsignedLong(opts ...LiteralOption) (XSDUnsignedLongLiteral, error) {
+    val, err := l.parseUint(XSDUnsignedLong, 64, opts)
+    return XSDUnsignedLongLiteral(val), err
+}
+
+// *******************
+// * xsd:unsignedInt *
+// *******************
+
+type XSDUnsignedIntLiteral uint32
+
+func (l XSDUnsignedIntLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(strconv.FormatUint(uint64(l), 10), "", XSDUnsignedInt)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDUnsignedInt parses the literal into a xsd:unsignedInt literal. If the literal is not of type xsd:unsignedInt, an `ErrLiteralTypeMismatch` is returned.
+func (l *GenericLiteral) ToXSDUnsignedInt(opts ...LiteralOption) (XSDUnsignedIntLiteral, error) {
+    val, err := l.parseUint(XSDUnsignedInt, 32, opts)
+    return XSDUnsignedIntLiteral(val), err
+}
+
+// *********************
+// * xsd:unsignedShort *
+// *********************
+
+type XSDUnsignedShortLiteral uint16
+
+func (l XSDUnsignedShortLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(strconv.FormatUint(uint64(l), 10), "", XSDUnsignedShort)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDUnsignedShort parses the literal into a xsd:unsignedShort literal. If the literal is not of type xsd:unsignedShort, an `ErrLiteralTypeMismatch` is returned.
+func (l *GenericLiteral) ToXSDUnsignedShort(opts ...LiteralOption) (XSDUnsignedShortLiteral, error) {
+    val, err := l.parseUint(XSDUnsignedShort, 16, opts)
+    return XSDUnsignedShortLiteral(val), err
+}
+
+// ********************
+// * xsd:unsignedByte *
+// ********************
+
+type XSDUnsignedByteLiteral uint8
+
+func (l XSDUnsignedByteLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(strconv.FormatUint(uint64(l), 10), "", XSDUnsignedByte)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDUnsignedByte parses the literal into a xsd:unsignedByte literal. If the literal is not of type xsd:unsignedByte, an `ErrLiteralTypeMismatch` is returned.
+func (l *GenericLiteral) ToXSDUnsignedByte(opts ...LiteralOption) (XSDUnsignedByteLiteral, error) {
+    val, err := l.parseUint(XSDUnsignedByte, 8, opts)
+    return XSDUnsignedByteLiteral(val), err
+}
+
+// **************************
+// * xsd:nonNegativeInteger *
+// **************************
+
+type XSDNonNegativeIntegerLiteral uint64
+
+func (l XSDNonNegativeIntegerLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(strconv.FormatUint(uint64(l), 10), "", XSDNonNegativeInteger)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDNonNegativeInteger parses the literal into a xsd:nonNegativeInteger literal. If the literal is not of type xsd:nonNegativeInteger, an `ErrLiteralTypeMismatch` is returned.
+func (l *GenericLiteral) ToXSDNonNegativeInteger(opts ...LiteralOption) (XSDNonNegativeIntegerLiteral, error) {
+    val, err := l.parseUint(XSDNonNegativeInteger, 64, opts)
+    return XSDNonNegativeIntegerLiteral(val), err
+}
+
+// ***********************
+// * xsd:positiveInteger *
+// ***********************
+
+type XSDPositiveIntegerLiteral uint64
+
+func (l XSDPositiveIntegerLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(strconv.FormatUint(uint64(l), 10), "", XSDPositiveInteger)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDPositiveInteger parses the literal into a xsd:positiveInteger literal. If the literal is not of type xsd:positiveInteger, an `ErrLiteralTypeMismatch` is returned.
+func (l *GenericLiteral) ToXSDPositiveInteger(opts ...LiteralOption) (XSDPositiveIntegerLiteral, error) {
+    val, err := l.parseUint(XSDPositiveInteger, 64, opts)
+    return XSDPositiveIntegerLiteral(val), err
+}
+
+// *************
+// * xsd:float *
+// *************
+
+type XSDFloatLiteral float32
+
+func (l XSDFloatLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(formatXSDFloat(float64(l), 32), "", XSDFloat)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDFloat parses the literal into a xsd:float literal. If the literal is not of type xsd:float, an `ErrLiteralTypeMismatch` is returned.
+// The special values `INF`, `-INF` and `NaN` are supported.
+func (l *GenericLiteral) ToXSDFloat(opts ...LiteralOption) (XSDFloatLiteral, error) {
+    // Check for type mismatch
+    value, err := l.numericValue(XSDFloat, opts)
+    if err != nil {
+        return 0, err
+    }
+    // Parse literal
+    val, err := strconv.ParseFloat(value, 32)
+    if err != nil {
+        return 0, err
+    }
+    return XSDFloatLiteral(val), nil
+}
+
+// **************
+// * xsd:double *
+// **************
+
+type XSDDoubleLiteral float64
+
+func (l XSDDoubleLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(formatXSDFloat(float64(l), 64), "", XSDDouble)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDDouble parses the literal into a xsd:double literal. If the literal is not of type xsd:double, an `ErrLiteralTypeMismatch` is returned.
+// The special values `INF`, `-INF` and `NaN` are supported.
+func (l *GenericLiteral) ToXSDDouble(opts ...LiteralOption) (XSDDoubleLiteral, error) {
+    // Check for type mismatch
+    value, err := l.numericValue(XSDDouble, opts)
+    if err != nil {
+        return 0, err
+    }
+    // Parse literal
+    val, err := strconv.ParseFloat(value, 64)
+    if err != nil {
+        return 0, err
+    }
+    return XSDDoubleLiteral(val), nil
+}
+
+// ************
+// * xsd:date *
+// ************
+
+type XSDDateLiteral time.Time
+
+func (l XSDDateLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(formatXSDTemporal(time.Time(l), "2006-01-02"), "", XSDDate)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDDate parses the literal into a xsd:date literal. If the literal is not of type xsd:date, an `ErrLiteralTypeMismatch` is returned.
+// The value must be formatted like `2006-01-02` or `2006-01-02+02:00`. Values without timezone are interpreted as UTC.
+func (l *GenericLiteral) ToXSDDate() (XSDDateLiteral, error) {
+    t, err := l.parseTemporal(XSDDate, "2006-01-02")
+    return XSDDateLiteral(t), err
+}
+
+// ************
+// * xsd:time *
+// ************
+
+type XSDTimeLiteral time.Time
+
+func (l XSDTimeLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(formatXSDTemporal(time.Time(l), "15:04:05.999999999"), "", XSDTime)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDTime parses the literal into a xsd:time literal. If the literal is not of type xsd:time, an `ErrLiteralTypeMismatch` is returned.
+// The value must be formatted like `15:04:05`, `15:04:05.123` or `15:04:05Z`. Values without timezone are interpreted as UTC.
+func (l *GenericLiteral) ToXSDTime() (XSDTimeLiteral, error) {
+    t, err := l.parseTemporal(XSDTime, "15:04:05")
+    return XSDTimeLiteral(t), err
+}
+
+// *************
+// * xsd:gYear *
+// *************
+
+type XSDGYearLiteral time.Time
+
+func (l XSDGYearLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(formatXSDTemporal(time.Time(l), "2006"), "", XSDGYear)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDGYear parses the literal into a xsd:gYear literal. If the literal is not of type xsd:gYear, an `ErrLiteralTypeMismatch` is returned.
+// The value must be formatted like `2006` or `2006Z`. Values without timezone are interpreted as UTC.
+func (l *GenericLiteral) ToXSDGYear() (XSDGYearLiteral, error) {
+    t, err := l.parseTemporal(XSDGYear, "2006")
+    return XSDGYearLiteral(t), err
+}
+
+// ******************
+// * xsd:gYearMonth *
+// ******************
+
+type XSDGYearMonthLiteral time.Time
+
+func (l XSDGYearMonthLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(formatXSDTemporal(time.Time(l), "2006-01"), "", XSDGYearMonth)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDGYearMonth parses the literal into a xsd:gYearMonth literal. If the literal is not of type xsd:gYearMonth, an `ErrLiteralTypeMismatch` is returned.
+// The value must be formatted like `2006-01` or `2006-01-05:00`. Values without timezone are interpreted as UTC.
+func (l *GenericLiteral) ToXSDGYearMonth() (XSDGYearMonthLiteral, error) {
+    t, err := l.parseTemporal(XSDGYearMonth, "2006-01")
+    return XSDGYearMonthLiteral(t), err
+}
+
+// ****************
+// * xsd:duration *
+// ****************
+
+// XSDDurationLiteral is a xsd:duration literal (ISO 8601 duration like `P1Y2M3DT4H5M6.5S`). The components are kept as given, so
+// that lexical forms round-trip (apart from components that are zero).
+type XSDDurationLiteral struct {
+    Negative bool
+    Years    int
+    Months   int
+    Days     int
+    Hours    int
+    Minutes  int
+    Seconds  float64
+}
+
+// NewXSDDurationLiteral converts the duration into a xsd:duration literal with days, hours, minutes and seconds.
+func NewXSDDurationLiteral(d time.Duration) XSDDurationLiteral {
+    l := XSDDurationLiteral{Negative: d < 0}
+    if l.Negative {
+        d = -d
+    }
+    l.Days = int(d / (24 * time.Hour))
+    d -= time.Duration(l.Days) * 24 * time.Hour
+    l.Hours = int(d / time.Hour)
+    d -= time.Duration(l.Hours) * time.Hour
+    l.Minutes = int(d / time.Minute)
+    d -= time.Duration(l.Minutes) * time.Minute
+    l.Seconds = d.Seconds()
+    return l
+}
+
+// Duration converts the literal into a duration, counting days as 24 hours. If the literal has years or months (which have no fixed
+// length) or exceeds the range of `time.Duration`, an `ErrDurationNotRepresentable` is returned.
+func (l XSDDurationLiteral) Duration() (time.Duration, error) {
+    if l.Years != 0 || l.Months != 0 {
+        return 0, ErrDurationNotRepresentable
+    }
+    secs := float64(l.Days)*86400 + float64(l.Hours)*3600 + float64(l.Minutes)*60 + l.Seconds
+    if secs*1e9 >= math.MaxInt64 {
+        return 0, ErrDurationNotRepresentable
+    }
+    d := time.Duration(math.Round(secs * 1e9))
+    if l.Negative {
+        d = -d
+    }
+    return d, nil
+}
+
+// String returns the ISO 8601 representation of the duration.
+func (l XSDDurationLiteral) String() string {
+    var b strings.Builder
+    if l.Negative {
+        b.WriteString("-")
+    }
+    b.WriteString("P")
+    for _, c := range []struct {
+        value int
+        unit  string
+    }{{l.Years, "Y"}, {l.Months, "M"}, {l.Days, "D"}} {
+        if c.value != 0 {
+            b.WriteString(strconv.Itoa(c.value) + c.unit)
+        }
+    }
+    if l.Hours != 0 || l.Minutes != 0 || l.Seconds != 0 {
+        b.WriteString("T")
+        if l.Hours != 0 {
+            b.WriteString(strconv.Itoa(l.Hours) + "H")
+        }
+        if l.Minutes != 0 {
+            b.WriteString(strconv.Itoa(l.Minutes) + "M")
+        }
+        if l.Seconds != 0 {
+            b.WriteString(strconv.FormatFloat(l.Seconds, 'f', -1, 64) + "S")
+        }
+    }
+    if b.Len() <= 2 {
+        // Zero durations need at least one component
+        return "PT0S"
+    }
+    return b.String()
+}
+
+func (l XSDDurationLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(l.String(), "", XSDDuration)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDDuration parses the literal into a xsd:duration literal. If the literal is not of type xsd:duration, an `ErrLiteralTypeMismatch`
+// is returned. If the value is no ISO 8601 duration, an `ErrInvalidLexicalForm` is returned.
+func (l *GenericLiteral) ToXSDDuration() (XSDDurationLiteral, error) {
+    // Check for type mismatch
+    if l.Type().URI != XSDDuration {
+        return XSDDurationLiteral{}, ErrLiteralTypeMismatch
+    }
+    // Parse literal
+    value := strings.TrimSpace(l.Value())
+    m := xsdDurationPattern.FindStringSubmatch(value)
+    if m == nil || strings.HasSuffix(value, "P") || strings.HasSuffix(value, "T") {
+        return XSDDurationLiteral{}, ErrInvalidLexicalForm
+    }
+    dur := XSDDurationLiteral{Negative: m[1] == "-"}
+    for i, field := range []*int{&dur.Years, &dur.Months, &dur.Days, &dur.Hours, &dur.Minutes} {
+        if m[i+2] == "" {
+            continue
+        }
+        n, err := strconv.Atoi(m[i+2])
+        if err != nil {
+            return XSDDurationLiteral{}, err
+        }
+        *field = n
+    }
+    if m[7] != "" {
+        secs, err := strconv.ParseFloat(m[7], 64)
+        if err != nil {
+            return XSDDurationLiteral{}, err
+        }
+        dur.Seconds = secs
+    }
+    return dur, nil
+}
+
+// xsdDurationPattern matches the lexical form of xsd:duration. Empty durations (`P` and `PT`) must be rejected separately.
+var xsdDurationPattern = regexp.MustCompile(`^(-)?P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ********************
+// * xsd:base64Binary *
+// ********************
+
+type XSDBase64BinaryLiteral []byte
+
+func (l XSDBase64BinaryLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(base64.StdEncoding.EncodeToString(l), "", XSDBase64Binary)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDBase64Binary decodes the literal into a xsd:base64Binary literal. If the literal is not of type xsd:base64Binary, an
+// `ErrLiteralTypeMismatch` is returned. If the value is no valid base64 encoding, an `ErrInvalidLexicalForm` is returned.
+func (l *GenericLiteral) ToXSDBase64Binary() (XSDBase64BinaryLiteral, error) {
+    // Check for type mismatch
+    if l.Type().URI != XSDBase64Binary {
+        return nil, ErrLiteralTypeMismatch
+    }
+    // Decode literal (the lexical form may contain whitespace)
+    val, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(l.Value()), ""))
+    if err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidLexicalForm, err)
+    }
+    return XSDBase64BinaryLiteral(val), nil
+}
+
+// *****************
+// * xsd:hexBinary *
+// *****************
+
+type XSDHexBinaryLiteral []byte
+
+func (l XSDHexBinaryLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(strings.ToUpper(hex.EncodeToString(l)), "", XSDHexBinary)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDHexBinary decodes the literal into a xsd:hexBinary literal. If the literal is not of type xsd:hexBinary, an
+// `ErrLiteralTypeMismatch` is returned. If the value is no valid hex encoding, an `ErrInvalidLexicalForm` is returned.
+func (l *GenericLiteral) ToXSDHexBinary() (XSDHexBinaryLiteral, error) {
+    // Check for type mismatch
+    if l.Type().URI != XSDHexBinary {
+        return nil, ErrLiteralTypeMismatch
+    }
+    // Decode literal
+    val, err := hex.DecodeString(strings.TrimSpace(l.Value()))
+    if err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidLexicalForm, err)
+    }
+    return XSDHexBinaryLiteral(val), nil
+}
+
+// ******************
+// * rdf:langString *
+// ******************
+
+// LangStringLiteral is a language-tagged string literal (e.g. `"Haus"@de`).
+type LangStringLiteral struct {
+    Value string
+    Lang  string
+}
+
+func (l LangStringLiteral) Generic() GenericLiteral {
+    t := NewLiteralTerm(l.Value, l.Lang, "")
+    return *NewGenericLiteral(t)
+}
+
+// ToLangString parses the literal into a language-tagged string literal. If the literal has no language tag, an `ErrLiteralTypeMismatch`
+// is returned.
+func (l *GenericLiteral) ToLangString() (LangStringLiteral, error) {
+    // Check for type mismatch
+    if l.Type().URI != RDFLangString {
+        return LangStringLiteral{}, ErrLiteralTypeMismatch
+    }
+    // Parse literal
+    return LangStringLiteral{Value: l.Value(), Lang: l.Language()}, nil
+}
+
+// *************************************
+// * xsd:integer (arbitrary precision) *
+// *************************************
+
+// XSDBigIntegerLiteral is a xsd:integer literal of arbitrary size. A nil value represents zero.
+type XSDBigIntegerLiteral struct {
+    *big.Int
+}
+
+func (l XSDBigIntegerLiteral) Generic() GenericLiteral {
+    val := "0"
+    if l.Int != nil {
+        val = l.Int.String()
+    }
+    t := NewLiteralTerm(val, "", XSDInteger)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDBigInteger parses the literal into a xsd:integer literal of arbitrary size. Literals of the datatypes derived from xsd:integer
+// (e.g. xsd:long or xsd:nonNegativeInteger) are accepted as well. If the literal is of another type, an `ErrLiteralTypeMismatch` is
+// returned. If the value is no integer, an `ErrInvalidLexicalForm` is returned.
+func (l *GenericLiteral) ToXSDBigInteger() (XSDBigIntegerLiteral, error) {
+    // Check for type mismatch
+    if !xsdIntegerDatatypes[l.Type().URI] {
+        return XSDBigIntegerLiteral{}, ErrLiteralTypeMismatch
+    }
+    // Parse literal
+    value := strings.TrimSpace(l.Value())
+    val, ok := new(big.Int).SetString(value, 10)
+    if !ok || !xsdIntegerPattern.MatchString(value) {
+        return XSDBigIntegerLiteral{}, ErrInvalidLexicalForm
+    }
+    return XSDBigIntegerLiteral{val}, nil
+}
+
+// *************************************
+// * xsd:decimal (arbitrary precision) *
+// *************************************
+
+// XSDBigDecimalLiteral is a xsd:decimal literal of arbitrary precision. A nil value represents zero.
+type XSDBigDecimalLiteral struct {
+    *big.Rat
+}
+
+// Generic converts the literal into a generic literal. Rationals without finite decimal representation (e.g. 1/3) are rounded to 34
+// fractional digits.
+func (l XSDBigDecimalLiteral) Generic() GenericLiteral {
+    val := "0.0"
+    if l.Rat != nil {
+        val = formatXSDDecimal(l.Rat)
+    }
+    t := NewLiteralTerm(val, "", XSDDecimal)
+    return *NewGenericLiteral(t)
+}
+
+// ToXSDBigDecimal parses the literal into a xsd:decimal literal of arbitrary precision. Literals of the integer datatypes are accepted
+// as well. If the literal is of another type, an `ErrLiteralTypeMismatch` is returned. If the value is no decimal number, an
+// `ErrInvalidLexicalForm` is returned.
+func (l *GenericLiteral) ToXSDBigDecimal() (XSDBigDecimalLiteral, error) {
+    // Check for type mismatch
+    if l.Type().URI != XSDDecimal && !xsdIntegerDatatypes[l.Type().URI] {
+        return XSDBigDecimalLiteral{}, ErrLiteralTypeMismatch
+    }
+    // Parse literal
+    value := strings.TrimSpace(l.Value())
+    if !xsdDecimalPattern.MatchString(value) {
+        return XSDBigDecimalLiteral{}, ErrInvalidLexicalForm
+    }
+    val, ok := new(big.Rat).SetString(value)
+    if !ok {
+        return XSDBigDecimalLiteral{}, ErrInvalidLexicalForm
+    }
+    return XSDBigDecimalLiteral{val}, nil
+}
+
+// xsdIntegerDatatypes contains xsd:integer and the datatypes derived from it.
+var xsdIntegerDatatypes = map[string]bool{
+    XSDInteger: true, XSDNonNegativeInteger: true, XSDPositiveInteger: true, XSDLong: true, XSDInt: true, XSDShort: true, XSDByte: true,
+    XSDUnsignedLong: true, XSDUnsignedInt: true, XSDUnsignedShort: true, XSDUnsignedByte: true,
+    "http://www.w3.org/2001/XMLSchema#negativeInteger": true, "http://www.w3.org/2001/XMLSchema#nonPositiveInteger": true,
+}
+
+var (
+    xsdIntegerPattern = regexp.MustCompile(`^[+-]?[0-9]+$`)
+    xsdDecimalPattern = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+)
+
+// ********************
+// * Helper functions *
+// ********************
+
+// literalOptions are the options of the literal conversions.
+type literalOptions struct {
+    coerceNumeric bool
+}
+
+// numericValue returns the trimmed value of the literal if it has the given numeric datatype or, with numeric coercion, any numeric or
+// string datatype. Otherwise an `ErrLiteralTypeMismatch` is returned.
+func (l *GenericLiteral) numericValue(datatype string, opts []LiteralOption) (string, error) {
+    options := literalOptions{}
+    for _, opt := range opts {
+        opt(&options)
+    }
+    value := strings.TrimSpace(l.Value())
+    switch uri := l.Type().URI; {
+    case uri == datatype:
+        return value, nil
+    case options.coerceNumeric && (xsdNumericDatatypes[uri] || uri == "" || uri == XSDString):
+        // Coerce integral values of other lexical forms (e.g. `42.0` or `4.2E1`) into the integer form
+        if xsdIntegerDatatypes[datatype] && !xsdIntegerPattern.MatchString(value) {
+            if r, ok := new(big.Rat).SetString(value); ok && r.IsInt() {
+                return r.Num().String(), nil
+            }
+        }
+        return value, nil
+    }
+    return "", ErrLiteralTypeMismatch
+}
+
+// parseInt parses the value of the literal of the given integer datatype into an integer of the given bit size. Values that do not
+// fit into the bit size (i.e. the value space of the datatype) are rejected.
+func (l *GenericLiteral) parseInt(datatype string, bitSize int, opts []LiteralOption) (int64, error) {
+    // Check for type mismatch
+    value, err := l.numericValue(datatype, opts)
+    if err != nil {
+        return 0, err
+    }
+    // Parse literal
+    return strconv.ParseInt(value, 10, bitSize)
+}
+
+// parseUint parses the value of the literal of the given unsigned integer datatype into an unsigned integer of the given bit size.
+// Values of xsd:positiveInteger must be greater than zero.
+func (l *GenericLiteral) parseUint(datatype string, bitSize int, opts []LiteralOption) (uint64, error) {
+    // Check for type mismatch
+    value, err := l.numericValue(datatype, opts)
+    if err != nil {
+        return 0, err
+    }
+    // Parse literal (allowing the explicit sign of non-negative values)
+    val, err := strconv.ParseUint(strings.TrimPrefix(value, "+"), 10, bitSize)
+    if err == nil && datatype == XSDPositiveInteger && val == 0 {
+        return 0, &strconv.NumError{Func: "ParseUint", Num: l.Value(), Err: strconv.ErrRange}
+    }
+    return val, err
+}
+
+// formatXSDFloat formats the float of the given bit size in the lexical space of xsd:float and xsd:double.
+func formatXSDFloat(f float64, bitSize int) string {
+    switch {
+    case math.IsInf(f, 1):
+        return "INF"
+    case math.IsInf(f, -1):
+        return "-INF"
+    case math.IsNaN(f):
+        return "NaN"
+    }
+    return strconv.FormatFloat(f, 'g', -1, bitSize)
+}
+
+// parseTemporal parses the value of the literal of the given date or time datatype with the layout and an optional timezone.
+func (l *GenericLiteral) parseTemporal(datatype, layout string) (time.Time, error) {
+    // Check for type mismatch
+    if l.Type().URI != datatype {
+        return time.Time{}, ErrLiteralTypeMismatch
+    }
+    // Parse literal with and without timezone
+    value := strings.TrimSpace(l.Value())
+    t, err := time.Parse(layout+"Z07:00", value)
+    if err != nil {
+        t, err = time.Parse(layout, value)
+    }
+    return t, err
+}
+
+// formatXSDTemporal formats the time with the layout. The timezone is only appended if the time is not in UTC, so that values without
+// timezone round-trip.
+func formatXSDTemporal(t time.Time, layout string) string {
+    if t.Location() == time.UTC {
+        return t.Format(layout)
+    }
+    return t.Format(layout + "Z07:00")
+}
+
+// formatXSDDecimal formats the rational as decimal with at least one fractional digit. Rationals without finite decimal representation
+// are rounded to 34 fractional digits.
+func formatXSDDecimal(r *big.Rat) string {
+    // Determine the number of fractional digits from the factors 2 and 5 of the denominator
+    denom := new(big.Int).Set(r.Denom())
+    twos, fives := 0, 0
+    two, five, zero := big.NewInt(2), big.NewInt(5), big.NewInt(0)
+    mod := new(big.Int)
+    for denom.Cmp(big.NewInt(1)) != 0 {
+        if mod.Mod(denom, two).Cmp(zero) == 0 {
+            denom.Div(denom, two)
+            twos++
+        } else if mod.Mod(denom, five).Cmp(zero) == 0 {
+            denom.Div(denom, five)
+            fives++
+        } else {
+            break
+        }
+    }
+    digits := twos
+    if fives > digits {
+        digits = fives
+    }
+    if denom.Cmp(big.NewInt(1)) != 0 {
+        digits = 34
+    }
+    val := r.FloatString(digits)
+    if strings.Contains(val, ".") {
+        val = strings.TrimRight(val, "0")
+    }
+    if strings.HasSuffix(val, ".") || !strings.Contains(val, ".") {
+        val = strings.TrimSuffix(val, ".") + ".0"
+    }
+    return val
+}
+
+// xsdTemporalLayouts contains the layouts (without timezone) of the date and time datatypes.
+var xsdTemporalLayouts = map[string]string{
+    XSDDateTime:   "2006-01-02T15:04:05",
+    XSDDate:       "2006-01-02",
+    XSDTime:       "15:04:05",
+    XSDGYear:      "2006",
+    XSDGYearMonth: "2006-01",
+}
+
+// isStringDatatype checks if the datatype is the datatype of plain or xsd:string literals.
+func isStringDatatype(datatype string) bool {
+    return datatype == "" || datatype == XSDString
+}
+
+// compareNumericLiterals compares two numeric literals by value. Integers and decimals are compared exactly, if one of the literals is
+// a xsd:float or xsd:double, both are compared as floating point numbers.
+func compareNumericLiterals(a, b *GenericLiteral) (int, error) {
+    isFloat := func(l *GenericLiteral) bool {
+        return l.Type().URI == XSDFloat || l.Type().URI == XSDDouble
+    }
+    if isFloat(a) || isFloat(b) {
+        x, err1 := strconv.ParseFloat(strings.TrimSpace(a.Value()), 64)
+        y, err2 := strconv.ParseFloat(strings.TrimSpace(b.Value()), 64)
+        if err1 != nil || err2 != nil || math.IsNaN(x) || math.IsNaN(y) {
+            return 0, ErrLiteralsNotComparable
+        }
+        return compareFloats(x, y), nil
+    }
+    x, err1 := a.ToXSDBigDecimal()
+    y, err2 := b.ToXSDBigDecimal()
+    if err1 != nil || err2 != nil {
+        return 0, ErrLiteralsNotComparable
+    }
+    return x.Cmp(y.Rat), nil
+}
+
+var (
+    xsdFloatPattern    = regexp.MustCompile(`^([+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([Ee][+-]?[0-9]+)?|[+-]?INF|NaN)$`)
+    xsdTimezonePattern = regexp.MustCompile(`(Z|[+-][0-9]{2}:[0-9]{2})$`)
+)
+
+// xsdIntegerBounds contains the inclusive bounds of the bounded integer datatypes. Nil bounds are unbounded.
+var xsdIntegerBounds = func() map[string][2]*big.Int {
+    bounds := func(min, max string) [2]*big.Int {
+        var b [2]*big.Int
+        for i, s := range []string{min, max} {
+            if s != "" {
+                b[i], _ = new(big.Int).SetString(s, 10)
+            }
+        }
+        return b
+    }
+    return map[string][2]*big.Int{
+        XSDNonNegativeInteger: bounds("0", ""),
+        XSDPositiveInteger:    bounds("1", ""),
+        "http://www.w3.org/2001/XMLSchema#nonPositiveInteger": bounds("", "0"),
+        "http://www.w3.org/2001/XMLSchema#negativeInteger":    bounds("", "-1"),
+        XSDLong:          bounds("-9223372036854775808", "9223372036854775807"),
+        XSDInt:           bounds("-2147483648", "2147483647"),
+        XSDShort:         bounds("-32768", "32767"),
+        XSDByte:          bounds("-128", "127"),
+        XSDUnsignedLong:  bounds("0", "18446744073709551615"),
+        XSDUnsignedInt:   bounds("0", "4294967295"),
+        XSDUnsignedShort: bounds("0", "65535"),
+        XSDUnsignedByte:  bounds("0", "255"),
+    }
+}()
+
+// checkIntegerRange checks that the integer lies within the value space of the integer datatype.
+func checkIntegerRange(datatype string, val *big.Int) error {
+    bounds := xsdIntegerBounds[datatype]
+    if (bounds[0] != nil && val.Cmp(bounds[0]) < 0) || (bounds[1] != nil && val.Cmp(bounds[1]) > 0) {
+        return strconv.ErrRange
+    }
+    return nil
+}
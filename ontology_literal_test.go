@@ -0,0 +1,348 @@
+package ontograph_test
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Literals", func() {
+	literal := func(value, datatype string) *GenericLiteral {
+		return NewGenericLiteral(NewLiteralTerm(value, "", datatype))
+	}
+	term := func(l interface{ Generic() GenericLiteral }) Term {
+		generic := l.Generic()
+		return generic.Term()
+	}
+
+	It("should convert the numeric literals", func() {
+		Expect(term(XSDLongLiteral(-9223372036854775808))).To(Equal(NewLiteralTerm("-9223372036854775808", "", XSDLong)))
+		Expect(term(XSDIntLiteral(-42))).To(Equal(NewLiteralTerm("-42", "", XSDInt)))
+		Expect(term(XSDShortLiteral(42))).To(Equal(NewLiteralTerm("42", "", XSDShort)))
+		Expect(term(XSDByteLiteral(-128))).To(Equal(NewLiteralTerm("-128", "", XSDByte)))
+		Expect(term(XSDUnsignedLongLiteral(18446744073709551615))).To(Equal(NewLiteralTerm("18446744073709551615", "", XSDUnsignedLong)))
+		Expect(term(XSDUnsignedIntLiteral(7))).To(Equal(NewLiteralTerm("7", "", XSDUnsignedInt)))
+		Expect(term(XSDUnsignedShortLiteral(7))).To(Equal(NewLiteralTerm("7", "", XSDUnsignedShort)))
+		Expect(term(XSDUnsignedByteLiteral(255))).To(Equal(NewLiteralTerm("255", "", XSDUnsignedByte)))
+		Expect(term(XSDNonNegativeIntegerLiteral(0))).To(Equal(NewLiteralTerm("0", "", XSDNonNegativeInteger)))
+		Expect(term(XSDPositiveIntegerLiteral(1))).To(Equal(NewLiteralTerm("1", "", XSDPositiveInteger)))
+		Expect(term(XSDFloatLiteral(1.5))).To(Equal(NewLiteralTerm("1.5", "", XSDFloat)))
+		Expect(term(XSDDoubleLiteral(math.Inf(-1)))).To(Equal(NewLiteralTerm("-INF", "", XSDDouble)))
+
+		Expect(literal("-42", XSDLong).ToXSDLong()).To(Equal(XSDLongLiteral(-42)))
+		Expect(literal("+42", XSDInt).ToXSDInt()).To(Equal(XSDIntLiteral(42)))
+		Expect(literal("-32768", XSDShort).ToXSDShort()).To(Equal(XSDShortLiteral(-32768)))
+		Expect(literal(" 127 ", XSDByte).ToXSDByte()).To(Equal(XSDByteLiteral(127)))
+		Expect(literal("18446744073709551615", XSDUnsignedLong).ToXSDUnsignedLong()).To(Equal(XSDUnsignedLongLiteral(18446744073709551615)))
+		Expect(literal("4294967295", XSDUnsignedInt).ToXSDUnsignedInt()).To(Equal(XSDUnsignedIntLiteral(4294967295)))
+		Expect(literal("65535", XSDUnsignedShort).ToXSDUnsignedShort()).To(Equal(XSDUnsignedShortLiteral(65535)))
+		Expect(literal("+0", XSDUnsignedByte).ToXSDUnsignedByte()).To(Equal(XSDUnsignedByteLiteral(0)))
+		Expect(literal("0", XSDNonNegativeInteger).ToXSDNonNegativeInteger()).To(Equal(XSDNonNegativeIntegerLiteral(0)))
+		Expect(literal("12", XSDPositiveInteger).ToXSDPositiveInteger()).To(Equal(XSDPositiveIntegerLiteral(12)))
+		Expect(literal("1.5E2", XSDFloat).ToXSDFloat()).To(Equal(XSDFloatLiteral(150)))
+		Expect(literal("INF", XSDDouble).ToXSDDouble()).To(Equal(XSDDoubleLiteral(math.Inf(1))))
+		nan, err := literal("NaN", XSDDouble).ToXSDDouble()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(math.IsNaN(float64(nan))).To(BeTrue())
+	})
+
+	It("should reject numeric literals outside of the value space", func() {
+		_, err := literal("128", XSDByte).ToXSDByte()
+		Expect(err).To(MatchError(strconv.ErrRange))
+		_, err = literal("256", XSDUnsignedByte).ToXSDUnsignedByte()
+		Expect(err).To(MatchError(strconv.ErrRange))
+		_, err = literal("0", XSDPositiveInteger).ToXSDPositiveInteger()
+		Expect(err).To(MatchError(strconv.ErrRange))
+		_, err = literal("-1", XSDUnsignedInt).ToXSDUnsignedInt()
+		Expect(err).To(MatchError(strconv.ErrSyntax))
+		_, err = literal("1.5", XSDLong).ToXSDLong()
+		Expect(err).To(MatchError(strconv.ErrSyntax))
+		_, err = literal("42", XSDInteger).ToXSDLong()
+		Expect(err).To(MatchError(ErrLiteralTypeMismatch))
+		_, err = literal("42", XSDDecimal).ToXSDDouble()
+		Expect(err).To(MatchError(ErrLiteralTypeMismatch))
+	})
+	It("should convert the date and time literals", func() {
+		Expect(term(XSDDateLiteral(time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)))).To(Equal(NewLiteralTerm("2021-03-04", "", XSDDate)))
+		Expect(term(XSDDateLiteral(time.Date(2021, 3, 4, 0, 0, 0, 0, time.FixedZone("", 2*3600))))).To(Equal(NewLiteralTerm("2021-03-04+02:00", "", XSDDate)))
+		Expect(term(XSDTimeLiteral(time.Date(0, 1, 1, 13, 5, 9, 500000000, time.UTC)))).To(Equal(NewLiteralTerm("13:05:09.5", "", XSDTime)))
+		Expect(term(XSDGYearLiteral(time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC)))).To(Equal(NewLiteralTerm("1999", "", XSDGYear)))
+		Expect(term(XSDGYearMonthLiteral(time.Date(1999, 12, 1, 0, 0, 0, 0, time.UTC)))).To(Equal(NewLiteralTerm("1999-12", "", XSDGYearMonth)))
+
+		date, err := literal("2021-03-04", XSDDate).ToXSDDate()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Time(date)).To(Equal(time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)))
+		date, err = literal("2021-03-04-05:00", XSDDate).ToXSDDate()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Time(date).Equal(time.Date(2021, 3, 4, 5, 0, 0, 0, time.UTC))).To(BeTrue())
+		tm, err := literal("13:05:09.25Z", XSDTime).ToXSDTime()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Time(tm)).To(Equal(time.Date(0, 1, 1, 13, 5, 9, 250000000, time.UTC)))
+		year, err := literal("1999", XSDGYear).ToXSDGYear()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Time(year).Year()).To(Equal(1999))
+		yearMonth, err := literal("1999-12Z", XSDGYearMonth).ToXSDGYearMonth()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Time(yearMonth)).To(Equal(time.Date(1999, 12, 1, 0, 0, 0, 0, time.UTC)))
+
+		// Lexical forms round-trip
+		for _, lit := range []*GenericLiteral{literal("2021-03-04", XSDDate), literal("23:59:59", XSDTime), literal("2021+01:00", XSDGYear)} {
+			var generic GenericLiteral
+			switch lit.Type().URI {
+			case XSDDate:
+				v, err := lit.ToXSDDate()
+				Expect(err).NotTo(HaveOccurred())
+				generic = v.Generic()
+			case XSDTime:
+				v, err := lit.ToXSDTime()
+				Expect(err).NotTo(HaveOccurred())
+				generic = v.Generic()
+			case XSDGYear:
+				v, err := lit.ToXSDGYear()
+				Expect(err).NotTo(HaveOccurred())
+				generic = v.Generic()
+			}
+			Expect(generic.Value()).To(Equal(lit.Value()))
+		}
+	})
+
+	It("should reject invalid date and time literals", func() {
+		_, err := literal("2021-13-01", XSDDate).ToXSDDate()
+		Expect(err).To(HaveOccurred())
+		_, err = literal("25:00:00", XSDTime).ToXSDTime()
+		Expect(err).To(HaveOccurred())
+		_, err = literal("99", XSDGYear).ToXSDGYear()
+		Expect(err).To(HaveOccurred())
+		_, err = literal("2021-03-04T10:00:00Z", XSDDateTime).ToXSDDate()
+		Expect(err).To(MatchError(ErrLiteralTypeMismatch))
+	})
+	It("should convert the duration literals", func() {
+		for _, value := range []string{"P1Y2M3DT4H5M6.5S", "-P3D", "PT36H", "PT0.001S"} {
+			dur, err := literal(value, XSDDuration).ToXSDDuration()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(term(dur)).To(Equal(NewLiteralTerm(value, "", XSDDuration)))
+		}
+		dur, err := literal("P0Y", XSDDuration).ToXSDDuration()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dur.String()).To(Equal("PT0S"))
+		dur, err = literal("P1Y2M3DT4H5M6.5S", XSDDuration).ToXSDDuration()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dur).To(Equal(XSDDurationLiteral{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6.5}))
+		_, err = dur.Duration()
+		Expect(err).To(MatchError(ErrDurationNotRepresentable))
+
+		dur, err = literal("-P1DT2H30M", XSDDuration).ToXSDDuration()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dur.Duration()).To(Equal(-(26*time.Hour + 30*time.Minute)))
+
+		Expect(NewXSDDurationLiteral(50*time.Hour + 90*time.Second + 250*time.Millisecond).String()).To(Equal("P2DT2H1M30.25S"))
+		Expect(NewXSDDurationLiteral(-90 * time.Minute).String()).To(Equal("-PT1H30M"))
+		Expect(NewXSDDurationLiteral(0).String()).To(Equal("PT0S"))
+		Expect(NewXSDDurationLiteral(90 * time.Minute).Duration()).To(Equal(90 * time.Minute))
+	})
+
+	It("should reject invalid duration literals", func() {
+		for _, value := range []string{"P", "PT", "P1DT", "1D", "P1H", "PT1D", "P-1D", "P1.5D"} {
+			_, err := literal(value, XSDDuration).ToXSDDuration()
+			Expect(err).To(MatchError(ErrInvalidLexicalForm), value)
+		}
+		_, err := literal("PT1S", XSDString).ToXSDDuration()
+		Expect(err).To(MatchError(ErrLiteralTypeMismatch))
+	})
+	It("should convert the binary literals", func() {
+		payload := []byte{0xde, 0xad, 0xbe, 0xef, 0x00}
+		Expect(term(XSDBase64BinaryLiteral(payload))).To(Equal(NewLiteralTerm("3q2+7wA=", "", XSDBase64Binary)))
+		Expect(term(XSDHexBinaryLiteral(payload))).To(Equal(NewLiteralTerm("DEADBEEF00", "", XSDHexBinary)))
+
+		Expect(literal("3q2+\n7wA=", XSDBase64Binary).ToXSDBase64Binary()).To(Equal(XSDBase64BinaryLiteral(payload)))
+		Expect(literal("deadBEEF00", XSDHexBinary).ToXSDHexBinary()).To(Equal(XSDHexBinaryLiteral(payload)))
+
+		_, err := literal("not base64!", XSDBase64Binary).ToXSDBase64Binary()
+		Expect(err).To(MatchError(ErrInvalidLexicalForm))
+		_, err = literal("ABC", XSDHexBinary).ToXSDHexBinary()
+		Expect(err).To(MatchError(ErrInvalidLexicalForm))
+		_, err = literal("DEADBEEF", XSDString).ToXSDHexBinary()
+		Expect(err).To(MatchError(ErrLiteralTypeMismatch))
+	})
+	It("should handle language-tagged literals", func() {
+		generic := LangStringLiteral{Value: "Haus", Lang: "de"}.Generic()
+		Expect(generic.Term()).To(Equal(NewLiteralTerm("Haus", "de", "")))
+		Expect(generic.Language()).To(Equal("de"))
+		Expect(generic.Type().URI).To(Equal(RDFLangString))
+		Expect(generic.ToLangString()).To(Equal(LangStringLiteral{Value: "Haus", Lang: "de"}))
+		_, err := literal("house", XSDString).ToLangString()
+		Expect(err).To(MatchError(ErrLiteralTypeMismatch))
+	})
+
+	It("should look up language-tagged data property values with fallbacks", func() {
+		const prop = "http://example.com/onto#title"
+		indiv := OntologyIndividual{}
+		indiv.AddLangString(prop, "Fahrstuhl", "de")
+		indiv.AddLangString(prop, "Lift", "en-GB")
+		indiv.AddLangString(prop, "elevator", "")
+		indiv.AddDataProperty(prop, XSDIntegerLiteral(42).Generic())
+		lookup := func(langs ...string) LangStringLiteral {
+			value, ok := indiv.GetLangString(prop, langs...)
+			Expect(ok).To(BeTrue())
+			return value
+		}
+
+		Expect(lookup("de-AT")).To(Equal(LangStringLiteral{Value: "Fahrstuhl", Lang: "de"}))
+		Expect(lookup("EN-gb")).To(Equal(LangStringLiteral{Value: "Lift", Lang: "en-GB"}))
+		Expect(lookup("en", "de")).To(Equal(LangStringLiteral{Value: "Fahrstuhl", Lang: "de"}))
+		Expect(lookup("fr")).To(Equal(LangStringLiteral{Value: "elevator"}))
+		_, ok := indiv.GetLangString("http://example.com/onto#other", "de")
+		Expect(ok).To(BeFalse())
+	})
+	It("should convert arbitrary precision integer and decimal literals", func() {
+		huge := "-123456789012345678901234567890"
+		val, err := literal(huge, XSDInteger).ToXSDBigInteger()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val.String()).To(Equal(huge))
+		Expect(term(val)).To(Equal(NewLiteralTerm(huge, "", XSDInteger)))
+		val, err = literal("+18446744073709551616", XSDNonNegativeInteger).ToXSDBigInteger()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val.String()).To(Equal("18446744073709551616"))
+		Expect(term(XSDBigIntegerLiteral{})).To(Equal(NewLiteralTerm("0", "", XSDInteger)))
+		_, err = literal("1.0", XSDInteger).ToXSDBigInteger()
+		Expect(err).To(MatchError(ErrInvalidLexicalForm))
+		_, err = literal("0x10", XSDInteger).ToXSDBigInteger()
+		Expect(err).To(MatchError(ErrInvalidLexicalForm))
+		_, err = literal("1", XSDDecimal).ToXSDBigInteger()
+		Expect(err).To(MatchError(ErrLiteralTypeMismatch))
+
+		dec, err := literal("12345678901234567890.000000000000000000001", XSDDecimal).ToXSDBigDecimal()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(term(dec)).To(Equal(NewLiteralTerm("12345678901234567890.000000000000000000001", "", XSDDecimal)))
+		dec, err = literal("-.5", XSDDecimal).ToXSDBigDecimal()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dec.Rat).To(Equal(big.NewRat(-1, 2)))
+		dec, err = literal("42", XSDInteger).ToXSDBigDecimal()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(term(dec)).To(Equal(NewLiteralTerm("42.0", "", XSDDecimal)))
+		Expect(term(XSDBigDecimalLiteral{big.NewRat(1, 3)})).To(Equal(NewLiteralTerm("0."+strings.Repeat("3", 34), "", XSDDecimal)))
+		Expect(term(XSDBigDecimalLiteral{big.NewRat(3, 40)})).To(Equal(NewLiteralTerm("0.075", "", XSDDecimal)))
+		_, err = literal("1e5", XSDDecimal).ToXSDBigDecimal()
+		Expect(err).To(MatchError(ErrInvalidLexicalForm))
+		_, err = literal("1/3", XSDDecimal).ToXSDBigDecimal()
+		Expect(err).To(MatchError(ErrInvalidLexicalForm))
+	})
+	It("should round-trip every literal type through generic literals", func() {
+		generic := XSDIntegerLiteral(-42).Generic()
+		Expect(generic.ToXSDInteger()).To(Equal(XSDIntegerLiteral(-42)))
+		generic = XSDDecimalLiteral(1.25).Generic()
+		Expect(generic.ToXSDDecimal()).To(Equal(XSDDecimalLiteral(1.25)))
+		generic = XSDStringLiteral("text").Generic()
+		Expect(generic.ToXSDString()).To(Equal(XSDStringLiteral("text")))
+		generic = XSDBooleanLiteral(true).Generic()
+		Expect(generic.ToXSDBoolean()).To(Equal(XSDBooleanLiteral(true)))
+		generic = XSDAnyURILiteral("http://example.com").Generic()
+		Expect(generic.ToXSDAnyURI()).To(Equal(XSDAnyURILiteral("http://example.com")))
+		_, err := literal("42", XSDLong).ToXSDInteger()
+		Expect(err).To(MatchError(ErrLiteralTypeMismatch))
+	})
+
+	It("should coerce numeric literals leniently if requested", func() {
+		Expect(literal("42", XSDLong).ToXSDInteger(WithNumericCoercion())).To(Equal(XSDIntegerLiteral(42)))
+		Expect(literal("42.0", XSDDecimal).ToXSDInteger(WithNumericCoercion())).To(Equal(XSDIntegerLiteral(42)))
+		Expect(literal("4.2E1", XSDDouble).ToXSDByte(WithNumericCoercion())).To(Equal(XSDByteLiteral(42)))
+		Expect(literal(" 7 ", XSDString).ToXSDUnsignedInt(WithNumericCoercion())).To(Equal(XSDUnsignedIntLiteral(7)))
+		Expect(NewGenericLiteral(NewLiteralTerm("2.5", "", "")).ToXSDDouble(WithNumericCoercion())).To(Equal(XSDDoubleLiteral(2.5)))
+		Expect(literal("3", XSDInteger).ToXSDDecimal(WithNumericCoercion())).To(Equal(XSDDecimalLiteral(3)))
+		Expect(literal("1.5", XSDDecimal).ToXSDFloat(WithNumericCoercion())).To(Equal(XSDFloatLiteral(1.5)))
+
+		_, err := literal("4.2", XSDDecimal).ToXSDInteger(WithNumericCoercion())
+		Expect(err).To(MatchError(strconv.ErrSyntax))
+		_, err = literal("300", XSDInteger).ToXSDByte(WithNumericCoercion())
+		Expect(err).To(MatchError(strconv.ErrRange))
+		_, err = literal("true", XSDBoolean).ToXSDInteger(WithNumericCoercion())
+		Expect(err).To(MatchError(ErrLiteralTypeMismatch))
+		_, err = literal("1", XSDString).ToXSDInteger()
+		Expect(err).To(MatchError(ErrLiteralTypeMismatch))
+	})
+	It("should compare literals by value", func() {
+		compare := func(a, b *GenericLiteral) int {
+			c, err := a.Compare(b)
+			Expect(err).NotTo(HaveOccurred())
+			return c
+		}
+		Expect(compare(literal("10", XSDInteger), literal("9.5", XSDDecimal))).To(Equal(1))
+		Expect(compare(literal("12345678901234567890", XSDInteger), literal("12345678901234567891", XSDLong))).To(Equal(-1))
+		Expect(compare(literal("1.50", XSDDecimal), literal("1.5", XSDDecimal))).To(Equal(0))
+		Expect(compare(literal("1E1", XSDDouble), literal("10", XSDInteger))).To(Equal(0))
+		Expect(compare(literal("-INF", XSDDouble), literal("-1", XSDInteger))).To(Equal(-1))
+		Expect(compare(literal("false", XSDBoolean), literal("true", XSDBoolean))).To(Equal(-1))
+		Expect(compare(literal("apple", XSDString), NewGenericLiteral(NewLiteralTerm("banana", "", "")))).To(Equal(-1))
+		Expect(compare(NewGenericLiteral(NewLiteralTerm("b", "en", "")), NewGenericLiteral(NewLiteralTerm("a", "EN", "")))).To(Equal(1))
+		Expect(compare(literal("2021-03-04T10:00:00+02:00", XSDDateTime), literal("2021-03-04T09:00:00Z", XSDDateTime))).To(Equal(-1))
+		Expect(compare(literal("2021-03-04T09:00:00.5Z", XSDDateTime), literal("2021-03-04T09:00:00", XSDDateTime))).To(Equal(1))
+		Expect(compare(literal("2021-03-04", XSDDate), literal("2021-03-04Z", XSDDate))).To(Equal(0))
+		Expect(compare(literal("1999", XSDGYear), literal("2000", XSDGYear))).To(Equal(-1))
+
+		for _, pair := range [][2]*GenericLiteral{
+			{literal("1", XSDInteger), literal("1", XSDString)},
+			{literal("NaN", XSDDouble), literal("1", XSDDouble)},
+			{NewGenericLiteral(NewLiteralTerm("a", "en", "")), NewGenericLiteral(NewLiteralTerm("a", "de", ""))},
+			{literal("2021-03-04", XSDDate), literal("2021-03-04T00:00:00Z", XSDDateTime)},
+			{literal("x", XSDInteger), literal("1", XSDInteger)},
+			{literal("P1D", XSDDuration), literal("P1D", XSDDuration)},
+		} {
+			_, err := pair[0].Compare(pair[1])
+			Expect(err).To(MatchError(ErrLiteralsNotComparable))
+		}
+	})
+	It("should canonicalize literals", func() {
+		for _, c := range []struct{ value, datatype, canonical string }{
+			{"01", XSDInteger, "1"},
+			{"+0042", XSDLong, "42"},
+			{"-0", XSDInteger, "0"},
+			{"1.50", XSDDecimal, "1.5"},
+			{"+3", XSDDecimal, "3.0"},
+			{"1.5E2", XSDDouble, "150"},
+			{"-INF", XSDFloat, "-INF"},
+			{"1", XSDBoolean, "true"},
+			{"0", XSDBoolean, "false"},
+			{"2021-03-04T10:00:00+02:00", XSDDateTime, "2021-03-04T08:00:00Z"},
+			{"2021-03-04T10:00:00.500", XSDDateTime, "2021-03-04T10:00:00.5"},
+			{"10:00:00-01:00", XSDTime, "11:00:00Z"},
+			{"2021-03-04+00:00", XSDDate, "2021-03-04Z"},
+			{"P0Y1D", XSDDuration, "P1D"},
+			{"deadbeef", XSDHexBinary, "DEADBEEF"},
+			{" text ", XSDString, " text "},
+		} {
+			lit, err := literal(c.value, c.datatype).Canonicalize()
+			Expect(err).NotTo(HaveOccurred(), c.value)
+			Expect(lit.Term()).To(Equal(NewLiteralTerm(c.canonical, "", c.datatype)), c.value)
+		}
+		lit, err := NewGenericLiteral(NewLiteralTerm("Haus", "DE-at", "")).Canonicalize()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lit.Term()).To(Equal(NewLiteralTerm("Haus", "de-at", "")))
+	})
+
+	It("should validate the lexical forms of literals", func() {
+		for _, c := range []struct{ value, datatype string }{
+			{"1.0", XSDInteger},
+			{"128", XSDByte},
+			{"-1", XSDUnsignedLong},
+			{"0", XSDPositiveInteger},
+			{"1", "http://www.w3.org/2001/XMLSchema#negativeInteger"},
+			{"1e5", XSDDecimal},
+			{"Infinity", XSDDouble},
+			{"yes", XSDBoolean},
+			{"2021-02-30", XSDDate},
+			{"P", XSDDuration},
+			{"xyz", XSDHexBinary},
+		} {
+			Expect(literal(c.value, c.datatype).Validate()).To(MatchError(ErrInvalidLexicalForm), c.value)
+		}
+		Expect(literal("18446744073709551615", XSDUnsignedLong).Validate()).To(Succeed())
+		Expect(literal("anything", "http://example.com/onto#Custom").Validate()).To(Succeed())
+	})
+})
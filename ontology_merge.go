@@ -0,0 +1,112 @@
+package ontograph
+
+// MergeConflictPolicy controls how resources are merged that are defined differently in the destination and a source ontology.
+type MergeConflictPolicy int
+
+const (
+	// MergeSkip keeps the resource of the destination and skips the resource of the source.
+	MergeSkip MergeConflictPolicy = iota
+	// MergeOverwrite replaces the resource of the destination with the resource of the source.
+	MergeOverwrite
+	// MergeError aborts the merge with `ErrMergeConflict`.
+	MergeError
+	// MergeUnion keeps the values of both resources.
+	MergeUnion
+)
+
+// MergeOptions configures how ontologies are merged (see `MergeOntologies`).
+type MergeOptions struct {
+	// Conflicts is the policy for resources that are defined differently in the destination and a source ontology.
+	Conflicts MergeConflictPolicy
+	// RewriteBaseURIs rewrites the URIs in the namespace of a source ontology to the namespace of the destination ontology.
+	RewriteBaseURIs bool
+}
+
+// MergeOntologies copies the resources of the source ontologies into the destination ontology. The ontology headers of the sources are
+// not copied. Blank nodes of the sources (e.g. restrictions) are relabeled, so that they do not collide with the blank nodes of the
+// destination. Sources are merged in the given order, so with `MergeOverwrite` the last source wins.
+func MergeOntologies(dst *OntologyGraph, opts MergeOptions, srcs ...*OntologyGraph) error {
+	for _, src := range srcs {
+		if err := mergeOntology(dst, src, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// mergeOntology copies the resources of the source ontology into the destination ontology.
+func mergeOntology(dst, src *OntologyGraph, opts MergeOptions) error {
+	trps, err := src.graph.GetAllTriples()
+	if err != nil {
+		return err
+	}
+	// Rewrite base URIs and relabel blank nodes
+	rewrites := parseOptions{}
+	if opts.RewriteBaseURIs {
+		rewrites.rewrites = []uriRewrite{{from: src.GetURI(), to: dst.GetURI()}}
+	}
+	labels := map[Term]Term{}
+	rewrite := func(t Term) Term {
+		switch {
+		case t.IsBlankNode():
+			if _, ok := labels[t]; !ok {
+				labels[t] = dst.newBlankNode()
+			}
+			return labels[t]
+		case t.IsResource():
+			return NewResourceTerm(rewrites.rewriteURI(t.Value()))
+		case t.IsLiteral() && t.Datatype() != "":
+			return NewLiteralTerm(t.Value(), "", rewrites.rewriteURI(t.Datatype()))
+		}
+		return t
+	}
+	for i := range trps {
+		trps[i] = Triple{Subject: rewrite(trps[i].Subject), Predicate: rewrite(trps[i].Predicate), Object: rewrite(trps[i].Object)}
+	}
+	// Merge the resources with their blank nodes one by one
+	groups, _ := groupResourceTriples(trps)
+	delete(groups, rewrites.rewriteURI(src.GetURI()))
+	uris := map[string]bool{}
+	for uri := range groups {
+		uris[uri] = true
+	}
+	for _, uri := range sortedKeys(uris) {
+		if err := mergeResource(dst, uri, groups[uri], opts.Conflicts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeResource merges the triples of the resource from a source ontology into the destination ontology.
+func mergeResource(dst *OntologyGraph, uri string, srcTrps []Triple, policy MergeConflictPolicy) error {
+	dstTrps, err := dst.graph.GetAllMatches(NewResourceTerm(uri).String(), "", "")
+	if err != nil {
+		return err
+	}
+	if len(dstTrps) > 0 && policy != MergeUnion {
+		blankNodeTrps, err := dst.getBlankNodeTriples(dstTrps)
+		if err != nil {
+			return err
+		}
+		dstTrps = append(dstTrps, blankNodeClosure(dstTrps, blankNodeTrps)...)
+		if DiffTriples(dstTrps, srcTrps).IsEmpty() {
+			return nil
+		}
+		switch policy {
+		case MergeSkip:
+			return nil
+		case MergeError:
+			return wrapResourceError("MergeOntologies", uri, ErrMergeConflict)
+		case MergeOverwrite:
+			if err := dst.deleteSubjectTriples(uri); err != nil {
+				return err
+			}
+		}
+	}
+	return dst.graph.AddTriplesUnchecked(srcTrps)
+}
@@ -0,0 +1,104 @@
+package ontograph_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Merging ontologies", func() {
+	const dstNS = "http://example.com/dst#"
+	const srcNS = "http://example.com/src#"
+	var dst, src *OntologyGraph
+	var dstStore *MemoryStore
+
+	load := func(ttl string) (*OntologyGraph, *MemoryStore) {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+` + ttl))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err := LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		return ont, store
+	}
+
+	BeforeEach(func() {
+		dst, dstStore = load(`@prefix : <http://example.com/dst#> .
+<http://example.com/dst> a owl:Ontology ; rdfs:label "Destination" .
+:Person a owl:Class ; rdfs:label "Person" .
+`)
+		src, _ = load(`@prefix : <http://example.com/src#> .
+<http://example.com/src> a owl:Ontology ; rdfs:label "Source" .
+<http://example.com/dst#Person> a owl:Class ; rdfs:label "Human" ; rdfs:subClassOf <http://example.com/dst#Agent> .
+:Parent a owl:Class ; rdfs:subClassOf <http://example.com/dst#Person>, [
+	a owl:Restriction ; owl:onProperty :hasChild ; owl:someValuesFrom <http://example.com/dst#Person>
+] .
+:hasChild a owl:ObjectProperty .
+`)
+	})
+
+	It("should copy the resources without the ontology header", func() {
+		Expect(MergeOntologies(dst, MergeOptions{}, src)).To(Succeed())
+		Expect(dst.GetLabel("")).To(Equal("Destination"))
+		class, err := dst.GetClass(srcNS + "Parent")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.SubClassOf).To(Equal([]string{dstNS + "Person"}))
+		Expect(class.Restrictions).To(HaveLen(1))
+		_, err = dst.GetObjectProperty(srcNS + "hasChild")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should skip conflicting resources by default", func() {
+		Expect(MergeOntologies(dst, MergeOptions{}, src)).To(Succeed())
+		class, err := dst.GetClass(dstNS + "Person")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.Label).To(Equal(map[string]string{"": "Person"}))
+		Expect(class.SubClassOf).To(BeEmpty())
+	})
+
+	It("should overwrite conflicting resources", func() {
+		Expect(MergeOntologies(dst, MergeOptions{Conflicts: MergeOverwrite}, src)).To(Succeed())
+		class, err := dst.GetClass(dstNS + "Person")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.Label).To(Equal(map[string]string{"": "Human"}))
+		Expect(class.SubClassOf).To(Equal([]string{dstNS + "Agent"}))
+	})
+
+	It("should fail on conflicting resources", func() {
+		err := MergeOntologies(dst, MergeOptions{Conflicts: MergeError}, src)
+		Expect(errors.Is(err, ErrMergeConflict)).To(BeTrue())
+		var resErr *ResourceError
+		Expect(errors.As(err, &resErr)).To(BeTrue())
+		Expect(resErr.URI).To(Equal(dstNS + "Person"))
+	})
+
+	It("should not treat equal resources as conflicts", func() {
+		Expect(MergeOntologies(dst, MergeOptions{Conflicts: MergeError}, src)).NotTo(Succeed())
+		Expect(MergeOntologies(src, MergeOptions{Conflicts: MergeError}, src)).To(Succeed())
+	})
+
+	It("should unite the values of conflicting resources", func() {
+		Expect(MergeOntologies(dst, MergeOptions{Conflicts: MergeUnion}, src)).To(Succeed())
+		class, err := dst.GetClass(dstNS + "Person")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.SubClassOf).To(Equal([]string{dstNS + "Agent"}))
+		trps, err := dstStore.GetAllMatches(NewResourceTerm(dstNS+"Person").String(), NewResourceTerm(RDFSLabel).String(), "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trps).To(HaveLen(2))
+	})
+
+	It("should rewrite the base URIs of the sources", func() {
+		Expect(MergeOntologies(dst, MergeOptions{RewriteBaseURIs: true}, src)).To(Succeed())
+		class, err := dst.GetClass(dstNS + "Parent")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.Restrictions).To(HaveLen(1))
+		Expect(class.Restrictions[0].OnProperty).To(Equal(dstNS + "hasChild"))
+		_, err = dst.GetClass(srcNS + "Parent")
+		Expect(errors.Is(err, ErrResourceNotFound)).To(BeTrue())
+		Expect(dst.GetLabel("")).To(Equal("Destination"))
+	})
+})
@@ -0,0 +1,84 @@
+package ontograph
+
+import (
+	"time"
+)
+
+// OntologyMetadata holds the metadata of the ontology header (see `GetMetadata`). Unset values are empty.
+type OntologyMetadata struct {
+	URI                    string
+	Version                string
+	VersionIRI             string
+	PriorVersions          []string
+	BackwardCompatibleWith []string
+	IncompatibleWith       []string
+	Imports                []string
+	// Labels and Comments map language codes to values.
+	Labels   map[string]string
+	Comments map[string]string
+	// Descriptions map language codes to the descriptions of the Dublin Core terms.
+	Descriptions map[string]string
+	Creators     []string
+	Publishers   []string
+	License      string
+	Issued       time.Time
+	Modified     time.Time
+	// Annotations holds all other literal values of the header, keyed by property URI.
+	Annotations map[string][]GenericLiteral
+}
+
+// GetMetadata returns the metadata of the ontology header, i.e. the values of the individual getters like `GetVersion`, `GetImports`,
+// `GetLabel` or `GetCreators`, together with all other literal annotations of the header. The header is retrieved from the graph store
+// with a single request, so the metadata is consistent and cheap to fetch from remote stores. All lists are sorted.
+func (ont *OntologyGraph) GetMetadata() (OntologyMetadata, error) {
+	trps, err := ont.graph.GetAllMatches(NewResourceTerm(ont.GetURI()).String(), "", "")
+	if err != nil {
+		return OntologyMetadata{}, err
+	}
+	md := OntologyMetadata{
+		URI:                    ont.GetURI(),
+		PriorVersions:          headerURIs(trps, OWLPriorVersion),
+		BackwardCompatibleWith: headerURIs(trps, OWLBackwardCompatibleWith),
+		IncompatibleWith:       headerURIs(trps, OWLIncompatibleWith),
+		Imports:                headerURIs(trps, OWLImports),
+		Labels:                 map[string]string{},
+		Comments:               map[string]string{},
+		Descriptions:           map[string]string{},
+		Creators:               headerValues(trps, DCTermsCreator),
+		Publishers:             headerValues(trps, DCTermsPublisher),
+		Annotations:            map[string][]GenericLiteral{},
+	}
+	if versions := headerValues(trps, OWLVersionInfo); len(versions) > 0 {
+		md.Version = versions[0]
+	}
+	if uris := headerURIs(trps, OWLVersionIRI); len(uris) > 0 {
+		md.VersionIRI = uris[0]
+	}
+	if licenses := headerValues(trps, DCTermsLicense); len(licenses) > 0 {
+		md.License = licenses[0]
+	}
+	if md.Issued, err = headerDate(trps, DCTermsIssued); err != nil {
+		return OntologyMetadata{}, err
+	}
+	if md.Modified, err = headerDate(trps, DCTermsModified); err != nil {
+		return OntologyMetadata{}, err
+	}
+	SortTriples(trps)
+	for _, trp := range trps {
+		switch trp.Predicate {
+		case NewResourceTerm(RDFSLabel):
+			md.Labels[trp.Object.Language()] = trp.Object.Value()
+		case NewResourceTerm(RDFSComment):
+			md.Comments[trp.Object.Language()] = trp.Object.Value()
+		case NewResourceTerm(DCTermsDescription):
+			md.Descriptions[trp.Object.Language()] = trp.Object.Value()
+		case NewResourceTerm(OWLVersionInfo), NewResourceTerm(DCTermsCreator), NewResourceTerm(DCTermsPublisher),
+			NewResourceTerm(DCTermsLicense), NewResourceTerm(DCTermsIssued), NewResourceTerm(DCTermsModified):
+		default:
+			if trp.Object.IsLiteral() {
+				md.Annotations[trp.Predicate.Value()] = append(md.Annotations[trp.Predicate.Value()], *NewGenericLiteral(trp.Object))
+			}
+		}
+	}
+	return md, nil
+}
@@ -0,0 +1,59 @@
+package ontograph_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Ontology metadata", func() {
+	It("should return the header metadata in one call", func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix dcterms: <http://purl.org/dc/terms/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.com/onto> a owl:Ontology ;
+  owl:versionInfo "2.0" ;
+  owl:versionIRI <http://example.com/onto/2.0> ;
+  owl:priorVersion <http://example.com/onto/1.0> ;
+  owl:imports <http://example.com/b>, <http://example.com/a> ;
+  rdfs:label "Onto", "Ontologie"@de ;
+  rdfs:comment "An ontology"@en ;
+  dcterms:description "Eine Ontologie"@de ;
+  dcterms:creator "Jane Doe", <http://example.com/people/john> ;
+  dcterms:license <http://example.com/license> ;
+  dcterms:issued "2024-05-01"^^xsd:date ;
+  dcterms:source "https://example.com/docs" .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err := LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+
+		md, err := ont.GetMetadata()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(md.URI).To(Equal("http://example.com/onto"))
+		Expect(md.Version).To(Equal("2.0"))
+		Expect(md.VersionIRI).To(Equal("http://example.com/onto/2.0"))
+		Expect(md.PriorVersions).To(Equal([]string{"http://example.com/onto/1.0"}))
+		Expect(md.BackwardCompatibleWith).To(BeEmpty())
+		Expect(md.Imports).To(Equal([]string{"http://example.com/a", "http://example.com/b"}))
+		Expect(md.Labels).To(Equal(map[string]string{"": "Onto", "de": "Ontologie"}))
+		Expect(md.Comments).To(Equal(map[string]string{"en": "An ontology"}))
+		Expect(md.Descriptions).To(Equal(map[string]string{"de": "Eine Ontologie"}))
+		Expect(md.Creators).To(Equal([]string{"Jane Doe", "http://example.com/people/john"}))
+		Expect(md.Publishers).To(BeEmpty())
+		Expect(md.License).To(Equal("http://example.com/license"))
+		Expect(md.Issued).To(Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)))
+		Expect(md.Modified.IsZero()).To(BeTrue())
+		Expect(md.Annotations).To(HaveLen(1))
+		Expect(md.Annotations[DCTermsSource]).To(HaveLen(1))
+		Expect(md.Annotations[DCTermsSource][0].Value()).To(Equal("https://example.com/docs"))
+
+		Expect(ont.GetCreators()).To(Equal(md.Creators))
+		Expect(ont.GetIssued()).To(Equal(md.Issued))
+	})
+})
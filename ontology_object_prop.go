@@ -2,13 +2,15 @@ package ontograph
 
 // An OntologyObjectProperty represents an object property from an ontology.
 type OntologyObjectProperty struct {
-	URI                 string
-	EquivalentTo        []string
-	SubPropertyOf       []string
-	InverseOf           []string
-	Domains             []string
-	Ranges              []string
-	DisjointWith        []string
+	URI           string
+	EquivalentTo  []string
+	SubPropertyOf []string
+	InverseOf     []string
+	Domains       []string
+	Ranges        []string
+	DisjointWith  []string
+	// PropertyChains are the chains of properties that imply the property, e.g. `[hasParent hasParent]` for `hasGrandparent`.
+	PropertyChains      [][]string
 	IsFunctional        bool
 	IsInverseFunctional bool
 	IsTransitive        bool
@@ -16,8 +18,11 @@ type OntologyObjectProperty struct {
 	IsAsymmetric        bool
 	IsReflexive         bool
 	IsIrreflexive       bool
+	IsDeprecated        bool
 	Label               map[string]string
 	Comment             map[string]string
+	// Annotations hold all other literal-valued annotations of the property, keyed by annotation property.
+	Annotations map[string][]GenericLiteral
 }
 
 // GetURI returns the URI of the object property.
@@ -85,6 +90,20 @@ func (prop *OntologyObjectProperty) ToTriples() []Triple {
 		})
 	}
 
+	// Add property chain triples
+	for _, chain := range prop.PropertyChains {
+		items := []Term{}
+		for _, uri := range chain {
+			items = append(items, NewResourceTerm(uri))
+		}
+		head, listTrps := rdfListTriples(items)
+		trps = append(trps, Triple{
+			Subject:   subj,
+			Predicate: NewResourceTerm(OWLPropertyChainAxiom),
+			Object:    head,
+		})
+		trps = append(trps, listTrps...)
+	}
 	// Add logical property triples
 	if prop.IsFunctional {
 		trps = append(trps, Triple{
@@ -136,6 +155,10 @@ func (prop *OntologyObjectProperty) ToTriples() []Triple {
 		})
 	}
 
+	// Add deprecation flag
+	if prop.IsDeprecated {
+		trps = append(trps, deprecationTriple(subj))
+	}
 	// Add labels
 	for lang, label := range prop.Label {
 		trps = append(trps, Triple{
@@ -152,6 +175,8 @@ func (prop *OntologyObjectProperty) ToTriples() []Triple {
 			Object:    NewLiteralTerm(comment, lang, ""),
 		})
 	}
+	// Add annotations
+	trps = append(trps, annotationTriples(subj, prop.Annotations)...)
 	// Done, return triples
 	return trps
 }
@@ -0,0 +1,52 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Property chain axioms", func() {
+	const ns = "http://example.com/onto#"
+	var store *MemoryStore
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+<http://example.com/onto> a owl:Ontology .
+:hasUncle a owl:ObjectProperty ; owl:propertyChainAxiom _:l1 .
+_:l1 rdf:first :hasParent ; rdf:rest _:l2 .
+_:l2 rdf:first :hasBrother ; rdf:rest rdf:nil .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should parse property chains", func() {
+		prop, err := ont.GetObjectProperty(ns + "hasUncle")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prop.PropertyChains).To(Equal([][]string{{ns + "hasParent", ns + "hasBrother"}}))
+	})
+
+	It("should round-trip property chains", func() {
+		prop := OntologyObjectProperty{
+			URI:            ns + "hasGrandparent",
+			PropertyChains: [][]string{{ns + "hasParent", ns + "hasParent"}},
+		}
+		Expect(ont.UpsertResource(&prop)).To(Succeed())
+		retProp, err := ont.GetObjectProperty(prop.URI)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retProp.PropertyChains).To(Equal(prop.PropertyChains))
+		// The list nodes are replaced on upsert
+		size, _ := store.Size()
+		Expect(ont.UpsertResource(&prop)).To(Succeed())
+		Expect(store.Size()).To(Equal(size))
+	})
+})
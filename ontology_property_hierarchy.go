@@ -0,0 +1,56 @@
+package ontograph
+
+import (
+	"sort"
+)
+
+// GetSubProperties returns the sorted URIs of the properties that specialize the property (`rdfs:subPropertyOf`). If `direct` is
+// true, only the direct sub properties are returned, otherwise the hierarchy is traversed transitively.
+func (ont *OntologyGraph) GetSubProperties(uri string, direct bool) ([]string, error) {
+	uri = ont.prefixes.Expand(uri)
+	return ont.getPathURIs(uri, hierarchyPath("^", RDFSSubPropertyOf, direct))
+}
+
+// GetSuperProperties returns the sorted URIs of the properties that the property specializes (`rdfs:subPropertyOf`). If `direct` is
+// true, only the direct super properties are returned, otherwise the hierarchy is traversed transitively.
+func (ont *OntologyGraph) GetSuperProperties(uri string, direct bool) ([]string, error) {
+	uri = ont.prefixes.Expand(uri)
+	return ont.getPathURIs(uri, hierarchyPath("", RDFSSubPropertyOf, direct))
+}
+
+// GetInverseProperties returns the sorted URIs of the properties that are declared inverse to the property (`owl:inverseOf`), regardless
+// of the direction of the declaration.
+func (ont *OntologyGraph) GetInverseProperties(uri string) ([]string, error) {
+	uri = ont.prefixes.Expand(uri)
+	return ont.getPathURIs(uri, NewResourceTerm(OWLInverseOf).String()+"|^"+NewResourceTerm(OWLInverseOf).String())
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// hierarchyPath returns the property path that follows the hierarchy predicate (in inverse direction if `inverse` is `^`) either one
+// step or transitively.
+func hierarchyPath(inverse, pred string, direct bool) string {
+	path := inverse + NewResourceTerm(pred).String()
+	if !direct {
+		path += "+"
+	}
+	return path
+}
+
+// getPathURIs returns the sorted URIs of the resources that are reachable from the URI via the property path, excluding the URI itself.
+func (ont *OntologyGraph) getPathURIs(uri, path string) ([]string, error) {
+	targets, err := GetPathTargets(ont.graph, NewResourceTerm(uri).String(), path)
+	if err != nil {
+		return nil, err
+	}
+	uris := []string{}
+	for _, t := range targets {
+		if t.IsResource() && t.Value() != uri {
+			uris = append(uris, t.Value())
+		}
+	}
+	sort.Strings(uris)
+	return uris, nil
+}
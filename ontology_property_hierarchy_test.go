@@ -0,0 +1,49 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Property hierarchy", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.com/onto> a owl:Ontology .
+:hasRelative a owl:ObjectProperty .
+:hasChild a owl:ObjectProperty ; rdfs:subPropertyOf :hasRelative ; owl:inverseOf :hasParent .
+:hasSon a owl:ObjectProperty ; rdfs:subPropertyOf :hasChild .
+:hasDaughter a owl:ObjectProperty ; rdfs:subPropertyOf :hasChild .
+:hasParent a owl:ObjectProperty ; rdfs:subPropertyOf :hasRelative .
+:isParentOf a owl:ObjectProperty ; owl:inverseOf :hasParent .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should traverse the sub properties", func() {
+		Expect(ont.GetSubProperties(ns+"hasRelative", true)).To(Equal([]string{ns + "hasChild", ns + "hasParent"}))
+		Expect(ont.GetSubProperties(ns+"hasRelative", false)).To(Equal([]string{ns + "hasChild", ns + "hasDaughter", ns + "hasParent", ns + "hasSon"}))
+		Expect(ont.GetSubProperties(ns+"hasSon", false)).To(BeEmpty())
+	})
+
+	It("should traverse the super properties", func() {
+		Expect(ont.GetSuperProperties(ns+"hasSon", true)).To(Equal([]string{ns + "hasChild"}))
+		Expect(ont.GetSuperProperties(ns+"hasSon", false)).To(Equal([]string{ns + "hasChild", ns + "hasRelative"}))
+		Expect(ont.GetSuperProperties(ns+"hasRelative", false)).To(BeEmpty())
+	})
+
+	It("should look up inverse properties in both directions", func() {
+		Expect(ont.GetInverseProperties(ns + "hasParent")).To(Equal([]string{ns + "hasChild", ns + "isParentOf"}))
+		Expect(ont.GetInverseProperties(ns + "hasChild")).To(Equal([]string{ns + "hasParent"}))
+	})
+})
@@ -0,0 +1,119 @@
+package ontograph
+
+// A RenameOption configures how a resource is renamed (see `RenameResource`).
+type RenameOption func(*renameOptions)
+
+// renameOptions holds the configuration compiled from a list of rename options.
+type renameOptions struct {
+	bridge bool
+}
+
+// WithRenameBridge leaves a bridge from the new to the old URI, so that data that still uses the old URI can be related to the renamed
+// resource: `owl:equivalentClass` for classes, `owl:equivalentProperty` for properties and `owl:sameAs` for all other resources.
+func WithRenameBridge() RenameOption {
+	return func(opts *renameOptions) {
+		opts.bridge = true
+	}
+}
+
+// newRenameOptions compiles the given list of options.
+func newRenameOptions(opts []RenameOption) renameOptions {
+	options := renameOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// RenameResource renames the resource by rewriting its URI in all subject, predicate and object positions. If the rewritten triples
+// cannot be added, the original triples are restored. Errors with `ErrResourceNotFound` if the old URI is not used at all, with
+// `ErrResourceAlreadyExists` if the new URI is already used and with `ErrResourceDoesNotBelongToGraph` if the new URI is outside of the
+// namespace of the ontology.
+func (ont *OntologyGraph) RenameResource(oldURI, newURI string, opts ...RenameOption) error {
+	oldURI = ont.prefixes.Expand(oldURI)
+	newURI = ont.prefixes.Expand(newURI)
+	options := newRenameOptions(opts)
+	if !ont.isOwnURI(newURI) {
+		return wrapResourceError("RenameResource", newURI, ErrResourceDoesNotBelongToGraph)
+	}
+	// Check that the new URI is not used yet
+	newTrps, err := ont.getURITriples(newURI)
+	if err != nil {
+		return err
+	}
+	if len(newTrps) > 0 {
+		return wrapResourceError("RenameResource", newURI, ErrResourceAlreadyExists)
+	}
+	// Collect and rewrite all triples that use the old URI
+	oldTrps, err := ont.getURITriples(oldURI)
+	if err != nil {
+		return err
+	}
+	if len(oldTrps) == 0 {
+		return wrapResourceError("RenameResource", oldURI, ErrResourceNotFound)
+	}
+	rewrite := func(t Term) Term {
+		if t == uriTerm(oldURI) {
+			return uriTerm(newURI)
+		}
+		return t
+	}
+	renamedTrps := []Triple{}
+	for _, trp := range oldTrps {
+		renamedTrps = append(renamedTrps, Triple{Subject: rewrite(trp.Subject), Predicate: rewrite(trp.Predicate), Object: rewrite(trp.Object)})
+	}
+	if options.bridge {
+		renamedTrps = append(renamedTrps, Triple{Subject: uriTerm(newURI), Predicate: NewResourceTerm(bridgePredicate(oldTrps, oldURI)), Object: uriTerm(oldURI)})
+	}
+	// Replace the triples and restore the original ones on failure
+	if err := ont.graph.DeleteTriplesUnchecked(oldTrps); err != nil {
+		return err
+	}
+	if err := ont.graph.AddTriplesUnchecked(renamedTrps); err != nil {
+		_ = ont.graph.DeleteTriplesUnchecked(renamedTrps)
+		_ = ont.graph.AddTriplesUnchecked(oldTrps)
+		return err
+	}
+	return nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// getURITriples returns the distinct triples that use the URI in subject, predicate or object position.
+func (ont *OntologyGraph) getURITriples(uri string) ([]Triple, error) {
+	t := uriTerm(uri).String()
+	trps := []Triple{}
+	seen := map[Triple]bool{}
+	for _, pattern := range [][3]string{{t, "", ""}, {"", t, ""}, {"", "", t}} {
+		matches, err := ont.graph.GetAllMatches(pattern[0], pattern[1], pattern[2])
+		if err != nil {
+			return nil, err
+		}
+		for _, trp := range matches {
+			if !seen[trp] {
+				seen[trp] = true
+				trps = append(trps, trp)
+			}
+		}
+	}
+	SortTriples(trps)
+	return trps, nil
+}
+
+// bridgePredicate returns the predicate that relates a renamed resource to its old URI based on the declared type of the resource.
+func bridgePredicate(trps []Triple, uri string) string {
+	for _, trp := range trps {
+		if trp.Subject != uriTerm(uri) || trp.Predicate != NewResourceTerm(RDFType) {
+			continue
+		}
+		switch trp.Object.Value() {
+		case OWLClass:
+			return OWLEquivalentClass
+		case OWLObjectProperty, OWLDatatypeProperty:
+			return OWLEquivalentProperty
+		}
+	}
+	return OWLSameAs
+}
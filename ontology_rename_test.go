@@ -0,0 +1,67 @@
+package ontograph_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Renaming resources", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.com/onto> a owl:Ontology .
+:Person a owl:Class .
+:Parent a owl:Class ; rdfs:subClassOf :Person .
+:hasChild a owl:ObjectProperty ; rdfs:domain :Parent .
+:alice a owl:NamedIndividual, :Parent ; :hasChild :bob .
+:bob a owl:NamedIndividual, :Person .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should rewrite the URI in all positions", func() {
+		Expect(ont.RenameResource(ns+"Parent", ns+"Mother")).To(Succeed())
+		Expect(ont.RenameResource(ns+"hasChild", ns+"hasKid")).To(Succeed())
+
+		_, err := ont.GetClass(ns + "Parent")
+		Expect(errors.Is(err, ErrResourceNotFound)).To(BeTrue())
+		class, err := ont.GetClass(ns + "Mother")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.SubClassOf).To(Equal([]string{ns + "Person"}))
+		prop, err := ont.GetObjectProperty(ns + "hasKid")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prop.Domains).To(Equal([]string{ns + "Mother"}))
+		alice, err := ont.GetIndividual(ns + "alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(alice.Types).To(Equal([]string{ns + "Mother"}))
+		Expect(alice.ObjectProperties).To(Equal(map[string][]string{ns + "hasKid": {ns + "bob"}}))
+	})
+
+	It("should leave a bridge to the old URI", func() {
+		Expect(ont.RenameResource(ns+"Parent", ns+"Mother", WithRenameBridge())).To(Succeed())
+		Expect(ont.RenameResource(ns+"bob", ns+"robert", WithRenameBridge())).To(Succeed())
+		class, err := ont.GetClass(ns + "Mother")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.EquivalentTo).To(Equal([]string{ns + "Parent"}))
+		robert, err := ont.GetIndividual(ns + "robert")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(robert.SameIndividualAs).To(Equal([]string{ns + "bob"}))
+	})
+
+	It("should not rename to existing or foreign URIs", func() {
+		Expect(errors.Is(ont.RenameResource(ns+"Parent", ns+"Person"), ErrResourceAlreadyExists)).To(BeTrue())
+		Expect(errors.Is(ont.RenameResource(ns+"Parent", "http://other.com/onto#Parent"), ErrResourceDoesNotBelongToGraph)).To(BeTrue())
+		Expect(errors.Is(ont.RenameResource(ns+"Unknown", ns+"Other"), ErrResourceNotFound)).To(BeTrue())
+	})
+})
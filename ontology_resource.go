@@ -1,7 +1,167 @@
 package ontograph
 
-// An OntologyResource abstracts a class, object property, data property, datatype property or an individual to a general resource.
+import (
+	"fmt"
+)
+
+// An OntologyResource abstracts a class, object property, data property, annotation property, datatype property, an individual or a
+// SKOS concept (scheme) to a general resource.
 type OntologyResource interface {
 	GetURI() string
 	ToTriples() []Triple
 }
+
+// GetResource retrieves the resource with the specified URI from the graph as the concrete structure that matches its `rdf:type`
+// triples, i.e. a `*OntologyClass`, `*OntologyObjectProperty`, `*OntologyDataProperty`, `*OntologyAnnotationProperty`,
+// `*OntologyDatatype`, `*SKOSConcept`, `*SKOSConceptScheme` or `*OntologyIndividual`. If a resource is declared with several of these
+// types (punning), the first type in this order is used.
+func (ont *OntologyGraph) GetResource(uri string) (OntologyResource, error) {
+	uri = ont.prefixes.Expand(uri)
+	// Retrieve the types of the resource
+	trps, err := ont.graph.GetAllMatches(uriTerm(uri).String(), NewResourceTerm(RDFType).String(), "")
+	if err != nil {
+		return nil, wrapResourceError("GetResource", uri, err)
+	}
+	// Dispatch to the getter of the matching type
+	var res OntologyResource
+	switch resourceKindOf(trps) {
+	case ClassResource:
+		class, getErr := ont.GetClass(uri)
+		res, err = &class, getErr
+	case ObjectPropertyResource:
+		prop, getErr := ont.GetObjectProperty(uri)
+		res, err = &prop, getErr
+	case DataPropertyResource:
+		prop, getErr := ont.GetDataProperty(uri)
+		res, err = &prop, getErr
+	case AnnotationPropertyResource:
+		prop, getErr := ont.GetAnnotationProperty(uri)
+		res, err = &prop, getErr
+	case DatatypeResource:
+		dt, getErr := ont.GetDatatype(uri)
+		res, err = &dt, getErr
+	case SKOSConceptResource:
+		concept, getErr := ont.GetSKOSConcept(uri)
+		res, err = &concept, getErr
+	case SKOSConceptSchemeResource:
+		scheme, getErr := ont.GetSKOSConceptScheme(uri)
+		res, err = &scheme, getErr
+	default:
+		indiv, getErr := ont.GetIndividual(uri)
+		res, err = &indiv, getErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ResourceKind is the kind of a resource as determined by its `rdf:type` triples (see `ResourceExists`).
+type ResourceKind int
+
+const (
+	// NoResource is the kind of URIs that are not the subject of any triple.
+	NoResource ResourceKind = iota
+	// ClassResource is the kind of resources declared as `owl:Class`.
+	ClassResource
+	// ObjectPropertyResource is the kind of resources declared as `owl:ObjectProperty`.
+	ObjectPropertyResource
+	// DataPropertyResource is the kind of resources declared as `owl:DatatypeProperty`.
+	DataPropertyResource
+	// AnnotationPropertyResource is the kind of resources declared as `owl:AnnotationProperty`.
+	AnnotationPropertyResource
+	// DatatypeResource is the kind of resources declared as `rdfs:Datatype`.
+	DatatypeResource
+	// SKOSConceptResource is the kind of resources declared as `skos:Concept`.
+	SKOSConceptResource
+	// SKOSConceptSchemeResource is the kind of resources declared as `skos:ConceptScheme`.
+	SKOSConceptSchemeResource
+	// IndividualResource is the kind of resources declared as `owl:NamedIndividual` and of anonymous individuals.
+	IndividualResource
+	// UndeclaredResource is the kind of URIs that are the subject of triples, but not declared with one of the other kinds.
+	UndeclaredResource
+)
+
+// String returns the name of the resource kind.
+func (kind ResourceKind) String() string {
+	switch kind {
+	case NoResource:
+		return "none"
+	case ClassResource:
+		return "class"
+	case ObjectPropertyResource:
+		return "object property"
+	case DataPropertyResource:
+		return "data property"
+	case AnnotationPropertyResource:
+		return "annotation property"
+	case DatatypeResource:
+		return "datatype"
+	case SKOSConceptResource:
+		return "SKOS concept"
+	case SKOSConceptSchemeResource:
+		return "SKOS concept scheme"
+	case IndividualResource:
+		return "individual"
+	case UndeclaredResource:
+		return "undeclared"
+	}
+	return fmt.Sprintf("ResourceKind(%d)", int(kind))
+}
+
+// ResourceExists checks if the URI is the subject of any triple in the graph and returns the kind of the resource. If the resource is
+// declared with several types, the kind is chosen in the same order as by `GetResource`. Resources that only have other triples (e.g.
+// individuals that are not declared as `owl:NamedIndividual`) are of kind `UndeclaredResource`.
+func (ont *OntologyGraph) ResourceExists(uri string) (bool, ResourceKind, error) {
+	uri = ont.prefixes.Expand(uri)
+	trps, err := ont.graph.GetAllMatches(uriTerm(uri).String(), NewResourceTerm(RDFType).String(), "")
+	if err != nil {
+		return false, NoResource, wrapResourceError("ResourceExists", uri, err)
+	}
+	if kind := resourceKindOf(trps); kind != UndeclaredResource {
+		return true, kind, nil
+	}
+	trp, err := ont.graph.GetFirstMatch(uriTerm(uri).String(), "", "")
+	if err != nil {
+		return false, NoResource, wrapResourceError("ResourceExists", uri, err)
+	}
+	if trp == nil {
+		return false, NoResource, nil
+	}
+	if isAnonymousURI(uri) {
+		return true, IndividualResource, nil
+	}
+	return true, UndeclaredResource, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// resourceKindOf returns the kind of the resource for its `rdf:type` triples. Types other than the ones of the resource kinds are
+// reported as `UndeclaredResource`.
+func resourceKindOf(typeTrps []Triple) ResourceKind {
+	types := map[string]bool{}
+	for _, trp := range typeTrps {
+		types[trp.Object.Value()] = true
+	}
+	switch {
+	case types[OWLClass]:
+		return ClassResource
+	case types[OWLObjectProperty]:
+		return ObjectPropertyResource
+	case types[OWLDatatypeProperty]:
+		return DataPropertyResource
+	case types[OWLAnnotationProperty]:
+		return AnnotationPropertyResource
+	case types[RDFSDatatype]:
+		return DatatypeResource
+	case types[SKOSConceptClass]:
+		return SKOSConceptResource
+	case types[SKOSConceptSchemeClass]:
+		return SKOSConceptSchemeResource
+	case types[OWLNamedIndividual]:
+		return IndividualResource
+	}
+	return UndeclaredResource
+}
@@ -0,0 +1,108 @@
+package ontograph_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Retrieving generic resources", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix skos: <http://www.w3.org/2004/02/skos/core#> .
+<http://example.com/onto> a owl:Ontology .
+:Person a owl:Class .
+:knows a owl:ObjectProperty .
+:age a owl:DatatypeProperty .
+:note a owl:AnnotationProperty .
+:percent a rdfs:Datatype .
+:topic a skos:Concept .
+:topics a skos:ConceptScheme .
+:alice a owl:NamedIndividual, :Person .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should return the concrete structure for the type of the resource", func() {
+		expected := map[string]interface{}{
+			"Person":  &OntologyClass{},
+			"knows":   &OntologyObjectProperty{},
+			"age":     &OntologyDataProperty{},
+			"note":    &OntologyAnnotationProperty{},
+			"percent": &OntologyDatatype{},
+			"topic":   &SKOSConcept{},
+			"topics":  &SKOSConceptScheme{},
+			"alice":   &OntologyIndividual{},
+		}
+		for name, typ := range expected {
+			res, err := ont.GetResource(ns + name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(BeAssignableToTypeOf(typ))
+			Expect(res.GetURI()).To(Equal(ns + name))
+		}
+		res, err := ont.GetResource(ns + "alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.(*OntologyIndividual).Types).To(Equal([]string{ns + "Person"}))
+	})
+
+	It("should fail for unknown resources", func() {
+		res, err := ont.GetResource(ns + "unknown")
+		Expect(errors.Is(err, ErrResourceNotFound)).To(BeTrue())
+		Expect(res).To(BeNil())
+	})
+
+	It("should report the existence and kind of resources", func() {
+		expected := map[string]ResourceKind{
+			"Person":  ClassResource,
+			"knows":   ObjectPropertyResource,
+			"age":     DataPropertyResource,
+			"note":    AnnotationPropertyResource,
+			"percent": DatatypeResource,
+			"topic":   SKOSConceptResource,
+			"topics":  SKOSConceptSchemeResource,
+			"alice":   IndividualResource,
+		}
+		for name, kind := range expected {
+			exists, actual, err := ont.ResourceExists(ns + name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(actual).To(Equal(kind), name)
+		}
+
+		store, err := ParseFromTurtle(strings.NewReader(`<http://example.com/onto> a <http://www.w3.org/2002/07/owl#Ontology> .
+<http://example.com/onto#bob> a <http://example.com/onto#Person> .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		other, err := LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		exists, kind, err := other.ResourceExists(ns + "bob")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeTrue())
+		Expect(kind).To(Equal(UndeclaredResource))
+
+		exists, kind, err = ont.ResourceExists(ns + "unknown")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+		Expect(kind).To(Equal(NoResource))
+		Expect(kind.String()).To(Equal("none"))
+	})
+
+	It("should check if the ontology exists in a store", func() {
+		store := NewMemoryStore("http://example.com/other")
+		Expect(OntologyExists(store)).To(BeFalse())
+		_, err := InitOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(OntologyExists(store)).To(BeTrue())
+	})
+})
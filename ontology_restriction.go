@@ -0,0 +1,111 @@
+package ontograph
+
+import (
+	"strconv"
+)
+
+// An OntologyRestriction represents an anonymous `owl:Restriction` on a property, e.g. the superclass of a class whose members must
+// have some value of a class for the property. Only the fields of the restriction kind are set. If `OnClass` or `OnDataRange` is set,
+// the cardinalities are qualified cardinalities.
+type OntologyRestriction struct {
+	OnProperty     string
+	SomeValuesFrom string
+	AllValuesFrom  string
+	HasValue       Term
+	Cardinality    *int
+	MinCardinality *int
+	MaxCardinality *int
+	OnClass        string
+	OnDataRange    string
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// toTriples converts the restriction into a set of triples with the given blank node as subject.
+func (restr *OntologyRestriction) toTriples(node Term) []Triple {
+	trps := []Triple{
+		{Subject: node, Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLRestriction)},
+		{Subject: node, Predicate: NewResourceTerm(OWLOnProperty), Object: NewResourceTerm(restr.OnProperty)},
+	}
+	add := func(pred string, obj Term) {
+		trps = append(trps, Triple{Subject: node, Predicate: NewResourceTerm(pred), Object: obj})
+	}
+	if restr.SomeValuesFrom != "" {
+		add(OWLSomeValuesFrom, NewResourceTerm(restr.SomeValuesFrom))
+	}
+	if restr.AllValuesFrom != "" {
+		add(OWLAllValuesFrom, NewResourceTerm(restr.AllValuesFrom))
+	}
+	if restr.HasValue != "" {
+		add(OWLHasValue, restr.HasValue)
+	}
+	// Cardinalities are qualified if a class or data range is given
+	qualified := restr.OnClass != "" || restr.OnDataRange != ""
+	for _, card := range []struct {
+		value                *int
+		plain, qualifiedPred string
+	}{
+		{restr.Cardinality, OWLCardinality, OWLQualifiedCardinality},
+		{restr.MinCardinality, OWLMinCardinality, OWLMinQualifiedCardinality},
+		{restr.MaxCardinality, OWLMaxCardinality, OWLMaxQualifiedCardinality},
+	} {
+		if card.value == nil {
+			continue
+		}
+		pred := card.plain
+		if qualified {
+			pred = card.qualifiedPred
+		}
+		add(pred, NewLiteralTerm(strconv.Itoa(*card.value), "", XSDNonNegativeInteger))
+	}
+	if restr.OnClass != "" {
+		add(OWLOnClass, NewResourceTerm(restr.OnClass))
+	}
+	if restr.OnDataRange != "" {
+		add(OWLOnDataRange, NewResourceTerm(restr.OnDataRange))
+	}
+	return trps
+}
+
+// restrictionFromTriples parses the triples of a blank node into the restriction structure. Returns false if the blank node is no
+// restriction.
+func restrictionFromTriples(trps []Triple) (OntologyRestriction, bool) {
+	restr := OntologyRestriction{}
+	isRestriction := false
+	for _, trp := range trps {
+		switch trp.Predicate.Value() {
+		case RDFType:
+			isRestriction = isRestriction || trp.Object == NewResourceTerm(OWLRestriction)
+		case OWLOnProperty:
+			restr.OnProperty = trp.Object.Value()
+		case OWLSomeValuesFrom:
+			restr.SomeValuesFrom = trp.Object.Value()
+		case OWLAllValuesFrom:
+			restr.AllValuesFrom = trp.Object.Value()
+		case OWLHasValue:
+			restr.HasValue = trp.Object
+		case OWLCardinality, OWLQualifiedCardinality:
+			restr.Cardinality = parseCardinality(trp.Object)
+		case OWLMinCardinality, OWLMinQualifiedCardinality:
+			restr.MinCardinality = parseCardinality(trp.Object)
+		case OWLMaxCardinality, OWLMaxQualifiedCardinality:
+			restr.MaxCardinality = parseCardinality(trp.Object)
+		case OWLOnClass:
+			restr.OnClass = trp.Object.Value()
+		case OWLOnDataRange:
+			restr.OnDataRange = trp.Object.Value()
+		}
+	}
+	return restr, isRestriction
+}
+
+// parseCardinality returns the value of the cardinality literal or nil if it is no valid cardinality.
+func parseCardinality(t Term) *int {
+	n, err := strconv.Atoi(unescapeLiteral(t.Value()))
+	if err != nil || n < 0 {
+		return nil
+	}
+	return &n
+}
@@ -0,0 +1,98 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+// scanCountingStore counts the retrievals of all triples of the wrapped store.
+type scanCountingStore struct {
+	GraphStore
+	scans int
+}
+
+func (store *scanCountingStore) GetAllTriples() ([]Triple, error) {
+	store.scans++
+	return store.GraphStore.GetAllTriples()
+}
+
+func (store *scanCountingStore) GetAllMatches(subj, pred, obj string) ([]Triple, error) {
+	if subj == "" && pred == "" && obj == "" {
+		store.scans++
+	}
+	return store.GraphStore.GetAllMatches(subj, pred, obj)
+}
+
+var _ = Describe("OWL restrictions", func() {
+	const ns = "http://example.com/onto#"
+	var store *MemoryStore
+	var ont *OntologyGraph
+	one, two := 1, 2
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.com/onto> a owl:Ontology .
+:Parent a owl:Class ;
+  rdfs:subClassOf :Person , _:r1 , _:r2 .
+_:r1 a owl:Restriction ; owl:onProperty :hasChild ; owl:someValuesFrom :Person .
+_:r2 a owl:Restriction ; owl:onProperty :hasChild ; owl:minQualifiedCardinality "1"^^xsd:nonNegativeInteger ; owl:onClass :Person .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should parse restrictions of classes", func() {
+		class, err := ont.GetClass(ns + "Parent")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.SubClassOf).To(Equal([]string{ns + "Person"}))
+		Expect(class.Restrictions).To(ConsistOf(
+			OntologyRestriction{OnProperty: ns + "hasChild", SomeValuesFrom: ns + "Person"},
+			OntologyRestriction{OnProperty: ns + "hasChild", MinCardinality: &one, OnClass: ns + "Person"},
+		))
+	})
+
+	It("should round-trip restrictions and replace them on upsert", func() {
+		class := OntologyClass{
+			URI: ns + "Couple",
+			Restrictions: []OntologyRestriction{
+				{OnProperty: ns + "hasMember", AllValuesFrom: ns + "Person"},
+				{OnProperty: ns + "hasMember", Cardinality: &two},
+				{OnProperty: ns + "status", HasValue: NewLiteralTerm("married", "", "")},
+			},
+		}
+		Expect(ont.UpsertResource(&class)).To(Succeed())
+		size, _ := store.Size()
+		retClass, err := ont.GetClass(class.URI)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retClass.Restrictions).To(ConsistOf(class.Restrictions))
+		Expect(store.GetFirstMatch("", NewResourceTerm(OWLCardinality).String(), NewLiteralTerm("2", "", XSDNonNegativeInteger).String())).NotTo(BeNil())
+
+		// Upserting again replaces the blank nodes of the old restrictions
+		Expect(ont.UpsertResource(&class)).To(Succeed())
+		Expect(store.Size()).To(Equal(size))
+		Expect(ont.DeleteResource(class.URI)).To(Succeed())
+		Expect(store.Size()).To(Equal(size - 13))
+	})
+
+	It("should only retrieve the reachable blank nodes", func() {
+		scanning := &scanCountingStore{GraphStore: store}
+		ont, err := LoadOntologyGraph(scanning)
+		Expect(err).NotTo(HaveOccurred())
+		scanning.scans = 0
+		class, err := ont.GetClass(ns + "Parent")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.Restrictions).To(HaveLen(2))
+		Expect(ont.DeleteResource(ns + "Parent")).To(Succeed())
+		Expect(scanning.scans).To(Equal(0))
+		Expect(store.GetFirstMatch("", NewResourceTerm(OWLOnProperty).String(), "")).To(BeNil())
+	})
+})
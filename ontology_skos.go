@@ -0,0 +1,219 @@
+package ontograph
+
+import "sort"
+
+// A SKOSConcept represents a concept of a SKOS thesaurus (`skos:Concept`).
+type SKOSConcept struct {
+	URI string
+	// PrefLabel holds the preferred label of the concept per language.
+	PrefLabel map[string]string
+	// AltLabel holds the alternative labels of the concept per language.
+	AltLabel map[string][]string
+	// Definition holds the definition of the concept per language.
+	Definition map[string]string
+	Notation   []string
+	InScheme   []string
+	Broader    []string
+	Narrower   []string
+	Related    []string
+	// Annotations hold all other literal-valued annotations of the concept, keyed by annotation property.
+	Annotations map[string][]GenericLiteral
+}
+
+// GetURI returns the URI of the concept.
+func (concept *SKOSConcept) GetURI() string {
+	return concept.URI
+}
+
+// ToTriples converts the concept into a set of triples.
+func (concept *SKOSConcept) ToTriples() []Triple {
+	subj := NewResourceTerm(concept.URI)
+	trps := []Triple{{
+		Subject:   subj,
+		Predicate: NewResourceTerm(RDFType),
+		Object:    NewResourceTerm(SKOSConceptClass),
+	}}
+	// Add labels and definitions
+	for lang, label := range concept.PrefLabel {
+		trps = append(trps, Triple{Subject: subj, Predicate: NewResourceTerm(SKOSPrefLabel), Object: NewLiteralTerm(label, lang, "")})
+	}
+	for lang, labels := range concept.AltLabel {
+		for _, label := range labels {
+			trps = append(trps, Triple{Subject: subj, Predicate: NewResourceTerm(SKOSAltLabel), Object: NewLiteralTerm(label, lang, "")})
+		}
+	}
+	for lang, definition := range concept.Definition {
+		trps = append(trps, Triple{Subject: subj, Predicate: NewResourceTerm(SKOSDefinition), Object: NewLiteralTerm(definition, lang, "")})
+	}
+	for _, notation := range concept.Notation {
+		trps = append(trps, Triple{Subject: subj, Predicate: NewResourceTerm(SKOSNotation), Object: NewLiteralTerm(notation, "", "")})
+	}
+	// Add semantic relations
+	trps = append(trps, uriTriples(subj, SKOSInScheme, concept.InScheme)...)
+	trps = append(trps, uriTriples(subj, SKOSBroader, concept.Broader)...)
+	trps = append(trps, uriTriples(subj, SKOSNarrower, concept.Narrower)...)
+	trps = append(trps, uriTriples(subj, SKOSRelated, concept.Related)...)
+	// Add annotations
+	trps = append(trps, annotationTriples(subj, concept.Annotations)...)
+	// Done, return triples
+	return trps
+}
+
+// A SKOSConceptScheme represents a SKOS thesaurus (`skos:ConceptScheme`).
+type SKOSConceptScheme struct {
+	URI string
+	// PrefLabel holds the preferred label of the scheme per language.
+	PrefLabel map[string]string
+	// Definition holds the definition of the scheme per language.
+	Definition    map[string]string
+	HasTopConcept []string
+	// Annotations hold all other literal-valued annotations of the scheme, keyed by annotation property.
+	Annotations map[string][]GenericLiteral
+}
+
+// GetURI returns the URI of the concept scheme.
+func (scheme *SKOSConceptScheme) GetURI() string {
+	return scheme.URI
+}
+
+// ToTriples converts the concept scheme into a set of triples.
+func (scheme *SKOSConceptScheme) ToTriples() []Triple {
+	subj := NewResourceTerm(scheme.URI)
+	trps := []Triple{{
+		Subject:   subj,
+		Predicate: NewResourceTerm(RDFType),
+		Object:    NewResourceTerm(SKOSConceptSchemeClass),
+	}}
+	for lang, label := range scheme.PrefLabel {
+		trps = append(trps, Triple{Subject: subj, Predicate: NewResourceTerm(SKOSPrefLabel), Object: NewLiteralTerm(label, lang, "")})
+	}
+	for lang, definition := range scheme.Definition {
+		trps = append(trps, Triple{Subject: subj, Predicate: NewResourceTerm(SKOSDefinition), Object: NewLiteralTerm(definition, lang, "")})
+	}
+	trps = append(trps, uriTriples(subj, SKOSHasTopConcept, scheme.HasTopConcept)...)
+	trps = append(trps, annotationTriples(subj, scheme.Annotations)...)
+	return trps
+}
+
+// GetSKOSConcept retrieves the SKOS concept with the specified URI from the graph.
+func (ont *OntologyGraph) GetSKOSConcept(uri string) (SKOSConcept, error) {
+	uri = ont.prefixes.Expand(uri)
+	// Retrieve all relevant triples
+	trps, err := ont.graph.GetAllMatches(NewResourceTerm(uri).String(), "", "")
+	if err != nil {
+		return SKOSConcept{}, err
+	}
+	// Parse triples into the concept structure
+	concept := SKOSConcept{
+		PrefLabel:   map[string]string{},
+		AltLabel:    map[string][]string{},
+		Definition:  map[string]string{},
+		Notation:    []string{},
+		InScheme:    []string{},
+		Broader:     []string{},
+		Narrower:    []string{},
+		Related:     []string{},
+		Annotations: map[string][]GenericLiteral{},
+	}
+	for _, trp := range trps {
+		switch trp.Predicate {
+		case NewResourceTerm(RDFType):
+			if trp.Object == NewResourceTerm(SKOSConceptClass) {
+				concept.URI = uri
+			}
+		case NewResourceTerm(SKOSPrefLabel):
+			concept.PrefLabel[trp.Object.Language()] = trp.Object.Value()
+		case NewResourceTerm(SKOSAltLabel):
+			concept.AltLabel[trp.Object.Language()] = append(concept.AltLabel[trp.Object.Language()], trp.Object.Value())
+		case NewResourceTerm(SKOSDefinition):
+			concept.Definition[trp.Object.Language()] = trp.Object.Value()
+		case NewResourceTerm(SKOSNotation):
+			concept.Notation = append(concept.Notation, trp.Object.Value())
+		case NewResourceTerm(SKOSInScheme):
+			concept.InScheme = append(concept.InScheme, trp.Object.Value())
+		case NewResourceTerm(SKOSBroader):
+			concept.Broader = append(concept.Broader, trp.Object.Value())
+		case NewResourceTerm(SKOSNarrower):
+			concept.Narrower = append(concept.Narrower, trp.Object.Value())
+		case NewResourceTerm(SKOSRelated):
+			concept.Related = append(concept.Related, trp.Object.Value())
+		default:
+			if trp.Object.IsLiteral() {
+				concept.Annotations[trp.Predicate.Value()] = append(concept.Annotations[trp.Predicate.Value()], *NewGenericLiteral(trp.Object))
+			}
+		}
+	}
+	// If no URI was set, the requested URI is not a concept
+	if concept.URI == "" {
+		return SKOSConcept{}, wrapResourceError("GetSKOSConcept", uri, ErrResourceNotFound)
+	}
+	return concept, nil
+}
+
+// GetSKOSConceptScheme retrieves the SKOS concept scheme with the specified URI from the graph.
+func (ont *OntologyGraph) GetSKOSConceptScheme(uri string) (SKOSConceptScheme, error) {
+	uri = ont.prefixes.Expand(uri)
+	// Retrieve all relevant triples
+	trps, err := ont.graph.GetAllMatches(NewResourceTerm(uri).String(), "", "")
+	if err != nil {
+		return SKOSConceptScheme{}, err
+	}
+	// Parse triples into the concept scheme structure
+	scheme := SKOSConceptScheme{
+		PrefLabel:     map[string]string{},
+		Definition:    map[string]string{},
+		HasTopConcept: []string{},
+		Annotations:   map[string][]GenericLiteral{},
+	}
+	for _, trp := range trps {
+		switch trp.Predicate {
+		case NewResourceTerm(RDFType):
+			if trp.Object == NewResourceTerm(SKOSConceptSchemeClass) {
+				scheme.URI = uri
+			}
+		case NewResourceTerm(SKOSPrefLabel):
+			scheme.PrefLabel[trp.Object.Language()] = trp.Object.Value()
+		case NewResourceTerm(SKOSDefinition):
+			scheme.Definition[trp.Object.Language()] = trp.Object.Value()
+		case NewResourceTerm(SKOSHasTopConcept):
+			scheme.HasTopConcept = append(scheme.HasTopConcept, trp.Object.Value())
+		default:
+			if trp.Object.IsLiteral() {
+				scheme.Annotations[trp.Predicate.Value()] = append(scheme.Annotations[trp.Predicate.Value()], *NewGenericLiteral(trp.Object))
+			}
+		}
+	}
+	// If no URI was set, the requested URI is not a concept scheme
+	if scheme.URI == "" {
+		return SKOSConceptScheme{}, wrapResourceError("GetSKOSConceptScheme", uri, ErrResourceNotFound)
+	}
+	return scheme, nil
+}
+
+// GetSKOSConceptsInScheme returns the URIs of all concepts that are in the concept scheme via `skos:inScheme`, sorted by URI.
+func (ont *OntologyGraph) GetSKOSConceptsInScheme(schemeURI string) ([]string, error) {
+	schemeURI = ont.prefixes.Expand(schemeURI)
+	trps, err := ont.graph.GetAllMatches("", NewResourceTerm(SKOSInScheme).String(), NewResourceTerm(schemeURI).String())
+	if err != nil {
+		return nil, wrapResourceError("GetSKOSConceptsInScheme", schemeURI, err)
+	}
+	uris := make([]string, 0, len(trps))
+	for _, trp := range trps {
+		uris = append(uris, trp.Subject.Value())
+	}
+	sort.Strings(uris)
+	return uris, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// uriTriples returns a triple from the subject via the predicate to each of the URIs.
+func uriTriples(subj Term, pred string, uris []string) []Triple {
+	trps := make([]Triple, 0, len(uris))
+	for _, uri := range uris {
+		trps = append(trps, Triple{Subject: subj, Predicate: NewResourceTerm(pred), Object: NewResourceTerm(uri)})
+	}
+	return trps
+}
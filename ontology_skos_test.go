@@ -0,0 +1,75 @@
+package ontograph_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("SKOS vocabularies", func() {
+	const ns = "http://example.com/thesaurus#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/thesaurus#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix skos: <http://www.w3.org/2004/02/skos/core#> .
+<http://example.com/thesaurus> a owl:Ontology .
+:animals a skos:ConceptScheme ; skos:prefLabel "Animals"@en ; skos:hasTopConcept :mammal .
+:mammal a skos:Concept ; skos:prefLabel "Mammal"@en, "Säugetier"@de ; skos:inScheme :animals ; skos:narrower :cat .
+:cat a skos:Concept ; skos:prefLabel "Cat"@en ; skos:altLabel "Kitty"@en, "Feline"@en ; skos:notation "C-1" ;
+	skos:broader :mammal ; skos:inScheme :animals ; skos:definition "A small domesticated carnivore."@en .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should retrieve concepts", func() {
+		cat, err := ont.GetSKOSConcept(ns + "cat")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cat.PrefLabel).To(Equal(map[string]string{"en": "Cat"}))
+		Expect(cat.AltLabel["en"]).To(ConsistOf("Kitty", "Feline"))
+		Expect(cat.Definition).To(Equal(map[string]string{"en": "A small domesticated carnivore."}))
+		Expect(cat.Notation).To(Equal([]string{"C-1"}))
+		Expect(cat.Broader).To(Equal([]string{ns + "mammal"}))
+		Expect(cat.InScheme).To(Equal([]string{ns + "animals"}))
+		_, err = ont.GetSKOSConcept(ns + "animals")
+		Expect(errors.Is(err, ErrResourceNotFound)).To(BeTrue())
+	})
+
+	It("should retrieve concept schemes and their concepts", func() {
+		scheme, err := ont.GetSKOSConceptScheme(ns + "animals")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scheme.PrefLabel).To(Equal(map[string]string{"en": "Animals"}))
+		Expect(scheme.HasTopConcept).To(Equal([]string{ns + "mammal"}))
+		concepts, err := ont.GetSKOSConceptsInScheme(ns + "animals")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(concepts).To(Equal([]string{ns + "cat", ns + "mammal"}))
+	})
+
+	It("should upsert concepts", func() {
+		dog := SKOSConcept{
+			URI:       ns + "dog",
+			PrefLabel: map[string]string{"en": "Dog"},
+			AltLabel:  map[string][]string{"en": {"Hound"}},
+			Notation:  []string{"D-1"},
+			InScheme:  []string{ns + "animals"},
+			Broader:   []string{ns + "mammal"},
+			Related:   []string{ns + "cat"},
+		}
+		Expect(ont.UpsertResource(&dog)).To(Succeed())
+		retrieved, err := ont.GetSKOSConcept(ns + "dog")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retrieved.PrefLabel).To(Equal(dog.PrefLabel))
+		Expect(retrieved.AltLabel).To(Equal(dog.AltLabel))
+		Expect(retrieved.Notation).To(Equal(dog.Notation))
+		Expect(retrieved.Broader).To(Equal(dog.Broader))
+		Expect(retrieved.Related).To(Equal(dog.Related))
+		Expect(retrieved.Narrower).To(BeEmpty())
+	})
+})
@@ -0,0 +1,141 @@
+package ontograph
+
+import "sort"
+
+// A SmushOption configures how individuals are smushed (see `SmushSameAs`).
+type SmushOption func(*smushOptions)
+
+// smushOptions holds the configuration compiled from a list of smush options.
+type smushOptions struct {
+	canonical func(uris []string) string
+}
+
+// WithCanonicalChooser sets the function that chooses the canonical URI from the sorted URIs of a set of aliased individuals. By
+// default, the first URI in the namespace of the ontology is chosen, or the first URI if there is none.
+func WithCanonicalChooser(choose func(uris []string) string) SmushOption {
+	return func(opts *smushOptions) {
+		opts.canonical = choose
+	}
+}
+
+// SmushReport lists the individuals that were merged by `SmushSameAs`.
+type SmushReport struct {
+	// Merged maps each canonical URI to the sorted URIs of the individuals that were merged into it.
+	Merged map[string][]string
+}
+
+// SmushSameAs merges individuals that are connected via `owl:sameAs` (directly or transitively, in either direction) into a canonical
+// URI. The aliases are rewritten to the canonical URI in all subject and object positions, so the types, labels and properties of the
+// aliases are united and references to the aliases point to the canonical individual. The `owl:sameAs` triples between the merged
+// individuals are removed.
+func (ont *OntologyGraph) SmushSameAs(opts ...SmushOption) (SmushReport, error) {
+	options := smushOptions{canonical: ont.defaultCanonicalURI}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	report := SmushReport{Merged: map[string][]string{}}
+	// Group the individuals connected via owl:sameAs
+	sameAs, err := ont.graph.GetAllMatches("", NewResourceTerm(OWLSameAs).String(), "")
+	if err != nil {
+		return report, err
+	}
+	parent := map[string]string{}
+	var find func(uri string) string
+	find = func(uri string) string {
+		if p, ok := parent[uri]; ok && p != uri {
+			parent[uri] = find(p)
+			return parent[uri]
+		}
+		parent[uri] = uri
+		return uri
+	}
+	for _, trp := range sameAs {
+		if trp.Object.IsLiteral() {
+			continue
+		}
+		parent[find(uriOf(trp.Subject))] = find(uriOf(trp.Object))
+	}
+	groups := map[string][]string{}
+	for uri := range parent {
+		groups[find(uri)] = append(groups[find(uri)], uri)
+	}
+	// Merge each group into its canonical URI
+	for _, uris := range groups {
+		if len(uris) < 2 {
+			continue
+		}
+		sort.Strings(uris)
+		canonical := options.canonical(uris)
+		aliases := []string{}
+		for _, uri := range uris {
+			if uri != canonical {
+				aliases = append(aliases, uri)
+			}
+		}
+		if err := ont.smushAliases(canonical, uris); err != nil {
+			return report, wrapResourceError("SmushSameAs", canonical, err)
+		}
+		report.Merged[canonical] = aliases
+	}
+	return report, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// smushAliases rewrites all URIs of the group to the canonical URI and drops the resulting `owl:sameAs` self references.
+func (ont *OntologyGraph) smushAliases(canonical string, uris []string) error {
+	isAlias := map[Term]bool{}
+	for _, uri := range uris {
+		isAlias[uriTerm(uri)] = true
+	}
+	rewrite := func(t Term) Term {
+		if isAlias[t] {
+			return uriTerm(canonical)
+		}
+		return t
+	}
+	for _, uri := range uris {
+		if uri == canonical {
+			continue
+		}
+		oldTrps, err := ont.getURITriples(uri)
+		if err != nil {
+			return err
+		}
+		newTrps := []Triple{}
+		for _, trp := range oldTrps {
+			newTrp := Triple{Subject: rewrite(trp.Subject), Predicate: trp.Predicate, Object: rewrite(trp.Object)}
+			if newTrp.Predicate != NewResourceTerm(OWLSameAs) || newTrp.Subject != newTrp.Object {
+				newTrps = append(newTrps, newTrp)
+			}
+		}
+		if err := ont.graph.DeleteTriplesUnchecked(oldTrps); err != nil {
+			return err
+		}
+		if err := ont.graph.AddTriplesUnchecked(newTrps); err != nil {
+			return err
+		}
+	}
+	// Drop self references of the canonical individual
+	return ont.graph.DeleteTripleUnchecked(Triple{Subject: uriTerm(canonical), Predicate: NewResourceTerm(OWLSameAs), Object: uriTerm(canonical)})
+}
+
+// defaultCanonicalURI returns the first URI in the namespace of the ontology or the first URI if there is none.
+func (ont *OntologyGraph) defaultCanonicalURI(uris []string) string {
+	for _, uri := range uris {
+		if ont.isOwnURI(uri) && !isAnonymousURI(uri) {
+			return uri
+		}
+	}
+	return uris[0]
+}
+
+// uriOf returns the URI of a resource term or the `_:label` of a blank node.
+func uriOf(t Term) string {
+	if t.IsBlankNode() {
+		return t.String()
+	}
+	return t.Value()
+}
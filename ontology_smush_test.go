@@ -0,0 +1,59 @@
+package ontograph_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Smushing owl:sameAs individuals", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.com/onto> a owl:Ontology .
+:alice a owl:NamedIndividual, :Person ; rdfs:label "Alice"@en .
+<http://other.org/people#a1> a owl:NamedIndividual, :Employee ; :worksFor :acme ; owl:sameAs :alice .
+<http://third.org/p/42> a owl:NamedIndividual ; rdfs:label "Alicia"@es ; owl:sameAs <http://other.org/people#a1> .
+:bob a owl:NamedIndividual ; :knows <http://third.org/p/42> .
+:carol a owl:NamedIndividual .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should merge transitively aliased individuals into the canonical URI", func() {
+		report, err := ont.SmushSameAs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Merged).To(Equal(map[string][]string{
+			ns + "alice": {"http://other.org/people#a1", "http://third.org/p/42"},
+		}))
+		alice, err := ont.GetIndividual(ns + "alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(alice.Types).To(ConsistOf(ns+"Person", ns+"Employee"))
+		Expect(alice.Label).To(Equal(map[string]string{"en": "Alice", "es": "Alicia"}))
+		Expect(alice.ObjectProperties).To(Equal(map[string][]string{ns + "worksFor": {ns + "acme"}}))
+		Expect(alice.SameIndividualAs).To(BeEmpty())
+		bob, err := ont.GetIndividual(ns + "bob")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bob.ObjectProperties).To(Equal(map[string][]string{ns + "knows": {ns + "alice"}}))
+		_, err = ont.GetIndividual("http://third.org/p/42")
+		Expect(errors.Is(err, ErrResourceNotFound)).To(BeTrue())
+	})
+
+	It("should use the chosen canonical URI", func() {
+		report, err := ont.SmushSameAs(WithCanonicalChooser(func(uris []string) string { return uris[len(uris)-1] }))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Merged).To(HaveKey("http://third.org/p/42"))
+		_, err = ont.GetIndividual(ns + "alice")
+		Expect(errors.Is(err, ErrResourceNotFound)).To(BeTrue())
+	})
+})
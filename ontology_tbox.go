@@ -0,0 +1,130 @@
+package ontograph
+
+import (
+	"sort"
+)
+
+// GetClasses retrieves all named classes of the ontology sorted by URI. Anonymous class expressions are not included.
+func (ont *OntologyGraph) GetClasses() ([]OntologyClass, error) {
+	cached, err := ont.cachedTBox(OWLClass, func() (interface{}, error) {
+		uris, err := ont.getTypedURIs(OWLClass)
+		if err != nil {
+			return nil, err
+		}
+		classes := []OntologyClass{}
+		for _, uri := range uris {
+			class, err := ont.GetClass(uri)
+			if err != nil {
+				return classes, err
+			}
+			classes = append(classes, class)
+		}
+		return classes, nil
+	})
+	classes, _ := cached.([]OntologyClass)
+	return append([]OntologyClass{}, classes...), err
+}
+
+// GetObjectProperties retrieves all object properties of the ontology sorted by URI.
+func (ont *OntologyGraph) GetObjectProperties() ([]OntologyObjectProperty, error) {
+	cached, err := ont.cachedTBox(OWLObjectProperty, func() (interface{}, error) {
+		uris, err := ont.getTypedURIs(OWLObjectProperty)
+		if err != nil {
+			return nil, err
+		}
+		props := []OntologyObjectProperty{}
+		for _, uri := range uris {
+			prop, err := ont.GetObjectProperty(uri)
+			if err != nil {
+				return props, err
+			}
+			props = append(props, prop)
+		}
+		return props, nil
+	})
+	props, _ := cached.([]OntologyObjectProperty)
+	return append([]OntologyObjectProperty{}, props...), err
+}
+
+// GetDataProperties retrieves all data properties of the ontology sorted by URI.
+func (ont *OntologyGraph) GetDataProperties() ([]OntologyDataProperty, error) {
+	cached, err := ont.cachedTBox(OWLDatatypeProperty, func() (interface{}, error) {
+		uris, err := ont.getTypedURIs(OWLDatatypeProperty)
+		if err != nil {
+			return nil, err
+		}
+		props := []OntologyDataProperty{}
+		for _, uri := range uris {
+			prop, err := ont.GetDataProperty(uri)
+			if err != nil {
+				return props, err
+			}
+			props = append(props, prop)
+		}
+		return props, nil
+	})
+	props, _ := cached.([]OntologyDataProperty)
+	return append([]OntologyDataProperty{}, props...), err
+}
+
+// GetDatatypes retrieves all datatypes declared in the ontology sorted by URI.
+func (ont *OntologyGraph) GetDatatypes() ([]OntologyDatatype, error) {
+	cached, err := ont.cachedTBox(RDFSDatatype, func() (interface{}, error) {
+		uris, err := ont.getTypedURIs(RDFSDatatype)
+		if err != nil {
+			return nil, err
+		}
+		datatypes := []OntologyDatatype{}
+		for _, uri := range uris {
+			datatype, err := ont.GetDatatype(uri)
+			if err != nil {
+				return datatypes, err
+			}
+			datatypes = append(datatypes, datatype)
+		}
+		return datatypes, nil
+	})
+	datatypes, _ := cached.([]OntologyDatatype)
+	return append([]OntologyDatatype{}, datatypes...), err
+}
+
+// GetAnnotationProperties retrieves all annotation properties declared in the ontology sorted by URI.
+func (ont *OntologyGraph) GetAnnotationProperties() ([]OntologyAnnotationProperty, error) {
+	cached, err := ont.cachedTBox(OWLAnnotationProperty, func() (interface{}, error) {
+		uris, err := ont.getTypedURIs(OWLAnnotationProperty)
+		if err != nil {
+			return nil, err
+		}
+		props := []OntologyAnnotationProperty{}
+		for _, uri := range uris {
+			prop, err := ont.GetAnnotationProperty(uri)
+			if err != nil {
+				return props, err
+			}
+			props = append(props, prop)
+		}
+		return props, nil
+	})
+	props, _ := cached.([]OntologyAnnotationProperty)
+	return append([]OntologyAnnotationProperty{}, props...), err
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// getTypedURIs returns the sorted URIs of all named resources that have the type.
+func (ont *OntologyGraph) getTypedURIs(typeURI string) ([]string, error) {
+	trps, err := ont.graph.GetAllMatches("", NewResourceTerm(RDFType).String(), NewResourceTerm(typeURI).String())
+	if err != nil {
+		return nil, err
+	}
+	uris := []string{}
+	for _, trp := range trps {
+		if trp.Subject.IsResource() {
+			uris = append(uris, trp.Subject.Value())
+		}
+	}
+	sort.Strings(uris)
+	return uris, nil
+}
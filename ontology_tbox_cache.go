@@ -0,0 +1,78 @@
+package ontograph
+
+import (
+	"sync"
+)
+
+// SetTBoxCache enables or disables the caching of the TBox, i.e. the results of `GetClasses`, `GetObjectProperties`,
+// `GetDataProperties`, `GetDatatypes` and `GetAnnotationProperties`. Read-heavy services can enable the cache so the schema is only
+// queried once instead of for every request. The cache is not invalidated automatically, call `InvalidateTBoxCache` whenever classes or
+// properties change, through the ontology or directly in the graph store. The cached classes and properties are shared between callers
+// and must not be modified. Disabling the cache discards it.
+func (ont *OntologyGraph) SetTBoxCache(enabled bool) {
+	if !enabled {
+		ont.tbox = nil
+	} else if ont.tbox == nil {
+		ont.tbox = &tboxCache{entries: map[string]interface{}{}}
+	}
+}
+
+// InvalidateTBoxCache discards the cached TBox, so it is queried again on the next access (see `SetTBoxCache`). It is a no-op if the
+// cache is disabled.
+func (ont *OntologyGraph) InvalidateTBoxCache() {
+	if ont.tbox != nil {
+		ont.tbox.invalidate()
+	}
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// tboxCache caches the TBox lists of an ontology keyed by the type of their resources.
+type tboxCache struct {
+	mu sync.Mutex
+	// generation is increased on every invalidation, so lists loaded before are not cached
+	generation int
+	entries    map[string]interface{}
+}
+
+// cachedTBox returns the cached list of resources with the type, loading it if it is not cached. If the cache is disabled, the list is
+// always loaded.
+func (ont *OntologyGraph) cachedTBox(typeURI string, load func() (interface{}, error)) (interface{}, error) {
+	if ont.tbox == nil {
+		return load()
+	}
+	return ont.tbox.get(typeURI, load)
+}
+
+// get returns the cached entry, loading it if it is not cached. Entries are loaded without holding the lock, so concurrent loads of
+// different lists do not block each other. Failed loads are not cached.
+func (cache *tboxCache) get(key string, load func() (interface{}, error)) (interface{}, error) {
+	cache.mu.Lock()
+	if val, ok := cache.entries[key]; ok {
+		cache.mu.Unlock()
+		return val, nil
+	}
+	generation := cache.generation
+	cache.mu.Unlock()
+
+	val, err := load()
+	if err != nil {
+		return val, err
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.generation == generation {
+		cache.entries[key] = val
+	}
+	return val, nil
+}
+
+// invalidate discards all entries.
+func (cache *tboxCache) invalidate() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.generation++
+	cache.entries = map[string]interface{}{}
+}
@@ -0,0 +1,86 @@
+package ontograph_test
+
+import (
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("TBox cache", func() {
+	const ns = "http://example.com/onto#"
+	classTrp := func(name string) Triple {
+		return Triple{Subject: NewResourceTerm(ns + name), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)}
+	}
+	var store *SyncStore
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		parsed, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:Person a owl:Class .
+:hasChild a owl:ObjectProperty .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		store = NewSyncStore(parsed)
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should serve the TBox from the cache until it is invalidated", func() {
+		ont.SetTBoxCache(true)
+		Expect(ont.GetClasses()).To(HaveLen(1))
+		Expect(store.AddTriple(classTrp("Parent"))).To(Succeed())
+		Expect(ont.GetClasses()).To(HaveLen(1))
+		Expect(ont.GetObjectProperties()).To(HaveLen(1))
+
+		ont.InvalidateTBoxCache()
+		classes, err := ont.GetClasses()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(classes).To(HaveLen(2))
+		Expect(classes[0].URI).To(Equal(ns + "Parent"))
+
+		// Modifying the result does not modify the cache
+		classes[0].URI = ns + "Modified"
+		classes, err = ont.GetClasses()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(classes[0].URI).To(Equal(ns + "Parent"))
+	})
+
+	It("should always query the TBox if the cache is disabled", func() {
+		ont.SetTBoxCache(true)
+		Expect(ont.GetClasses()).To(HaveLen(1))
+		ont.SetTBoxCache(false)
+		Expect(store.AddTriple(classTrp("Parent"))).To(Succeed())
+		Expect(ont.GetClasses()).To(HaveLen(2))
+	})
+
+	It("should be safe for concurrent use", func() {
+		ont.SetTBoxCache(true)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					_, err := ont.GetClasses()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ont.SetLabel("Onto", "en")).To(Succeed())
+					Expect(ont.GetLabel("en")).To(Equal("Onto"))
+					if i == 0 {
+						Expect(store.AddTripleUnchecked(classTrp("Parent"))).To(Succeed())
+						ont.InvalidateTBoxCache()
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+		ont.InvalidateTBoxCache()
+		Expect(ont.GetClasses()).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,80 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Listing the TBox", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+<http://example.com/onto> a owl:Ontology .
+:Person a owl:Class ; rdfs:label "Person"@en .
+:Parent a owl:Class ; rdfs:subClassOf :Person .
+:Orphan a owl:Class ; owl:equivalentClass [ a owl:Class ; owl:complementOf :Parent ] .
+:hasChild a owl:ObjectProperty ; rdfs:domain :Parent ; rdfs:range :Person .
+:age a owl:DatatypeProperty .
+:name a owl:DatatypeProperty .
+:Age a rdfs:Datatype .
+:source a owl:AnnotationProperty ; rdfs:label "Source"@en .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should list all named classes", func() {
+		classes, err := ont.GetClasses()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(classes).To(HaveLen(3))
+		Expect(classes[0].URI).To(Equal(ns + "Orphan"))
+		Expect(classes[0].EquivalentToExpressions).To(HaveLen(1))
+		Expect(classes[1].URI).To(Equal(ns + "Parent"))
+		Expect(classes[1].SubClassOf).To(Equal([]string{ns + "Person"}))
+		Expect(classes[2].URI).To(Equal(ns + "Person"))
+		Expect(classes[2].Label).To(Equal(map[string]string{"en": "Person"}))
+	})
+
+	It("should list all properties and datatypes", func() {
+		objProps, err := ont.GetObjectProperties()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objProps).To(HaveLen(1))
+		Expect(objProps[0].Domains).To(Equal([]string{ns + "Parent"}))
+
+		dataProps, err := ont.GetDataProperties()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dataProps).To(HaveLen(2))
+		Expect(dataProps[0].URI).To(Equal(ns + "age"))
+		Expect(dataProps[1].URI).To(Equal(ns + "name"))
+
+		datatypes, err := ont.GetDatatypes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(datatypes).To(HaveLen(1))
+		Expect(datatypes[0].URI).To(Equal(ns + "Age"))
+
+		annotationProps, err := ont.GetAnnotationProperties()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotationProps).To(HaveLen(1))
+		Expect(annotationProps[0].Label).To(Equal(map[string]string{"en": "Source"}))
+	})
+
+	It("should round-trip annotation properties", func() {
+		prop := OntologyAnnotationProperty{URI: ns + "reviewedBy", SubPropertyOf: []string{ns + "source"}, Label: map[string]string{"en": "Reviewed by"}}
+		Expect(ont.UpsertResource(&prop)).To(Succeed())
+		retProp, err := ont.GetAnnotationProperty(prop.URI)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retProp.SubPropertyOf).To(Equal(prop.SubPropertyOf))
+		Expect(retProp.Label).To(Equal(prop.Label))
+		_, err = ont.GetAnnotationProperty(ns + "age")
+		Expect(err).To(MatchError(ErrResourceNotFound))
+	})
+})
@@ -0,0 +1,112 @@
+package ontograph
+
+// GetVersionIRI returns the version IRI of the ontology (`owl:versionIRI`). If no version IRI is set, the empty string is returned.
+func (ont *OntologyGraph) GetVersionIRI() (string, error) {
+	uris, err := ont.getHeaderURIs(OWLVersionIRI)
+	if err != nil || len(uris) == 0 {
+		return "", err
+	}
+	return uris[0], nil
+}
+
+// SetVersionIRI sets the version IRI of the ontology. A previous version IRI will be replaced. If `uri` is empty, the version IRI is
+// removed.
+func (ont *OntologyGraph) SetVersionIRI(uri string) error {
+	uri = ont.prefixes.Expand(uri)
+	if uri == "" {
+		return ont.setHeaderURIs(OWLVersionIRI)
+	}
+	return ont.setHeaderURIs(OWLVersionIRI, uri)
+}
+
+// GetPriorVersions returns the URIs of the prior versions of the ontology (`owl:priorVersion`).
+func (ont *OntologyGraph) GetPriorVersions() ([]string, error) {
+	return ont.getHeaderURIs(OWLPriorVersion)
+}
+
+// SetPriorVersions sets the URIs of the prior versions of the ontology, replacing the previous ones.
+func (ont *OntologyGraph) SetPriorVersions(uris ...string) error {
+	uris = ont.expandURIs(uris)
+	return ont.setHeaderURIs(OWLPriorVersion, uris...)
+}
+
+// GetBackwardCompatibleWith returns the URIs of the prior versions the ontology is backward compatible with
+// (`owl:backwardCompatibleWith`).
+func (ont *OntologyGraph) GetBackwardCompatibleWith() ([]string, error) {
+	return ont.getHeaderURIs(OWLBackwardCompatibleWith)
+}
+
+// SetBackwardCompatibleWith sets the URIs of the prior versions the ontology is backward compatible with, replacing the previous ones.
+func (ont *OntologyGraph) SetBackwardCompatibleWith(uris ...string) error {
+	uris = ont.expandURIs(uris)
+	return ont.setHeaderURIs(OWLBackwardCompatibleWith, uris...)
+}
+
+// GetIncompatibleWith returns the URIs of the prior versions the ontology is incompatible with (`owl:incompatibleWith`).
+func (ont *OntologyGraph) GetIncompatibleWith() ([]string, error) {
+	return ont.getHeaderURIs(OWLIncompatibleWith)
+}
+
+// SetIncompatibleWith sets the URIs of the prior versions the ontology is incompatible with, replacing the previous ones.
+func (ont *OntologyGraph) SetIncompatibleWith(uris ...string) error {
+	uris = ont.expandURIs(uris)
+	return ont.setHeaderURIs(OWLIncompatibleWith, uris...)
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// getHeaderURIs returns the sorted URIs that the ontology header references with the predicate.
+func (ont *OntologyGraph) getHeaderURIs(pred string) ([]string, error) {
+	trps, err := ont.graph.GetAllMatches(NewResourceTerm(ont.GetURI()).String(), NewResourceTerm(pred).String(), "")
+	if err != nil {
+		return nil, err
+	}
+	return headerURIs(trps, pred), nil
+}
+
+// headerURIs returns the sorted URIs of the header triples with the predicate.
+func headerURIs(trps []Triple, pred string) []string {
+	uris := []string{}
+	for _, trp := range headerTriples(trps, pred) {
+		if trp.Object.IsResource() {
+			uris = append(uris, trp.Object.Value())
+		}
+	}
+	return uris
+}
+
+// headerTriples returns a sorted copy of the header triples with the predicate.
+func headerTriples(trps []Triple, pred string) []Triple {
+	matching := []Triple{}
+	for _, trp := range trps {
+		if trp.Predicate == NewResourceTerm(pred) {
+			matching = append(matching, trp)
+		}
+	}
+	SortTriples(matching)
+	return matching
+}
+
+// setHeaderURIs replaces the URIs that the ontology header references with the predicate.
+func (ont *OntologyGraph) setHeaderURIs(pred string, uris ...string) error {
+	objs := []Term{}
+	for _, uri := range uris {
+		objs = append(objs, NewResourceTerm(uri))
+	}
+	return ont.setHeaderTerms(pred, objs...)
+}
+
+// setHeaderTerms replaces the objects of the ontology header triples with the predicate.
+func (ont *OntologyGraph) setHeaderTerms(pred string, objs ...Term) error {
+	subj := NewResourceTerm(ont.GetURI())
+	if err := ont.graph.DeleteAllMatches(subj.String(), NewResourceTerm(pred).String(), ""); err != nil {
+		return err
+	}
+	trps := []Triple{}
+	for _, obj := range objs {
+		trps = append(trps, Triple{Subject: subj, Predicate: NewResourceTerm(pred), Object: obj})
+	}
+	return ont.graph.AddTriplesUnchecked(trps)
+}
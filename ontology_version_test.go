@@ -0,0 +1,49 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Ontology versions", func() {
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology ;
+  owl:versionInfo "2.0" ;
+  owl:versionIRI <http://example.com/onto/2.0> ;
+  owl:priorVersion <http://example.com/onto/1.0> .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should read the version metadata", func() {
+		Expect(ont.GetVersion()).To(Equal("2.0"))
+		Expect(ont.GetVersionIRI()).To(Equal("http://example.com/onto/2.0"))
+		Expect(ont.GetPriorVersions()).To(Equal([]string{"http://example.com/onto/1.0"}))
+		Expect(ont.GetBackwardCompatibleWith()).To(BeEmpty())
+		Expect(ont.GetIncompatibleWith()).To(BeEmpty())
+	})
+
+	It("should replace the version metadata", func() {
+		Expect(ont.SetVersionIRI("http://example.com/onto/3.0")).To(Succeed())
+		Expect(ont.SetPriorVersions("http://example.com/onto/2.0", "http://example.com/onto/1.0")).To(Succeed())
+		Expect(ont.SetBackwardCompatibleWith("http://example.com/onto/2.0")).To(Succeed())
+		Expect(ont.SetIncompatibleWith("http://example.com/onto/1.0")).To(Succeed())
+
+		Expect(ont.GetVersionIRI()).To(Equal("http://example.com/onto/3.0"))
+		Expect(ont.GetPriorVersions()).To(Equal([]string{"http://example.com/onto/1.0", "http://example.com/onto/2.0"}))
+		Expect(ont.GetBackwardCompatibleWith()).To(Equal([]string{"http://example.com/onto/2.0"}))
+		Expect(ont.GetIncompatibleWith()).To(Equal([]string{"http://example.com/onto/1.0"}))
+
+		Expect(ont.SetVersionIRI("")).To(Succeed())
+		Expect(ont.GetVersionIRI()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,128 @@
+package ontograph
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+)
+
+// Media types of the built-in RDF serialization formats (see `RegisterFormat`).
+const (
+	MediaTypeTurtle   string = "text/turtle"
+	MediaTypeNTriples string = "application/n-triples"
+	MediaTypeJSONLD   string = "application/ld+json"
+	MediaTypeRDFXML   string = "application/rdf+xml"
+)
+
+// ParseGraph creates a new memory store from the RDF data given in the reader. The format is selected by the content type (e.g. the
+// `Content-Type` header of an upload), which may contain parameters like the charset. Supported are Turtle, N-Triples, JSON-LD and
+// RDF/XML as well as all formats added with `RegisterFormat`. If the content type is empty or generic (e.g. `application/octet-stream`
+// or `text/plain`), the format is sniffed from the data. Gzip compressed data is decompressed transparently. The import can be
+// configured with parse options like `ParseFromTurtle`.
+func ParseGraph(r io.Reader, contentType string, opts ...ParseOption) (*MemoryStore, error) {
+	r, err := decompressReader(r)
+	if err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(r)
+	mediaType, err := resolveMediaType(contentType, br)
+	if err != nil {
+		return nil, err
+	}
+	// Parse graph in the corresponding format
+	format, ok := LookupFormat(mediaType)
+	if !ok || format.Parse == nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedFormat, mediaType)
+	}
+	return format.Parse(br, opts...)
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrUnsupportedFormat is raised when the content type does not denote a supported RDF serialization format.
+var ErrUnsupportedFormat error = errors.New("The RDF serialization format is not supported")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// genericMediaTypes are media types that do not denote a format, so the format is sniffed from the data.
+var genericMediaTypes = map[string]bool{
+	"":                         true,
+	"application/octet-stream": true,
+	"text/plain":               true,
+}
+
+// resolveMediaType returns the media type of the content type, sniffing the buffered data if the content type is generic.
+func resolveMediaType(contentType string, br *bufio.Reader) (string, error) {
+	mediaType := ""
+	if contentType != "" {
+		var err error
+		if mediaType, _, err = mime.ParseMediaType(contentType); err != nil {
+			return "", err
+		}
+	}
+	if !genericMediaTypes[strings.ToLower(mediaType)] {
+		return mediaType, nil
+	}
+	// Sniff the format from the beginning of the data
+	head, err := br.Peek(512)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return "", err
+	}
+	return sniffMediaType(head), nil
+}
+
+// fixJSONLDIntegers reads the JSON-LD document and converts string values typed as xsd:integer into JSON numbers. This works around
+// the JSON-LD processor of rdf2go, which converts such values to 0.
+func fixJSONLDIntegers(r io.Reader) ([]byte, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	var fix func(v interface{}) interface{}
+	fix = func(v interface{}) interface{} {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if value, ok := v["@value"].(string); ok && (v["@type"] == XSDInteger || v["@type"] == "xsd:integer") {
+				if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+					v["@value"] = json.Number(strconv.FormatInt(i, 10))
+				}
+			}
+			for key, child := range v {
+				v[key] = fix(child)
+			}
+		case []interface{}:
+			for i, child := range v {
+				v[i] = fix(child)
+			}
+		}
+		return v
+	}
+	return json.Marshal(fix(doc))
+}
+
+// sniffMediaType guesses the format from the beginning of the data. Defaults to Turtle, which also covers N-Triples.
+func sniffMediaType(head []byte) string {
+	head = bytes.TrimPrefix(head, []byte("\xef\xbb\xbf"))
+	head = bytes.TrimLeft(head, " \t\r\n")
+	switch {
+	case bytes.HasPrefix(head, []byte("{")) || bytes.HasPrefix(head, []byte("[")):
+		return MediaTypeJSONLD
+	case bytes.HasPrefix(head, []byte("<?xml")) || bytes.HasPrefix(head, []byte("<!")):
+		return MediaTypeRDFXML
+	case bytes.HasPrefix(head, []byte("<")) && bytes.Contains(head, []byte("xmlns")):
+		return MediaTypeRDFXML
+	}
+	return MediaTypeTurtle
+}
@@ -0,0 +1,109 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("ParseGraph", func() {
+	const testUri = "http://example.com/onto"
+	testTriples := []Triple{
+		{Subject: NewResourceTerm(testUri), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLOntology)},
+		{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)},
+		{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(RDFSLabel), Object: NewLiteralTerm("Class A", "en", "")},
+		{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(testUri + "#size"), Object: NewLiteralTerm("4", "", XSDInteger)},
+	}
+	const turtleData = `@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+<http://example.com/onto#A> a owl:Class ;
+  <http://www.w3.org/2000/01/rdf-schema#label> "Class A"@en ;
+  <http://example.com/onto#size> "4"^^<http://www.w3.org/2001/XMLSchema#integer> .
+`
+	const ntriplesData = `<http://example.com/onto> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/2002/07/owl#Ontology> .
+<http://example.com/onto#A> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://www.w3.org/2002/07/owl#Class> .
+<http://example.com/onto#A> <http://www.w3.org/2000/01/rdf-schema#label> "Class A"@en .
+<http://example.com/onto#A> <http://example.com/onto#size> "4"^^<http://www.w3.org/2001/XMLSchema#integer> .
+`
+	const jsonldData = `[
+  {"@id": "http://example.com/onto", "@type": ["http://www.w3.org/2002/07/owl#Ontology"]},
+  {
+    "@id": "http://example.com/onto#A",
+    "@type": ["http://www.w3.org/2002/07/owl#Class"],
+    "http://www.w3.org/2000/01/rdf-schema#label": [{"@value": "Class A", "@language": "en"}],
+    "http://example.com/onto#size": [{"@value": "4", "@type": "http://www.w3.org/2001/XMLSchema#integer"}]
+  }
+]`
+	const rdfxmlData = `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+         xmlns:rdfs="http://www.w3.org/2000/01/rdf-schema#"
+         xmlns:owl="http://www.w3.org/2002/07/owl#"
+         xmlns:ex="http://example.com/onto#"
+         xml:base="http://example.com/onto">
+  <owl:Ontology rdf:about=""/>
+  <owl:Class rdf:ID="A">
+    <rdfs:label xml:lang="en">Class A</rdfs:label>
+    <ex:size rdf:datatype="http://www.w3.org/2001/XMLSchema#integer">4</ex:size>
+  </owl:Class>
+</rdf:RDF>`
+
+	expectTriples := func(store *MemoryStore) {
+		Expect(store.GetURI()).To(Equal(testUri))
+		trps, err := store.GetAllTriples()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trps).To(ConsistOf(testTriples))
+	}
+
+	formats := []struct {
+		name        string
+		data        string
+		contentType string
+	}{
+		{"Turtle", turtleData, "text/turtle; charset=utf-8"},
+		{"N-Triples", ntriplesData, MediaTypeNTriples},
+		{"JSON-LD", jsonldData, MediaTypeJSONLD},
+		{"RDF/XML", rdfxmlData, MediaTypeRDFXML},
+	}
+	for _, format := range formats {
+		format := format
+		It("should parse "+format.name+" with explicit content type", func() {
+			store, err := ParseGraph(strings.NewReader(format.data), format.contentType)
+			Expect(err).NotTo(HaveOccurred())
+			expectTriples(store)
+		})
+		It("should parse "+format.name+" with sniffed format", func() {
+			store, err := ParseGraph(strings.NewReader(format.data), "application/octet-stream")
+			Expect(err).NotTo(HaveOccurred())
+			expectTriples(store)
+		})
+	}
+
+	It("should parse nested RDF/XML nodes and collections", func() {
+		data := `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:ex="http://example.com/onto#">
+  <rdf:Description rdf:about="http://example.com/onto#A" ex:name="A">
+    <ex:knows>
+      <ex:Person rdf:about="http://example.com/onto#B"/>
+    </ex:knows>
+    <ex:likes rdf:resource="http://example.com/onto#C"/>
+  </rdf:Description>
+</rdf:RDF>`
+		store, err := ParseGraph(strings.NewReader(data), "")
+		Expect(err).NotTo(HaveOccurred())
+		trps, err := store.GetAllTriples()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trps).To(ConsistOf(
+			Triple{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(testUri + "#name"), Object: NewLiteralTerm("A", "", "")},
+			Triple{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(testUri + "#knows"), Object: NewResourceTerm(testUri + "#B")},
+			Triple{Subject: NewResourceTerm(testUri + "#B"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(testUri + "#Person")},
+			Triple{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(testUri + "#likes"), Object: NewResourceTerm(testUri + "#C")},
+		))
+	})
+
+	It("should reject unsupported formats", func() {
+		_, err := ParseGraph(strings.NewReader(turtleData), "application/pdf")
+		Expect(err).To(MatchError(ErrUnsupportedFormat))
+	})
+})
@@ -0,0 +1,89 @@
+package ontograph
+
+import (
+	"strings"
+
+	"github.com/deiu/rdf2go"
+)
+
+// A ParseOption configures how TTL data is imported (see `ParseFromTurtle` and `ParseTurtleStream`).
+type ParseOption func(*parseOptions)
+
+// parseOptions holds the configuration compiled from a list of parse options.
+type parseOptions struct {
+	baseURI    string
+	rewrites   []uriRewrite
+	strictness Strictness
+	warnings   WarningCollector
+}
+
+// uriRewrite replaces the base URI `from` with `to`.
+type uriRewrite struct {
+	from string
+	to   string
+}
+
+// WithBaseURI sets the base URI against which relative URIs in the TTL data are resolved. An `@base` directive within the data takes precedence.
+func WithBaseURI(uri string) ParseOption {
+	return func(opts *parseOptions) {
+		opts.baseURI = uri
+	}
+}
+
+// WithURIRewrite rewrites all URIs that start with the base URI `from` to start with `to` instead. This applies to subjects, predicates,
+// objects and literal datatypes. A URI only matches if it equals `from` or continues with a `#` or `/` after it. This is useful to
+// promote a draft ontology to its published namespace on import. Multiple rewrites are applied in the given order.
+func WithURIRewrite(from, to string) ParseOption {
+	return func(opts *parseOptions) {
+		opts.rewrites = append(opts.rewrites, uriRewrite{from: from, to: to})
+	}
+}
+
+// WithStrictness sets how parsed triples with issues (malformed terms, unknown datatypes or subjects outside of the ontology namespace)
+// are handled. In strict mode, parsing fails on the first issue. In lenient mode, the issues are passed to the warning collector (may be nil).
+// Defaults to `DefaultStrictness` without warning collector.
+func WithStrictness(strictness Strictness, warnings WarningCollector) ParseOption {
+	return func(opts *parseOptions) {
+		opts.strictness = strictness
+		opts.warnings = warnings
+	}
+}
+
+// newParseOptions compiles the given list of options.
+func newParseOptions(opts []ParseOption) parseOptions {
+	options := parseOptions{strictness: DefaultStrictness}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// checksTriples returns true if parsed triples need to be checked, i.e. if issues would either fail the parsing or be reported.
+func (opts parseOptions) checksTriples() bool {
+	return opts.strictness == Strict || opts.warnings != nil
+}
+
+// rewriteRDFTerm applies the configured URI rewrites to a rdf2go resource term or the datatype of a rdf2go literal term.
+func (opts parseOptions) rewriteRDFTerm(t rdf2go.Term) rdf2go.Term {
+	switch t := t.(type) {
+	case *rdf2go.Resource:
+		return rdf2go.NewResource(opts.rewriteURI(t.URI))
+	case *rdf2go.Literal:
+		if t.Datatype != nil {
+			return rdf2go.NewLiteralWithDatatype(t.Value, opts.rewriteRDFTerm(t.Datatype))
+		}
+	}
+	return t
+}
+
+// rewriteURI applies the configured URI rewrites to the given URI.
+func (opts parseOptions) rewriteURI(uri string) string {
+	for _, rw := range opts.rewrites {
+		if uri == rw.from {
+			uri = rw.to
+		} else if strings.HasPrefix(uri, rw.from+"#") || strings.HasPrefix(uri, rw.from+"/") {
+			uri = rw.to + uri[len(rw.from):]
+		}
+	}
+	return uri
+}
@@ -0,0 +1,176 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Prefixes registers prefixes to expand CURIEs (e.g. `ex:Sensor`) into full URIs and to compact full URIs into CURIEs. When set on an
+// ontology graph (see `OntologyGraph.SetPrefixes`), all methods that expect URIs accept CURIEs with registered prefixes as well. A nil
+// `*Prefixes` is valid and leaves all URIs unchanged, as are zero-value prefixes without any registered prefix. Prefixes are safe for
+// concurrent use.
+type Prefixes struct {
+	mu sync.RWMutex
+	pm PrefixMap
+}
+
+// NewPrefixes creates new prefixes with the standard prefixes for RDF, RDFS, OWL and XSD registered.
+func NewPrefixes() *Prefixes {
+	return &Prefixes{pm: NewStandardPrefixMap()}
+}
+
+// Register registers the prefix abbreviation (e.g. `ex`) for the namespace URI (e.g. `http://example.com/onto#`). A previously
+// registered namespace of the abbreviation is replaced. Errors with `ErrInvalidPrefix` if the abbreviation is no valid prefix name
+// or the namespace is empty, or if the prefixes are nil.
+func (p *Prefixes) Register(abbr, namespace string) error {
+	if p == nil {
+		return fmt.Errorf("%w: Cannot register '%s' on nil prefixes", ErrInvalidPrefix, abbr)
+	}
+	if !prefixNamePattern.MatchString(abbr) || namespace == "" {
+		return fmt.Errorf("%w: '%s' for <%s>", ErrInvalidPrefix, abbr, namespace)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.pm == nil {
+		p.pm = PrefixMap{}
+	}
+	p.pm[abbr] = namespace
+	return nil
+}
+
+// PrefixMap returns a copy of the registered prefixes, e.g. to serialize a graph store with them (see `WithPrefixes`).
+func (p *Prefixes) PrefixMap() PrefixMap {
+	pm := PrefixMap{}
+	if p != nil {
+		p.mu.RLock()
+		defer p.mu.RUnlock()
+		for abbr, namespace := range p.pm {
+			pm[abbr] = namespace
+		}
+	}
+	return pm
+}
+
+// Expand expands the CURIE into a full URI if its prefix is registered. All other values, in particular full URIs and blank node
+// labels, are returned unchanged.
+func (p *Prefixes) Expand(curie string) string {
+	if p == nil {
+		return curie
+	}
+	pos := strings.Index(curie, ":")
+	if pos < 0 || strings.HasPrefix(curie[pos+1:], "//") {
+		return curie
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if namespace, ok := p.pm[curie[:pos]]; ok {
+		return namespace + curie[pos+1:]
+	}
+	return curie
+}
+
+// Compact compacts the URI into a CURIE with the longest matching registered namespace. URIs without a matching namespace or whose
+// local name contains a `/` or `#` are returned unchanged.
+func (p *Prefixes) Compact(uri string) string {
+	if p == nil {
+		return uri
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	best := ""
+	for _, abbr := range p.pm.Abbreviations() {
+		namespace := p.pm[abbr]
+		if strings.HasPrefix(uri, namespace) && (best == "" || len(namespace) > len(p.pm[best])) {
+			best = abbr
+		}
+	}
+	if best == "" {
+		return uri
+	}
+	local := uri[len(p.pm[best]):]
+	if strings.ContainsAny(local, "/#") {
+		return uri
+	}
+	return best + ":" + local
+}
+
+// SetPrefixes sets the prefixes whose CURIEs are accepted in place of URIs by the methods of the ontology graph. The values of resources
+// and filters are expanded as well, while the retrieved resources always contain full URIs (see `Prefixes.Compact`). Set nil to disable
+// the expansion.
+func (ont *OntologyGraph) SetPrefixes(prefixes *Prefixes) {
+	ont.prefixes = prefixes
+}
+
+// GetPrefixes returns the prefixes of the ontology graph (may be nil).
+func (ont *OntologyGraph) GetPrefixes() *Prefixes {
+	return ont.prefixes
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrInvalidPrefix is raised when a prefix is registered with an invalid abbreviation or namespace.
+var ErrInvalidPrefix error = errors.New("The prefix is invalid")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// prefixNamePattern matches valid prefix names of CURIEs (the empty prefix is allowed).
+var prefixNamePattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_.-]*)?$`)
+
+// expandURIs expands all CURIEs in the list into a new list.
+func (ont *OntologyGraph) expandURIs(uris []string) []string {
+	if ont.prefixes == nil {
+		return uris
+	}
+	expanded := make([]string, len(uris))
+	for i, uri := range uris {
+		expanded[i] = ont.prefixes.Expand(uri)
+	}
+	return expanded
+}
+
+// expandTerm expands the CURIE of a resource term. All other terms are returned unchanged.
+func (ont *OntologyGraph) expandTerm(t Term) Term {
+	if ont.prefixes == nil || !t.IsResource() {
+		return t
+	}
+	return NewResourceTerm(ont.prefixes.Expand(t.Value()))
+}
+
+// expandTriple expands the CURIEs of the resource terms of the triple.
+func (ont *OntologyGraph) expandTriple(trp Triple) Triple {
+	return Triple{Subject: ont.expandTerm(trp.Subject), Predicate: ont.expandTerm(trp.Predicate), Object: ont.expandTerm(trp.Object)}
+}
+
+// expandTriples expands the CURIEs of the resource terms of all triples into a new list.
+func (ont *OntologyGraph) expandTriples(trps []Triple) []Triple {
+	if ont.prefixes == nil {
+		return trps
+	}
+	expanded := make([]Triple, len(trps))
+	for i, trp := range trps {
+		expanded[i] = ont.expandTriple(trp)
+	}
+	return expanded
+}
+
+// expandFilter expands the CURIEs of the filter triples into a new filter.
+func (ont *OntologyGraph) expandFilter(filters TripleFilter) TripleFilter {
+	if ont.prefixes == nil || filters == nil {
+		return filters
+	}
+	expanded := make(TripleFilter, len(filters))
+	for i, trps := range filters {
+		expanded[i] = make([]FilterTriple, len(trps))
+		for j, filterTrp := range trps {
+			expanded[i][j] = FilterTriple{Triple: ont.expandTriple(filterTrp.Triple), Condition: filterTrp.Condition}
+		}
+	}
+	return expanded
+}
@@ -0,0 +1,91 @@
+package ontograph_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Prefixes", func() {
+	const ns = "http://example.com/onto#"
+
+	It("should expand and compact CURIEs of registered prefixes", func() {
+		prefixes := NewPrefixes()
+		Expect(prefixes.Register("ex", ns)).To(Succeed())
+		Expect(prefixes.Register("exsub", ns+"sub/")).To(Succeed())
+		Expect(prefixes.Expand("ex:Sensor")).To(Equal(ns + "Sensor"))
+		Expect(prefixes.Expand("owl:Class")).To(Equal(OWLClass))
+		Expect(prefixes.Expand("unknown:Sensor")).To(Equal("unknown:Sensor"))
+		Expect(prefixes.Expand(ns + "Sensor")).To(Equal(ns + "Sensor"))
+		Expect(prefixes.Expand("_:b1")).To(Equal("_:b1"))
+
+		Expect(prefixes.Compact(ns + "Sensor")).To(Equal("ex:Sensor"))
+		Expect(prefixes.Compact(ns + "sub/Probe")).To(Equal("exsub:Probe"))
+		Expect(prefixes.Compact("http://other.com/onto#A")).To(Equal("http://other.com/onto#A"))
+		Expect(prefixes.PrefixMap()).To(HaveKeyWithValue("ex", ns))
+
+		Expect(errors.Is(prefixes.Register("1x", ns), ErrInvalidPrefix)).To(BeTrue())
+		Expect(errors.Is(prefixes.Register("ex", ""), ErrInvalidPrefix)).To(BeTrue())
+
+		var none *Prefixes
+		Expect(none.Expand("ex:Sensor")).To(Equal("ex:Sensor"))
+		Expect(none.Compact(ns + "Sensor")).To(Equal(ns + "Sensor"))
+		Expect(errors.Is(none.Register("ex", ns), ErrInvalidPrefix)).To(BeTrue())
+	})
+
+	It("should register prefixes on zero-value prefixes concurrently", func() {
+		prefixes := &Prefixes{}
+		Expect(prefixes.Expand("ex:Sensor")).To(Equal("ex:Sensor"))
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 100; i++ {
+				prefixes.Expand("ex:Sensor")
+				prefixes.Compact(ns + "Sensor")
+			}
+		}()
+		for i := 0; i < 100; i++ {
+			Expect(prefixes.Register(fmt.Sprintf("ex%d", i), ns)).To(Succeed())
+		}
+		<-done
+		Expect(prefixes.Register("ex", ns)).To(Succeed())
+		Expect(prefixes.Expand("ex:Sensor")).To(Equal(ns + "Sensor"))
+	})
+
+	It("should accept CURIEs in place of URIs in ontology graphs", func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err := LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		prefixes := NewPrefixes()
+		Expect(prefixes.Register("ex", ns)).To(Succeed())
+		ont.SetPrefixes(prefixes)
+		Expect(ont.GetPrefixes()).To(Equal(prefixes))
+
+		Expect(ont.UpsertResource(&OntologyClass{URI: "ex:Device"})).To(Succeed())
+		Expect(ont.UpsertResource(&OntologyClass{URI: "ex:Sensor", SubClassOf: []string{"ex:Device"}})).To(Succeed())
+		class, err := ont.GetClass("ex:Sensor")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.URI).To(Equal(ns + "Sensor"))
+		Expect(class.SubClassOf).To(Equal([]string{ns + "Device"}))
+		Expect(ont.GetSubClasses("ex:Device", true)).To(Equal([]string{ns + "Sensor"}))
+
+		indiv := OntologyIndividual{URI: "ex:probe1", Types: []string{"ex:Sensor"}}
+		Expect(ont.UpsertResource(&indiv)).To(Succeed())
+		indivs, err := ont.GetIndividuals(TripleFilter{}.OrWithClass("ex:Sensor"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indivs).To(HaveLen(1))
+		Expect(prefixes.Compact(indivs[0].URI)).To(Equal("ex:probe1"))
+
+		Expect(ont.DeleteResource("ex:probe1")).To(Succeed())
+		_, err = ont.GetIndividual(ns + "probe1")
+		Expect(errors.Is(err, ErrResourceNotFound)).To(BeTrue())
+	})
+})
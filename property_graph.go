@@ -0,0 +1,266 @@
+package ontograph
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// PropertyGraphIndividualLabel is the node label shared by all nodes exported from individuals. Property graph tools can use it to
+// index the `uri` attribute of all nodes at once.
+const PropertyGraphIndividualLabel = "Individual"
+
+// PropertyGraphOptions configures the mapping of an ontology graph onto a property graph.
+type PropertyGraphOptions struct {
+	// Language selects the language of the label stored in the `label` attribute of nodes. Nodes without label in the language have
+	// no `label` attribute.
+	Language string
+	// FullURIs uses the full URIs of classes and properties as node labels, relationship types and attribute names instead of their
+	// local names. Use this if local names are ambiguous within the ontology.
+	FullURIs bool
+}
+
+// A PropertyGraph is a labeled property graph, i.e. nodes with labels and attributes, connected by typed relationships.
+type PropertyGraph struct {
+	Nodes         []PropertyGraphNode
+	Relationships []PropertyGraphRelationship
+}
+
+// A PropertyGraphNode is a node of a property graph. Its ID is the URI of the individual it represents.
+type PropertyGraphNode struct {
+	ID         string
+	Labels     []string
+	Attributes map[string][]GenericLiteral
+}
+
+// A PropertyGraphRelationship is a directed and typed relationship between the nodes with the given IDs.
+type PropertyGraphRelationship struct {
+	Start string
+	End   string
+	Type  string
+}
+
+// ToPropertyGraph maps the individuals of the ontology onto a property graph. Each individual becomes a node labeled with its types,
+// data properties become node attributes and object properties become relationships. Relationships to resources that are no
+// individuals of the graph are dropped (and reported as `ErrDroppedTriple` to the warning collector of the graph, if any). The nodes
+// and relationships are sorted, so the same ontology always yields the same property graph.
+func (ont *OntologyGraph) ToPropertyGraph(opts PropertyGraphOptions) (*PropertyGraph, error) {
+	indivs, err := ont.GetIndividuals(nil)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(indivs, func(i, j int) bool { return indivs[i].URI < indivs[j].URI })
+	name := func(uri string) string {
+		if opts.FullURIs {
+			return uri
+		}
+		return localName(uri)
+	}
+	nodes := map[string]bool{}
+	for _, indiv := range indivs {
+		nodes[indiv.URI] = true
+	}
+	pg := PropertyGraph{}
+	for _, indiv := range indivs {
+		node := PropertyGraphNode{
+			ID:         indiv.URI,
+			Labels:     []string{PropertyGraphIndividualLabel},
+			Attributes: map[string][]GenericLiteral{},
+		}
+		types := append([]string{}, indiv.Types...)
+		sort.Strings(types)
+		for _, typ := range types {
+			node.Labels = append(node.Labels, name(typ))
+		}
+		if label, ok := indiv.Label[opts.Language]; ok {
+			node.Attributes["label"] = []GenericLiteral{*NewGenericLiteral(NewLiteralTerm(label, opts.Language, ""))}
+		}
+		for prop, values := range indiv.DataProperties {
+			node.Attributes[name(prop)] = append(node.Attributes[name(prop)], values...)
+		}
+		for _, values := range node.Attributes {
+			sort.Slice(values, func(i, j int) bool { return values[i].String() < values[j].String() })
+		}
+		pg.Nodes = append(pg.Nodes, node)
+		props := []string{}
+		for prop := range indiv.ObjectProperties {
+			props = append(props, prop)
+		}
+		sort.Strings(props)
+		for _, prop := range props {
+			targets := append([]string{}, indiv.ObjectProperties[prop]...)
+			sort.Strings(targets)
+			for _, target := range targets {
+				if !nodes[target] {
+					if ont.warnings != nil {
+						trp := Triple{Subject: NewResourceTerm(indiv.URI), Predicate: NewResourceTerm(prop), Object: NewResourceTerm(target)}
+						ont.warnings.Warn(Warning{Triple: trp, Err: ErrDroppedTriple})
+					}
+					continue
+				}
+				pg.Relationships = append(pg.Relationships, PropertyGraphRelationship{Start: indiv.URI, End: target, Type: name(prop)})
+			}
+		}
+	}
+	return &pg, nil
+}
+
+// ExportNeo4jCSV writes the individuals of the ontology (see `ToPropertyGraph`) as node and relationship CSV files in the format of
+// the Neo4j bulk importer (`neo4j-admin import --nodes=... --relationships=...`). Attribute columns are typed according to the
+// datatypes of their values and multiple values are written as arrays separated by `;`.
+func (ont *OntologyGraph) ExportNeo4jCSV(nodes, relationships io.Writer, opts PropertyGraphOptions) error {
+	pg, err := ont.ToPropertyGraph(opts)
+	if err != nil {
+		return err
+	}
+
+	// Write nodes with one column per attribute
+	attrs := pg.attributeTypes()
+	names := make([]string, 0, len(attrs))
+	for attr := range attrs {
+		names = append(names, attr)
+	}
+	sort.Strings(names)
+	nw := csv.NewWriter(nodes)
+	header := []string{"uri:ID", ":LABEL"}
+	for _, attr := range names {
+		header = append(header, attr+":"+attrs[attr])
+	}
+	if err := nw.Write(header); err != nil {
+		return err
+	}
+	for _, node := range pg.Nodes {
+		row := []string{node.ID, strings.Join(node.Labels, ";")}
+		for _, attr := range names {
+			values := make([]string, len(node.Attributes[attr]))
+			for i, value := range node.Attributes[attr] {
+				values[i] = value.Value()
+			}
+			row = append(row, strings.Join(values, ";"))
+		}
+		if err := nw.Write(row); err != nil {
+			return err
+		}
+	}
+	nw.Flush()
+	if err := nw.Error(); err != nil {
+		return err
+	}
+
+	// Write relationships
+	rw := csv.NewWriter(relationships)
+	if err := rw.Write([]string{":START_ID", ":END_ID", ":TYPE"}); err != nil {
+		return err
+	}
+	for _, rel := range pg.Relationships {
+		if err := rw.Write([]string{rel.Start, rel.End, rel.Type}); err != nil {
+			return err
+		}
+	}
+	rw.Flush()
+	return rw.Error()
+}
+
+// ExportCypher writes the individuals of the ontology (see `ToPropertyGraph`) as Cypher statements into the writer, which create
+// the nodes and relationships when run against Neo4j or other openCypher databases. Nodes are matched by their `uri` attribute.
+func (ont *OntologyGraph) ExportCypher(w io.Writer, opts PropertyGraphOptions) error {
+	pg, err := ont.ToPropertyGraph(opts)
+	if err != nil {
+		return err
+	}
+	attrs := pg.attributeTypes()
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("CREATE INDEX ON :%s(uri);\n", cypherName(PropertyGraphIndividualLabel)))
+	for _, node := range pg.Nodes {
+		labels := make([]string, len(node.Labels))
+		for i, label := range node.Labels {
+			labels[i] = ":" + cypherName(label)
+		}
+		names := []string{}
+		for attr := range node.Attributes {
+			names = append(names, attr)
+		}
+		sort.Strings(names)
+		fields := []string{"uri: " + cypherString(node.ID)}
+		for _, attr := range names {
+			values := make([]string, len(node.Attributes[attr]))
+			for i, value := range node.Attributes[attr] {
+				values[i] = cypherValue(value.Value(), attrs[attr])
+			}
+			if strings.HasSuffix(attrs[attr], "[]") {
+				fields = append(fields, fmt.Sprintf("%s: [%s]", cypherName(attr), strings.Join(values, ", ")))
+			} else {
+				fields = append(fields, fmt.Sprintf("%s: %s", cypherName(attr), values[0]))
+			}
+		}
+		sb.WriteString(fmt.Sprintf("CREATE (%s {%s});\n", strings.Join(labels, ""), strings.Join(fields, ", ")))
+	}
+	for _, rel := range pg.Relationships {
+		sb.WriteString(fmt.Sprintf("MATCH (a:%[1]s {uri: %[2]s}), (b:%[1]s {uri: %[3]s}) CREATE (a)-[:%[4]s]->(b);\n",
+			cypherName(PropertyGraphIndividualLabel), cypherString(rel.Start), cypherString(rel.End), cypherName(rel.Type)))
+	}
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// attributeTypes returns the Neo4j type of each attribute of the property graph (e.g. `long` or `string[]`). Attributes with values of
+// different types are typed as strings and attributes with multiple values on any node are typed as arrays.
+func (pg *PropertyGraph) attributeTypes() map[string]string {
+	types := map[string]string{}
+	arrays := map[string]bool{}
+	for _, node := range pg.Nodes {
+		for attr, values := range node.Attributes {
+			if len(values) > 1 {
+				arrays[attr] = true
+			}
+			for _, value := range values {
+				typ := neo4jType(value.Type().URI)
+				if prev, ok := types[attr]; ok && prev != typ {
+					typ = "string"
+				}
+				types[attr] = typ
+			}
+		}
+	}
+	for attr := range arrays {
+		types[attr] += "[]"
+	}
+	return types
+}
+
+// neo4jType returns the Neo4j type for values of the given datatype.
+func neo4jType(datatype string) string {
+	switch datatype {
+	case XSDInteger:
+		return "long"
+	case XSDDecimal, XSDDouble, XSDFloat:
+		return "double"
+	case XSDBoolean:
+		return "boolean"
+	}
+	return "string"
+}
+
+// cypherName quotes the string as Cypher identifier (e.g. a label, relationship type or property key).
+func cypherName(s string) string {
+	return "`" + strings.Replace(s, "`", "``", -1) + "`"
+}
+
+// cypherString quotes the string as Cypher string literal.
+func cypherString(s string) string {
+	return "'" + strings.Replace(strings.Replace(s, `\`, `\\`, -1), "'", `\'`, -1) + "'"
+}
+
+// cypherValue formats the value as Cypher literal of the given Neo4j type.
+func cypherValue(value, typ string) string {
+	if strings.TrimSuffix(typ, "[]") == "string" {
+		return cypherString(value)
+	}
+	return value
+}
@@ -0,0 +1,349 @@
+package ontograph
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// PropertyGraphMapping configures how the labels, relationship types and attributes of a property graph are mapped onto classes,
+// object properties and data properties of an ontology (see `OntologyGraph.ImportPropertyGraph`). Names without explicit mapping
+// that are absolute URIs (e.g. exported with `PropertyGraphOptions.FullURIs`) are used as they are, all other names are resolved
+// against the namespace or ignored if no namespace is set.
+type PropertyGraphMapping struct {
+	// Labels maps node labels to class URIs.
+	Labels map[string]string
+	// RelationshipTypes maps relationship types to object property URIs.
+	RelationshipTypes map[string]string
+	// Attributes maps node attributes to data property URIs.
+	Attributes map[string]string
+	// Namespace is prepended to unmapped names, e.g. `http://example.com/onto#`.
+	Namespace string
+	// Language is the language of the `label` attribute, which is imported as label of the individual unless it is mapped explicitly.
+	Language string
+}
+
+// ImportPropertyGraph creates an individual for each node of the property graph and returns the number of created individuals.
+// Nodes are typed with the classes of their labels, attributes become data properties and relationships become object properties.
+// Node IDs that are absolute URIs are used as URIs of the individuals, all other IDs are appended as fragment to the graph URI.
+// Already stored versions of the individuals are replaced. Relationships to unknown nodes are dropped (and reported as
+// `ErrDroppedTriple` to the warning collector of the graph, if any).
+func (ont *OntologyGraph) ImportPropertyGraph(pg *PropertyGraph, mapping PropertyGraphMapping) (int, error) {
+	resolve := func(name string, explicit map[string]string) string {
+		if uri, ok := explicit[name]; ok {
+			return uri
+		}
+		if strings.Contains(name, "://") {
+			return name
+		}
+		if mapping.Namespace != "" {
+			return mapping.Namespace + name
+		}
+		return ""
+	}
+	nodeURI := func(id string) string {
+		if strings.Contains(id, "://") {
+			return id
+		}
+		return ont.uriMatcher.ResourceURI(ont.graph.GetURI(), id)
+	}
+
+	// Map nodes onto individuals
+	indivs := map[string]*OntologyIndividual{}
+	for _, node := range pg.Nodes {
+		indiv := &OntologyIndividual{URI: nodeURI(node.ID)}
+		for _, label := range node.Labels {
+			if label == PropertyGraphIndividualLabel {
+				continue
+			}
+			if class := resolve(label, mapping.Labels); class != "" {
+				indiv.Types = append(indiv.Types, class)
+			}
+		}
+		attrs := []string{}
+		for attr := range node.Attributes {
+			attrs = append(attrs, attr)
+		}
+		sort.Strings(attrs)
+		for _, attr := range attrs {
+			prop := resolve(attr, mapping.Attributes)
+			if _, ok := mapping.Attributes[attr]; !ok && attr == "label" {
+				if values := node.Attributes[attr]; len(values) > 0 {
+					indiv.Label = map[string]string{mapping.Language: values[0].Value()}
+				}
+				continue
+			}
+			if prop == "" {
+				continue
+			}
+			for _, value := range node.Attributes[attr] {
+				indiv.AddDataProperty(prop, value)
+			}
+		}
+		indivs[node.ID] = indiv
+	}
+
+	// Map relationships onto object properties
+	for _, rel := range pg.Relationships {
+		prop := resolve(rel.Type, mapping.RelationshipTypes)
+		if prop == "" {
+			continue
+		}
+		start, end := indivs[rel.Start], indivs[rel.End]
+		if start == nil || end == nil {
+			if ont.warnings != nil {
+				trp := Triple{Subject: NewResourceTerm(nodeURI(rel.Start)), Predicate: NewResourceTerm(prop), Object: NewResourceTerm(nodeURI(rel.End))}
+				ont.warnings.Warn(Warning{Triple: trp, Err: ErrDroppedTriple})
+			}
+			continue
+		}
+		start.AddObjectProperty(prop, end.URI)
+	}
+
+	// Replace all individuals at once, so the relationships between them are kept
+	trps := []Triple{}
+	for _, node := range pg.Nodes {
+		indiv := indivs[node.ID]
+		if !ont.isOwnURI(indiv.URI) {
+			return 0, wrapResourceError("ImportPropertyGraph", indiv.URI, ErrResourceDoesNotBelongToGraph)
+		}
+		if err := ont.DeleteResource(indiv.URI); err != nil {
+			return 0, err
+		}
+		trps = append(trps, indiv.ToTriples()...)
+	}
+	if err := ont.graph.AddTriplesUnchecked(trps); err != nil {
+		return 0, err
+	}
+	return len(pg.Nodes), nil
+}
+
+// ReadNeo4jCSV reads a property graph from node and relationship CSV files in the format of the Neo4j bulk importer (as written by
+// `OntologyGraph.ExportNeo4jCSV`). The node file needs an `:ID` column and may contain a `:LABEL` column and typed attribute columns
+// like `age:long` or `aliases:string[]`. The relationship file needs `:START_ID`, `:END_ID` and `:TYPE` columns. Array values and
+// labels are separated by `;`.
+func ReadNeo4jCSV(nodes, relationships io.Reader) (*PropertyGraph, error) {
+	pg := PropertyGraph{}
+
+	// Read nodes
+	rows, err := readCSVRows(nodes)
+	if err != nil {
+		return nil, err
+	}
+	idCol, labelCol := -1, -1
+	attrs := map[int][2]string{}
+	for i, col := range rows[0] {
+		name, typ := col, "string"
+		if pos := strings.LastIndex(col, ":"); pos >= 0 {
+			name, typ = col[:pos], col[pos+1:]
+		}
+		switch typ {
+		case "ID":
+			idCol = i
+		case "LABEL":
+			labelCol = i
+		default:
+			attrs[i] = [2]string{name, typ}
+		}
+	}
+	if idCol < 0 {
+		return nil, fmt.Errorf("%w: Missing :ID column in node file", ErrInvalidPropertyGraph)
+	}
+	for n, row := range rows[1:] {
+		node := PropertyGraphNode{ID: row[idCol], Attributes: map[string][]GenericLiteral{}}
+		if labelCol >= 0 && row[labelCol] != "" {
+			node.Labels = strings.Split(row[labelCol], ";")
+		}
+		for i, attr := range attrs {
+			if row[i] == "" {
+				continue
+			}
+			values := []string{row[i]}
+			if strings.HasSuffix(attr[1], "[]") {
+				values = strings.Split(row[i], ";")
+			}
+			datatype, ok := neo4jDatatype(strings.TrimSuffix(attr[1], "[]"))
+			if !ok {
+				return nil, fmt.Errorf("%w: Unknown type '%s' of column '%s' in node row %d", ErrInvalidPropertyGraph, attr[1], attr[0], n+2)
+			}
+			for _, value := range values {
+				node.Attributes[attr[0]] = append(node.Attributes[attr[0]], *NewGenericLiteral(NewLiteralTerm(value, "", datatype)))
+			}
+		}
+		pg.Nodes = append(pg.Nodes, node)
+	}
+
+	// Read relationships
+	rows, err = readCSVRows(relationships)
+	if err != nil {
+		return nil, err
+	}
+	cols := map[string]int{}
+	for i, col := range rows[0] {
+		if pos := strings.LastIndex(col, ":"); pos >= 0 {
+			cols[col[pos+1:]] = i
+		}
+	}
+	startCol, ok1 := cols["START_ID"]
+	endCol, ok2 := cols["END_ID"]
+	typeCol, ok3 := cols["TYPE"]
+	if !ok1 || !ok2 || !ok3 {
+		return nil, fmt.Errorf("%w: Missing :START_ID, :END_ID or :TYPE column in relationship file", ErrInvalidPropertyGraph)
+	}
+	for _, row := range rows[1:] {
+		pg.Relationships = append(pg.Relationships, PropertyGraphRelationship{Start: row[startCol], End: row[endCol], Type: row[typeCol]})
+	}
+	return &pg, nil
+}
+
+// ReadGraphSON reads a property graph in the GraphSON adjacency list format of TinkerPop (one vertex per line, with its outgoing
+// edges in `outE`). Both typed (GraphSON 2.0 and 3.0) and untyped (GraphSON 1.0) values are supported. The label of a vertex
+// becomes its only node label and vertex properties become attributes (meta-properties are ignored).
+func ReadGraphSON(r io.Reader) (*PropertyGraph, error) {
+	type graphSONProperty struct {
+		Value json.RawMessage `json:"value"`
+	}
+	type graphSONEdge struct {
+		InV json.RawMessage `json:"inV"`
+	}
+	type graphSONVertex struct {
+		ID         json.RawMessage               `json:"id"`
+		Label      string                        `json:"label"`
+		OutE       map[string][]graphSONEdge     `json:"outE"`
+		Properties map[string][]graphSONProperty `json:"properties"`
+	}
+	r, err := decompressReader(r)
+	if err != nil {
+		return nil, err
+	}
+	pg := PropertyGraph{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		vertex := graphSONVertex{}
+		if err := json.Unmarshal(scanner.Bytes(), &vertex); err != nil {
+			return nil, fmt.Errorf("%w: Invalid vertex in line %d: %v", ErrInvalidPropertyGraph, line, err)
+		}
+		id, err := graphSONValue(vertex.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: Invalid vertex ID in line %d: %v", ErrInvalidPropertyGraph, line, err)
+		}
+		node := PropertyGraphNode{ID: id.Value(), Attributes: map[string][]GenericLiteral{}}
+		if vertex.Label != "" {
+			node.Labels = []string{vertex.Label}
+		}
+		for name, props := range vertex.Properties {
+			for _, prop := range props {
+				value, err := graphSONValue(prop.Value)
+				if err != nil {
+					return nil, fmt.Errorf("%w: Invalid value of property '%s' in line %d: %v", ErrInvalidPropertyGraph, name, line, err)
+				}
+				node.Attributes[name] = append(node.Attributes[name], value)
+			}
+		}
+		pg.Nodes = append(pg.Nodes, node)
+		labels := []string{}
+		for label := range vertex.OutE {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+		for _, label := range labels {
+			for _, edge := range vertex.OutE[label] {
+				target, err := graphSONValue(edge.InV)
+				if err != nil {
+					return nil, fmt.Errorf("%w: Invalid edge target in line %d: %v", ErrInvalidPropertyGraph, line, err)
+				}
+				pg.Relationships = append(pg.Relationships, PropertyGraphRelationship{Start: node.ID, End: target.Value(), Type: label})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &pg, nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrInvalidPropertyGraph is raised when a property graph export cannot be read.
+var ErrInvalidPropertyGraph error = errors.New("The property graph is invalid")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// readCSVRows reads all rows of the (possibly compressed) CSV data and checks that there is a header row.
+func readCSVRows(r io.Reader) ([][]string, error) {
+	r, err := decompressReader(r)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPropertyGraph, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: Missing header row", ErrInvalidPropertyGraph)
+	}
+	return rows, nil
+}
+
+// neo4jDatatype returns the datatype for values of the given Neo4j type.
+func neo4jDatatype(typ string) (string, bool) {
+	switch strings.ToLower(typ) {
+	case "", "string", "char":
+		return XSDString, true
+	case "long", "int", "short", "byte":
+		return XSDInteger, true
+	case "double", "float":
+		return XSDDouble, true
+	case "boolean":
+		return XSDBoolean, true
+	}
+	return "", false
+}
+
+// graphSONValue converts the (possibly typed) GraphSON value into a literal.
+func graphSONValue(raw json.RawMessage) (GenericLiteral, error) {
+	typed := struct {
+		Type  string          `json:"@type"`
+		Value json.RawMessage `json:"@value"`
+	}{}
+	if err := json.Unmarshal(raw, &typed); err == nil && typed.Type != "" {
+		switch typed.Type {
+		case "g:Int32", "g:Int64":
+			return *NewGenericLiteral(NewLiteralTerm(string(typed.Value), "", XSDInteger)), nil
+		case "g:Float", "g:Double":
+			return *NewGenericLiteral(NewLiteralTerm(string(typed.Value), "", XSDDouble)), nil
+		}
+		raw = typed.Value
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return GenericLiteral{}, err
+	}
+	switch value := value.(type) {
+	case string:
+		return *NewGenericLiteral(NewLiteralTerm(value, "", XSDString)), nil
+	case bool:
+		return *NewGenericLiteral(NewLiteralTerm(fmt.Sprint(value), "", XSDBoolean)), nil
+	case float64:
+		if strings.ContainsAny(string(raw), ".eE") {
+			return *NewGenericLiteral(NewLiteralTerm(string(raw), "", XSDDouble)), nil
+		}
+		return *NewGenericLiteral(NewLiteralTerm(string(raw), "", XSDInteger)), nil
+	}
+	return GenericLiteral{}, fmt.Errorf("Unsupported value %s", raw)
+}
@@ -0,0 +1,113 @@
+package ontograph_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Property graph export", func() {
+	const testUri = "http://example.com/onto"
+	var ont *OntologyGraph
+	var report *Report
+
+	BeforeEach(func() {
+		var err error
+		ont, err = InitOntologyGraph(NewMemoryStore(testUri))
+		Expect(err).NotTo(HaveOccurred())
+		report = &Report{}
+		ont.SetWarningCollector(report)
+		alice := OntologyIndividual{URI: testUri + "#alice", Types: []string{testUri + "#Person"}, Label: map[string]string{"en": "Alice"}}
+		alice.AddDataProperty(testUri+"#age", XSDIntegerLiteral(42).Generic())
+		alice.AddDataProperty(testUri+"#nickname", XSDStringLiteral("Al").Generic())
+		alice.AddDataProperty(testUri+"#nickname", XSDStringLiteral("Ali's").Generic())
+		alice.AddObjectProperty(testUri+"#knows", testUri+"#bob")
+		alice.AddObjectProperty(testUri+"#knows", "http://example.com/other#carol")
+		bob := OntologyIndividual{URI: testUri + "#bob", Types: []string{testUri + "#Person"}}
+		Expect(ont.UpsertResource(&bob)).To(Succeed())
+		Expect(ont.UpsertResource(&alice)).To(Succeed())
+	})
+
+	It("should map individuals to nodes and relationships", func() {
+		pg, err := ont.ToPropertyGraph(PropertyGraphOptions{Language: "en"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pg.Nodes).To(HaveLen(2))
+		Expect(pg.Nodes[0].ID).To(Equal(testUri + "#alice"))
+		Expect(pg.Nodes[0].Labels).To(Equal([]string{PropertyGraphIndividualLabel, "Person"}))
+		Expect(pg.Nodes[0].Attributes).To(HaveKey("label"))
+		Expect(pg.Nodes[0].Attributes["nickname"]).To(HaveLen(2))
+		Expect(pg.Relationships).To(Equal([]PropertyGraphRelationship{{Start: testUri + "#alice", End: testUri + "#bob", Type: "knows"}}))
+		Expect(report.Warnings()).To(HaveLen(1))
+		Expect(report.Warnings()[0].Err).To(MatchError(ErrDroppedTriple))
+	})
+
+	It("should export CSV files for the Neo4j bulk importer", func() {
+		var nodes, rels bytes.Buffer
+		Expect(ont.ExportNeo4jCSV(&nodes, &rels, PropertyGraphOptions{Language: "en"})).To(Succeed())
+		Expect(nodes.String()).To(Equal("uri:ID,:LABEL,age:long,label:string,nickname:string[]\n" +
+			"http://example.com/onto#alice,Individual;Person,42,Alice,Al;Ali's\n" +
+			"http://example.com/onto#bob,Individual;Person,,,\n"))
+		Expect(rels.String()).To(Equal(":START_ID,:END_ID,:TYPE\nhttp://example.com/onto#alice,http://example.com/onto#bob,knows\n"))
+	})
+
+	It("should export Cypher statements", func() {
+		var buf bytes.Buffer
+		Expect(ont.ExportCypher(&buf, PropertyGraphOptions{Language: "en"})).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("CREATE (:`Individual`:`Person` {uri: 'http://example.com/onto#alice', `age`: 42, `label`: 'Alice', `nickname`: ['Al', 'Ali\\'s']});\n"))
+		Expect(buf.String()).To(ContainSubstring("MATCH (a:`Individual` {uri: 'http://example.com/onto#alice'}), (b:`Individual` {uri: 'http://example.com/onto#bob'}) CREATE (a)-[:`knows`]->(b);\n"))
+	})
+	It("should round-trip individuals through Neo4j CSV files", func() {
+		var nodes, rels bytes.Buffer
+		Expect(ont.ExportNeo4jCSV(&nodes, &rels, PropertyGraphOptions{Language: "en", FullURIs: true})).To(Succeed())
+		pg, err := ReadNeo4jCSV(&nodes, &rels)
+		Expect(err).NotTo(HaveOccurred())
+		imported, err := InitOntologyGraph(NewMemoryStore(testUri))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(imported.ImportPropertyGraph(pg, PropertyGraphMapping{Language: "en"})).To(Equal(2))
+		alice, err := imported.GetIndividual(testUri + "#alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(alice.Types).To(ConsistOf(testUri + "#Person"))
+		Expect(alice.Label).To(Equal(map[string]string{"en": "Alice"}))
+		Expect(alice.ObjectProperties[testUri+"#knows"]).To(ConsistOf(testUri + "#bob"))
+		Expect(alice.DataProperties[testUri+"#age"]).To(HaveLen(1))
+		Expect(alice.DataProperties[testUri+"#age"][0].Value()).To(Equal("42"))
+		Expect(alice.DataProperties[testUri+"#age"][0].Type().URI).To(Equal(XSDInteger))
+		Expect(alice.DataProperties[testUri+"#nickname"]).To(HaveLen(2))
+	})
+
+	It("should import GraphSON with a mapping", func() {
+		graphSON := `{"id":{"@type":"g:Int64","@value":1},"label":"person","outE":{"knows":[{"id":{"@type":"g:Int64","@value":7},"inV":{"@type":"g:Int64","@value":2}},{"id":{"@type":"g:Int64","@value":8},"inV":{"@type":"g:Int64","@value":3}}]},"properties":{"name":[{"id":{"@type":"g:Int64","@value":0},"value":"marko"}],"age":[{"id":{"@type":"g:Int64","@value":1},"value":{"@type":"g:Int32","@value":29}}]}}
+{"id":2,"label":"person","properties":{"name":[{"id":2,"value":"vadas"}],"age":[{"id":3,"value":27}]}}
+`
+		pg, err := ReadGraphSON(strings.NewReader(graphSON))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pg.Nodes).To(HaveLen(2))
+		Expect(pg.Relationships).To(HaveLen(2))
+		n, err := ont.ImportPropertyGraph(pg, PropertyGraphMapping{
+			Labels:            map[string]string{"person": testUri + "#Person"},
+			RelationshipTypes: map[string]string{"knows": testUri + "#knows"},
+			Attributes:        map[string]string{"name": RDFSLabel},
+			Namespace:         testUri + "#",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(2))
+		marko, err := ont.GetIndividual(testUri + "#1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(marko.Types).To(ConsistOf(testUri + "#Person"))
+		Expect(marko.ObjectProperties[testUri+"#knows"]).To(ConsistOf(testUri + "#2"))
+		Expect(marko.DataProperties[testUri+"#age"][0].Value()).To(Equal("29"))
+		Expect(report.Warnings()).To(ContainElement(Warning{
+			Triple: Triple{Subject: NewResourceTerm(testUri + "#1"), Predicate: NewResourceTerm(testUri + "#knows"), Object: NewResourceTerm(testUri + "#3")},
+			Err:    ErrDroppedTriple,
+		}))
+	})
+
+	It("should reject node files without ID column", func() {
+		_, err := ReadNeo4jCSV(strings.NewReader("name\nfoo\n"), strings.NewReader(":START_ID,:END_ID,:TYPE\n"))
+		Expect(err).To(MatchError(ErrInvalidPropertyGraph))
+	})
+})
@@ -0,0 +1,340 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// A PathQuerier resolves property paths. It is implemented by graph stores that can evaluate property paths natively.
+type PathQuerier interface {
+	// GetPathTargets should return all distinct terms that are reachable from the subject via the property path.
+	GetPathTargets(subj, path string) ([]Term, error)
+}
+
+// GetPathTargets returns all distinct terms that are reachable from the subject via the property path, e.g. to resolve transitive
+// part-of chains in a single call. The path uses the SPARQL property path syntax with full URIs: `<p>` (or `a` for `rdf:type`),
+// sequences `p1/p2`, alternatives `p1|p2`, inverse paths `^p`, the modifiers `p*`, `p+` and `p?` and grouping with parentheses.
+// Stores that implement `PathQuerier` evaluate the path themselves, for all other stores the path is evaluated in-process. Errors
+// with `ErrInvalidPath` if the path cannot be parsed.
+func GetPathTargets(store GraphStore, subj, path string) ([]Term, error) {
+	if querier, ok := store.(PathQuerier); ok {
+		return querier.GetPathTargets(subj, path)
+	}
+	return getPathTargets(store, subj, path)
+}
+
+// GetPathTargets returns all distinct terms that are reachable from the subject via the property path (see `GetPathTargets`).
+// Zero-length paths include the subject itself. The targets are returned in the order in which they are reached.
+func (store *MemoryStore) GetPathTargets(subj, path string) ([]Term, error) {
+	return getPathTargets(store, subj, path)
+}
+
+// GetPathTargets returns all distinct terms that are reachable from the subject via the property path (see `GetPathTargets`). The path
+// is translated into a SPARQL query and evaluated by Blazegraph.
+func (store *BlazegraphStore) GetPathTargets(subj, path string) ([]Term, error) {
+	parsed, err := parsePropertyPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if subj == "" {
+		return nil, fmt.Errorf("%w: Missing subject", ErrInvalidPath)
+	}
+	// Construct and execute SPARQL query
+	sparqlReq := fmt.Sprintf(`SELECT DISTINCT ?o WHERE { GRAPH <%s> { %s %s ?o . } }`, store.uri, sparqlTerm(Term(subj)), parsed)
+	resSet, code, err := store.endpoint.DoSparqlJSONQuery(store.namespace, sparqlReq)
+	if err != nil {
+		return nil, store.wrapErr("GetPathTargets", nil, sparqlReq, err)
+	}
+	if code != http.StatusOK {
+		return nil, store.wrapErr("GetPathTargets", nil, sparqlReq, fmt.Errorf("Received unexpected status code from SPARQL query (HTTP %d): %s", code, sparqlReq))
+	}
+	targets := []Term{}
+	for _, binding := range resSet.Results.Bindings {
+		targets = append(targets, binding2Term(binding["o"]))
+	}
+	return targets, nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrInvalidPath is raised when a property path cannot be parsed.
+var ErrInvalidPath error = errors.New("The property path is invalid")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// propertyPath is a node of a parsed property path.
+type propertyPath interface {
+	String() string
+}
+
+// pathLink is a path that follows a single predicate.
+type pathLink struct{ predicate Term }
+
+// pathInverse is a path that is followed from object to subject.
+type pathInverse struct{ path propertyPath }
+
+// pathSequence is a path that follows all of its paths one after another.
+type pathSequence struct{ paths []propertyPath }
+
+// pathAlternative is a path that follows any of its paths.
+type pathAlternative struct{ paths []propertyPath }
+
+// pathModified is a path that is followed zero or more (`*`), one or more (`+`) or zero or one (`?`) times.
+type pathModified struct {
+	path     propertyPath
+	modifier byte
+}
+
+func (p pathLink) String() string    { return p.predicate.String() }
+func (p pathInverse) String() string { return "^" + p.path.String() }
+func (p pathModified) String() string {
+	return "(" + p.path.String() + ")" + string(p.modifier)
+}
+func (p pathSequence) String() string    { return "(" + joinPaths(p.paths, "/") + ")" }
+func (p pathAlternative) String() string { return "(" + joinPaths(p.paths, "|") + ")" }
+
+// joinPaths joins the SPARQL syntax of the paths with the separator.
+func joinPaths(paths []propertyPath, sep string) string {
+	strs := make([]string, len(paths))
+	for i, path := range paths {
+		strs[i] = path.String()
+	}
+	return strings.Join(strs, sep)
+}
+
+// pathParser is a recursive descent parser for property paths.
+type pathParser struct {
+	input string
+	pos   int
+}
+
+// parsePropertyPath parses the property path in SPARQL syntax.
+func parsePropertyPath(path string) (propertyPath, error) {
+	p := &pathParser{input: path}
+	parsed, err := p.parseAlternative()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpaces(); p.pos < len(p.input) {
+		return nil, fmt.Errorf("%w: Unexpected '%c' at position %d", ErrInvalidPath, p.input[p.pos], p.pos)
+	}
+	return parsed, nil
+}
+
+// skipSpaces advances the parser to the next non-whitespace character.
+func (p *pathParser) skipSpaces() {
+	for p.pos < len(p.input) && strings.ContainsRune(" \t\r\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// accept consumes the character if it is next in the input.
+func (p *pathParser) accept(c byte) bool {
+	if p.skipSpaces(); p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// parseAlternative parses `seq ('|' seq)*`.
+func (p *pathParser) parseAlternative() (propertyPath, error) {
+	paths := []propertyPath{}
+	for {
+		path, err := p.parseSequence()
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+		if !p.accept('|') {
+			break
+		}
+	}
+	if len(paths) == 1 {
+		return paths[0], nil
+	}
+	return pathAlternative{paths}, nil
+}
+
+// parseSequence parses `elt ('/' elt)*`.
+func (p *pathParser) parseSequence() (propertyPath, error) {
+	paths := []propertyPath{}
+	for {
+		path, err := p.parseElement()
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+		if !p.accept('/') {
+			break
+		}
+	}
+	if len(paths) == 1 {
+		return paths[0], nil
+	}
+	return pathSequence{paths}, nil
+}
+
+// parseElement parses `'^'? primary ('*' | '+' | '?')?`.
+func (p *pathParser) parseElement() (propertyPath, error) {
+	inverse := p.accept('^')
+	path, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for _, modifier := range []byte{'*', '+', '?'} {
+		if p.accept(modifier) {
+			path = pathModified{path: path, modifier: modifier}
+			break
+		}
+	}
+	if inverse {
+		path = pathInverse{path}
+	}
+	return path, nil
+}
+
+// parsePrimary parses `<uri>`, `a` or `'(' path ')'`.
+func (p *pathParser) parsePrimary() (propertyPath, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return nil, fmt.Errorf("%w: Unexpected end of path", ErrInvalidPath)
+	}
+	switch c := p.input[p.pos]; {
+	case c == '(':
+		p.pos++
+		path, err := p.parseAlternative()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(')') {
+			return nil, fmt.Errorf("%w: Missing ')' at position %d", ErrInvalidPath, p.pos)
+		}
+		return path, nil
+	case c == '<':
+		end := strings.IndexByte(p.input[p.pos:], '>')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: Unterminated URI at position %d", ErrInvalidPath, p.pos)
+		}
+		uri := p.input[p.pos+1 : p.pos+end]
+		p.pos += end + 1
+		return pathLink{NewResourceTerm(uri)}, nil
+	case c == 'a' && (p.pos+1 == len(p.input) || strings.ContainsRune(" \t\r\n/|)*+?", rune(p.input[p.pos+1]))):
+		p.pos++
+		return pathLink{NewResourceTerm(RDFType)}, nil
+	default:
+		return nil, fmt.Errorf("%w: Unexpected '%c' at position %d", ErrInvalidPath, c, p.pos)
+	}
+}
+
+// getPathTargets evaluates the property path in-process on an index of all triples in the store.
+func getPathTargets(store GraphStore, subj, path string) ([]Term, error) {
+	parsed, err := parsePropertyPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if subj == "" {
+		return nil, fmt.Errorf("%w: Missing subject", ErrInvalidPath)
+	}
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return nil, err
+	}
+	return newSPARQLDataset(trps).evalPath(parsed, []Term{Term(subj)}, false), nil
+}
+
+// evalPath returns all distinct terms that are reachable from the nodes via the path. If inverse is set, the path is followed from
+// object to subject.
+func (data *sparqlDataset) evalPath(path propertyPath, nodes []Term, inverse bool) []Term {
+	switch path := path.(type) {
+	case pathLink:
+		targets := newTermSet()
+		for _, node := range nodes {
+			if inverse {
+				for _, i := range data.byObject[node] {
+					if data.trps[i].Predicate == path.predicate {
+						targets.add(data.trps[i].Subject)
+					}
+				}
+				continue
+			}
+			for _, i := range data.bySubject[node] {
+				if data.trps[i].Predicate == path.predicate {
+					targets.add(data.trps[i].Object)
+				}
+			}
+		}
+		return targets.terms
+	case pathInverse:
+		return data.evalPath(path.path, nodes, !inverse)
+	case pathSequence:
+		for i := range path.paths {
+			next := path.paths[i]
+			if inverse {
+				next = path.paths[len(path.paths)-1-i]
+			}
+			nodes = data.evalPath(next, nodes, inverse)
+		}
+		return nodes
+	case pathAlternative:
+		targets := newTermSet()
+		for _, alt := range path.paths {
+			targets.add(data.evalPath(alt, nodes, inverse)...)
+		}
+		return targets.terms
+	case pathModified:
+		targets := newTermSet()
+		if path.modifier != '+' {
+			targets.add(nodes...)
+		}
+		if path.modifier == '?' {
+			targets.add(data.evalPath(path.path, nodes, inverse)...)
+			return targets.terms
+		}
+		// Follow the path until no new nodes are reached
+		visited := newTermSet()
+		frontier := nodes
+		for len(frontier) > 0 {
+			next := []Term{}
+			for _, node := range data.evalPath(path.path, frontier, inverse) {
+				targets.add(node)
+				if visited.add(node) {
+					next = append(next, node)
+				}
+			}
+			frontier = next
+		}
+		return targets.terms
+	}
+	return nil
+}
+
+// termSet is a set of terms that keeps the insertion order.
+type termSet struct {
+	terms    []Term
+	contains map[Term]bool
+}
+
+// newTermSet creates a new empty term set.
+func newTermSet() *termSet {
+	return &termSet{terms: []Term{}, contains: map[Term]bool{}}
+}
+
+// add adds the terms to the set. Returns true if any of the terms was not yet contained.
+func (set *termSet) add(terms ...Term) bool {
+	added := false
+	for _, t := range terms {
+		if !set.contains[t] {
+			set.contains[t] = true
+			set.terms = append(set.terms, t)
+			added = true
+		}
+	}
+	return added
+}
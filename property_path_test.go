@@ -0,0 +1,56 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Property paths", func() {
+	const ex = "http://example.com/onto#"
+	var store *MemoryStore
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:wheel :partOf :axle .
+:axle :partOf :chassis .
+:chassis :partOf :car .
+:car a :Vehicle ; :madeBy :acme .
+:acme :locatedIn :berlin .
+:bolt :partOf :wheel .
+`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	targets := func(subj, path string) []Term {
+		res, err := GetPathTargets(store, NewResourceTerm(ex+subj).String(), path)
+		Expect(err).NotTo(HaveOccurred())
+		return res
+	}
+
+	It("should resolve transitive paths", func() {
+		Expect(targets("wheel", "<"+ex+"partOf>+")).To(Equal([]Term{NewResourceTerm(ex + "axle"), NewResourceTerm(ex + "chassis"), NewResourceTerm(ex + "car")}))
+		Expect(targets("wheel", "<"+ex+"partOf>*")).To(HaveLen(4))
+		Expect(targets("car", "<"+ex+"partOf>?")).To(Equal([]Term{NewResourceTerm(ex + "car")}))
+	})
+
+	It("should resolve sequences, alternatives and inverse paths", func() {
+		Expect(targets("wheel", "<"+ex+"partOf>* / <"+ex+"madeBy>/<"+ex+"locatedIn>")).To(Equal([]Term{NewResourceTerm(ex + "berlin")}))
+		Expect(targets("axle", "^<"+ex+"partOf>+")).To(ConsistOf(NewResourceTerm(ex+"wheel"), NewResourceTerm(ex+"bolt")))
+		Expect(targets("car", "a | <"+ex+"madeBy>")).To(Equal([]Term{NewResourceTerm(ex + "Vehicle"), NewResourceTerm(ex + "acme")}))
+		Expect(targets("berlin", "^(<"+ex+"partOf>*/<"+ex+"madeBy>/<"+ex+"locatedIn>)")).To(HaveLen(5))
+	})
+
+	It("should reject invalid paths", func() {
+		for _, path := range []string{"", "<" + ex + "partOf", "(<" + ex + "partOf>", "<" + ex + "partOf>/", "!<" + ex + "partOf>"} {
+			_, err := GetPathTargets(store, NewResourceTerm(ex+"wheel").String(), path)
+			Expect(err).To(MatchError(ErrInvalidPath))
+		}
+	})
+})
@@ -0,0 +1,139 @@
+package ontograph
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// TripleMetadata holds the provenance of a single triple (see `AttachMetadata`). Zero values are not stored.
+type TripleMetadata struct {
+	// Source is the URI of the document the triple was taken from.
+	Source string
+	// Confidence is the confidence in the triple, e.g. of an extraction pipeline.
+	Confidence float64
+	// Ingested is the time the triple was ingested.
+	Ingested time.Time
+	// Annotations are further metadata values keyed by property URI.
+	Annotations map[string][]GenericLiteral
+}
+
+// TripleWithMetadata is a triple together with its provenance.
+type TripleWithMetadata struct {
+	Triple
+	Metadata TripleMetadata
+}
+
+// AttachMetadata attaches the metadata to the triple in the metadata graph, replacing any metadata the triple already has. The triple is
+// reified as `rdf:Statement` in the namespace of the metadata graph, so the data graph stays untouched and the metadata can be queried
+// with standard RDF tools. The source is stored as `dcterms:source` and the ingestion time as `prov:generatedAtTime`.
+func AttachMetadata(metadata GraphStore, trp Triple, md TripleMetadata) error {
+	if err := DeleteTripleMetadata(metadata, trp); err != nil {
+		return err
+	}
+	node := statementNode(metadata, trp)
+	trps := []Triple{
+		{Subject: node, Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(RDFStatement)},
+		{Subject: node, Predicate: NewResourceTerm(RDFSubject), Object: trp.Subject},
+		{Subject: node, Predicate: NewResourceTerm(RDFPredicate), Object: trp.Predicate},
+		{Subject: node, Predicate: NewResourceTerm(RDFObject), Object: trp.Object},
+	}
+	if md.Source != "" {
+		trps = append(trps, Triple{Subject: node, Predicate: NewResourceTerm(DCTermsSource), Object: NewResourceTerm(md.Source)})
+	}
+	if md.Confidence != 0 {
+		trps = append(trps, Triple{Subject: node, Predicate: confidencePredicate(metadata), Object: NewLiteralTerm(strconv.FormatFloat(md.Confidence, 'g', -1, 64), "", XSDDouble)})
+	}
+	if !md.Ingested.IsZero() {
+		trps = append(trps, Triple{Subject: node, Predicate: NewResourceTerm(PROVGeneratedAtTime), Object: NewLiteralTerm(md.Ingested.Format(time.RFC3339Nano), "", XSDDateTime)})
+	}
+	trps = append(trps, annotationTriples(node, md.Annotations)...)
+	return metadata.AddTriplesUnchecked(trps)
+}
+
+// GetTripleMetadata returns the metadata of the triple from the metadata graph. The boolean is false if no metadata is attached to the
+// triple.
+func GetTripleMetadata(metadata GraphStore, trp Triple) (TripleMetadata, bool, error) {
+	trps, err := metadata.GetAllMatches(statementNode(metadata, trp).String(), "", "")
+	if err != nil || len(trps) == 0 {
+		return TripleMetadata{}, false, err
+	}
+	md := TripleMetadata{Annotations: map[string][]GenericLiteral{}}
+	for _, mdTrp := range trps {
+		switch mdTrp.Predicate {
+		case NewResourceTerm(RDFType), NewResourceTerm(RDFSubject), NewResourceTerm(RDFPredicate), NewResourceTerm(RDFObject):
+		case NewResourceTerm(DCTermsSource):
+			md.Source = mdTrp.Object.Value()
+		case confidencePredicate(metadata):
+			if md.Confidence, err = strconv.ParseFloat(mdTrp.Object.Value(), 64); err != nil {
+				return TripleMetadata{}, false, err
+			}
+		case NewResourceTerm(PROVGeneratedAtTime):
+			if md.Ingested, err = time.Parse(time.RFC3339Nano, mdTrp.Object.Value()); err != nil {
+				return TripleMetadata{}, false, err
+			}
+		default:
+			if mdTrp.Object.IsLiteral() {
+				md.Annotations[mdTrp.Predicate.Value()] = append(md.Annotations[mdTrp.Predicate.Value()], *NewGenericLiteral(mdTrp.Object))
+			}
+		}
+	}
+	return md, true, nil
+}
+
+// DeleteTripleMetadata removes the metadata of the triple from the metadata graph.
+func DeleteTripleMetadata(metadata GraphStore, trp Triple) error {
+	return metadata.DeleteAllMatches(statementNode(metadata, trp).String(), "", "")
+}
+
+// AddTriplesWithMetadata adds the triples unchecked to the graph store and attaches the metadata to each of them. If no ingestion time is
+// set, the current time of the `DefaultClock` is used.
+func AddTriplesWithMetadata(store, metadata GraphStore, trps []Triple, md TripleMetadata) error {
+	if md.Ingested.IsZero() {
+		md.Ingested = DefaultClock.Now()
+	}
+	if err := store.AddTriplesUnchecked(trps); err != nil {
+		return err
+	}
+	for _, trp := range trps {
+		if err := AttachMetadata(metadata, trp, md); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetMatchesWithMetadata retrieves all triples of the graph store that match the pattern together with their metadata from the metadata
+// graph. Triples without metadata have empty metadata. Empty strings in subject, predicate or object are treated as wildcards.
+func GetMatchesWithMetadata(store, metadata GraphStore, subj, pred, obj string) ([]TripleWithMetadata, error) {
+	trps, err := store.GetAllMatches(subj, pred, obj)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]TripleWithMetadata, 0, len(trps))
+	for _, trp := range trps {
+		md, _, err := GetTripleMetadata(metadata, trp)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, TripleWithMetadata{Triple: trp, Metadata: md})
+	}
+	return result, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// statementNode returns the resource that reifies the triple in the metadata graph. It is derived from the hash of the triple, so the
+// metadata of a triple can be found without a query.
+func statementNode(metadata GraphStore, trp Triple) Term {
+	hash := sha1.Sum([]byte(nTriplesLine(trp)))
+	return NewResourceTerm(metadata.GetURI() + "#statement-" + hex.EncodeToString(hash[:]))
+}
+
+// confidencePredicate returns the predicate of the confidence in the namespace of the metadata graph.
+func confidencePredicate(metadata GraphStore) Term {
+	return NewResourceTerm(metadata.GetURI() + "#confidence")
+}
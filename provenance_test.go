@@ -0,0 +1,72 @@
+package ontograph_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Triple provenance", func() {
+	const testUri = "http://example.com/onto"
+	classTrp := func(name string) Triple {
+		return Triple{Subject: NewResourceTerm(testUri + "#" + name), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)}
+	}
+	var store, metadata *MemoryStore
+
+	BeforeEach(func() {
+		store = NewMemoryStore(testUri)
+		metadata = NewMemoryStore("http://example.com/provenance")
+	})
+
+	It("should attach metadata to triples in a side graph", func() {
+		ingested := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+		note := *NewGenericLiteral(NewLiteralTerm("extracted", "en", ""))
+		Expect(AddTriplesWithMetadata(store, metadata, []Triple{classTrp("A")}, TripleMetadata{
+			Source:      "http://example.com/docs/1",
+			Confidence:  0.75,
+			Ingested:    ingested,
+			Annotations: map[string][]GenericLiteral{RDFSComment: {note}},
+		})).To(Succeed())
+		Expect(store.AddTriple(classTrp("B"))).To(Succeed())
+		Expect(store.Size()).To(Equal(2))
+
+		md, ok, err := GetTripleMetadata(metadata, classTrp("A"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(md.Source).To(Equal("http://example.com/docs/1"))
+		Expect(md.Confidence).To(Equal(0.75))
+		Expect(md.Ingested.Equal(ingested)).To(BeTrue())
+		Expect(md.Annotations).To(HaveKeyWithValue(RDFSComment, []GenericLiteral{note}))
+		Expect(metadata.GetAllMatches("", NewResourceTerm(RDFSubject).String(), classTrp("A").Subject.String())).To(HaveLen(1))
+
+		matches, err := GetMatchesWithMetadata(store, metadata, "", NewResourceTerm(RDFType).String(), "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(HaveLen(2))
+		for _, match := range matches {
+			if match.Triple == classTrp("A") {
+				Expect(match.Metadata.Source).To(Equal("http://example.com/docs/1"))
+			} else {
+				Expect(match.Metadata.Source).To(BeEmpty())
+			}
+		}
+	})
+
+	It("should replace and delete the metadata of a triple", func() {
+		Expect(AttachMetadata(metadata, classTrp("A"), TripleMetadata{Source: "http://example.com/docs/1", Confidence: 0.5})).To(Succeed())
+		Expect(AttachMetadata(metadata, classTrp("A"), TripleMetadata{Source: "http://example.com/docs/2"})).To(Succeed())
+		md, ok, err := GetTripleMetadata(metadata, classTrp("A"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(md.Source).To(Equal("http://example.com/docs/2"))
+		Expect(md.Confidence).To(BeZero())
+
+		Expect(DeleteTripleMetadata(metadata, classTrp("A"))).To(Succeed())
+		_, ok, err = GetTripleMetadata(metadata, classTrp("A"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		Expect(metadata.Size()).To(Equal(0))
+	})
+})
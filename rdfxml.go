@@ -0,0 +1,337 @@
+package ontograph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/deiu/rdf2go"
+)
+
+// rdfNS is the namespace of the RDF vocabulary.
+const rdfNS = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+
+// xmlNS is the namespace of the reserved `xml` prefix as resolved by the XML decoder.
+const xmlNS = "http://www.w3.org/XML/1998/namespace"
+
+// rdfXMLParser parses RDF/XML data into a rdf2go graph. It supports node elements (typed or `rdf:Description`) identified by
+// `rdf:about`, `rdf:ID` or `rdf:nodeID`, property attributes, property elements with resource, literal or nested node objects,
+// `rdf:datatype`, `xml:lang`, `xml:base`, `rdf:li` and the parse types `Resource`, `Literal` and `Collection`. Reification via
+// `rdf:ID` on property elements is not supported.
+type rdfXMLParser struct {
+	dec     *xml.Decoder
+	graph   *rdf2go.Graph
+	bnodes  map[string]rdf2go.Term
+	bnodeID int
+}
+
+// parseRDFXML parses the RDF/XML data from the reader and adds the triples to the graph. Relative URIs are resolved against the base URI.
+func parseRDFXML(r io.Reader, g *rdf2go.Graph, baseURI string) error {
+	p := rdfXMLParser{dec: xml.NewDecoder(r), graph: g, bnodes: map[string]rdf2go.Term{}}
+	for {
+		tok, err := p.dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		base := p.base(start, baseURI)
+		lang := p.lang(start, "")
+		// The root element may be rdf:RDF, which contains the node elements, or a single node element
+		if start.Name.Space == rdfNS && start.Name.Local == "RDF" {
+			if err := p.nodeElements(base, lang, nil); err != nil {
+				return err
+			}
+		} else if _, err := p.nodeElement(start, base, lang); err != nil {
+			return err
+		}
+	}
+}
+
+// nodeElements parses node elements until the end of the current element. If collect is not nil, the parsed nodes are passed to it.
+func (p *rdfXMLParser) nodeElements(base, lang string, collect func(rdf2go.Term)) error {
+	for {
+		tok, err := p.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			node, err := p.nodeElement(tok, p.base(tok, base), p.lang(tok, lang))
+			if err != nil {
+				return err
+			}
+			if collect != nil {
+				collect(node)
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// nodeElement parses the node element that starts with the given token and returns its subject.
+func (p *rdfXMLParser) nodeElement(start xml.StartElement, base, lang string) (rdf2go.Term, error) {
+	// Determine subject
+	var subj rdf2go.Term
+	for _, attr := range start.Attr {
+		if attr.Name.Space != rdfNS {
+			continue
+		}
+		switch attr.Name.Local {
+		case "about":
+			subj = rdf2go.NewResource(resolveURI(base, attr.Value))
+		case "ID":
+			subj = rdf2go.NewResource(resolveURI(base, "#"+attr.Value))
+		case "nodeID":
+			subj = p.blankNode(attr.Value)
+		}
+	}
+	if subj == nil {
+		subj = p.blankNode("")
+	}
+	// Typed node elements declare the type of the subject
+	if !(start.Name.Space == rdfNS && start.Name.Local == "Description") {
+		p.graph.AddTriple(subj, rdf2go.NewResource(RDFType), rdf2go.NewResource(start.Name.Space+start.Name.Local))
+	}
+	p.propertyAttributes(subj, start.Attr, base, lang)
+	// Parse property elements
+	li := 0
+	for {
+		tok, err := p.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if err := p.propertyElement(tok, subj, p.base(tok, base), p.lang(tok, lang), &li); err != nil {
+				return nil, err
+			}
+		case xml.EndElement:
+			return subj, nil
+		}
+	}
+}
+
+// propertyElement parses the property element that starts with the given token and adds the corresponding triples for the subject.
+func (p *rdfXMLParser) propertyElement(start xml.StartElement, subj rdf2go.Term, base, lang string, li *int) error {
+	predURI := start.Name.Space + start.Name.Local
+	if predURI == rdfNS+"li" {
+		*li++
+		predURI = fmt.Sprintf("%s_%d", rdfNS, *li)
+	}
+	pred := rdf2go.NewResource(predURI)
+	// Collect RDF attributes of the property element
+	var obj rdf2go.Term
+	datatype, parseType := "", ""
+	hasPropAttrs := false
+	for _, attr := range start.Attr {
+		switch {
+		case attr.Name.Space == rdfNS && attr.Name.Local == "resource":
+			obj = rdf2go.NewResource(resolveURI(base, attr.Value))
+		case attr.Name.Space == rdfNS && attr.Name.Local == "nodeID":
+			obj = p.blankNode(attr.Value)
+		case attr.Name.Space == rdfNS && attr.Name.Local == "datatype":
+			datatype = resolveURI(base, attr.Value)
+		case attr.Name.Space == rdfNS && attr.Name.Local == "parseType":
+			parseType = attr.Value
+		case isPropertyAttribute(attr):
+			hasPropAttrs = true
+		}
+	}
+
+	switch {
+	case parseType == "Resource":
+		// The content describes a new blank node
+		obj = p.blankNode("")
+		p.graph.AddTriple(subj, pred, obj)
+		objLi := 0
+		for {
+			tok, err := p.dec.Token()
+			if err != nil {
+				return err
+			}
+			switch tok := tok.(type) {
+			case xml.StartElement:
+				if err := p.propertyElement(tok, obj, p.base(tok, base), p.lang(tok, lang), &objLi); err != nil {
+					return err
+				}
+			case xml.EndElement:
+				return nil
+			}
+		}
+	case parseType == "Collection":
+		// The content is a list of node elements
+		nodes := []rdf2go.Term{}
+		if err := p.nodeElements(base, lang, func(node rdf2go.Term) { nodes = append(nodes, node) }); err != nil {
+			return err
+		}
+		var list rdf2go.Term = rdf2go.NewResource(rdfNS + "nil")
+		for i := len(nodes) - 1; i >= 0; i-- {
+			cell := p.blankNode("")
+			p.graph.AddTriple(cell, rdf2go.NewResource(rdfNS+"first"), nodes[i])
+			p.graph.AddTriple(cell, rdf2go.NewResource(rdfNS+"rest"), list)
+			list = cell
+		}
+		p.graph.AddTriple(subj, pred, list)
+		return nil
+	case parseType == "Literal":
+		// The content is kept as XML literal
+		content, err := p.innerXML()
+		if err != nil {
+			return err
+		}
+		p.graph.AddTriple(subj, pred, rdf2go.NewLiteralWithDatatype(content, rdf2go.NewResource(rdfNS+"XMLLiteral")))
+		return nil
+	case obj != nil || hasPropAttrs:
+		// Empty property element referring to a resource or describing a blank node with property attributes
+		if obj == nil {
+			obj = p.blankNode("")
+		}
+		p.propertyAttributes(obj, start.Attr, base, lang)
+		p.graph.AddTriple(subj, pred, obj)
+		return p.dec.Skip()
+	}
+
+	// The content is either a literal or a single nested node element
+	var text strings.Builder
+	for {
+		tok, err := p.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			text.Write(tok)
+		case xml.StartElement:
+			node, err := p.nodeElement(tok, p.base(tok, base), p.lang(tok, lang))
+			if err != nil {
+				return err
+			}
+			obj = node
+		case xml.EndElement:
+			if obj == nil {
+				obj = newRDFLiteral(text.String(), lang, datatype)
+			}
+			p.graph.AddTriple(subj, pred, obj)
+			return nil
+		}
+	}
+}
+
+// propertyAttributes adds a literal triple for every property attribute (or a type triple for `rdf:type`).
+func (p *rdfXMLParser) propertyAttributes(subj rdf2go.Term, attrs []xml.Attr, base, lang string) {
+	for _, attr := range attrs {
+		if attr.Name.Space == rdfNS && attr.Name.Local == "type" {
+			p.graph.AddTriple(subj, rdf2go.NewResource(RDFType), rdf2go.NewResource(resolveURI(base, attr.Value)))
+		} else if isPropertyAttribute(attr) {
+			p.graph.AddTriple(subj, rdf2go.NewResource(attr.Name.Space+attr.Name.Local), newRDFLiteral(attr.Value, lang, ""))
+		}
+	}
+}
+
+// innerXML returns the content of the current element as XML string.
+func (p *rdfXMLParser) innerXML() (string, error) {
+	var content strings.Builder
+	enc := xml.NewEncoder(&content)
+	depth := 0
+	for {
+		tok, err := p.dec.Token()
+		if err != nil {
+			return "", err
+		}
+		switch tok.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			if depth == 0 {
+				if err := enc.Flush(); err != nil {
+					return "", err
+				}
+				return content.String(), nil
+			}
+			depth--
+		}
+		if err := enc.EncodeToken(xml.CopyToken(tok)); err != nil {
+			return "", err
+		}
+	}
+}
+
+// blankNode returns the blank node for the given node ID or a new blank node if the ID is empty.
+func (p *rdfXMLParser) blankNode(nodeID string) rdf2go.Term {
+	if node, ok := p.bnodes[nodeID]; ok && nodeID != "" {
+		return node
+	}
+	p.bnodeID++
+	node := rdf2go.NewBlankNode(p.bnodeID)
+	if nodeID != "" {
+		p.bnodes[nodeID] = node
+	}
+	return node
+}
+
+// base returns the base URI in scope of the element.
+func (p *rdfXMLParser) base(start xml.StartElement, base string) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Space == xmlNS && attr.Name.Local == "base" {
+			return resolveURI(base, attr.Value)
+		}
+	}
+	return base
+}
+
+// lang returns the language in scope of the element.
+func (p *rdfXMLParser) lang(start xml.StartElement, lang string) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Space == xmlNS && attr.Name.Local == "lang" {
+			return attr.Value
+		}
+	}
+	return lang
+}
+
+// isPropertyAttribute checks if the attribute is a property attribute, i.e. neither a syntax attribute of RDF nor a XML attribute.
+func isPropertyAttribute(attr xml.Attr) bool {
+	if attr.Name.Space == "" || attr.Name.Space == "xmlns" || attr.Name.Space == xmlNS || strings.HasPrefix(strings.ToLower(attr.Name.Local), "xml") {
+		return false
+	}
+	if attr.Name.Space == rdfNS {
+		switch attr.Name.Local {
+		case "about", "ID", "nodeID", "resource", "datatype", "parseType", "type", "li", "RDF", "Description", "bagID", "aboutEach", "aboutEachPrefix":
+			return false
+		}
+	}
+	return true
+}
+
+// newRDFLiteral creates a rdf2go literal with the given language or datatype.
+func newRDFLiteral(value, lang, datatype string) rdf2go.Term {
+	if datatype != "" {
+		return rdf2go.NewLiteralWithDatatype(value, rdf2go.NewResource(datatype))
+	}
+	if lang != "" {
+		return rdf2go.NewLiteralWithLanguage(value, lang)
+	}
+	return rdf2go.NewLiteral(value)
+}
+
+// resolveURI resolves the (possibly relative) reference against the base URI.
+func resolveURI(base, ref string) string {
+	baseURL, err := url.Parse(base)
+	if err != nil || base == "" {
+		return ref
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return baseURL.ResolveReference(refURL).String()
+}
@@ -0,0 +1,106 @@
+package ontograph
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"sort"
+	"strings"
+)
+
+// ResourceHashProperty is the annotation property that stores the hash of a signed resource. The value is a literal of the form
+// `sha256:<hex>` or, if a signing key is set, `hmac-sha256:<hex>`.
+const ResourceHashProperty string = "urn:ontograph:resourceHash"
+
+// SetSigningKey sets the key used to sign and verify resources. With a key, resource hashes are HMAC-SHA256 signatures that can only be
+// recomputed with the key. Without key (nil), plain SHA-256 hashes are used, which detect accidental changes but not deliberate ones.
+func (ont *OntologyGraph) SetSigningKey(key []byte) {
+	ont.signingKey = key
+}
+
+// ResourceHash computes the hash of the resource from all triples with the resource as subject (except for its stored hash). Blank node
+// objects are hashed as references only, i.e. changes within nested blank node structures are not covered.
+func (ont *OntologyGraph) ResourceHash(uri string) (string, error) {
+	uri = ont.prefixes.Expand(uri)
+	trps, err := ont.graph.GetAllMatches(NewResourceTerm(uri).String(), "", "")
+	if err != nil {
+		return "", wrapResourceError("ResourceHash", uri, err)
+	}
+	lines := []string{}
+	for _, trp := range trps {
+		if trp.Predicate == NewResourceTerm(ResourceHashProperty) {
+			continue
+		}
+		if trp.Object.IsBlankNode() {
+			trp.Object = "_:"
+		}
+		lines = append(lines, nTriplesLine(trp))
+	}
+	if len(lines) == 0 {
+		return "", wrapResourceError("ResourceHash", uri, ErrResourceNotFound)
+	}
+	sort.Strings(lines)
+	// Hash canonical N-Triples of the resource
+	var h hash.Hash
+	prefix := "sha256:"
+	if ont.signingKey != nil {
+		h = hmac.New(sha256.New, ont.signingKey)
+		prefix = "hmac-sha256:"
+	} else {
+		h = sha256.New()
+	}
+	h.Write([]byte(strings.Join(lines, "")))
+	return prefix + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SignResource computes the hash of the resource and stores it as `ResourceHashProperty` annotation of the resource, replacing a
+// previous hash. Since upserts replace all triples of a resource, resources must be signed again after they were updated.
+func (ont *OntologyGraph) SignResource(uri string) (string, error) {
+	uri = ont.prefixes.Expand(uri)
+	resHash, err := ont.ResourceHash(uri)
+	if err != nil {
+		return "", err
+	}
+	subj := NewResourceTerm(uri)
+	if err := ont.graph.DeleteAllMatches(subj.String(), NewResourceTerm(ResourceHashProperty).String(), ""); err != nil {
+		return "", wrapResourceError("SignResource", uri, err)
+	}
+	err = ont.graph.AddTriple(Triple{Subject: subj, Predicate: NewResourceTerm(ResourceHashProperty), Object: NewLiteralTerm(resHash, "", "")})
+	if err != nil {
+		return "", wrapResourceError("SignResource", uri, err)
+	}
+	return resHash, nil
+}
+
+// VerifyResource checks the resource against its stored hash. Errors with `ErrResourceNotSigned` if the resource has no stored hash and
+// with `ErrResourceTampered` if the triples of the resource changed since it was signed (or it was signed with another key).
+func (ont *OntologyGraph) VerifyResource(uri string) error {
+	uri = ont.prefixes.Expand(uri)
+	trp, err := ont.graph.GetFirstMatch(NewResourceTerm(uri).String(), NewResourceTerm(ResourceHashProperty).String(), "")
+	if err != nil {
+		return wrapResourceError("VerifyResource", uri, err)
+	}
+	if trp == nil {
+		return wrapResourceError("VerifyResource", uri, ErrResourceNotSigned)
+	}
+	resHash, err := ont.ResourceHash(uri)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(resHash), []byte(trp.Object.Value())) {
+		return wrapResourceError("VerifyResource", uri, ErrResourceTampered)
+	}
+	return nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrResourceNotSigned is raised when a resource is verified that has no stored hash.
+var ErrResourceNotSigned error = errors.New("The resource is not signed")
+
+// ErrResourceTampered is raised when the triples of a resource do not match its stored hash.
+var ErrResourceTampered error = errors.New("The resource does not match its signature")
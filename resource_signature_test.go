@@ -0,0 +1,65 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Resource signatures", func() {
+	const approval = "http://example.com/onto#approval1"
+	var store *MemoryStore
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:approval1 a owl:NamedIndividual , :Approval ;
+  :approvedBy :alice ;
+  :amount 1000 .
+:approval2 a owl:NamedIndividual , :Approval .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should verify signed resources and detect tampering", func() {
+		resHash, err := ont.SignResource(approval)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resHash).To(HavePrefix("sha256:"))
+		Expect(ont.VerifyResource(approval)).To(Succeed())
+		// Signing again replaces the stored hash
+		Expect(ont.SignResource(approval)).To(Equal(resHash))
+		Expect(store.GetAllMatches(NewResourceTerm(approval).String(), NewResourceTerm(ResourceHashProperty).String(), "")).To(HaveLen(1))
+
+		Expect(store.DeleteTriple(Triple{Subject: NewResourceTerm(approval), Predicate: NewResourceTerm("http://example.com/onto#amount"), Object: NewLiteralTerm("1000", "", XSDInteger)})).To(Succeed())
+		Expect(store.AddTriple(Triple{Subject: NewResourceTerm(approval), Predicate: NewResourceTerm("http://example.com/onto#amount"), Object: NewLiteralTerm("9000", "", XSDInteger)})).To(Succeed())
+		Expect(ont.VerifyResource(approval)).To(MatchError(ErrResourceTampered))
+		// Other resources are verified independently
+		_, err = ont.SignResource("http://example.com/onto#approval2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ont.VerifyResource("http://example.com/onto#approval2")).To(Succeed())
+	})
+
+	It("should sign resources with a key", func() {
+		ont.SetSigningKey([]byte("secret"))
+		resHash, err := ont.SignResource(approval)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resHash).To(HavePrefix("hmac-sha256:"))
+		Expect(ont.VerifyResource(approval)).To(Succeed())
+		ont.SetSigningKey([]byte("other"))
+		Expect(ont.VerifyResource(approval)).To(MatchError(ErrResourceTampered))
+	})
+
+	It("should error for unsigned or missing resources", func() {
+		Expect(ont.VerifyResource(approval)).To(MatchError(ErrResourceNotSigned))
+		_, err := ont.SignResource("http://example.com/onto#missing")
+		Expect(err).To(MatchError(ErrResourceNotFound))
+	})
+})
@@ -0,0 +1,168 @@
+package ontograph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SchemaCheckedStore wraps a graph store and checks that every added triple only uses schema terms that are declared in the graph or
+// its imports closure: properties used as predicates, classes used with `rdf:type` and the classes or datatypes used as domain and
+// range. A term is declared if it is the subject of an `rdf:type` triple. This catches typos in URIs when writing instead of in
+// downstream reasoning. Terms of the RDF, RDFS, OWL and XML Schema vocabularies are always known. All other methods are passed through.
+type SchemaCheckedStore struct {
+	GraphStore
+	imports    []GraphStore
+	strictness Strictness
+	warnings   WarningCollector
+}
+
+// NewSchemaCheckedStore wraps the given store into a schema checked store with the given strictness. The imports closure is resolved by
+// following the `owl:imports` statements of the graph to the given import stores (matched by their graph URI), imports that are not
+// given are ignored. The warning collector is optional and may be nil.
+func NewSchemaCheckedStore(store GraphStore, strictness Strictness, warnings WarningCollector, imports ...GraphStore) *SchemaCheckedStore {
+	return &SchemaCheckedStore{GraphStore: store, imports: imports, strictness: strictness, warnings: warnings}
+}
+
+// WithContext returns a copy of the store whose store and import stores are bound to the context (see `StoreWithContext`).
+func (store *SchemaCheckedStore) WithContext(ctx context.Context) GraphStore {
+	imports := make([]GraphStore, 0, len(store.imports))
+	for _, imported := range store.imports {
+		imports = append(imports, StoreWithContext(imported, ctx))
+	}
+	return NewSchemaCheckedStore(StoreWithContext(store.GraphStore, ctx), store.strictness, store.warnings, imports...)
+}
+
+// AddTriple checks the triple and adds it to the store. If the triple already exists, it errors with `ErrTripleAlreadyExists`.
+func (store *SchemaCheckedStore) AddTriple(trp Triple) error {
+	if err := store.check([]Triple{trp}); err != nil {
+		return err
+	}
+	return store.GraphStore.AddTriple(trp)
+}
+
+// AddTriples checks the triples and adds them to the store. If one of the triples already exist, it errors with `ErrTripleAlreadyExists`.
+func (store *SchemaCheckedStore) AddTriples(trps []Triple) error {
+	if err := store.check(trps); err != nil {
+		return err
+	}
+	return store.GraphStore.AddTriples(trps)
+}
+
+// AddTripleUnchecked checks the triple and adds it to the store. It does not error if the triple already exists.
+func (store *SchemaCheckedStore) AddTripleUnchecked(trp Triple) error {
+	if err := store.check([]Triple{trp}); err != nil {
+		return err
+	}
+	return store.GraphStore.AddTripleUnchecked(trp)
+}
+
+// AddTriplesUnchecked checks the triples and adds them to the store. It does not error if any of the triples already exists.
+func (store *SchemaCheckedStore) AddTriplesUnchecked(trps []Triple) error {
+	if err := store.check(trps); err != nil {
+		return err
+	}
+	return store.GraphStore.AddTriplesUnchecked(trps)
+}
+
+// ImportsClosure returns the graph store and all given import stores that are imported by it directly or transitively.
+func (store *SchemaCheckedStore) ImportsClosure() ([]GraphStore, error) {
+	byURI := map[string]GraphStore{}
+	for _, imported := range store.imports {
+		byURI[imported.GetURI()] = imported
+	}
+	closure := []GraphStore{store.GraphStore}
+	visited := map[string]bool{store.GetURI(): true}
+	for i := 0; i < len(closure); i++ {
+		trps, err := closure[i].GetAllMatches(NewResourceTerm(closure[i].GetURI()).String(), NewResourceTerm(OWLImports).String(), "")
+		if err != nil {
+			return nil, err
+		}
+		for _, trp := range trps {
+			uri := trp.Object.Value()
+			if imported, ok := byURI[uri]; ok && !visited[uri] {
+				visited[uri] = true
+				closure = append(closure, imported)
+			}
+		}
+	}
+	return closure, nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrUndeclaredTerm is raised when a triple uses a class, property or datatype that is not declared in the graph or its imports.
+var ErrUndeclaredTerm error = errors.New("The term is not declared in the graph or its imports")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// check checks all triples for undeclared schema terms and handles them according to the strictness of the store. Terms declared by
+// the checked triples themselves are accepted.
+func (store *SchemaCheckedStore) check(trps []Triple) error {
+	closure, err := store.ImportsClosure()
+	if err != nil {
+		return err
+	}
+	declaredInBatch := map[Term]bool{}
+	for _, trp := range trps {
+		if trp.Predicate == NewResourceTerm(RDFType) {
+			declaredInBatch[trp.Subject] = true
+		}
+	}
+	isDeclared := func(t Term) (bool, error) {
+		if !t.IsResource() || declaredInBatch[t] || isBuiltinSchemaTerm(t.Value()) {
+			return true, nil
+		}
+		for _, graph := range closure {
+			trp, err := graph.GetFirstMatch(t.String(), NewResourceTerm(RDFType).String(), "")
+			if err != nil {
+				return false, err
+			}
+			if trp != nil {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	for _, trp := range trps {
+		used := []Term{trp.Predicate}
+		switch trp.Predicate {
+		case NewResourceTerm(RDFType), NewResourceTerm(RDFSDomain), NewResourceTerm(RDFSRange):
+			used = append(used, trp.Object)
+		}
+		issues := []error{}
+		for _, t := range used {
+			ok, err := isDeclared(t)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				issues = append(issues, fmt.Errorf("%w: %s", ErrUndeclaredTerm, t))
+			}
+		}
+		if err := handleTripleIssues(trp, issues, store.strictness, store.warnings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isBuiltinSchemaTerm checks if the URI belongs to the RDF, RDFS, OWL or XML Schema vocabulary.
+func isBuiltinSchemaTerm(uri string) bool {
+	for _, ns := range []string{
+		"http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+		"http://www.w3.org/2000/01/rdf-schema#",
+		"http://www.w3.org/2002/07/owl#",
+		"http://www.w3.org/2001/XMLSchema#",
+	} {
+		if strings.HasPrefix(uri, ns) {
+			return true
+		}
+	}
+	return false
+}
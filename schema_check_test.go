@@ -0,0 +1,65 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Schema checked store", func() {
+	var store *SchemaCheckedStore
+
+	BeforeEach(func() {
+		base, err := ParseFromTurtle(strings.NewReader(`@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/base> a owl:Ontology .
+<http://example.com/base#Agent> a owl:Class .
+<http://example.com/base#name> a owl:DatatypeProperty .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		onto, err := ParseFromTurtle(strings.NewReader(`@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology ;
+  owl:imports <http://example.com/base> .
+<http://example.com/onto#Person> a owl:Class .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		unrelated := NewMemoryStore("http://example.com/unrelated")
+		Expect(unrelated.AddTriple(Triple{Subject: NewResourceTerm("http://example.com/onto#age"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLDatatypeProperty)})).To(Succeed())
+		store = NewSchemaCheckedStore(onto, Strict, nil, base, unrelated)
+	})
+
+	It("should resolve the imports closure", func() {
+		closure, err := store.ImportsClosure()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(closure).To(HaveLen(2))
+		Expect(closure[1].GetURI()).To(Equal("http://example.com/base"))
+	})
+
+	It("should accept terms declared in the graph, its imports or the same batch", func() {
+		Expect(store.AddTriples([]Triple{
+			{Subject: NewResourceTerm("http://example.com/onto#bob"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm("http://example.com/onto#Person")},
+			{Subject: NewResourceTerm("http://example.com/onto#bob"), Predicate: NewResourceTerm("http://example.com/base#name"), Object: NewLiteralTerm("Bob", "", "")},
+			{Subject: NewResourceTerm("http://example.com/onto#knows"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLObjectProperty)},
+			{Subject: NewResourceTerm("http://example.com/onto#knows"), Predicate: NewResourceTerm(RDFSRange), Object: NewResourceTerm("http://example.com/base#Agent")},
+		})).To(Succeed())
+	})
+
+	It("should reject undeclared terms in strict mode", func() {
+		err := store.AddTriple(Triple{Subject: NewResourceTerm("http://example.com/onto#bob"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm("http://example.com/onto#Persn")})
+		Expect(err).To(MatchError(ErrUndeclaredTerm))
+		err = store.AddTriple(Triple{Subject: NewResourceTerm("http://example.com/onto#bob"), Predicate: NewResourceTerm("http://example.com/onto#age"), Object: NewLiteralTerm("42", "", XSDInteger)})
+		Expect(err).To(MatchError(ErrUndeclaredTerm))
+		Expect(store.Size()).To(Equal(3))
+	})
+
+	It("should report undeclared terms as warnings in lenient mode", func() {
+		report := &Report{}
+		closure, _ := store.ImportsClosure()
+		lenient := NewSchemaCheckedStore(closure[0], Lenient, report, closure[1])
+		Expect(lenient.AddTriple(Triple{Subject: NewResourceTerm("http://example.com/onto#Student"), Predicate: NewResourceTerm(RDFSDomain), Object: NewResourceTerm("http://example.com/onto#Persn")})).To(Succeed())
+		Expect(report.Warnings()).To(HaveLen(1))
+		Expect(report.Warnings()[0].Err).To(MatchError(ErrUndeclaredTerm))
+	})
+})
@@ -0,0 +1,263 @@
+package ontograph
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A PrefixMap maps prefix abbreviations (e.g. `owl`) to their namespace URIs (e.g. `http://www.w3.org/2002/07/owl#`).
+type PrefixMap map[string]string
+
+// NewStandardPrefixMap creates a new prefix map with the standard prefixes for RDF, RDFS, OWL and XSD.
+func NewStandardPrefixMap() PrefixMap {
+	return PrefixMap{
+		"rdf":  "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+		"rdfs": "http://www.w3.org/2000/01/rdf-schema#",
+		"owl":  "http://www.w3.org/2002/07/owl#",
+		"xsd":  "http://www.w3.org/2001/XMLSchema#",
+	}
+}
+
+// Abbreviations returns the abbreviations in the prefix map in alphabetical order.
+func (pm PrefixMap) Abbreviations() []string {
+	abbrs := make([]string, 0, len(pm))
+	for abbr := range pm {
+		abbrs = append(abbrs, abbr)
+	}
+	sort.Strings(abbrs)
+	return abbrs
+}
+
+// A SerializeOption configures how a graph store is serialized (see `SerializeToTurtle`).
+type SerializeOption func(*serializeOptions)
+
+// A TurtleSerializer serializes triples in Turtle format with serialize options. It is implemented by graph stores that can apply the
+// options natively.
+type TurtleSerializer interface {
+	// SerializeToTurtleWithOptions should write the entire store into the writer in Turtle (TTL) format like `SerializeToTurtle` of the
+	// graph store and respect the serialize options.
+	SerializeToTurtleWithOptions(w io.Writer, pretty bool, opts ...SerializeOption) error
+}
+
+// serializeOptions holds the configuration compiled from a list of serialize options.
+type serializeOptions struct {
+	prefixes PrefixMap
+	sorted   bool
+	gzip     bool
+}
+
+// WithPrefixes sets the prefixes that are declared and applied when pretty printing. Only the given prefixes are used, i.e. neither the
+// standard prefixes nor prefixes derived from the graph URI and its imports are added.
+func WithPrefixes(pm PrefixMap) SerializeOption {
+	return func(opts *serializeOptions) {
+		opts.prefixes = pm
+	}
+}
+
+// WithSortedOutput serializes the triples in a stable canonical order (sorted by subject, predicate and object), one triple per line.
+// This keeps the output deterministic, e.g. to avoid spurious diffs when ontologies are kept under version control.
+func WithSortedOutput() SerializeOption {
+	return func(opts *serializeOptions) {
+		opts.sorted = true
+	}
+}
+
+// WithGzip compresses the serialized data with gzip.
+func WithGzip() SerializeOption {
+	return func(opts *serializeOptions) {
+		opts.gzip = true
+	}
+}
+
+// SerializeToTurtle writes the entire store into the writer in Turtle (TTL) format with the serialize options. If pretty is set to true,
+// the TTL is pretty printed. The options are applied to the plain TTL of stores that are not a `TurtleSerializer`.
+func SerializeToTurtle(store GraphStore, w io.Writer, pretty bool, opts ...SerializeOption) error {
+	if serializer, ok := store.(TurtleSerializer); ok {
+		return serializer.SerializeToTurtleWithOptions(w, pretty, opts...)
+	}
+	if len(opts) == 0 {
+		return store.SerializeToTurtle(w, pretty)
+	}
+	return newSerializeOptions(opts).writeTurtle(w, store, pretty, func() (string, error) {
+		var buf bytes.Buffer
+		if err := store.SerializeToTurtle(&buf, false); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	})
+}
+
+// newSerializeOptions compiles the given list of options.
+func newSerializeOptions(opts []SerializeOption) serializeOptions {
+	options := serializeOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// writeTurtle writes the TTL content of the store into the writer, sorted, pretty printed and compressed as configured. The unsorted TTL
+// content is retrieved from the given function.
+func (opts serializeOptions) writeTurtle(w io.Writer, store GraphStore, pretty bool, unsorted func() (string, error)) error {
+	var ttlContent string
+	var err error
+	if opts.sorted {
+		ttlContent, err = sortedTurtle(store)
+	} else {
+		ttlContent, err = unsorted()
+	}
+	if err != nil {
+		return err
+	}
+
+	// Write out serialized TTL if we do not need to prettify it
+	if !pretty {
+		return opts.write(w, ttlContent)
+	}
+
+	// Setup prefix map
+	prefixMap := opts.prefixes
+	if prefixMap == nil {
+		if prefixMap, err = defaultPrefixMap(store); err != nil {
+			return err
+		}
+	}
+
+	// Apply prefixes and pretty format triples
+	return opts.write(w, prettifyTurtle(ttlContent, store.GetURI(), prefixMap))
+}
+
+// write writes the serialized content into the writer, compressing it if configured.
+func (opts serializeOptions) write(w io.Writer, content string) error {
+	if !opts.gzip {
+		_, err := io.WriteString(w, content)
+		return err
+	}
+	gz := gzip.NewWriter(w)
+	if _, err := io.WriteString(gz, content); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// defaultPrefixMap returns the prefix map used when no prefixes are configured: The graph URI as empty prefix, the standard prefixes
+// and a prefix for each import (abbreviated by the last path segment of the import URI).
+func defaultPrefixMap(store GraphStore) (PrefixMap, error) {
+	prefixMap := NewStandardPrefixMap()
+	prefixMap[""] = store.GetURI() + "#"
+	// Find all imports
+	trps, err := store.GetAllMatches(NewResourceTerm(store.GetURI()).String(), NewResourceTerm(OWLImports).String(), "")
+	if err != nil {
+		return nil, err
+	}
+	// Add imports to prefix map
+	for _, trp := range trps {
+		importURI := trp.Object.Value()
+		abbr := importURI[strings.LastIndex(importURI, "/")+1:]
+		prefixMap[abbr] = importURI + "#"
+	}
+	return prefixMap, nil
+}
+
+// sortedTurtle returns the triples of the store in canonical order. Triples are grouped by subject and each statement ends with a new line.
+func sortedTurtle(store GraphStore) (string, error) {
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return "", err
+	}
+	return formatTurtleStatements(trps, nil), nil
+}
+
+// formatTurtleStatements sorts the triples and formats them as TTL statements grouped by subject, where each statement ends with a
+// new line. URIs are abbreviated with the given prefixes where possible.
+func formatTurtleStatements(trps []Triple, prefixMap PrefixMap) string {
+	trps = append([]Triple{}, trps...)
+	SortTriples(trps)
+	var ttlContent strings.Builder
+	for i, trp := range trps {
+		// Start a new statement for every subject
+		if i == 0 || trps[i-1].Subject != trp.Subject {
+			ttlContent.WriteString(fmt.Sprintf("%s\n", abbreviateTerm(trp.Subject, prefixMap)))
+		}
+		ttlContent.WriteString(fmt.Sprintf("  %s %s", abbreviateTerm(trp.Predicate, prefixMap), abbreviateTerm(trp.Object, prefixMap)))
+		// Terminate the statement after the last triple of the subject
+		if i == len(trps)-1 || trps[i+1].Subject != trp.Subject {
+			ttlContent.WriteString(" .\n")
+		} else {
+			ttlContent.WriteString(" ;\n")
+		}
+	}
+	return ttlContent.String()
+}
+
+// abbreviateTerm abbreviates the URI of a resource term (or the datatype of a literal term) with the matching prefix with the longest namespace.
+// If no prefix matches or the local name is not a simple name, the term is returned in NTriple format.
+func abbreviateTerm(t Term, prefixMap PrefixMap) string {
+	if len(prefixMap) == 0 {
+		return t.String()
+	}
+	if t.IsResource() {
+		if abbr, ok := abbreviateURI(t.Value(), prefixMap); ok {
+			return abbr
+		}
+	} else if t.IsLiteral() && t.Datatype() != "" {
+		if abbr, ok := abbreviateURI(t.Datatype(), prefixMap); ok {
+			s := t.String()
+			return s[:strings.LastIndex(s, "^^")+2] + abbr
+		}
+	}
+	return t.String()
+}
+
+// abbreviateURI abbreviates the URI with the matching prefix with the longest namespace.
+func abbreviateURI(uri string, prefixMap PrefixMap) (string, bool) {
+	bestAbbr, bestNS := "", ""
+	for abbr, ns := range prefixMap {
+		if !strings.HasPrefix(uri, ns) || !localNameRegex.MatchString(uri[len(ns):]) {
+			continue
+		}
+		// Prefer longer namespaces and break ties by the abbreviation to stay deterministic
+		if len(ns) > len(bestNS) || (len(ns) == len(bestNS) && abbr < bestAbbr) {
+			bestAbbr, bestNS = abbr, ns
+		}
+	}
+	if bestNS == "" {
+		return "", false
+	}
+	return bestAbbr + ":" + uri[len(bestNS):], true
+}
+
+// localNameRegex matches simple local names which can be used in prefixed names without escaping.
+var localNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// prettifyTurtle applies the prefixes to the TTL content and prepends the prefix block and base directive.
+func prettifyTurtle(ttlContent, baseURI string, prefixMap PrefixMap) string {
+	// Setup Prefix block
+	ttlPrefixes := ""
+	for _, abbr := range prefixMap.Abbreviations() {
+		ttlPrefixes = fmt.Sprintf("%s@prefix %s: <%s> .\n", ttlPrefixes, abbr, prefixMap[abbr])
+	}
+	// Apply prefixes with the longest namespace first, so that nested namespaces are not shadowed
+	abbrs := prefixMap.Abbreviations()
+	sort.SliceStable(abbrs, func(i, j int) bool {
+		return len(prefixMap[abbrs[i]]) > len(prefixMap[abbrs[j]])
+	})
+	for _, abbr := range abbrs {
+		var re = regexp.MustCompile(fmt.Sprintf(`\<%s(.+?)\>`, regexp.QuoteMeta(prefixMap[abbr])))
+		ttlContent = re.ReplaceAllString(ttlContent, fmt.Sprintf(`%s:$1`, abbr))
+	}
+	// Pretty format triples
+	ttlContent = regexp.MustCompile(` \.\n?`).ReplaceAllString(ttlContent, " .\n\n")
+
+	// Append prefix block and base path
+	return fmt.Sprintf("%s@base <%s> .\n\n%s", ttlPrefixes, baseURI, ttlContent)
+}
@@ -0,0 +1,251 @@
+// Package server exposes ontograph graph stores as read-only SPARQL 1.1 protocol endpoints, so that SPARQL tools like YASGUI or Jena
+// clients can query ontologies served from Go processes.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/kahefi/ontograph"
+)
+
+// Media types of the SPARQL protocol.
+const (
+	MediaTypeSPARQLQuery       string = "application/sparql-query"
+	MediaTypeSPARQLUpdate      string = "application/sparql-update"
+	MediaTypeSPARQLResultsJSON string = "application/sparql-results+json"
+	MediaTypeFormURLEncoded    string = "application/x-www-form-urlencoded"
+)
+
+// maxQuerySize limits the size of query bodies.
+const maxQuerySize = 1 << 20
+
+// Handler is a HTTP handler that serves a graph store as read-only SPARQL 1.1 protocol endpoint. Queries are accepted via GET
+// (`?query=...`) and POST (form encoded or as `application/sparql-query` body) and evaluated with `ontograph.QueryGraph`, i.e. by
+// the database for Blazegraph stores. Results are returned as SPARQL JSON results or, if requested via the Accept header, as Turtle
+// using the result set vocabulary. Requests without query return the entire graph as Turtle. Updates are rejected.
+type Handler struct {
+	store ontograph.GraphStore
+	// AllowOrigin is sent as Access-Control-Allow-Origin header, so browser based tools can query the endpoint. Defaults to `*`, set
+	// to the empty string to disable CORS.
+	AllowOrigin string
+}
+
+// NewHandler creates a new SPARQL endpoint handler for the graph store.
+func NewHandler(store ontograph.GraphStore) *Handler {
+	return &Handler{
+		store:       store,
+		AllowOrigin: "*",
+	}
+}
+
+// ServeHTTP handles a SPARQL protocol request.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.AllowOrigin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", h.AllowOrigin)
+	}
+	switch r.Method {
+	case http.MethodOptions:
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case http.MethodGet, http.MethodHead, http.MethodPost:
+	default:
+		w.Header().Set("Allow", "GET, POST, OPTIONS")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Extract query from request
+	query, update, err := readQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if update {
+		http.Error(w, "The endpoint is read-only and does not support SPARQL updates", http.StatusForbidden)
+		return
+	}
+	// Cancel the store operations when the client disconnects
+	store := ontograph.StoreWithContext(h.store, r.Context())
+	turtle := acceptsTurtle(r.Header.Get("Accept"))
+	if query == "" {
+		if !turtle {
+			http.Error(w, "Missing query parameter", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", ontograph.MediaTypeTurtle+"; charset=utf-8")
+		if err := store.SerializeToTurtle(w, true); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Evaluate query and write results
+	resSet, err := ontograph.QueryGraph(store, query)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, ontograph.ErrInvalidQuery) || errors.Is(err, ontograph.ErrUnsupportedQuery) {
+			code = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+	ask := isAskQuery(query)
+	if turtle {
+		w.Header().Set("Content-Type", ontograph.MediaTypeTurtle+"; charset=utf-8")
+		fmt.Fprint(w, resultSetToTurtle(resSet, ask))
+		return
+	}
+	data, err := json.Marshal(newResultsJSON(resSet, ask))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", MediaTypeSPARQLResultsJSON+"; charset=utf-8")
+	w.Write(data)
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// readQuery extracts the query string of a SPARQL protocol request. Returns true if the request is an update.
+func readQuery(r *http.Request) (string, bool, error) {
+	if r.Method != http.MethodPost {
+		params := r.URL.Query()
+		return params.Get("query"), params.Get("update") != "", nil
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", false, fmt.Errorf("Invalid content type: %v", err)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxQuerySize))
+	if err != nil {
+		return "", false, err
+	}
+	switch mediaType {
+	case MediaTypeSPARQLQuery:
+		return string(body), false, nil
+	case MediaTypeSPARQLUpdate:
+		return "", true, nil
+	case MediaTypeFormURLEncoded:
+		params, err := url.ParseQuery(string(body))
+		if err != nil {
+			return "", false, fmt.Errorf("Invalid form data: %v", err)
+		}
+		return params.Get("query"), params.Get("update") != "", nil
+	}
+	return "", false, fmt.Errorf("Unsupported content type '%s'", mediaType)
+}
+
+// acceptsTurtle returns true if the Accept header prefers Turtle over JSON.
+func acceptsTurtle(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case ontograph.MediaTypeTurtle, "application/x-turtle":
+			return true
+		case MediaTypeSPARQLResultsJSON, "application/json", "*/*":
+			return false
+		}
+	}
+	return false
+}
+
+// askQueryPattern matches ASK queries after their prologue of comments and PREFIX and BASE declarations.
+var askQueryPattern = regexp.MustCompile(`(?is)^(?:\s+|#[^\n]*|BASE\s*<[^>]*>|PREFIX\s+[^\s:]*:\s*<[^>]*>)*ASK\b`)
+
+// isAskQuery returns true if the query is an ASK query.
+func isAskQuery(query string) bool {
+	return askQueryPattern.MatchString(query)
+}
+
+// resultsJSON is the SPARQL 1.1 JSON results format. Unlike `ontograph.JSONResultSet`, which is used to decode results leniently, it
+// always contains the members that are mandatory for the query form.
+type resultsJSON struct {
+	Head    interface{}   `json:"head"`
+	Results *bindingsJSON `json:"results,omitempty"`
+	Boolean *bool         `json:"boolean,omitempty"`
+}
+
+// selectHeadJSON is the head of SELECT results.
+type selectHeadJSON struct {
+	Vars []string `json:"vars"`
+	Link []string `json:"link,omitempty"`
+}
+
+// bindingsJSON holds the solutions of SELECT results.
+type bindingsJSON struct {
+	Bindings []map[string]bindingJSON `json:"bindings"`
+}
+
+// bindingJSON is the RDF term bound to a variable.
+type bindingJSON struct {
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	Lang     string `json:"xml:lang,omitempty"`
+	DataType string `json:"datatype,omitempty"`
+}
+
+// newResultsJSON returns the JSON representation of the result set. ASK results contain the boolean only.
+func newResultsJSON(resSet ontograph.JSONResultSet, ask bool) resultsJSON {
+	if ask {
+		return resultsJSON{Head: struct{}{}, Boolean: &resSet.Boolean}
+	}
+	head := selectHeadJSON{Vars: resSet.Head.Vars, Link: resSet.Head.Link}
+	if head.Vars == nil {
+		head.Vars = []string{}
+	}
+	results := &bindingsJSON{Bindings: make([]map[string]bindingJSON, 0, len(resSet.Results.Bindings))}
+	for _, binding := range resSet.Results.Bindings {
+		solution := make(map[string]bindingJSON, len(binding))
+		for name, b := range binding {
+			// Typed literals of SPARQL 1.0 are literals with datatype in SPARQL 1.1
+			if b.Type == "typed-literal" {
+				b.Type = "literal"
+			}
+			solution[name] = bindingJSON{Type: b.Type, Value: b.Value, Lang: b.Lang, DataType: b.DataType}
+		}
+		results.Bindings = append(results.Bindings, solution)
+	}
+	return resultsJSON{Head: head, Results: results}
+}
+
+// resultSetToTurtle serializes the result set in Turtle using the result set vocabulary of the SPARQL test suite (as supported by
+// Jena and other SPARQL tools).
+func resultSetToTurtle(resSet ontograph.JSONResultSet, ask bool) string {
+	var sb strings.Builder
+	sb.WriteString("@prefix rs: <http://www.w3.org/2001/sw/DataAccess/tests/result-set#> .\n\n")
+	sb.WriteString("[] a rs:ResultSet")
+	if ask {
+		sb.WriteString(fmt.Sprintf(" ;\n   rs:boolean %t .\n", resSet.Boolean))
+		return sb.String()
+	}
+	for _, v := range resSet.Head.Vars {
+		sb.WriteString(fmt.Sprintf(" ;\n   rs:resultVariable %q", v))
+	}
+	for i, binding := range resSet.Results.Bindings {
+		sb.WriteString(fmt.Sprintf(" ;\n   rs:solution [ rs:index %d", i+1))
+		for _, v := range resSet.Head.Vars {
+			if b, ok := binding[v]; ok {
+				sb.WriteString(fmt.Sprintf(" ;\n      rs:binding [ rs:variable %q ; rs:value %s ]", v, b.Term()))
+			}
+		}
+		sb.WriteString(" ]")
+	}
+	sb.WriteString(" .\n")
+	return sb.String()
+}
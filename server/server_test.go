@@ -0,0 +1,164 @@
+package server_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/kahefi/ontograph"
+	. "github.com/kahefi/ontograph/server"
+)
+
+var _ = Describe("SPARQL endpoint", func() {
+	const query = `SELECT ?c WHERE { ?c a <http://www.w3.org/2002/07/owl#Class> }`
+	var srv *httptest.Server
+
+	BeforeEach(func() {
+		store, err := ontograph.ParseFromTurtle(strings.NewReader(`@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+<http://example.com/onto#A> a owl:Class .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		srv = httptest.NewServer(NewHandler(store))
+	})
+
+	AfterEach(func() {
+		srv.Close()
+	})
+
+	do := func(req *http.Request) (*http.Response, string) {
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return res, string(body)
+	}
+
+	expectClassA := func(body string) {
+		resSet := ontograph.JSONResultSet{}
+		Expect(json.Unmarshal([]byte(body), &resSet)).To(Succeed())
+		Expect(resSet.Head.Vars).To(Equal([]string{"c"}))
+		Expect(resSet.Results.Bindings).To(Equal([]map[string]ontograph.JSONResultSetBinding{{"c": {Type: "uri", Value: "http://example.com/onto#A"}}}))
+	}
+
+	It("should answer queries via GET and POST with JSON results", func() {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"?query="+url.QueryEscape(query), nil)
+		res, body := do(req)
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(res.Header.Get("Content-Type")).To(HavePrefix(MediaTypeSPARQLResultsJSON))
+		Expect(res.Header.Get("Access-Control-Allow-Origin")).To(Equal("*"))
+		expectClassA(body)
+
+		req, _ = http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("query="+url.QueryEscape(query)))
+		req.Header.Set("Content-Type", MediaTypeFormURLEncoded)
+		res, body = do(req)
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		expectClassA(body)
+
+		req, _ = http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(query))
+		req.Header.Set("Content-Type", MediaTypeSPARQLQuery)
+		res, body = do(req)
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		expectClassA(body)
+	})
+
+	It("should return Turtle results and ASK results", func() {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"?query="+url.QueryEscape(query), nil)
+		req.Header.Set("Accept", "text/turtle")
+		res, body := do(req)
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(body).To(ContainSubstring(`rs:binding [ rs:variable "c" ; rs:value <http://example.com/onto#A> ]`))
+		parsed, err := ontograph.ParseFromTurtle(strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.Size()).To(BeNumerically(">", 0))
+
+		req, _ = http.NewRequest(http.MethodGet, srv.URL+"?query="+url.QueryEscape("ASK { ?s ?p ?o }"), nil)
+		_, body = do(req)
+		Expect(body).To(MatchJSON(`{"head": {}, "boolean": true}`))
+	})
+
+	It("should return well-formed JSON results for empty results and empty literals", func() {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"?query="+url.QueryEscape(`SELECT ?s WHERE { ?s a <http://example.com/onto#Missing> }`), nil)
+		_, body := do(req)
+		Expect(body).To(MatchJSON(`{"head": {"vars": ["s"]}, "results": {"bindings": []}}`))
+
+		req, _ = http.NewRequest(http.MethodGet, srv.URL+"?query="+url.QueryEscape(`PREFIX ex: <http://example.com/onto#>
+ASK { ex:A a ex:Missing }`), nil)
+		_, body = do(req)
+		Expect(body).To(MatchJSON(`{"head": {}, "boolean": false}`))
+
+		store, err := ontograph.ParseFromTurtle(strings.NewReader(`<http://example.com/onto#A> <http://www.w3.org/2000/01/rdf-schema#label> "" .`))
+		Expect(err).NotTo(HaveOccurred())
+		labelSrv := httptest.NewServer(NewHandler(store))
+		defer labelSrv.Close()
+		req, _ = http.NewRequest(http.MethodGet, labelSrv.URL+"?query="+url.QueryEscape(`SELECT ?l WHERE { ?s ?p ?l }`), nil)
+		_, body = do(req)
+		Expect(body).To(MatchJSON(`{"head": {"vars": ["l"]}, "results": {"bindings": [{"l": {"type": "literal", "value": ""}}]}}`))
+	})
+
+	It("should serve the graph as Turtle without query", func() {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		req.Header.Set("Accept", "text/turtle")
+		res, body := do(req)
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(body).To(ContainSubstring(":A\n  rdf:type owl:Class"))
+	})
+
+	It("should reject invalid queries and updates", func() {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"?query="+url.QueryEscape("SELECT ?s WHERE {"), nil)
+		res, _ := do(req)
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+		req, _ = http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("DROP ALL"))
+		req.Header.Set("Content-Type", MediaTypeSPARQLUpdate)
+		res, _ = do(req)
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+		req, _ = http.NewRequest(http.MethodDelete, srv.URL, nil)
+		res, _ = do(req)
+		Expect(res.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+	})
+
+	It("should pass queries to Blazegraph stores", func() {
+		forms := []url.Values{}
+		bg := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).To(Succeed())
+			forms = append(forms, r.PostForm)
+			if strings.Contains(r.PostForm.Get("query"), "{") && !strings.Contains(r.PostForm.Get("query"), "}") {
+				http.Error(w, "MALFORMED QUERY", http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", MediaTypeSPARQLResultsJSON)
+			w.Write([]byte(`{"head":{"vars":["c"]},"results":{"bindings":[{"c":{"type":"uri","value":"http://example.com/onto#A"}},` +
+				`{"c":{"type":"uri","value":"urn:ontograph:bnode:r1"}}]}}`))
+		}))
+		defer bg.Close()
+		store := ontograph.NewBlazegraphEndpoint(bg.URL).NewBlazegraphStore("http://example.com/onto", "test")
+		bgSrv := httptest.NewServer(NewHandler(store))
+		defer bgSrv.Close()
+
+		req, _ := http.NewRequest(http.MethodGet, bgSrv.URL+"?query="+url.QueryEscape(query), nil)
+		res, body := do(req)
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		resSet := ontograph.JSONResultSet{}
+		Expect(json.Unmarshal([]byte(body), &resSet)).To(Succeed())
+		Expect(resSet.Results.Bindings).To(Equal([]map[string]ontograph.JSONResultSetBinding{
+			{"c": {Type: "uri", Value: "http://example.com/onto#A"}},
+			{"c": {Type: "bnode", Value: "r1"}},
+		}))
+		// The query is evaluated by Blazegraph on the graph of the store with a single request
+		Expect(forms).To(HaveLen(1))
+		Expect(forms[0].Get("query")).To(Equal(query))
+		Expect(forms[0].Get("default-graph-uri")).To(Equal("http://example.com/onto"))
+		Expect(forms[0].Get("named-graph-uri")).To(Equal("http://example.com/onto"))
+
+		req, _ = http.NewRequest(http.MethodGet, bgSrv.URL+"?query="+url.QueryEscape("SELECT ?s WHERE {"), nil)
+		res, _ = do(req)
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+})
@@ -0,0 +1,276 @@
+package ontograph
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// GenerateSHACLShapes derives SHACL shapes from the ontology and returns them as a new shapes graph with the given URI. Each
+// non-deprecated class gets a node shape `<class URI>Shape` targeting the class, with one property shape per property that has the
+// class as domain or is restricted by an `owl:Restriction` superclass of the class. Ranges become `sh:class` or `sh:datatype`
+// constraints, functional properties get `sh:maxCount 1`, cardinality restrictions become `sh:minCount` and `sh:maxCount`,
+// `owl:someValuesFrom` and `owl:hasValue` restrictions require a value and `owl:allValuesFrom` restricts the value type. Qualified
+// cardinality restrictions are generated as separate property shapes with a `sh:qualifiedValueShape`. Anonymous class expressions
+// are not translated.
+func (ont *OntologyGraph) GenerateSHACLShapes(shapesGraphURI string) (*MemoryStore, error) {
+	classes, err := ont.GetClasses()
+	if err != nil {
+		return nil, err
+	}
+	objProps, err := ont.GetObjectProperties()
+	if err != nil {
+		return nil, err
+	}
+	dataProps, err := ont.GetDataProperties()
+	if err != nil {
+		return nil, err
+	}
+	datatypes, err := ont.GetDatatypes()
+	if err != nil {
+		return nil, err
+	}
+	isDatatype := map[string]bool{}
+	for uri := range builtinDatatypes {
+		isDatatype[uri] = true
+	}
+	for _, dt := range datatypes {
+		isDatatype[dt.URI] = true
+	}
+
+	// Collect the property constraints implied by the property axioms per domain class
+	byDomain := map[string][]*shaclPropertyShape{}
+	for _, prop := range objProps {
+		if prop.IsDeprecated {
+			continue
+		}
+		for _, domain := range prop.Domains {
+			shape := &shaclPropertyShape{path: prop.URI, classes: prop.Ranges}
+			if prop.IsFunctional {
+				shape.mergeMaxCount(1)
+			}
+			byDomain[domain] = append(byDomain[domain], shape)
+		}
+	}
+	for _, prop := range dataProps {
+		if prop.IsDeprecated {
+			continue
+		}
+		for _, domain := range prop.Domains {
+			shape := &shaclPropertyShape{path: prop.URI}
+			for _, rng := range prop.Ranges {
+				if rng != RDFSLiteral {
+					shape.datatypes = append(shape.datatypes, rng)
+				}
+			}
+			if prop.IsFunctional {
+				shape.mergeMaxCount(1)
+			}
+			byDomain[domain] = append(byDomain[domain], shape)
+		}
+	}
+
+	store := NewMemoryStore(shapesGraphURI)
+	trps := []Triple{}
+	blankNodes := 0
+	for _, class := range classes {
+		if class.IsDeprecated || isAnonymousURI(class.URI) {
+			continue
+		}
+		// Merge the unqualified constraints per property and keep qualified restrictions separately
+		shapes := map[string]*shaclPropertyShape{}
+		qualified := []*shaclPropertyShape{}
+		shapeOf := func(path string) *shaclPropertyShape {
+			if shapes[path] == nil {
+				shapes[path] = &shaclPropertyShape{path: path}
+			}
+			return shapes[path]
+		}
+		for _, shape := range byDomain[class.URI] {
+			shapeOf(shape.path).merge(shape)
+		}
+		for _, restr := range class.Restrictions {
+			if restr.OnClass != "" || restr.OnDataRange != "" {
+				qualified = append(qualified, qualifiedPropertyShape(restr))
+				continue
+			}
+			shape := shapeOf(restr.OnProperty)
+			if restr.Cardinality != nil {
+				shape.mergeMinCount(*restr.Cardinality)
+				shape.mergeMaxCount(*restr.Cardinality)
+			}
+			if restr.MinCardinality != nil {
+				shape.mergeMinCount(*restr.MinCardinality)
+			}
+			if restr.MaxCardinality != nil {
+				shape.mergeMaxCount(*restr.MaxCardinality)
+			}
+			if restr.SomeValuesFrom != "" {
+				shape.mergeMinCount(1)
+				shape.addValueType(restr.SomeValuesFrom, isDatatype)
+			}
+			if restr.AllValuesFrom != "" {
+				shape.addValueType(restr.AllValuesFrom, isDatatype)
+			}
+			if restr.HasValue != "" {
+				shape.mergeMinCount(1)
+				shape.hasValues = append(shape.hasValues, restr.HasValue)
+			}
+		}
+
+		// Convert the node shape and its property shapes into triples
+		node := NewResourceTerm(class.URI + "Shape")
+		trps = append(trps,
+			Triple{Subject: node, Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(SHNodeShape)},
+			Triple{Subject: node, Predicate: NewResourceTerm(SHTargetClass), Object: NewResourceTerm(class.URI)},
+		)
+		paths := []string{}
+		for path := range shapes {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		propShapes := []*shaclPropertyShape{}
+		for _, path := range paths {
+			propShapes = append(propShapes, shapes[path])
+		}
+		for _, shape := range append(propShapes, qualified...) {
+			blankNodes++
+			propNode := NewBlankNodeTerm(fmt.Sprintf("shape%d", blankNodes))
+			trps = append(trps, Triple{Subject: node, Predicate: NewResourceTerm(SHProperty), Object: propNode})
+			trps = append(trps, shape.toTriples(propNode)...)
+			if shape.qualifiedShape != nil {
+				blankNodes++
+				valueNode := NewBlankNodeTerm(fmt.Sprintf("shape%d", blankNodes))
+				trps = append(trps, Triple{Subject: propNode, Predicate: NewResourceTerm(SHQualifiedValueShape), Object: valueNode})
+				trps = append(trps, shape.qualifiedShape.toTriples(valueNode)...)
+			}
+		}
+	}
+	if err := store.AddTriplesUnchecked(trps); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// shaclPropertyShape collects the constraints of a SHACL property shape. For qualified value shapes, the path is empty and the counts
+// are the qualified counts.
+type shaclPropertyShape struct {
+	path           string
+	minCount       *int
+	maxCount       *int
+	classes        []string
+	datatypes      []string
+	hasValues      []Term
+	qualifiedShape *shaclPropertyShape
+}
+
+// qualifiedPropertyShape converts the qualified cardinality restriction into a property shape with a qualified value shape.
+func qualifiedPropertyShape(restr OntologyRestriction) *shaclPropertyShape {
+	valueShape := &shaclPropertyShape{}
+	if restr.OnClass != "" {
+		valueShape.classes = []string{restr.OnClass}
+	} else {
+		valueShape.datatypes = []string{restr.OnDataRange}
+	}
+	shape := &shaclPropertyShape{path: restr.OnProperty, qualifiedShape: valueShape}
+	if restr.Cardinality != nil {
+		shape.mergeMinCount(*restr.Cardinality)
+		shape.mergeMaxCount(*restr.Cardinality)
+	}
+	if restr.MinCardinality != nil {
+		shape.mergeMinCount(*restr.MinCardinality)
+	}
+	if restr.MaxCardinality != nil {
+		shape.mergeMaxCount(*restr.MaxCardinality)
+	}
+	return shape
+}
+
+// merge merges the constraints of the other shape into the shape.
+func (shape *shaclPropertyShape) merge(other *shaclPropertyShape) {
+	if other.minCount != nil {
+		shape.mergeMinCount(*other.minCount)
+	}
+	if other.maxCount != nil {
+		shape.mergeMaxCount(*other.maxCount)
+	}
+	shape.classes = appendMissing(shape.classes, other.classes...)
+	shape.datatypes = appendMissing(shape.datatypes, other.datatypes...)
+}
+
+// mergeMinCount raises the minimum count if the given count is stricter.
+func (shape *shaclPropertyShape) mergeMinCount(n int) {
+	if shape.minCount == nil || n > *shape.minCount {
+		shape.minCount = &n
+	}
+}
+
+// mergeMaxCount lowers the maximum count if the given count is stricter.
+func (shape *shaclPropertyShape) mergeMaxCount(n int) {
+	if shape.maxCount == nil || n < *shape.maxCount {
+		shape.maxCount = &n
+	}
+}
+
+// addValueType adds the class or datatype as value type of the shape.
+func (shape *shaclPropertyShape) addValueType(uri string, isDatatype map[string]bool) {
+	if isDatatype[uri] {
+		shape.datatypes = appendMissing(shape.datatypes, uri)
+	} else if !isAnonymousURI(uri) {
+		shape.classes = appendMissing(shape.classes, uri)
+	}
+}
+
+// toTriples converts the property shape into triples with the given node as subject.
+func (shape *shaclPropertyShape) toTriples(node Term) []Triple {
+	trps := []Triple{}
+	add := func(pred string, obj Term) {
+		trps = append(trps, Triple{Subject: node, Predicate: NewResourceTerm(pred), Object: obj})
+	}
+	count := func(n int) Term {
+		return NewLiteralTerm(strconv.Itoa(n), "", XSDInteger)
+	}
+	if shape.path != "" {
+		add(SHPath, NewResourceTerm(shape.path))
+	}
+	minPred, maxPred := SHMinCount, SHMaxCount
+	if shape.qualifiedShape != nil {
+		minPred, maxPred = SHQualifiedMinCount, SHQualifiedMaxCount
+	}
+	if shape.minCount != nil {
+		add(minPred, count(*shape.minCount))
+	}
+	if shape.maxCount != nil {
+		add(maxPred, count(*shape.maxCount))
+	}
+	for _, class := range shape.classes {
+		if class != OWLThing {
+			add(SHClass, NewResourceTerm(class))
+		}
+	}
+	for _, dt := range shape.datatypes {
+		add(SHDatatype, NewResourceTerm(dt))
+	}
+	for _, value := range shape.hasValues {
+		add(SHHasValue, value)
+	}
+	return trps
+}
+
+// appendMissing appends the values that are not yet contained in the slice.
+func appendMissing(values []string, others ...string) []string {
+	for _, other := range others {
+		found := false
+		for _, value := range values {
+			found = found || value == other
+		}
+		if !found {
+			values = append(values, other)
+		}
+	}
+	return values
+}
@@ -0,0 +1,102 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Generating SHACL shapes", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.com/onto> a owl:Ontology .
+:Person a owl:Class ;
+	rdfs:subClassOf _:r1 , _:r2 , _:r3 .
+_:r1 a owl:Restriction ; owl:onProperty :name ; owl:minCardinality "1"^^xsd:nonNegativeInteger .
+_:r2 a owl:Restriction ; owl:onProperty :name ; owl:maxCardinality "3"^^xsd:nonNegativeInteger .
+_:r3 a owl:Restriction ; owl:onProperty :owns ; owl:minQualifiedCardinality "2"^^xsd:nonNegativeInteger ; owl:onClass :Car .
+:Company a owl:Class .
+:Car a owl:Class .
+:Legacy a owl:Class ; owl:deprecated "true"^^xsd:boolean .
+:worksFor a owl:ObjectProperty , owl:FunctionalProperty ; rdfs:domain :Person ; rdfs:range :Company .
+:name a owl:DatatypeProperty ; rdfs:domain :Person ; rdfs:range xsd:string .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	// propertyShapes returns the objects of the property shapes of the node shape keyed by path and predicate. The constraints of
+	// qualified value shapes are inlined.
+	propertyShapes := func(shapes GraphStore, node string) map[string]map[string][]Term {
+		trps, err := shapes.GetAllTriples()
+		Expect(err).NotTo(HaveOccurred())
+		bySubject := map[Term][]Triple{}
+		for _, trp := range trps {
+			bySubject[trp.Subject] = append(bySubject[trp.Subject], trp)
+		}
+		result := map[string]map[string][]Term{}
+		for _, trp := range bySubject[NewResourceTerm(node)] {
+			if trp.Predicate != NewResourceTerm(SHProperty) {
+				continue
+			}
+			objs := map[string][]Term{}
+			path := ""
+			for _, propTrp := range bySubject[trp.Object] {
+				if propTrp.Predicate == NewResourceTerm(SHPath) {
+					path = propTrp.Object.Value()
+				} else if propTrp.Predicate == NewResourceTerm(SHQualifiedValueShape) {
+					for _, valueTrp := range bySubject[propTrp.Object] {
+						objs[valueTrp.Predicate.Value()] = append(objs[valueTrp.Predicate.Value()], valueTrp.Object)
+					}
+				} else {
+					objs[propTrp.Predicate.Value()] = append(objs[propTrp.Predicate.Value()], propTrp.Object)
+				}
+			}
+			result[path] = objs
+		}
+		return result
+	}
+
+	It("should derive node shapes from classes, property axioms and restrictions", func() {
+		shapes, err := ont.GenerateSHACLShapes("http://example.com/shapes")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(shapes.GetURI()).To(Equal("http://example.com/shapes"))
+
+		targets, err := shapes.GetAllMatches("", NewResourceTerm(SHTargetClass).String(), "")
+		Expect(err).NotTo(HaveOccurred())
+		targetClasses := []string{}
+		for _, trp := range targets {
+			Expect(trp.Subject).To(Equal(NewResourceTerm(trp.Object.Value() + "Shape")))
+			targetClasses = append(targetClasses, trp.Object.Value())
+		}
+		Expect(targetClasses).To(ConsistOf(ns+"Person", ns+"Company", ns+"Car"))
+
+		integer := func(n string) Term { return NewLiteralTerm(n, "", XSDInteger) }
+		Expect(propertyShapes(shapes, ns+"PersonShape")).To(Equal(map[string]map[string][]Term{
+			ns + "worksFor": {
+				SHMaxCount: {integer("1")},
+				SHClass:    {NewResourceTerm(ns + "Company")},
+			},
+			ns + "name": {
+				SHMinCount: {integer("1")},
+				SHMaxCount: {integer("3")},
+				SHDatatype: {NewResourceTerm(XSDString)},
+			},
+			ns + "owns": {
+				SHQualifiedMinCount: {integer("2")},
+				SHClass:             {NewResourceTerm(ns + "Car")},
+			},
+		}))
+		Expect(propertyShapes(shapes, ns+"CompanyShape")).To(BeEmpty())
+	})
+})
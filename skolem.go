@@ -0,0 +1,95 @@
+package ontograph
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SkolemURI returns the skolem URI (RDF 1.1) of the blank node with the given label within the namespace of the base URI, e.g.
+// `http://example.com/onto/.well-known/genid/b1` for `_:b1`.
+func SkolemURI(baseURI, label string) string {
+	return baseURI + SkolemPathSegment + label
+}
+
+// SkolemizeTriples returns a copy of the triples in which all blank nodes are replaced by skolem URIs within the namespace of the base
+// URI (see `SkolemURI`). The blank node labels are kept in the URIs, so `DeskolemizeTriples` restores the original triples. Skolem URIs
+// keep their identity in stores that relabel blank nodes, e.g. when data is loaded, queried and written back.
+func SkolemizeTriples(trps []Triple, baseURI string) []Triple {
+	skolemize := func(t Term) Term {
+		if t.IsBlankNode() {
+			return NewResourceTerm(SkolemURI(baseURI, t.Value()))
+		}
+		return t
+	}
+	skolemized := make([]Triple, len(trps))
+	for i, trp := range trps {
+		skolemized[i] = Triple{Subject: skolemize(trp.Subject), Predicate: trp.Predicate, Object: skolemize(trp.Object)}
+	}
+	return skolemized
+}
+
+// DeskolemizeTriples returns a copy of the triples in which all skolem URIs within the namespace of the base URI are replaced by blank
+// nodes, e.g. to export skolemized data with anonymous nodes again. The blank node labels are taken from the URIs. Skolem URIs whose
+// identifiers are no valid blank node labels are kept.
+func DeskolemizeTriples(trps []Triple, baseURI string) []Triple {
+	prefix := baseURI + SkolemPathSegment
+	deskolemize := func(t Term) Term {
+		if !t.IsResource() || !strings.HasPrefix(t.Value(), prefix) {
+			return t
+		}
+		if label := strings.TrimPrefix(t.Value(), prefix); blankNodeLabelRegex.MatchString(label) {
+			return NewBlankNodeTerm(label)
+		}
+		return t
+	}
+	deskolemized := make([]Triple, len(trps))
+	for i, trp := range trps {
+		deskolemized[i] = Triple{Subject: deskolemize(trp.Subject), Predicate: trp.Predicate, Object: deskolemize(trp.Object)}
+	}
+	return deskolemized
+}
+
+// SkolemizeStore replaces all blank nodes in the graph store by skolem URIs within the namespace of the base URI (see
+// `SkolemizeTriples`). The triples are replaced within a single transaction (see `Begin`). Returns the number of replaced triples.
+func SkolemizeStore(store GraphStore, baseURI string) (int, error) {
+	return rewriteStore(store, func(trps []Triple) []Triple {
+		return SkolemizeTriples(trps, baseURI)
+	})
+}
+
+// DeskolemizeStore replaces all skolem URIs within the namespace of the base URI in the graph store by blank nodes (see
+// `DeskolemizeTriples`). The triples are replaced within a single transaction (see `Begin`). Returns the number of replaced triples.
+func DeskolemizeStore(store GraphStore, baseURI string) (int, error) {
+	return rewriteStore(store, func(trps []Triple) []Triple {
+		return DeskolemizeTriples(trps, baseURI)
+	})
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// blankNodeLabelRegex matches blank node labels that can be written in Turtle and N-Triples without escaping.
+var blankNodeLabelRegex = regexp.MustCompile(`^[A-Za-z0-9_]([A-Za-z0-9_.-]*[A-Za-z0-9_-])?$`)
+
+// rewriteStore replaces the triples of the store with their rewritten version within a single transaction. The rewrite must return the
+// triples in the same order. Returns the number of replaced triples.
+func rewriteStore(store GraphStore, rewrite func([]Triple) []Triple) (int, error) {
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return 0, err
+	}
+	rewritten := rewrite(trps)
+	deleted, added := []Triple{}, []Triple{}
+	for i := range trps {
+		if trps[i] != rewritten[i] {
+			deleted = append(deleted, trps[i])
+			added = append(added, rewritten[i])
+		}
+	}
+	if len(deleted) == 0 {
+		return 0, nil
+	}
+	cs := Changeset{Deleted: deleted, Added: added}
+	return len(deleted), cs.Apply(store)
+}
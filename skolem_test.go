@@ -0,0 +1,56 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Skolemization", func() {
+	const testUri = "http://example.com/onto"
+	trps := []Triple{
+		{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(testUri + "#ref"), Object: NewBlankNodeTerm("b1")},
+		{Subject: NewBlankNodeTerm("b1"), Predicate: NewResourceTerm(RDFSLabel), Object: NewLiteralTerm("Anonymous", "en", "")},
+	}
+
+	It("should replace blank nodes by skolem URIs and back", func() {
+		skolemized := SkolemizeTriples(trps, testUri)
+		Expect(skolemized).To(Equal([]Triple{
+			{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(testUri + "#ref"), Object: NewResourceTerm(testUri + "/.well-known/genid/b1")},
+			{Subject: NewResourceTerm(testUri + "/.well-known/genid/b1"), Predicate: NewResourceTerm(RDFSLabel), Object: NewLiteralTerm("Anonymous", "en", "")},
+		}))
+		Expect(DeskolemizeTriples(skolemized, testUri)).To(Equal(trps))
+		Expect(DeskolemizeTriples(skolemized, "http://other.com/onto")).To(Equal(skolemized))
+	})
+
+	It("should skolemize the blank nodes of a store within a transaction", func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+:A :ref [ :value "1" ] .
+:B :value "2" .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		n, err := SkolemizeStore(store, testUri)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(2))
+		all, err := store.GetAllTriples()
+		Expect(err).NotTo(HaveOccurred())
+		for _, trp := range all {
+			Expect(trp.Subject.IsBlankNode() || trp.Object.IsBlankNode()).To(BeFalse())
+		}
+		ref, err := store.GetFirstMatch(NewResourceTerm(testUri+"#A").String(), "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ref.Object.Value()).To(HavePrefix(testUri + SkolemPathSegment))
+		Expect(store.GetAllMatches(ref.Object.String(), "", "")).To(HaveLen(1))
+
+		n, err = DeskolemizeStore(store, testUri)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(2))
+		ref, err = store.GetFirstMatch(NewResourceTerm(testUri+"#A").String(), "", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ref.Object.IsBlankNode()).To(BeTrue())
+		Expect(store.Size()).To(Equal(3))
+	})
+})
@@ -0,0 +1,58 @@
+package ontograph_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Snapshots", func() {
+	const testUri = "http://example.com/onto"
+
+	It("should restore a memory store from its snapshot", func() {
+		store := NewMemoryStore(testUri)
+		trps := []Triple{
+			{Subject: NewResourceTerm(testUri), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLOntology)},
+			{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)},
+			{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(RDFSLabel), Object: NewLiteralTerm("Class A", "", XSDString)},
+			{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(RDFSComment), Object: NewLiteralTerm("Klasse", "de", "")},
+			{Subject: NewResourceTerm(testUri + "#a"), Predicate: NewResourceTerm(testUri + "#count"), Object: NewLiteralTerm("42", "", XSDInteger)},
+		}
+		Expect(store.AddTriples(trps)).To(Succeed())
+		var buf bytes.Buffer
+		Expect(store.WriteSnapshot(&buf)).To(Succeed())
+		restored, err := ReadSnapshot(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored.GetURI()).To(Equal(testUri))
+		Expect(restored.GetAllTriples()).To(ConsistOf(trps))
+	})
+
+	It("should restore parsed stores including blank nodes", func() {
+		ttl := `@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+<http://example.com/onto#A> owl:equivalentClass [ a owl:Restriction ] .
+`
+		store, err := ParseFromTurtle(strings.NewReader(ttl))
+		Expect(err).NotTo(HaveOccurred())
+		var buf bytes.Buffer
+		Expect(store.WriteSnapshot(&buf)).To(Succeed())
+		restored, err := ReadSnapshot(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		trps, err := store.GetAllTriples()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trps).To(ContainElement(Triple{Subject: "<http://example.com/onto#A>", Predicate: NewResourceTerm(OWLEquivalentClass), Object: "_:n0"}))
+		Expect(restored.GetAllTriples()).To(ConsistOf(trps))
+	})
+
+	It("should reject data that is no snapshot", func() {
+		_, err := ReadSnapshot(strings.NewReader("<http://example.com/onto> a <http://www.w3.org/2002/07/owl#Ontology> ."))
+		Expect(err).To(MatchError(ErrInvalidSnapshot))
+		_, err = ReadSnapshot(strings.NewReader("OGSNAP\x01garbage"))
+		Expect(errors.Is(err, ErrInvalidSnapshot)).To(BeTrue())
+	})
+})
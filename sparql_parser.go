@@ -0,0 +1,865 @@
+package ontograph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// *********************
+// * SPARQL Query Tree *
+// *********************
+
+// sparqlQuery is a parsed SPARQL SELECT or ASK query.
+type sparqlQuery struct {
+	ask      bool
+	distinct bool
+	// vars are the projected variables (nil for `SELECT *`).
+	vars    []string
+	where   *sparqlGroup
+	orderBy []sparqlOrder
+	// limit is the maximum number of results (-1 for no limit).
+	limit  int
+	offset int
+	// seenVars are all variables of the query in order of their first occurrence.
+	seenVars []string
+}
+
+// sparqlGroup is a group graph pattern. Its elements are evaluated in order, filters apply to the whole group.
+type sparqlGroup struct {
+	patterns []interface{} // sparqlTriplePattern, *sparqlGroup, sparqlOptional or sparqlUnion
+	filters  []sparqlExpr
+}
+
+// sparqlOptional is an OPTIONAL group.
+type sparqlOptional struct {
+	group *sparqlGroup
+}
+
+// sparqlUnion is a UNION of alternative groups.
+type sparqlUnion struct {
+	groups []*sparqlGroup
+}
+
+// sparqlTriplePattern is a triple pattern whose positions are either variables or terms.
+type sparqlTriplePattern struct {
+	subject, predicate, object sparqlNode
+}
+
+// sparqlNode is either a variable (if the name is set) or a fixed term.
+type sparqlNode struct {
+	variable string
+	term     Term
+}
+
+// sparqlOrder is an ORDER BY condition.
+type sparqlOrder struct {
+	expr       sparqlExpr
+	descending bool
+}
+
+// sparqlExpr is an expression of a FILTER or ORDER BY condition.
+type sparqlExpr interface{}
+
+// sparqlVarExpr is a variable in an expression.
+type sparqlVarExpr string
+
+// sparqlTermExpr is a constant term in an expression.
+type sparqlTermExpr Term
+
+// sparqlCallExpr is an operator or function call (operators are named by their symbol, functions by their upper case name).
+type sparqlCallExpr struct {
+	name string
+	args []sparqlExpr
+}
+
+// ****************
+// * SPARQL Lexer *
+// ****************
+
+// Kinds of SPARQL tokens.
+const (
+	sparqlTokenEOF = iota
+	sparqlTokenIRI
+	sparqlTokenPName
+	sparqlTokenVar
+	sparqlTokenString
+	sparqlTokenLang
+	sparqlTokenNumber
+	sparqlTokenBlank
+	sparqlTokenName
+	sparqlTokenPunct
+)
+
+// sparqlToken is a token of a SPARQL query. The text of IRIs, variables, strings, language tags and blank nodes is stored without
+// their delimiters (and strings are unescaped).
+type sparqlToken struct {
+	kind int
+	text string
+	pos  int
+}
+
+// lexSPARQL splits the query into tokens.
+func lexSPARQL(query string) ([]sparqlToken, error) {
+	tokens := []sparqlToken{}
+	rs := []rune(query)
+	isName := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.'
+	}
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '#':
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+			continue
+		case r == '<':
+			// Distinguish IRIs from the less-than operator
+			j := i + 1
+			for j < len(rs) && rs[j] != '>' && !unicode.IsSpace(rs[j]) && !strings.ContainsRune("<\"{}|^`", rs[j]) {
+				j++
+			}
+			if j < len(rs) && rs[j] == '>' && j > i+1 && rs[i+1] != '=' {
+				tokens = append(tokens, sparqlToken{kind: sparqlTokenIRI, text: string(rs[i+1 : j]), pos: start})
+				i = j + 1
+				continue
+			}
+			if i+1 < len(rs) && rs[i+1] == '=' {
+				tokens = append(tokens, sparqlToken{kind: sparqlTokenPunct, text: "<=", pos: start})
+				i += 2
+				continue
+			}
+			tokens = append(tokens, sparqlToken{kind: sparqlTokenPunct, text: "<", pos: start})
+			i++
+		case (r == '?' || r == '$') && i+1 < len(rs) && (unicode.IsLetter(rs[i+1]) || unicode.IsDigit(rs[i+1]) || rs[i+1] == '_'):
+			j := i + 1
+			for j < len(rs) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j]) || rs[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, sparqlToken{kind: sparqlTokenVar, text: string(rs[i+1 : j]), pos: start})
+			i = j
+		case r == '"' || r == '\'':
+			value, end, err := lexSPARQLString(rs, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, sparqlToken{kind: sparqlTokenString, text: value, pos: start})
+			i = end
+		case r == '@':
+			j := i + 1
+			for j < len(rs) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j]) || rs[j] == '-') {
+				j++
+			}
+			if j == i+1 {
+				return nil, fmt.Errorf("Invalid language tag at position %d", start)
+			}
+			tokens = append(tokens, sparqlToken{kind: sparqlTokenLang, text: string(rs[i+1 : j]), pos: start})
+			i = j
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(rs) && unicode.IsDigit(rs[j]) {
+				j++
+			}
+			if j+1 < len(rs) && rs[j] == '.' && unicode.IsDigit(rs[j+1]) {
+				j++
+				for j < len(rs) && unicode.IsDigit(rs[j]) {
+					j++
+				}
+			}
+			if j < len(rs) && (rs[j] == 'e' || rs[j] == 'E') {
+				k := j + 1
+				if k < len(rs) && (rs[k] == '+' || rs[k] == '-') {
+					k++
+				}
+				if k < len(rs) && unicode.IsDigit(rs[k]) {
+					for k < len(rs) && unicode.IsDigit(rs[k]) {
+						k++
+					}
+					j = k
+				}
+			}
+			tokens = append(tokens, sparqlToken{kind: sparqlTokenNumber, text: string(rs[i:j]), pos: start})
+			i = j
+		case r == '_' && i+1 < len(rs) && rs[i+1] == ':':
+			j := i + 2
+			for j < len(rs) && isName(rs[j]) {
+				j++
+			}
+			for j > i+2 && rs[j-1] == '.' {
+				j--
+			}
+			tokens = append(tokens, sparqlToken{kind: sparqlTokenBlank, text: string(rs[i+2 : j]), pos: start})
+			i = j
+		case unicode.IsLetter(r) || r == ':':
+			// Keyword, function name or prefixed name
+			j := i
+			for j < len(rs) && isName(rs[j]) {
+				j++
+			}
+			kind := sparqlTokenName
+			if j < len(rs) && rs[j] == ':' {
+				kind = sparqlTokenPName
+				j++
+				for j < len(rs) && (isName(rs[j]) || rs[j] == ':' || rs[j] == '%') {
+					j++
+				}
+			}
+			for j > i && rs[j-1] == '.' {
+				j--
+			}
+			tokens = append(tokens, sparqlToken{kind: kind, text: string(rs[i:j]), pos: start})
+			i = j
+		default:
+			// Punctuation and operators
+			text := string(r)
+			if i+1 < len(rs) {
+				if two := string(rs[i : i+2]); two == "!=" || two == ">=" || two == "&&" || two == "||" || two == "^^" {
+					text = two
+				}
+			}
+			if !strings.Contains("{}(),;.*=!<>&|+-/^[]?", string(r)) || text == "&" {
+				return nil, fmt.Errorf("Unexpected character '%c' at position %d", r, start)
+			}
+			tokens = append(tokens, sparqlToken{kind: sparqlTokenPunct, text: text, pos: start})
+			i += len([]rune(text))
+		}
+	}
+	return append(tokens, sparqlToken{kind: sparqlTokenEOF, pos: len(rs)}), nil
+}
+
+// lexSPARQLString reads the (possibly long) string literal starting at the given position and returns its unescaped value and the
+// position after the literal.
+func lexSPARQLString(rs []rune, start int) (string, int, error) {
+	quote := rs[start]
+	long := start+2 < len(rs) && rs[start+1] == quote && rs[start+2] == quote
+	i := start + 1
+	if long {
+		i = start + 3
+	}
+	var sb strings.Builder
+	for i < len(rs) {
+		r := rs[i]
+		switch {
+		case r == '\\' && i+1 < len(rs):
+			i++
+			switch rs[i] {
+			case 't':
+				sb.WriteRune('\t')
+			case 'n':
+				sb.WriteRune('\n')
+			case 'r':
+				sb.WriteRune('\r')
+			case 'b':
+				sb.WriteRune('\b')
+			case 'f':
+				sb.WriteRune('\f')
+			case 'u', 'U':
+				n := 4
+				if rs[i] == 'U' {
+					n = 8
+				}
+				if i+n >= len(rs) {
+					return "", 0, fmt.Errorf("Invalid escape sequence at position %d", i-1)
+				}
+				code, err := strconv.ParseUint(string(rs[i+1:i+1+n]), 16, 32)
+				if err != nil {
+					return "", 0, fmt.Errorf("Invalid escape sequence at position %d", i-1)
+				}
+				sb.WriteRune(rune(code))
+				i += n
+			default:
+				sb.WriteRune(rs[i])
+			}
+			i++
+		case long && r == quote && i+2 < len(rs) && rs[i+1] == quote && rs[i+2] == quote:
+			return sb.String(), i + 3, nil
+		case !long && r == quote:
+			return sb.String(), i + 1, nil
+		case !long && (r == '\n' || r == '\r'):
+			return "", 0, fmt.Errorf("Unterminated string at position %d", start)
+		default:
+			sb.WriteRune(r)
+			i++
+		}
+	}
+	return "", 0, fmt.Errorf("Unterminated string at position %d", start)
+}
+
+// *****************
+// * SPARQL Parser *
+// *****************
+
+// sparqlParser is a recursive descent parser for the supported subset of SPARQL.
+type sparqlParser struct {
+	tokens   []sparqlToken
+	pos      int
+	prefixes PrefixMap
+	base     string
+	anon     int
+	query    *sparqlQuery
+}
+
+// parseSPARQL parses the SELECT or ASK query.
+func parseSPARQL(query string) (*sparqlQuery, error) {
+	tokens, err := lexSPARQL(query)
+	if err != nil {
+		return nil, err
+	}
+	p := sparqlParser{tokens: tokens, prefixes: PrefixMap{}, query: &sparqlQuery{limit: -1}}
+	if err := p.parseQuery(); err != nil {
+		return nil, err
+	}
+	return p.query, nil
+}
+
+func (p *sparqlParser) peek() sparqlToken {
+	return p.tokens[p.pos]
+}
+
+func (p *sparqlParser) next() sparqlToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != sparqlTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// isKeyword returns true if the next token is the given keyword (case insensitive).
+func (p *sparqlParser) isKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == sparqlTokenName && strings.EqualFold(tok.text, keyword)
+}
+
+// isPunct returns true if the next token is the given punctuation.
+func (p *sparqlParser) isPunct(punct string) bool {
+	tok := p.peek()
+	return tok.kind == sparqlTokenPunct && tok.text == punct
+}
+
+func (p *sparqlParser) expectKeyword(keyword string) error {
+	if !p.isKeyword(keyword) {
+		return p.unexpected(keyword)
+	}
+	p.next()
+	return nil
+}
+
+func (p *sparqlParser) expectPunct(punct string) error {
+	if !p.isPunct(punct) {
+		return p.unexpected("'" + punct + "'")
+	}
+	p.next()
+	return nil
+}
+
+// unexpected returns an error for the next token.
+func (p *sparqlParser) unexpected(expected string) error {
+	tok := p.peek()
+	if tok.kind == sparqlTokenEOF {
+		return fmt.Errorf("Expected %s but reached end of query", expected)
+	}
+	return fmt.Errorf("Expected %s at position %d but found '%s'", expected, tok.pos, tok.text)
+}
+
+// addVar records the variable in order of appearance.
+func (p *sparqlParser) addVar(name string) {
+	for _, v := range p.query.seenVars {
+		if v == name {
+			return
+		}
+	}
+	p.query.seenVars = append(p.query.seenVars, name)
+}
+
+func (p *sparqlParser) parseQuery() error {
+	// Prologue
+	for {
+		if p.isKeyword("PREFIX") {
+			p.next()
+			pname := p.next()
+			if pname.kind != sparqlTokenPName || !strings.HasSuffix(pname.text, ":") {
+				return fmt.Errorf("Expected prefix declaration at position %d", pname.pos)
+			}
+			iri := p.next()
+			if iri.kind != sparqlTokenIRI {
+				return fmt.Errorf("Expected IRI of prefix '%s' at position %d", pname.text, iri.pos)
+			}
+			p.prefixes[strings.TrimSuffix(pname.text, ":")] = p.resolveIRI(iri.text)
+		} else if p.isKeyword("BASE") {
+			p.next()
+			iri := p.next()
+			if iri.kind != sparqlTokenIRI {
+				return fmt.Errorf("Expected base IRI at position %d", iri.pos)
+			}
+			p.base = iri.text
+		} else {
+			break
+		}
+	}
+
+	// Query form and projection
+	switch {
+	case p.isKeyword("ASK"):
+		p.next()
+		p.query.ask = true
+	case p.isKeyword("SELECT"):
+		p.next()
+		if p.isKeyword("DISTINCT") || p.isKeyword("REDUCED") {
+			p.next()
+			p.query.distinct = true
+		}
+		if p.isPunct("*") {
+			p.next()
+		} else {
+			p.query.vars = []string{}
+			for p.peek().kind == sparqlTokenVar {
+				name := p.next().text
+				p.addVar(name)
+				p.query.vars = append(p.query.vars, name)
+			}
+			if p.isPunct("(") {
+				return fmt.Errorf("%w: Aggregates and expressions in the projection at position %d", ErrUnsupportedQuery, p.peek().pos)
+			}
+			if len(p.query.vars) == 0 {
+				return p.unexpected("projected variables or '*'")
+			}
+		}
+	default:
+		return fmt.Errorf("%w: Only SELECT and ASK queries are supported", ErrUnsupportedQuery)
+	}
+	for p.isKeyword("FROM") {
+		// The store is the only graph of the dataset
+		p.next()
+		if p.isKeyword("NAMED") {
+			p.next()
+		}
+		if p.next().kind != sparqlTokenIRI {
+			return p.unexpected("IRI of graph")
+		}
+	}
+	if p.isKeyword("WHERE") {
+		p.next()
+	}
+	where, err := p.parseGroup()
+	if err != nil {
+		return err
+	}
+	p.query.where = where
+
+	// Solution modifiers
+	if p.isKeyword("GROUP") || p.isKeyword("HAVING") {
+		return fmt.Errorf("%w: Keyword '%s' at position %d", ErrUnsupportedQuery, p.peek().text, p.peek().pos)
+	}
+	if p.isKeyword("ORDER") {
+		p.next()
+		if err := p.expectKeyword("BY"); err != nil {
+			return err
+		}
+		for {
+			order := sparqlOrder{}
+			if p.isKeyword("ASC") || p.isKeyword("DESC") {
+				order.descending = p.isKeyword("DESC")
+				p.next()
+				if err := p.expectPunct("("); err != nil {
+					return err
+				}
+				if order.expr, err = p.parseExpr(); err != nil {
+					return err
+				}
+				if err := p.expectPunct(")"); err != nil {
+					return err
+				}
+			} else if p.peek().kind == sparqlTokenVar {
+				order.expr = sparqlVarExpr(p.next().text)
+			} else if p.isPunct("(") {
+				if order.expr, err = p.parsePrimary(); err != nil {
+					return err
+				}
+			} else {
+				break
+			}
+			p.query.orderBy = append(p.query.orderBy, order)
+		}
+		if len(p.query.orderBy) == 0 {
+			return p.unexpected("order condition")
+		}
+	}
+	for p.isKeyword("LIMIT") || p.isKeyword("OFFSET") {
+		isLimit := p.isKeyword("LIMIT")
+		p.next()
+		tok := p.next()
+		n, err := strconv.Atoi(tok.text)
+		if tok.kind != sparqlTokenNumber || err != nil || n < 0 {
+			return fmt.Errorf("Expected non-negative integer at position %d", tok.pos)
+		}
+		if isLimit {
+			p.query.limit = n
+		} else {
+			p.query.offset = n
+		}
+	}
+	if p.peek().kind != sparqlTokenEOF {
+		return p.unexpected("end of query")
+	}
+	return nil
+}
+
+// parseGroup parses a group graph pattern in curly braces.
+func (p *sparqlParser) parseGroup() (*sparqlGroup, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	group := &sparqlGroup{}
+	for !p.isPunct("}") {
+		switch {
+		case p.peek().kind == sparqlTokenEOF:
+			return nil, p.unexpected("'}'")
+		case p.isKeyword("FILTER"):
+			p.next()
+			var expr sparqlExpr
+			var err error
+			if p.isPunct("(") {
+				expr, err = p.parsePrimary()
+			} else {
+				expr, err = p.parseFunction()
+			}
+			if err != nil {
+				return nil, err
+			}
+			group.filters = append(group.filters, expr)
+		case p.isKeyword("OPTIONAL"):
+			p.next()
+			optional, err := p.parseGroup()
+			if err != nil {
+				return nil, err
+			}
+			group.patterns = append(group.patterns, sparqlOptional{group: optional})
+		case p.isPunct("{"):
+			sub, err := p.parseGroup()
+			if err != nil {
+				return nil, err
+			}
+			if !p.isKeyword("UNION") {
+				group.patterns = append(group.patterns, sub)
+				break
+			}
+			union := sparqlUnion{groups: []*sparqlGroup{sub}}
+			for p.isKeyword("UNION") {
+				p.next()
+				alt, err := p.parseGroup()
+				if err != nil {
+					return nil, err
+				}
+				union.groups = append(union.groups, alt)
+			}
+			group.patterns = append(group.patterns, union)
+		case p.isPunct("."):
+			p.next()
+		case p.peek().kind == sparqlTokenName && !p.isKeyword("a") && !p.isKeyword("true") && !p.isKeyword("false"):
+			return nil, fmt.Errorf("%w: Keyword '%s' at position %d", ErrUnsupportedQuery, p.peek().text, p.peek().pos)
+		default:
+			if err := p.parseTriples(group); err != nil {
+				return nil, err
+			}
+		}
+	}
+	p.next()
+	return group, nil
+}
+
+// parseTriples parses the triples of a subject with its predicate-object list (using `;` and `,` abbreviations).
+func (p *sparqlParser) parseTriples(group *sparqlGroup) error {
+	subj, err := p.parseNode()
+	if err != nil {
+		return err
+	}
+	for {
+		pred, err := p.parseVerb()
+		if err != nil {
+			return err
+		}
+		for {
+			obj, err := p.parseNode()
+			if err != nil {
+				return err
+			}
+			group.patterns = append(group.patterns, sparqlTriplePattern{subject: subj, predicate: pred, object: obj})
+			if !p.isPunct(",") {
+				break
+			}
+			p.next()
+		}
+		if !p.isPunct(";") {
+			break
+		}
+		for p.isPunct(";") {
+			p.next()
+		}
+		if p.isPunct(".") || p.isPunct("}") {
+			break
+		}
+	}
+	return nil
+}
+
+// parseVerb parses a predicate (including the `a` abbreviation for `rdf:type`). Property paths are not supported.
+func (p *sparqlParser) parseVerb() (sparqlNode, error) {
+	if p.isPunct("^") || p.isPunct("!") || p.isPunct("(") {
+		return sparqlNode{}, p.unsupportedPath()
+	}
+	var node sparqlNode
+	var err error
+	if p.isKeyword("a") && p.peek().text == "a" {
+		p.next()
+		node = sparqlNode{term: NewResourceTerm(RDFType)}
+	} else if node, err = p.parseNode(); err != nil {
+		return node, err
+	} else if node.variable == "" && !node.term.IsResource() {
+		return node, fmt.Errorf("Predicate '%s' is not a resource", node.term)
+	}
+	// A plus sign in front of a number is the sign of the object
+	if p.isPunct("*") || p.isPunct("?") || p.isPunct("/") || p.isPunct("|") ||
+		(p.isPunct("+") && p.tokens[p.pos+1].kind != sparqlTokenNumber) {
+		return node, p.unsupportedPath()
+	}
+	return node, nil
+}
+
+// unsupportedPath returns an error for the property path operator of the next token.
+func (p *sparqlParser) unsupportedPath() error {
+	return fmt.Errorf("%w: Property path operator '%s' at position %d", ErrUnsupportedQuery, p.peek().text, p.peek().pos)
+}
+
+// parseNode parses a variable, IRI, blank node or literal of a triple pattern.
+func (p *sparqlParser) parseNode() (sparqlNode, error) {
+	tok := p.peek()
+	switch {
+	case tok.kind == sparqlTokenVar:
+		p.next()
+		p.addVar(tok.text)
+		return sparqlNode{variable: tok.text}, nil
+	case tok.kind == sparqlTokenBlank:
+		// Blank nodes in patterns act as variables that are not projected
+		p.next()
+		return sparqlNode{variable: "_:" + tok.text}, nil
+	case p.isPunct("["):
+		p.next()
+		if err := p.expectPunct("]"); err != nil {
+			return sparqlNode{}, fmt.Errorf("%w: Blank node property lists are not supported", ErrUnsupportedQuery)
+		}
+		p.anon++
+		return sparqlNode{variable: fmt.Sprintf("_:anon%d", p.anon)}, nil
+	}
+	term, err := p.parseTerm()
+	return sparqlNode{term: term}, err
+}
+
+// parseTerm parses an IRI, prefixed name or literal into a term.
+func (p *sparqlParser) parseTerm() (Term, error) {
+	tok := p.next()
+	switch tok.kind {
+	case sparqlTokenIRI:
+		return NewResourceTerm(p.resolveIRI(tok.text)), nil
+	case sparqlTokenPName:
+		pos := strings.Index(tok.text, ":")
+		ns, ok := p.prefixes[tok.text[:pos]]
+		if !ok {
+			return "", fmt.Errorf("Undefined prefix '%s' at position %d", tok.text[:pos], tok.pos)
+		}
+		return NewResourceTerm(ns + tok.text[pos+1:]), nil
+	case sparqlTokenString:
+		if p.peek().kind == sparqlTokenLang {
+			return newSPARQLLiteral(tok.text, p.next().text, ""), nil
+		}
+		if p.isPunct("^^") {
+			p.next()
+			datatype, err := p.parseTerm()
+			if err != nil {
+				return "", err
+			}
+			if !datatype.IsResource() {
+				return "", fmt.Errorf("Datatype '%s' is not a resource", datatype)
+			}
+			return newSPARQLLiteral(tok.text, "", datatype.Value()), nil
+		}
+		return newSPARQLLiteral(tok.text, "", ""), nil
+	case sparqlTokenNumber:
+		return newSPARQLNumber(tok.text), nil
+	case sparqlTokenPunct:
+		if (tok.text == "-" || tok.text == "+") && p.peek().kind == sparqlTokenNumber {
+			num := p.next().text
+			if tok.text == "-" {
+				num = "-" + num
+			}
+			return newSPARQLNumber(num), nil
+		}
+	case sparqlTokenName:
+		if strings.EqualFold(tok.text, "true") || strings.EqualFold(tok.text, "false") {
+			return newSPARQLLiteral(strings.ToLower(tok.text), "", XSDBoolean), nil
+		}
+	}
+	p.pos--
+	return "", p.unexpected("term")
+}
+
+// resolveIRI resolves the (possibly relative) IRI against the base IRI.
+func (p *sparqlParser) resolveIRI(iri string) string {
+	if p.base == "" || strings.Contains(iri, ":") {
+		return iri
+	}
+	return resolveURI(p.base, iri)
+}
+
+// parseExpr parses an expression with the usual precedence of logical, relational and arithmetic operators.
+func (p *sparqlParser) parseExpr() (sparqlExpr, error) {
+	return p.parseBinary(0)
+}
+
+// sparqlOperators lists the binary operators by precedence level.
+var sparqlOperators = [][]string{{"||"}, {"&&"}, {"=", "!=", "<", ">", "<=", ">="}, {"+", "-"}, {"*", "/"}}
+
+func (p *sparqlParser) parseBinary(level int) (sparqlExpr, error) {
+	if level == len(sparqlOperators) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := ""
+		for _, candidate := range sparqlOperators[level] {
+			if p.isPunct(candidate) {
+				op = candidate
+			}
+		}
+		if op == "" {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = sparqlCallExpr{name: op, args: []sparqlExpr{left, right}}
+	}
+}
+
+func (p *sparqlParser) parseUnary() (sparqlExpr, error) {
+	if p.isPunct("!") || p.isPunct("-") || p.isPunct("+") {
+		op := p.next().text
+		arg, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if op == "+" {
+			return arg, nil
+		}
+		return sparqlCallExpr{name: "u" + op, args: []sparqlExpr{arg}}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *sparqlParser) parsePrimary() (sparqlExpr, error) {
+	tok := p.peek()
+	switch {
+	case p.isPunct("("):
+		p.next()
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return expr, p.expectPunct(")")
+	case tok.kind == sparqlTokenVar:
+		p.next()
+		p.addVar(tok.text)
+		return sparqlVarExpr(tok.text), nil
+	case tok.kind == sparqlTokenName && !strings.EqualFold(tok.text, "true") && !strings.EqualFold(tok.text, "false"):
+		return p.parseFunction()
+	}
+	term, err := p.parseTerm()
+	return sparqlTermExpr(term), err
+}
+
+// sparqlFunctions lists the supported functions with their minimum and maximum number of arguments.
+var sparqlFunctions = map[string][2]int{
+	"BOUND": {1, 1}, "ISIRI": {1, 1}, "ISURI": {1, 1}, "ISLITERAL": {1, 1}, "ISBLANK": {1, 1}, "ISNUMERIC": {1, 1},
+	"STR": {1, 1}, "LANG": {1, 1}, "DATATYPE": {1, 1}, "LANGMATCHES": {2, 2}, "SAMETERM": {2, 2}, "REGEX": {2, 3},
+	"CONTAINS": {2, 2}, "STRSTARTS": {2, 2}, "STRENDS": {2, 2}, "STRLEN": {1, 1}, "LCASE": {1, 1}, "UCASE": {1, 1},
+}
+
+// parseFunction parses a call of a built-in function.
+func (p *sparqlParser) parseFunction() (sparqlExpr, error) {
+	tok := p.next()
+	name := strings.ToUpper(tok.text)
+	arity, ok := sparqlFunctions[name]
+	if tok.kind != sparqlTokenName || !ok {
+		return nil, fmt.Errorf("%w: Function '%s' at position %d", ErrUnsupportedQuery, tok.text, tok.pos)
+	}
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	call := sparqlCallExpr{name: name}
+	for !p.isPunct(")") {
+		if len(call.args) > 0 {
+			if err := p.expectPunct(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+	}
+	p.next()
+	if len(call.args) < arity[0] || len(call.args) > arity[1] {
+		return nil, fmt.Errorf("Wrong number of arguments for function %s at position %d", name, tok.pos)
+	}
+	if _, isVar := call.args[0].(sparqlVarExpr); name == "BOUND" && !isVar {
+		return nil, fmt.Errorf("Function BOUND requires a variable at position %d", tok.pos)
+	}
+	return call, nil
+}
+
+// newSPARQLLiteral creates a literal term with the value escaped like in the stored N-Triples terms. Simple literals and literals
+// of type `xsd:string` are both represented as simple literals, since they are equal in RDF 1.1.
+func newSPARQLLiteral(value, lang, datatype string) Term {
+	if datatype == XSDString {
+		datatype = ""
+	}
+	return NewLiteralTerm(escapeLiteral(value), lang, datatype)
+}
+
+// newSPARQLNumber creates a numeric literal term with the datatype of the SPARQL number syntax.
+func newSPARQLNumber(num string) Term {
+	switch {
+	case strings.ContainsAny(num, "eE"):
+		return newSPARQLLiteral(num, "", XSDDouble)
+	case strings.Contains(num, "."):
+		return newSPARQLLiteral(num, "", XSDDecimal)
+	}
+	return newSPARQLLiteral(num, "", XSDInteger)
+}
+
+// literalEscaper escapes literal values like in N-Triples terms.
+var literalEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// literalUnescaper reverts the escaping of `literalEscaper`.
+var literalUnescaper = strings.NewReplacer(`\\`, `\`, `\"`, `"`, `\n`, "\n", `\r`, "\r", `\t`, "\t")
+
+// escapeLiteral escapes the literal value for use in N-Triples terms.
+func escapeLiteral(value string) string {
+	return literalEscaper.Replace(value)
+}
+
+// unescapeLiteral returns the lexical form of the escaped literal value of an N-Triples term.
+func unescapeLiteral(value string) string {
+	return literalUnescaper.Replace(value)
+}
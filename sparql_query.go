@@ -0,0 +1,728 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Querier evaluates SPARQL queries against a graph. It is implemented by graph stores that can evaluate queries natively.
+type Querier interface {
+	// Query should evaluate the SPARQL query and return its results.
+	Query(sparql string) (JSONResultSet, error)
+}
+
+// QueryGraph evaluates the SPARQL SELECT or ASK query against the store. Stores that implement `Querier` evaluate the query
+// themselves (e.g. Blazegraph stores pass it to the database), for all other stores all triples are retrieved and evaluated with the
+// in-process engine (see `MemoryStore.Query`).
+func QueryGraph(store GraphStore, sparql string) (JSONResultSet, error) {
+	if querier, ok := store.(Querier); ok {
+		return querier.Query(sparql)
+	}
+	return queryTriples(store, sparql)
+}
+
+// Query evaluates the SPARQL SELECT or ASK query against the store and returns the results in the same format as SPARQL endpoints
+// (see `BlazegraphEndpoint.DoSparqlJSONQuery`). The in-process engine supports basic graph patterns, FILTER (with the usual operators
+// and the common string and type functions), OPTIONAL, UNION, DISTINCT, ORDER BY and LIMIT/OFFSET. Errors with `ErrInvalidQuery` if the
+// query cannot be parsed and with `ErrUnsupportedQuery` if it uses other SPARQL features.
+func (store *MemoryStore) Query(sparql string) (JSONResultSet, error) {
+	return queryTriples(store, sparql)
+}
+
+// Query evaluates the SPARQL query in the database and returns its results. The dataset of the query is restricted to the graph of
+// the store, so FROM clauses and GRAPH patterns cannot read other graphs of the namespace. Blank nodes of the store are returned as
+// blank nodes. Errors with `ErrInvalidQuery` if the database rejects the query.
+func (store *BlazegraphStore) Query(sparql string) (JSONResultSet, error) {
+	resSet, code, err := store.endpoint.doSparqlJSONQuery(store.namespace, sparql, store.uri)
+	if err != nil {
+		return resSet, store.wrapErr("Query", nil, sparql, err)
+	}
+	if code == http.StatusBadRequest {
+		return resSet, store.wrapErr("Query", nil, sparql, fmt.Errorf("%w (HTTP %d)", ErrInvalidQuery, code))
+	}
+	if code != http.StatusOK {
+		return resSet, store.wrapErr("Query", nil, sparql, fmt.Errorf("Received unexpected status code from SPARQL query (HTTP %d)", code))
+	}
+	// Convert the stand-ins of blank nodes back
+	for _, binding := range resSet.Results.Bindings {
+		for name, value := range binding {
+			if value.Type == "uri" && strings.HasPrefix(value.Value, blazegraphBlankNodePrefix) {
+				binding[name] = JSONResultSetBinding{Type: "bnode", Value: strings.TrimPrefix(value.Value, blazegraphBlankNodePrefix)}
+			}
+		}
+	}
+	return resSet, nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrInvalidQuery is raised when a SPARQL query cannot be parsed.
+var ErrInvalidQuery error = errors.New("The SPARQL query is invalid")
+
+// ErrUnsupportedQuery is raised when a SPARQL query uses features that are not supported by the in-process query engine.
+var ErrUnsupportedQuery error = errors.New("The SPARQL query uses unsupported features")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// queryTriples evaluates the SPARQL query against all triples of the store with the in-process engine.
+func queryTriples(store GraphStore, sparql string) (JSONResultSet, error) {
+	resSet := JSONResultSet{}
+	query, err := parseSPARQL(sparql)
+	if err != nil {
+		if errors.Is(err, ErrUnsupportedQuery) {
+			return resSet, err
+		}
+		return resSet, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+	}
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return resSet, err
+	}
+	data := newSPARQLDataset(trps)
+	solutions := data.evalGroup(query.where, []sparqlSolution{{}})
+
+	// Apply solution modifiers
+	if query.ask {
+		resSet.Boolean = len(solutions) > 0
+		return resSet, nil
+	}
+	if len(query.orderBy) > 0 {
+		sort.SliceStable(solutions, func(i, j int) bool {
+			for _, order := range query.orderBy {
+				a, _ := evalSPARQLExpr(order.expr, solutions[i])
+				b, _ := evalSPARQLExpr(order.expr, solutions[j])
+				if c := compareSPARQLOrder(a, b); c != 0 {
+					return (c < 0) != order.descending
+				}
+			}
+			return false
+		})
+	}
+	vars := query.vars
+	if vars == nil {
+		for _, v := range query.seenVars {
+			if !strings.HasPrefix(v, "_:") {
+				vars = append(vars, v)
+			}
+		}
+	}
+	resSet.Head.Vars = vars
+	resSet.Results.Bindings = []map[string]JSONResultSetBinding{}
+	seen := map[string]bool{}
+	skipped := 0
+	for _, sol := range solutions {
+		if query.limit >= 0 && len(resSet.Results.Bindings) >= query.limit {
+			break
+		}
+		if query.distinct {
+			key := ""
+			for _, v := range vars {
+				key += string(sol[v]) + "\x00"
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		if skipped < query.offset {
+			skipped++
+			continue
+		}
+		binding := map[string]JSONResultSetBinding{}
+		for _, v := range vars {
+			if t, ok := sol[v]; ok {
+				binding[v] = term2Binding(t)
+			}
+		}
+		resSet.Results.Bindings = append(resSet.Results.Bindings, binding)
+	}
+	return resSet, nil
+}
+
+// sparqlSolution maps variable names to their bound terms.
+type sparqlSolution map[string]Term
+
+// sparqlDataset indexes the triples of a store by subject, predicate and object for pattern matching.
+type sparqlDataset struct {
+	trps        []Triple
+	bySubject   map[Term][]int
+	byPredicate map[Term][]int
+	byObject    map[Term][]int
+}
+
+// newSPARQLDataset creates the indexes for the triples. Literals of type `xsd:string` are normalized to simple literals.
+func newSPARQLDataset(trps []Triple) *sparqlDataset {
+	data := sparqlDataset{
+		trps:        make([]Triple, len(trps)),
+		bySubject:   map[Term][]int{},
+		byPredicate: map[Term][]int{},
+		byObject:    map[Term][]int{},
+	}
+	for i, trp := range trps {
+		trp.Object = normalizeSPARQLTerm(trp.Object)
+		data.trps[i] = trp
+		data.bySubject[trp.Subject] = append(data.bySubject[trp.Subject], i)
+		data.byPredicate[trp.Predicate] = append(data.byPredicate[trp.Predicate], i)
+		data.byObject[trp.Object] = append(data.byObject[trp.Object], i)
+	}
+	return &data
+}
+
+// evalGroup evaluates the group pattern for each of the input solutions.
+func (data *sparqlDataset) evalGroup(group *sparqlGroup, solutions []sparqlSolution) []sparqlSolution {
+	for _, pattern := range group.patterns {
+		switch pattern := pattern.(type) {
+		case sparqlTriplePattern:
+			solutions = data.evalTriplePattern(pattern, solutions)
+		case *sparqlGroup:
+			solutions = data.evalGroup(pattern, solutions)
+		case sparqlOptional:
+			extended := []sparqlSolution{}
+			for _, sol := range solutions {
+				if matches := data.evalGroup(pattern.group, []sparqlSolution{sol}); len(matches) > 0 {
+					extended = append(extended, matches...)
+				} else {
+					extended = append(extended, sol)
+				}
+			}
+			solutions = extended
+		case sparqlUnion:
+			combined := []sparqlSolution{}
+			for _, alt := range pattern.groups {
+				combined = append(combined, data.evalGroup(alt, solutions)...)
+			}
+			solutions = combined
+		}
+	}
+	// Filters apply to the whole group
+	if len(group.filters) > 0 {
+		filtered := []sparqlSolution{}
+		for _, sol := range solutions {
+			keep := true
+			for _, filter := range group.filters {
+				value, err := evalSPARQLExpr(filter, sol)
+				if err != nil {
+					keep = false
+					break
+				}
+				if ebv, err := effectiveBooleanValue(value); err != nil || !ebv {
+					keep = false
+					break
+				}
+			}
+			if keep {
+				filtered = append(filtered, sol)
+			}
+		}
+		solutions = filtered
+	}
+	return solutions
+}
+
+// evalTriplePattern extends each solution with the matches of the triple pattern.
+func (data *sparqlDataset) evalTriplePattern(pattern sparqlTriplePattern, solutions []sparqlSolution) []sparqlSolution {
+	extended := []sparqlSolution{}
+	for _, sol := range solutions {
+		s, sBound := pattern.subject.resolve(sol)
+		p, pBound := pattern.predicate.resolve(sol)
+		o, oBound := pattern.object.resolve(sol)
+		// Select the smallest index for the bound positions
+		var candidates []int
+		all := true
+		for _, idx := range []struct {
+			bound bool
+			index map[Term][]int
+			term  Term
+		}{{sBound, data.bySubject, s}, {pBound, data.byPredicate, p}, {oBound, data.byObject, o}} {
+			if idx.bound && (all || len(idx.index[idx.term]) < len(candidates)) {
+				candidates, all = idx.index[idx.term], false
+			}
+		}
+		if all {
+			candidates = make([]int, len(data.trps))
+			for i := range candidates {
+				candidates[i] = i
+			}
+		}
+		for _, i := range candidates {
+			trp := data.trps[i]
+			if (sBound && trp.Subject != s) || (pBound && trp.Predicate != p) || (oBound && trp.Object != o) {
+				continue
+			}
+			match := make(sparqlSolution, len(sol)+3)
+			for k, v := range sol {
+				match[k] = v
+			}
+			if !match.bind(pattern.subject, trp.Subject) || !match.bind(pattern.predicate, trp.Predicate) || !match.bind(pattern.object, trp.Object) {
+				continue
+			}
+			extended = append(extended, match)
+		}
+	}
+	return extended
+}
+
+// resolve returns the term of the node in the solution and whether it is bound.
+func (node sparqlNode) resolve(sol sparqlSolution) (Term, bool) {
+	if node.variable == "" {
+		return normalizeSPARQLTerm(node.term), true
+	}
+	t, ok := sol[node.variable]
+	return t, ok
+}
+
+// bind binds the variable of the node to the term, unless it is bound to another term already (e.g. `?x ?p ?x`).
+func (sol sparqlSolution) bind(node sparqlNode, t Term) bool {
+	if node.variable == "" {
+		return true
+	}
+	if bound, ok := sol[node.variable]; ok {
+		return bound == t
+	}
+	sol[node.variable] = t
+	return true
+}
+
+// normalizeSPARQLTerm converts literals of type `xsd:string` into simple literals.
+func normalizeSPARQLTerm(t Term) Term {
+	if t.IsLiteral() && t.Datatype() == XSDString {
+		return NewLiteralTerm(t.Value(), "", "")
+	}
+	return t
+}
+
+// term2Binding converts the term into a binding of a JSON result set.
+func term2Binding(t Term) JSONResultSetBinding {
+	switch {
+	case t.IsResource():
+		return JSONResultSetBinding{Type: "uri", Value: t.Value()}
+	case t.IsBlankNode():
+		return JSONResultSetBinding{Type: "bnode", Value: t.Value()}
+	}
+	return JSONResultSetBinding{Type: "literal", Value: unescapeLiteral(t.Value()), Lang: t.Language(), DataType: t.Datatype()}
+}
+
+// errSPARQLType is the type error of SPARQL expressions, e.g. for unbound variables or operators applied to incompatible terms.
+var errSPARQLType = errors.New("Type error")
+
+var (
+	sparqlTrue  = newSPARQLLiteral("true", "", XSDBoolean)
+	sparqlFalse = newSPARQLLiteral("false", "", XSDBoolean)
+)
+
+// sparqlBool converts the boolean into a literal.
+func sparqlBool(b bool) Term {
+	if b {
+		return sparqlTrue
+	}
+	return sparqlFalse
+}
+
+// evalSPARQLExpr evaluates the expression for the solution.
+func evalSPARQLExpr(expr sparqlExpr, sol sparqlSolution) (Term, error) {
+	switch expr := expr.(type) {
+	case sparqlVarExpr:
+		if t, ok := sol[string(expr)]; ok {
+			return t, nil
+		}
+		return "", errSPARQLType
+	case sparqlTermExpr:
+		return normalizeSPARQLTerm(Term(expr)), nil
+	case sparqlCallExpr:
+		return evalSPARQLCall(expr, sol)
+	}
+	return "", errSPARQLType
+}
+
+// evalSPARQLCall evaluates the operator or function call for the solution.
+func evalSPARQLCall(call sparqlCallExpr, sol sparqlSolution) (Term, error) {
+	// Operators and functions with special handling of errors and unbound variables
+	switch call.name {
+	case "BOUND":
+		_, ok := sol[string(call.args[0].(sparqlVarExpr))]
+		return sparqlBool(ok), nil
+	case "||", "&&":
+		left, lerr := evalSPARQLBool(call.args[0], sol)
+		right, rerr := evalSPARQLBool(call.args[1], sol)
+		if call.name == "||" {
+			if (lerr == nil && left) || (rerr == nil && right) {
+				return sparqlTrue, nil
+			}
+		} else if (lerr == nil && !left) || (rerr == nil && !right) {
+			return sparqlFalse, nil
+		}
+		if lerr != nil || rerr != nil {
+			return "", errSPARQLType
+		}
+		return sparqlBool(call.name == "&&"), nil
+	}
+
+	args := make([]Term, len(call.args))
+	for i, arg := range call.args {
+		t, err := evalSPARQLExpr(arg, sol)
+		if err != nil {
+			return "", err
+		}
+		args[i] = t
+	}
+	switch call.name {
+	case "u!":
+		b, err := effectiveBooleanValue(args[0])
+		return sparqlBool(!b), err
+	case "u-":
+		n, ok := sparqlNumeric(args[0])
+		if !ok {
+			return "", errSPARQLType
+		}
+		if r, ok := sparqlDecimal(args[0]); ok {
+			return formatSPARQLDecimal(r.Neg(r), args[0].Datatype()), nil
+		}
+		return formatSPARQLNumber(-n, args[0].Datatype()), nil
+	case "+", "-", "*", "/":
+		a, ok1 := sparqlNumeric(args[0])
+		b, ok2 := sparqlNumeric(args[1])
+		if !ok1 || !ok2 {
+			return "", errSPARQLType
+		}
+		datatype := XSDInteger
+		if args[0].Datatype() != XSDInteger || args[1].Datatype() != XSDInteger || call.name == "/" {
+			datatype = XSDDouble
+			if args[0].Datatype() != XSDDouble && args[1].Datatype() != XSDDouble && args[0].Datatype() != XSDFloat && args[1].Datatype() != XSDFloat {
+				datatype = XSDDecimal
+			}
+		}
+		if datatype != XSDDouble {
+			return evalSPARQLDecimalArithmetic(call.name, args[0], args[1], datatype)
+		}
+		switch call.name {
+		case "+":
+			return formatSPARQLNumber(a+b, datatype), nil
+		case "-":
+			return formatSPARQLNumber(a-b, datatype), nil
+		case "*":
+			return formatSPARQLNumber(a*b, datatype), nil
+		}
+		if b == 0 {
+			return "", errSPARQLType
+		}
+		return formatSPARQLNumber(a/b, datatype), nil
+	case "=", "!=":
+		c, err := compareSPARQLValues(args[0], args[1])
+		if err != nil {
+			// Terms that cannot be compared by value are equal only if they are the same term
+			if args[0].IsLiteral() && args[1].IsLiteral() && args[0] != args[1] && (isSPARQLComparable(args[0]) || isSPARQLComparable(args[1])) {
+				return "", errSPARQLType
+			}
+			return sparqlBool((args[0] == args[1]) == (call.name == "=")), nil
+		}
+		return sparqlBool((c == 0) == (call.name == "=")), nil
+	case "<", ">", "<=", ">=":
+		c, err := compareSPARQLValues(args[0], args[1])
+		if err != nil {
+			return "", err
+		}
+		switch call.name {
+		case "<":
+			return sparqlBool(c < 0), nil
+		case ">":
+			return sparqlBool(c > 0), nil
+		case "<=":
+			return sparqlBool(c <= 0), nil
+		}
+		return sparqlBool(c >= 0), nil
+	case "ISIRI", "ISURI":
+		return sparqlBool(args[0].IsResource()), nil
+	case "ISLITERAL":
+		return sparqlBool(args[0].IsLiteral()), nil
+	case "ISBLANK":
+		return sparqlBool(args[0].IsBlankNode()), nil
+	case "ISNUMERIC":
+		_, ok := sparqlNumeric(args[0])
+		return sparqlBool(ok), nil
+	case "SAMETERM":
+		return sparqlBool(args[0] == args[1]), nil
+	case "STR":
+		if args[0].IsBlankNode() {
+			return "", errSPARQLType
+		}
+		return NewLiteralTerm(args[0].Value(), "", ""), nil
+	case "LANG":
+		if !args[0].IsLiteral() {
+			return "", errSPARQLType
+		}
+		return NewLiteralTerm(args[0].Language(), "", ""), nil
+	case "DATATYPE":
+		if !args[0].IsLiteral() {
+			return "", errSPARQLType
+		}
+		switch {
+		case args[0].Datatype() != "":
+			return NewResourceTerm(args[0].Datatype()), nil
+		case args[0].Language() != "":
+			return NewResourceTerm(RDFLangString), nil
+		}
+		return NewResourceTerm(XSDString), nil
+	case "LANGMATCHES":
+		tag, rng := strings.ToLower(args[0].Value()), strings.ToLower(args[1].Value())
+		if rng == "*" {
+			return sparqlBool(tag != ""), nil
+		}
+		return sparqlBool(tag == rng || strings.HasPrefix(tag, rng+"-")), nil
+	case "STRLEN", "LCASE", "UCASE":
+		s, ok := sparqlString(args[0])
+		if !ok {
+			return "", errSPARQLType
+		}
+		switch call.name {
+		case "STRLEN":
+			return newSPARQLLiteral(strconv.Itoa(len([]rune(s))), "", XSDInteger), nil
+		case "LCASE":
+			return newSPARQLLiteral(strings.ToLower(s), args[0].Language(), ""), nil
+		}
+		return newSPARQLLiteral(strings.ToUpper(s), args[0].Language(), ""), nil
+	case "CONTAINS", "STRSTARTS", "STRENDS", "REGEX":
+		s, ok1 := sparqlString(args[0])
+		arg, ok2 := sparqlString(args[1])
+		if !ok1 || !ok2 {
+			return "", errSPARQLType
+		}
+		switch call.name {
+		case "CONTAINS":
+			return sparqlBool(strings.Contains(s, arg)), nil
+		case "STRSTARTS":
+			return sparqlBool(strings.HasPrefix(s, arg)), nil
+		case "STRENDS":
+			return sparqlBool(strings.HasSuffix(s, arg)), nil
+		}
+		if len(args) == 3 {
+			flags, ok := sparqlString(args[2])
+			if !ok || strings.Trim(flags, "ims") != "" {
+				return "", errSPARQLType
+			}
+			if flags != "" {
+				arg = "(?" + flags + ")" + arg
+			}
+		}
+		re, err := regexp.Compile(arg)
+		if err != nil {
+			return "", errSPARQLType
+		}
+		return sparqlBool(re.MatchString(s)), nil
+	}
+	return "", errSPARQLType
+}
+
+// evalSPARQLBool evaluates the expression to its effective boolean value.
+func evalSPARQLBool(expr sparqlExpr, sol sparqlSolution) (bool, error) {
+	t, err := evalSPARQLExpr(expr, sol)
+	if err != nil {
+		return false, err
+	}
+	return effectiveBooleanValue(t)
+}
+
+// effectiveBooleanValue returns the effective boolean value of the term as defined by SPARQL.
+func effectiveBooleanValue(t Term) (bool, error) {
+	if !t.IsLiteral() {
+		return false, errSPARQLType
+	}
+	if t.Datatype() == XSDBoolean {
+		return t.Value() == "true" || t.Value() == "1", nil
+	}
+	if n, ok := sparqlNumeric(t); ok {
+		return n != 0 && !math.IsNaN(n), nil
+	}
+	if s, ok := sparqlString(t); ok {
+		return s != "", nil
+	}
+	return false, errSPARQLType
+}
+
+// sparqlNumeric returns the value of numeric literals.
+func sparqlNumeric(t Term) (float64, bool) {
+	if !t.IsLiteral() {
+		return 0, false
+	}
+	if !xsdNumericDatatypes[t.Datatype()] {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(t.Value(), 64)
+	return n, err == nil
+}
+
+// sparqlDecimal returns the exact value of integer and decimal literals, i.e. of all numeric literals except xsd:double and xsd:float.
+func sparqlDecimal(t Term) (*big.Rat, bool) {
+	if !t.IsLiteral() || !xsdNumericDatatypes[t.Datatype()] || t.Datatype() == XSDDouble || t.Datatype() == XSDFloat {
+		return nil, false
+	}
+	return new(big.Rat).SetString(t.Value())
+}
+
+// evalSPARQLDecimalArithmetic evaluates the arithmetic operator on integer and decimal literals without loss of precision.
+func evalSPARQLDecimalArithmetic(op string, x, y Term, datatype string) (Term, error) {
+	a, ok1 := sparqlDecimal(x)
+	b, ok2 := sparqlDecimal(y)
+	if !ok1 || !ok2 {
+		return "", errSPARQLType
+	}
+	switch op {
+	case "+":
+		return formatSPARQLDecimal(a.Add(a, b), datatype), nil
+	case "-":
+		return formatSPARQLDecimal(a.Sub(a, b), datatype), nil
+	case "*":
+		return formatSPARQLDecimal(a.Mul(a, b), datatype), nil
+	}
+	if b.Sign() == 0 {
+		return "", errSPARQLType
+	}
+	return formatSPARQLDecimal(a.Quo(a, b), datatype), nil
+}
+
+// xsdNumericDatatypes contains the numeric XML Schema datatypes including the types derived from xsd:integer.
+var xsdNumericDatatypes = func() map[string]bool {
+	datatypes := map[string]bool{}
+	for _, name := range []string{
+		"integer", "decimal", "double", "float", "byte", "short", "int", "long", "unsignedByte", "unsignedShort", "unsignedInt",
+		"unsignedLong", "positiveInteger", "nonNegativeInteger", "negativeInteger", "nonPositiveInteger",
+	} {
+		datatypes["http://www.w3.org/2001/XMLSchema#"+name] = true
+	}
+	return datatypes
+}()
+
+// parseXSDTemporal parses the lexical value of a xsd:dateTime or xsd:date literal. Values without timezone are interpreted as UTC.
+func parseXSDTemporal(value, datatype string) (time.Time, error) {
+	layouts := []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"}
+	if datatype == XSDDate {
+		layouts = []string{"2006-01-02Z07:00", "2006-01-02"}
+	}
+	var err error
+	for _, layout := range layouts {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// sparqlString returns the lexical form of simple and language tagged literals.
+func sparqlString(t Term) (string, bool) {
+	if !t.IsLiteral() || (t.Datatype() != "" && t.Datatype() != XSDString) {
+		return "", false
+	}
+	return unescapeLiteral(t.Value()), true
+}
+
+// formatSPARQLNumber creates a floating point literal of the given datatype.
+func formatSPARQLNumber(n float64, datatype string) Term {
+	return newSPARQLLiteral(strconv.FormatFloat(n, 'f', -1, 64), "", datatype)
+}
+
+// formatSPARQLDecimal creates a numeric literal of the given datatype from the exact value. Fractions are rounded to 20 decimal places.
+func formatSPARQLDecimal(r *big.Rat, datatype string) Term {
+	if r.IsInt() {
+		return newSPARQLLiteral(r.Num().String(), "", datatype)
+	}
+	return newSPARQLLiteral(strings.TrimRight(strings.TrimRight(r.FloatString(20), "0"), "."), "", datatype)
+}
+
+// isSPARQLComparable returns true if literals of the term's type are compared by value.
+func isSPARQLComparable(t Term) bool {
+	_, isNum := sparqlNumeric(t)
+	_, isStr := sparqlString(t)
+	return isNum || isStr || t.Datatype() == XSDBoolean || t.Datatype() == XSDDateTime || t.Datatype() == XSDDate
+}
+
+// compareSPARQLValues compares numeric, string, boolean, date and date time literals by value. Errors if the terms are not comparable.
+func compareSPARQLValues(a, b Term) (int, error) {
+	if x, ok := sparqlDecimal(a); ok {
+		if y, ok := sparqlDecimal(b); ok {
+			return x.Cmp(y), nil
+		}
+	}
+	if x, ok := sparqlNumeric(a); ok {
+		if y, ok := sparqlNumeric(b); ok {
+			return compareFloats(x, y), nil
+		}
+		return 0, errSPARQLType
+	}
+	if x, ok := sparqlString(a); ok && a.Language() == b.Language() {
+		if y, ok := sparqlString(b); ok {
+			return strings.Compare(x, y), nil
+		}
+		return 0, errSPARQLType
+	}
+	if a.IsLiteral() && b.IsLiteral() && a.Datatype() == b.Datatype() {
+		switch a.Datatype() {
+		case XSDBoolean:
+			x, _ := effectiveBooleanValue(a)
+			y, _ := effectiveBooleanValue(b)
+			if x == y {
+				return 0, nil
+			} else if !x {
+				return -1, nil
+			}
+			return 1, nil
+		case XSDDateTime, XSDDate:
+			x, err1 := parseXSDTemporal(a.Value(), a.Datatype())
+			y, err2 := parseXSDTemporal(b.Value(), b.Datatype())
+			if err1 != nil || err2 != nil {
+				return 0, errSPARQLType
+			}
+			if x.Before(y) {
+				return -1, nil
+			} else if x.After(y) {
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	return 0, errSPARQLType
+}
+
+// compareSPARQLOrder compares the terms for ORDER BY: unbound terms first, then blank nodes, IRIs and literals. Literals are compared
+// by value if possible and by their lexical form otherwise.
+func compareSPARQLOrder(a, b Term) int {
+	rank := func(t Term) int {
+		switch {
+		case t == "":
+			return 0
+		case t.IsBlankNode():
+			return 1
+		case t.IsResource():
+			return 2
+		}
+		return 3
+	}
+	if ra, rb := rank(a), rank(b); ra != rb {
+		return ra - rb
+	}
+	if c, err := compareSPARQLValues(a, b); err == nil {
+		return c
+	}
+	return strings.Compare(string(a), string(b))
+}
+
+// compareFloats compares the numbers like `strings.Compare`.
+func compareFloats(x, y float64) int {
+	if x < y {
+		return -1
+	} else if x > y {
+		return 1
+	}
+	return 0
+}
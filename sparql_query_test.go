@@ -0,0 +1,125 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("SPARQL queries on memory stores", func() {
+	var store *MemoryStore
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.com/onto> a owl:Ontology .
+:alice a :Person ; rdfs:label "Alice"@en ; :age "42"^^xsd:integer ; :knows :bob, :carol .
+:bob a :Person ; rdfs:label "Bob"@en ; :age "27"^^xsd:integer .
+:carol a :Person ; :age "35"^^xsd:integer ; :knows :alice .
+`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	values := func(resSet JSONResultSet, v string) []string {
+		vals := []string{}
+		for _, binding := range resSet.Results.Bindings {
+			vals = append(vals, binding[v].Value)
+		}
+		return vals
+	}
+
+	It("should join basic graph patterns", func() {
+		resSet, err := store.Query(`PREFIX : <http://example.com/onto#>
+SELECT ?a ?b WHERE { ?a :knows ?b . ?b :knows ?a }`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resSet.Head.Vars).To(Equal([]string{"a", "b"}))
+		Expect(values(resSet, "a")).To(ConsistOf("http://example.com/onto#alice", "http://example.com/onto#carol"))
+		Expect(resSet.Results.Bindings[0]["a"].Type).To(Equal("uri"))
+	})
+
+	It("should filter, order and slice results", func() {
+		resSet, err := store.Query(`PREFIX : <http://example.com/onto#>
+SELECT ?p ?age WHERE { ?p a :Person ; :age ?age . FILTER(?age > 30 && isIRI(?p)) } ORDER BY DESC(?age)`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values(resSet, "age")).To(Equal([]string{"42", "35"}))
+		Expect(resSet.Results.Bindings[0]["age"].DataType).To(Equal(XSDInteger))
+		resSet, err = store.Query(`PREFIX : <http://example.com/onto#>
+SELECT ?p WHERE { ?p :age ?age } ORDER BY ?age LIMIT 1 OFFSET 1`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values(resSet, "p")).To(Equal([]string{"http://example.com/onto#carol"}))
+	})
+
+	It("should leave optional variables unbound", func() {
+		resSet, err := store.Query(`PREFIX : <http://example.com/onto#>
+PREFIX rdfs: <http://www.w3.org/2000/01/rdf-schema#>
+SELECT * WHERE { ?p a :Person OPTIONAL { ?p rdfs:label ?label FILTER(langMatches(lang(?label), "en")) } FILTER(!bound(?label)) }`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resSet.Head.Vars).To(Equal([]string{"p", "label"}))
+		Expect(resSet.Results.Bindings).To(HaveLen(1))
+		Expect(resSet.Results.Bindings[0]).To(Equal(map[string]JSONResultSetBinding{"p": {Type: "uri", Value: "http://example.com/onto#carol"}}))
+	})
+
+	It("should support unions, distinct results and string functions", func() {
+		resSet, err := store.Query(`PREFIX : <http://example.com/onto#>
+SELECT DISTINCT ?p WHERE { { ?p :knows :bob } UNION { ?p :knows :carol } UNION { ?p <http://www.w3.org/2000/01/rdf-schema#label> ?l FILTER regex(str(?l), "^a", "i") } }`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values(resSet, "p")).To(Equal([]string{"http://example.com/onto#alice"}))
+		resSet, err = store.Query(`SELECT ?l WHERE { ?p <http://www.w3.org/2000/01/rdf-schema#label> ?l FILTER(?l = "Bob"@en) }`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resSet.Results.Bindings).To(Equal([]map[string]JSONResultSetBinding{{"l": {Type: "literal", Value: "Bob", Lang: "en"}}}))
+	})
+
+	It("should answer ASK queries", func() {
+		resSet, err := store.Query(`ASK { <http://example.com/onto#bob> <http://example.com/onto#age> 27 }`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resSet.Boolean).To(BeTrue())
+	})
+
+	It("should evaluate integer and decimal arithmetic exactly", func() {
+		ask := func(filter string) bool {
+			resSet, err := store.Query(`ASK { FILTER(` + filter + `) }`)
+			Expect(err).NotTo(HaveOccurred())
+			return resSet.Boolean
+		}
+		Expect(ask(`9007199254740993 + 1 = 9007199254740994`)).To(BeTrue())
+		Expect(ask(`9007199254740993 + 1 = 9007199254740993`)).To(BeFalse())
+		Expect(ask(`9007199254740993 > 9007199254740992`)).To(BeTrue())
+		Expect(ask(`-9007199254740993 * 2 = -18014398509481986`)).To(BeTrue())
+		Expect(ask(`0.1 + 0.2 = 0.3`)).To(BeTrue())
+		Expect(ask(`7 / 2 = 3.5`)).To(BeTrue())
+	})
+
+	It("should reject invalid and unsupported queries", func() {
+		_, err := store.Query(`SELECT ?s WHERE { ?s ?p }`)
+		Expect(err).To(MatchError(ErrInvalidQuery))
+		_, err = store.Query(`SELECT ?s WHERE { ?s ex:p ?o }`)
+		Expect(err).To(MatchError(ErrInvalidQuery))
+		_, err = store.Query(`CONSTRUCT { ?s ?p ?o } WHERE { ?s ?p ?o }`)
+		Expect(err).To(MatchError(ErrUnsupportedQuery))
+		_, err = store.Query(`SELECT ?s WHERE { ?s ?p ?o MINUS { ?s a ?t } }`)
+		Expect(err).To(MatchError(ErrUnsupportedQuery))
+	})
+
+	It("should reject aggregates as unsupported", func() {
+		_, err := store.Query(`SELECT (COUNT(?s) AS ?n) WHERE { ?s ?p ?o }`)
+		Expect(err).To(MatchError(ErrUnsupportedQuery))
+		_, err = store.Query(`SELECT ?t WHERE { ?s a ?t } GROUP BY ?t`)
+		Expect(err).To(MatchError(ErrUnsupportedQuery))
+	})
+
+	It("should reject property paths as unsupported", func() {
+		for _, path := range []string{"<http://x/p>+", "<http://x/p>*", "<http://x/p>?", "<http://x/p>/<http://x/q>", "<http://x/p>|<http://x/q>", "^<http://x/p>", "!<http://x/p>"} {
+			_, err := store.Query(`SELECT ?s WHERE { ?s ` + path + ` ?o }`)
+			Expect(err).To(MatchError(ErrUnsupportedQuery), path)
+		}
+		// A signed number is no path
+		_, err := store.Query(`SELECT ?s WHERE { ?s <http://example.com/onto#age> +27 }`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,30 @@
+package ontograph
+
+import (
+	"context"
+)
+
+// A ContextStore is a graph store whose operations can be bound to a context, so that long running operations (e.g. SPARQL queries
+// against a remote endpoint) are cancelled when the context is cancelled or its deadline is exceeded.
+type ContextStore interface {
+	GraphStore
+	// WithContext should return a shallow copy of the store whose operations use the context.
+	WithContext(ctx context.Context) GraphStore
+}
+
+// StoreWithContext binds the store to the context if it implements `ContextStore`, e.g. to cancel the queries of a HTTP handler when
+// the client disconnects. All other stores are returned unchanged, i.e. their operations ignore the context.
+func StoreWithContext(store GraphStore, ctx context.Context) GraphStore {
+	if ctxStore, ok := store.(ContextStore); ok {
+		return ctxStore.WithContext(ctx)
+	}
+	return store
+}
+
+// WithContext returns a shallow copy of the ontology graph whose operations on the graph store use the context (see
+// `StoreWithContext`). The copy shares the configuration of the ontology graph.
+func (ont *OntologyGraph) WithContext(ctx context.Context) *OntologyGraph {
+	bound := *ont
+	bound.graph = StoreWithContext(ont.graph, ctx)
+	return &bound
+}
@@ -0,0 +1,102 @@
+package ontograph_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Store contexts", func() {
+	var server *httptest.Server
+	var release chan struct{}
+
+	BeforeEach(func() {
+		// The SPARQL endpoint only responds once it is released
+		release = make(chan struct{})
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+			w.Header().Set("Content-Type", "application/sparql-results+json")
+			if r.ParseForm(); strings.Contains(r.Form.Get("query"), "COUNT") {
+				w.Write([]byte(`{"head":{"vars":["n"]},"results":{"bindings":[{"n":{"type":"literal","value":"3"}}]}}`))
+				return
+			}
+			w.Write([]byte(`{"head":{"vars":["s","p","o"]},"results":{"bindings":[]}}`))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should abort the requests of Blazegraph stores when the context is done", func() {
+		store := NewBlazegraphEndpoint(server.URL).NewBlazegraphStore("http://example.com/onto", "test")
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := StoreWithContext(store, ctx).Size()
+		Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+
+		// The store itself is not bound to the context
+		close(release)
+		Expect(store.Size()).To(Equal(3))
+	})
+
+	It("should bind the stores of unions and ontology graphs", func() {
+		close(release)
+		remote := NewBlazegraphEndpoint(server.URL).NewBlazegraphStore("http://example.com/onto", "test")
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		union := NewUnionStore(NewMemoryStore("http://example.com/onto"), remote)
+		_, err := StoreWithContext(union, ctx).GetAllTriples()
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+
+		ont, err := InitOntologyGraph(union)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ont.WithContext(ctx).GetClasses()
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+	})
+
+	It("should bind the stores of checked stores", func() {
+		close(release)
+		remote := NewBlazegraphEndpoint(server.URL).NewBlazegraphStore("http://example.com/onto", "test")
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		checked := NewCheckedStore(remote, Lenient, nil)
+		_, err := StoreWithContext(checked, ctx).GetAllTriples()
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		Expect(checked.GetAllTriples()).To(BeEmpty())
+
+		// The schema checked store queries its import stores for declarations
+		_, err = StoreWithContext(NewSchemaCheckedStore(remote, Lenient, nil), ctx).GetAllTriples()
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		local := NewMemoryStore("http://example.com/onto")
+		Expect(local.AddTriple(Triple{
+			Subject: NewResourceTerm("http://example.com/onto"), Predicate: NewResourceTerm(OWLImports), Object: NewResourceTerm("http://example.com/base"),
+		})).To(Succeed())
+		imported := NewBlazegraphEndpoint(server.URL).NewBlazegraphStore("http://example.com/base", "test")
+		schemaChecked := NewSchemaCheckedStore(local, Lenient, nil, imported)
+		err = StoreWithContext(schemaChecked, ctx).AddTriple(Triple{
+			Subject: NewResourceTerm("http://example.com/onto#a"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm("http://example.com/onto#C"),
+		})
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+	})
+
+	It("should return other stores unchanged", func() {
+		store, err := ParseFromTurtle(strings.NewReader(`<http://example.com/a> <http://example.com/b> <http://example.com/c> .`))
+		Expect(err).NotTo(HaveOccurred())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(StoreWithContext(store, ctx)).To(BeIdenticalTo(store))
+	})
+})
@@ -0,0 +1,100 @@
+package ontograph
+
+// A CopyOption configures how triples are copied between graph stores (see `CopyStore`).
+type CopyOption func(*copyOptions)
+
+// copyOptions holds the configuration compiled from a list of copy options.
+type copyOptions struct {
+	batchSize int
+	progress  func(copied, total int)
+	replace   bool
+}
+
+// DefaultCopyBatchSize is the number of triples that are added to the destination store at once unless another batch size is set with
+// `WithCopyBatchSize`.
+var DefaultCopyBatchSize = 1000
+
+// WithCopyBatchSize sets the number of triples that are added to the destination store at once. Larger batches need fewer requests to
+// database stores, but more memory. Non-positive sizes are ignored.
+func WithCopyBatchSize(size int) CopyOption {
+	return func(opts *copyOptions) {
+		if size > 0 {
+			opts.batchSize = size
+		}
+	}
+}
+
+// WithCopyProgress sets a callback that is called after every batch with the number of triples copied so far and the total number of
+// triples in the source store.
+func WithCopyProgress(fn func(copied, total int)) CopyOption {
+	return func(opts *copyOptions) {
+		opts.progress = fn
+	}
+}
+
+// WithReplaceDestination deletes all triples of the destination store before copying, so the destination holds exactly the triples of
+// the source.
+func WithReplaceDestination() CopyOption {
+	return func(opts *copyOptions) {
+		opts.replace = true
+	}
+}
+
+// newCopyOptions compiles the given list of options.
+func newCopyOptions(opts []CopyOption) copyOptions {
+	options := copyOptions{batchSize: DefaultCopyBatchSize}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// CopyStore copies all triples of the source store into the destination store, e.g. to promote an edited memory store into a database
+// or to pull a graph from a database for local processing. The triples are streamed from the source (see `IterTriples`) and added
+// unchecked in batches, so already existing triples do not cause an error. Returns the number of copied triples. If copying fails, the
+// triples of the previous batches remain in the destination store.
+func CopyStore(dst, src GraphStore, opts ...CopyOption) (int, error) {
+	options := newCopyOptions(opts)
+	total := 0
+	if options.progress != nil {
+		var err error
+		if total, err = src.Size(); err != nil {
+			return 0, err
+		}
+	}
+	if options.replace {
+		if err := dst.DeleteAllMatches("", "", ""); err != nil {
+			return 0, err
+		}
+	}
+	copied := 0
+	batch := make([]Triple, 0, options.batchSize)
+	flush := func() error {
+		if err := dst.AddTriplesUnchecked(batch); err != nil {
+			return err
+		}
+		copied += len(batch)
+		batch = batch[:0]
+		if options.progress != nil {
+			options.progress(copied, total)
+		}
+		return nil
+	}
+	err := IterTriples(src, func(trp Triple) error {
+		batch = append(batch, trp)
+		if len(batch) < options.batchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return copied, err
+	}
+	// Flush remaining triples
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return copied, err
+		}
+	}
+	return copied, nil
+}
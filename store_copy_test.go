@@ -0,0 +1,56 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Copying stores", func() {
+	const testUri = "http://example.com/onto"
+	var src *MemoryStore
+
+	BeforeEach(func() {
+		var err error
+		src, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:A a owl:Class .
+:B a owl:Class .
+:C a owl:Class .
+:D a owl:Class .
+`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should copy all triples in batches and report the progress", func() {
+		dst := NewMemoryStore(testUri)
+		progress := [][2]int{}
+		n, err := CopyStore(dst, src, WithCopyBatchSize(2), WithCopyProgress(func(copied, total int) {
+			progress = append(progress, [2]int{copied, total})
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(5))
+		Expect(progress).To(Equal([][2]int{{2, 5}, {4, 5}, {5, 5}}))
+		expected, err := src.GetAllTriples()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dst.GetAllTriples()).To(ConsistOf(expected))
+	})
+
+	It("should replace the triples of the destination if requested", func() {
+		dst := NewMemoryStore(testUri)
+		extra := Triple{Subject: NewResourceTerm(testUri + "#E"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)}
+		Expect(dst.AddTriple(extra)).To(Succeed())
+		_, err := CopyStore(dst, src)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dst.Size()).To(Equal(6))
+
+		_, err = CopyStore(dst, src, WithReplaceDestination())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dst.Size()).To(Equal(5))
+		Expect(dst.GetFirstMatch(extra.Subject.String(), "", "")).To(BeNil())
+	})
+})
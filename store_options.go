@@ -0,0 +1,118 @@
+package ontograph
+
+import (
+	"net/http"
+	"time"
+)
+
+// A StoreOption configures a graph store on construction (see `NewMemoryStore` and `BlazegraphEndpoint.NewBlazegraphStore`). Options
+// that do not apply to a store are ignored, e.g. HTTP settings for memory stores.
+type StoreOption func(*storeOptions)
+
+// storeOptions holds the configuration compiled from a list of store options.
+type storeOptions struct {
+	client     *http.Client
+	timeout    time.Duration
+	prefixes   PrefixMap
+	checked    bool
+	strictness Strictness
+	warnings   WarningCollector
+	metrics    MetricsHook
+}
+
+// WithHTTPClient sets the HTTP client that is used for the requests of database stores instead of the client of the endpoint.
+func WithHTTPClient(client *http.Client) StoreOption {
+	return func(opts *storeOptions) {
+		opts.client = client
+	}
+}
+
+// WithTimeout limits the duration of every request of database stores, including reading the response body. A zero timeout means no
+// timeout.
+func WithTimeout(timeout time.Duration) StoreOption {
+	return func(opts *storeOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// WithDefaultPrefixes sets the prefixes that are used when the store is serialized without `WithPrefixes`.
+func WithDefaultPrefixes(pm PrefixMap) StoreOption {
+	return func(opts *storeOptions) {
+		opts.prefixes = pm
+	}
+}
+
+// WithStoreStrictness checks every triple added to the store like a `CheckedStore` does. In strict mode, triples with issues are
+// rejected, in lenient mode they are added and the issues are passed to the warning collector (may be nil).
+func WithStoreStrictness(strictness Strictness, warnings WarningCollector) StoreOption {
+	return func(opts *storeOptions) {
+		opts.checked = true
+		opts.strictness = strictness
+		opts.warnings = warnings
+	}
+}
+
+// WithMetrics passes the metrics of the store to the hook, i.e. the latency and errors of its queries and the number of triples added
+// and deleted (see `MetricsHook`).
+func WithMetrics(hook MetricsHook) StoreOption {
+	return func(opts *storeOptions) {
+		opts.metrics = hook
+	}
+}
+
+// newStoreOptions compiles the given list of options.
+func newStoreOptions(opts []StoreOption) storeOptions {
+	options := storeOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// httpClient returns the HTTP client to use instead of the given default client, applying the timeout to a copy of it (if any).
+func (options storeOptions) httpClient(defaultClient *http.Client) *http.Client {
+	client := defaultClient
+	if options.client != nil {
+		client = options.client
+	}
+	if options.timeout > 0 {
+		limited := *client
+		limited.Timeout = options.timeout
+		client = &limited
+	}
+	return client
+}
+
+// serializeOptions prepends the default prefixes of the store (if any) to the given serialize options, so explicit prefixes take precedence.
+func (options storeOptions) serializeOptions(opts []SerializeOption) []SerializeOption {
+	if options.prefixes == nil {
+		return opts
+	}
+	return append([]SerializeOption{WithPrefixes(options.prefixes)}, opts...)
+}
+
+// check checks the triples to be added to the store if the store was created with `WithStoreStrictness`.
+func (options storeOptions) check(store GraphStore, trps []Triple) error {
+	if !options.checked {
+		return nil
+	}
+	return checkTriples(store, trps, options.strictness, options.warnings)
+}
+
+// observeQuery passes the latency and error of a query started at the given time to the metrics hook of the store (if any).
+func (options storeOptions) observeQuery(backend, kind string, start time.Time, err error) {
+	if options.metrics != nil {
+		options.metrics.ObserveQuery(backend, kind, time.Since(start), err)
+	}
+}
+
+// observeTriples passes the number of added and deleted triples to the metrics hook of the store (if any).
+func (options storeOptions) observeTriples(backend string, added, deleted int) {
+	if options.metrics != nil && (added > 0 || deleted > 0) {
+		options.metrics.ObserveTriples(backend, added, deleted)
+	}
+}
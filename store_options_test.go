@@ -0,0 +1,100 @@
+package ontograph_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+// countingTransport counts the requests passed to the default transport.
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+var _ = Describe("Store options", func() {
+	const testUri = "http://example.com/onto"
+	outOfNamespace := Triple{
+		Subject:   NewResourceTerm("http://other.com/onto#B"),
+		Predicate: NewResourceTerm(RDFType),
+		Object:    NewResourceTerm(OWLClass),
+	}
+
+	It("should check added triples according to the strictness", func() {
+		store := NewMemoryStore(testUri, WithStoreStrictness(Strict, nil))
+		Expect(errors.Is(store.AddTriple(outOfNamespace), ErrOutOfNamespace)).To(BeTrue())
+		Expect(errors.Is(store.AddTriplesUnchecked([]Triple{outOfNamespace}), ErrOutOfNamespace)).To(BeTrue())
+		Expect(store.Size()).To(Equal(0))
+
+		report := &Report{}
+		store = NewMemoryStore(testUri, WithStoreStrictness(Lenient, report))
+		Expect(store.AddTriple(outOfNamespace)).To(Succeed())
+		Expect(report.Warnings()).To(HaveLen(1))
+		Expect(NewMemoryStore(testUri).AddTriple(outOfNamespace)).To(Succeed())
+	})
+
+	It("should serialize with the default prefixes unless others are given", func() {
+		store := NewMemoryStore(testUri, WithDefaultPrefixes(PrefixMap{"other": "http://other.com/onto#"}))
+		Expect(store.AddTriple(outOfNamespace)).To(Succeed())
+		var ttl strings.Builder
+		Expect(store.SerializeToTurtle(&ttl, true)).To(Succeed())
+		Expect(ttl.String()).To(ContainSubstring("other:B"))
+
+		ttl.Reset()
+		Expect(store.SerializeToTurtleWithOptions(&ttl, true, WithPrefixes(PrefixMap{"o": "http://other.com/onto#"}))).To(Succeed())
+		Expect(ttl.String()).To(ContainSubstring("o:B"))
+		Expect(ttl.String()).NotTo(ContainSubstring("other:"))
+	})
+
+	Describe("Blazegraph stores", func() {
+		var server *httptest.Server
+		var delay time.Duration
+
+		BeforeEach(func() {
+			delay = 0
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(delay):
+				case <-r.Context().Done():
+					return
+				}
+				w.Header().Set("Content-Type", "application/sparql-results+json")
+				w.Write([]byte(`{"head":{"vars":["n"]},"results":{"bindings":[{"n":{"type":"literal","value":"3"}}]}}`))
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should send the requests through the given HTTP client", func() {
+			transport := &countingTransport{}
+			ep := NewBlazegraphEndpoint(server.URL)
+			store := ep.NewBlazegraphStore(testUri, "test", WithHTTPClient(&http.Client{Transport: transport}))
+			Expect(store.Size()).To(Equal(3))
+			Expect(transport.requests).To(Equal(1))
+
+			// The endpoint and its other stores are not affected
+			Expect(ep.NewBlazegraphStore(testUri, "test").Size()).To(Equal(3))
+			Expect(transport.requests).To(Equal(1))
+		})
+
+		It("should abort requests that exceed the timeout", func() {
+			delay = time.Second
+			store := NewBlazegraphEndpoint(server.URL).NewBlazegraphStore(testUri, "test", WithTimeout(50*time.Millisecond))
+			_, err := store.Size()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
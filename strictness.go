@@ -0,0 +1,187 @@
+package ontograph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Strictness controls how issues with triples (malformed terms, unknown datatypes, invalid literal values or resources outside of the
+// graph namespace) are handled.
+type Strictness int
+
+const (
+	// Lenient accepts triples with issues and passes the issues as warnings to a warning collector.
+	Lenient Strictness = iota
+	// Strict rejects triples with issues and errors out on the first issue.
+	Strict
+)
+
+// DefaultStrictness is the strictness used for parsing unless another one is set with `WithStrictness`.
+var DefaultStrictness = Lenient
+
+// CheckedStore wraps a graph store and checks every added triple according to its strictness. In strict mode, triples with issues are
+// rejected, in lenient mode they are added and the issues are passed to the warning collector. All other methods are passed through.
+type CheckedStore struct {
+	GraphStore
+	strictness Strictness
+	warnings   WarningCollector
+}
+
+// NewCheckedStore wraps the given store into a checked store with the given strictness. The warning collector is optional and may be nil.
+func NewCheckedStore(store GraphStore, strictness Strictness, warnings WarningCollector) *CheckedStore {
+	return &CheckedStore{GraphStore: store, strictness: strictness, warnings: warnings}
+}
+
+// WithContext returns a copy of the store bound to the context (see `StoreWithContext`).
+func (store *CheckedStore) WithContext(ctx context.Context) GraphStore {
+	return &CheckedStore{GraphStore: StoreWithContext(store.GraphStore, ctx), strictness: store.strictness, warnings: store.warnings}
+}
+
+// AddTriple checks the triple and adds it to the store. If the triple already exists, it errors with `ErrTripleAlreadyExists`.
+func (store *CheckedStore) AddTriple(trp Triple) error {
+	if err := store.check([]Triple{trp}); err != nil {
+		return err
+	}
+	return store.GraphStore.AddTriple(trp)
+}
+
+// AddTriples checks the triples and adds them to the store. If one of the triples already exist, it errors with `ErrTripleAlreadyExists`.
+func (store *CheckedStore) AddTriples(trps []Triple) error {
+	if err := store.check(trps); err != nil {
+		return err
+	}
+	return store.GraphStore.AddTriples(trps)
+}
+
+// AddTripleUnchecked checks the triple and adds it to the store. It does not error if the triple already exists.
+func (store *CheckedStore) AddTripleUnchecked(trp Triple) error {
+	if err := store.check([]Triple{trp}); err != nil {
+		return err
+	}
+	return store.GraphStore.AddTripleUnchecked(trp)
+}
+
+// AddTriplesUnchecked checks the triples and adds them to the store. It does not error if any of the triples already exists.
+func (store *CheckedStore) AddTriplesUnchecked(trps []Triple) error {
+	if err := store.check(trps); err != nil {
+		return err
+	}
+	return store.GraphStore.AddTriplesUnchecked(trps)
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrMalformedTerm is raised when a term of a triple is not valid NTriple syntax or not allowed at its position.
+var ErrMalformedTerm error = errors.New("The term is malformed")
+
+// ErrUnknownDatatype is raised when a literal uses a datatype that is neither built-in nor declared in the graph.
+var ErrUnknownDatatype error = errors.New("The datatype of the literal is unknown")
+
+// ErrOutOfNamespace is raised when the subject of a triple does not belong to the namespace of the graph.
+var ErrOutOfNamespace error = errors.New("The subject does not belong to the namespace of the graph")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// check checks all triples for issues and handles them according to the strictness of the store.
+func (store *CheckedStore) check(trps []Triple) error {
+	return checkTriples(store.GraphStore, trps, store.strictness, store.warnings)
+}
+
+// checkTriples checks all triples for issues against the namespace and declared datatypes of the store and handles them according to
+// the strictness.
+func checkTriples(store GraphStore, trps []Triple, strictness Strictness, warnings WarningCollector) error {
+	isDeclared := func(datatype string) bool {
+		trp, err := store.GetFirstMatch(NewResourceTerm(datatype).String(), NewResourceTerm(RDFType).String(), NewResourceTerm(RDFSDatatype).String())
+		return err == nil && trp != nil
+	}
+	for _, trp := range trps {
+		if err := handleTripleIssues(trp, checkTriple(trp, store.GetURI(), isDeclared), strictness, warnings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleTripleIssues returns the first issue as error in strict mode and passes all issues to the warning collector (if any) in lenient mode.
+func handleTripleIssues(trp Triple, issues []error, strictness Strictness, warnings WarningCollector) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	if strictness == Strict {
+		return fmt.Errorf("Rejected triple %s %s %s: %w", trp.Subject, trp.Predicate, trp.Object, issues[0])
+	}
+	if warnings != nil {
+		for _, issue := range issues {
+			warnings.Warn(Warning{Triple: trp, Err: issue})
+		}
+	}
+	return nil
+}
+
+// checkTriple returns all issues of the triple. If the graph URI is not empty, subjects outside of its namespace are reported. Datatypes
+// are known if they are built-in or if isDeclared (may be nil) returns true for them.
+func checkTriple(trp Triple, graphURI string, isDeclared func(string) bool) []error {
+	issues := []error{}
+	// Check term syntax
+	if !isValidResourceTerm(trp.Subject) && !trp.Subject.IsBlankNode() {
+		issues = append(issues, fmt.Errorf("%w: Subject '%s' is not a resource", ErrMalformedTerm, trp.Subject))
+	}
+	if !isValidResourceTerm(trp.Predicate) {
+		issues = append(issues, fmt.Errorf("%w: Predicate '%s' is not a resource", ErrMalformedTerm, trp.Predicate))
+	}
+	if !isValidResourceTerm(trp.Object) && !trp.Object.IsBlankNode() && !trp.Object.IsLiteral() {
+		issues = append(issues, fmt.Errorf("%w: Object '%s' is not a resource or literal", ErrMalformedTerm, trp.Object))
+	}
+	// Check datatype
+	if datatype := trp.Object.Datatype(); trp.Object.IsLiteral() && datatype != "" && !builtinDatatypes[datatype] {
+		if isDeclared == nil || !isDeclared(datatype) {
+			issues = append(issues, fmt.Errorf("%w: <%s>", ErrUnknownDatatype, datatype))
+		}
+	}
+	// Check lexical form
+	if trp.Object.IsLiteral() {
+		if err := NewGenericLiteral(trp.Object).Validate(); err != nil {
+			issues = append(issues, err)
+		}
+	}
+	// Check namespace
+	if graphURI != "" && trp.Subject.IsResource() {
+		subj := trp.Subject.Value()
+		if subj != graphURI && !strings.HasPrefix(subj, graphURI+"#") && !strings.HasPrefix(subj, graphURI+"/") {
+			issues = append(issues, fmt.Errorf("%w: <%s>", ErrOutOfNamespace, subj))
+		}
+	}
+	return issues
+}
+
+// isValidResourceTerm checks if the term is a resource whose URI does not contain characters that are forbidden in IRIs.
+func isValidResourceTerm(t Term) bool {
+	return t.IsResource() && !strings.ContainsAny(t.Value(), " <>\"{}|\\^`\t\r\n")
+}
+
+// builtinDatatypes contains the datatypes defined by RDF, RDFS and XML Schema.
+var builtinDatatypes = func() map[string]bool {
+	datatypes := map[string]bool{
+		"http://www.w3.org/1999/02/22-rdf-syntax-ns#langString":   true,
+		"http://www.w3.org/1999/02/22-rdf-syntax-ns#XMLLiteral":   true,
+		"http://www.w3.org/1999/02/22-rdf-syntax-ns#HTML":         true,
+		"http://www.w3.org/1999/02/22-rdf-syntax-ns#PlainLiteral": true,
+		"http://www.w3.org/2000/01/rdf-schema#Literal":            true,
+	}
+	for _, name := range []string{
+		"string", "boolean", "decimal", "integer", "double", "float", "date", "time", "dateTime", "dateTimeStamp",
+		"gYear", "gMonth", "gDay", "gYearMonth", "gMonthDay", "duration", "yearMonthDuration", "dayTimeDuration",
+		"byte", "short", "int", "long", "unsignedByte", "unsignedShort", "unsignedInt", "unsignedLong",
+		"positiveInteger", "nonNegativeInteger", "negativeInteger", "nonPositiveInteger",
+		"hexBinary", "base64Binary", "anyURI", "language", "normalizedString", "token", "NMTOKEN", "Name", "NCName",
+	} {
+		datatypes["http://www.w3.org/2001/XMLSchema#"+name] = true
+	}
+	return datatypes
+}()
@@ -0,0 +1,84 @@
+package ontograph_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Strictness", func() {
+	const testUri = "http://example.com/onto"
+	const testTTL = `@prefix ex: <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+
+<http://example.com/onto> a owl:Ontology .
+ex:Unit a rdfs:Datatype .
+ex:A ex:weight "3"^^ex:Unit ;
+     ex:size "4"^^ex:Unknown .
+<http://other.com/onto#B> a owl:Class .
+`
+	validTriple := Triple{
+		Subject:   NewResourceTerm(testUri + "#A"),
+		Predicate: NewResourceTerm(RDFType),
+		Object:    NewResourceTerm(OWLClass),
+	}
+
+	Describe("Parsing", func() {
+		It("should collect warnings in lenient mode", func() {
+			report := &Report{}
+			_, err := ParseFromTurtle(strings.NewReader(testTTL), WithStrictness(Lenient, report))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Warnings()).To(HaveLen(2))
+			Expect(report.Warnings()[0].Err).To(MatchError(ErrUnknownDatatype))
+			Expect(report.Warnings()[1].Err).To(MatchError(ErrOutOfNamespace))
+		})
+
+		It("should fail on the first issue in strict mode", func() {
+			_, err := ParseFromTurtle(strings.NewReader(testTTL), WithStrictness(Strict, nil))
+			Expect(err).To(MatchError(ErrUnknownDatatype))
+		})
+
+		It("should check streamed triples", func() {
+			report := &Report{}
+			err := ParseTurtleStream(strings.NewReader(testTTL), func(Triple) error { return nil }, WithStrictness(Lenient, report))
+			Expect(err).NotTo(HaveOccurred())
+			// Declared datatypes are unknown while streaming
+			Expect(report.Warnings()).To(HaveLen(2))
+			Expect(errors.Is(report.Warnings()[0].Err, ErrUnknownDatatype)).To(BeTrue())
+		})
+	})
+
+	Describe("Checked store", func() {
+		It("should reject triples with issues in strict mode", func() {
+			store := NewCheckedStore(NewMemoryStore(testUri), Strict, nil)
+			Expect(store.AddTriple(validTriple)).To(Succeed())
+			err := store.AddTriple(Triple{Subject: NewResourceTerm("http://example.com/other A"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)})
+			Expect(err).To(MatchError(ErrMalformedTerm))
+			Expect(store.Size()).To(Equal(1))
+		})
+
+		It("should accept triples with issues in lenient mode", func() {
+			report := &Report{}
+			store := NewCheckedStore(NewMemoryStore(testUri), Lenient, report)
+			Expect(store.AddTriples([]Triple{
+				validTriple,
+				{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(RDFSComment), Object: NewLiteralTerm("1", "", testUri+"#Unit")},
+			})).To(Succeed())
+			Expect(store.Size()).To(Equal(2))
+			Expect(report.HasWarnings()).To(BeTrue())
+			Expect(report.Warnings()[0].Err).To(MatchError(ErrUnknownDatatype))
+		})
+
+		It("should reject literals with invalid values in strict mode", func() {
+			store := NewCheckedStore(NewMemoryStore(testUri), Strict, nil)
+			err := store.AddTriple(Triple{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(testUri + "#age"), Object: NewLiteralTerm("old", "", XSDInteger)})
+			Expect(err).To(MatchError(ErrInvalidLexicalForm))
+			Expect(store.AddTriple(Triple{Subject: NewResourceTerm(testUri + "#A"), Predicate: NewResourceTerm(testUri + "#age"), Object: NewLiteralTerm("42", "", XSDInteger)})).To(Succeed())
+		})
+	})
+})
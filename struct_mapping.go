@@ -0,0 +1,602 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MarshalIndividual converts the struct (or pointer to a struct) into an individual using the `onto` struct tags of its fields. The field
+// tagged with `onto:"@id"` holds the URI of the individual and the string (or string slice) field tagged with `onto:"@type"` its types.
+// Any other tag is the URI of the property the field is mapped to, e.g. `onto:"https://example.org/people#hasName"`:
+//
+//   - Strings, booleans, numbers, `time.Time`, `time.Duration`, `[]byte`, `*big.Int`, `*big.Rat` and the literal types of this package
+//     (e.g. `XSDDateLiteral` or `LangStringLiteral`) become data properties with the corresponding XSD datatype.
+//   - Structs with an `@id` field become object properties to the nested individual, which is marshaled as well.
+//   - String fields with the `ref` option (e.g. `onto:"https://example.org/people#knows,ref"`) become object properties to the URI.
+//   - Slices add one value per element and nil pointers and empty untagged `LangStringLiteral` values are omitted. Zero values are omitted with the `omitempty` option.
+//
+// Fields without tag or with tag `onto:"-"` are ignored and embedded structs without tag are flattened. Returns the individual followed
+// by the nested individuals. Errors with `ErrInvalidStructMapping` if the struct cannot be mapped.
+func MarshalIndividual(v interface{}) ([]OntologyIndividual, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T is no struct", ErrInvalidStructMapping, v)
+	}
+	m := &structMarshaler{visited: map[string]bool{}}
+	if _, err := m.marshal(val); err != nil {
+		return nil, err
+	}
+	return m.indivs, nil
+}
+
+// UnmarshalIndividual sets the fields of the struct that the pointer points to from the individual (see `MarshalIndividual` for the
+// mapping). Nested structs are loaded with the lookup function, which may be nil if the struct has no nested structs. Cyclic references
+// are resolved by pointers to the same struct, nested value structs that are part of a cycle only get their URI set. Errors with
+// `ErrInvalidStructMapping` if the struct cannot be mapped and with `ErrLiteralTypeMismatch` or a parse error if a value cannot be
+// converted into the type of its field.
+func UnmarshalIndividual(indiv OntologyIndividual, v interface{}, lookup func(uri string) (OntologyIndividual, error)) error {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T is no pointer to a struct", ErrInvalidStructMapping, v)
+	}
+	u := &structUnmarshaler{lookup: lookup, loaded: map[string]reflect.Value{}, inProgress: map[string]bool{}}
+	u.loaded[indiv.URI+" "+val.Elem().Type().String()] = val
+	return u.unmarshal(indiv, val.Elem())
+}
+
+// UpsertStruct marshals the struct and inserts or updates the resulting individuals in the graph (see `MarshalIndividual`). All
+// individuals are upserted within a single transaction (see `Transaction`).
+func (ont *OntologyGraph) UpsertStruct(v interface{}) error {
+	indivs, err := MarshalIndividual(v)
+	if err != nil {
+		return err
+	}
+	return ont.Transaction(func(tx *OntologyGraph) error {
+		trps := []Triple{}
+		for i := range indivs {
+			if err := tx.upsertResource(&indivs[i]); err != nil {
+				return err
+			}
+			trps = append(trps, indivs[i].ToTriples()...)
+		}
+		// Upserting an individual removes the references to it, so restore the references between the upserted individuals
+		return tx.graph.AddTriplesUnchecked(trps)
+	})
+}
+
+// GetStruct retrieves the individual with the specified URI from the graph and unmarshals it into the struct that the pointer points
+// to, loading nested individuals from the graph as well (see `UnmarshalIndividual`).
+func (ont *OntologyGraph) GetStruct(uri string, v interface{}) error {
+	uri = ont.prefixes.Expand(uri)
+	indiv, err := ont.GetIndividual(uri)
+	if err != nil {
+		return err
+	}
+	return UnmarshalIndividual(indiv, v, ont.GetIndividual)
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrInvalidStructMapping is returned if a struct cannot be mapped to an individual, e.g. because of an unsupported field type.
+var ErrInvalidStructMapping error = errors.New("The struct cannot be mapped to an individual")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// literalValue is implemented by the literal types of this package.
+type literalValue interface {
+	Generic() GenericLiteral
+}
+
+var (
+	literalValueType = reflect.TypeOf((*literalValue)(nil)).Elem()
+	timeType         = reflect.TypeOf(time.Time{})
+	durationType     = reflect.TypeOf(time.Duration(0))
+	bigIntType       = reflect.TypeOf((*big.Int)(nil))
+	bigRatType       = reflect.TypeOf((*big.Rat)(nil))
+)
+
+// literalDecoders convert generic literals into the literal types of this package. Numeric literals are coerced.
+var literalDecoders = map[reflect.Type]func(l *GenericLiteral) (interface{}, error){
+	reflect.TypeOf(XSDStringLiteral("")):            func(l *GenericLiteral) (interface{}, error) { return l.ToXSDString() },
+	reflect.TypeOf(XSDBooleanLiteral(false)):        func(l *GenericLiteral) (interface{}, error) { return l.ToXSDBoolean() },
+	reflect.TypeOf(XSDAnyURILiteral("")):            func(l *GenericLiteral) (interface{}, error) { return l.ToXSDAnyURI() },
+	reflect.TypeOf(XSDIntegerLiteral(0)):            func(l *GenericLiteral) (interface{}, error) { return l.ToXSDInteger(WithNumericCoercion()) },
+	reflect.TypeOf(XSDDecimalLiteral(0)):            func(l *GenericLiteral) (interface{}, error) { return l.ToXSDDecimal(WithNumericCoercion()) },
+	reflect.TypeOf(XSDLongLiteral(0)):               func(l *GenericLiteral) (interface{}, error) { return l.ToXSDLong(WithNumericCoercion()) },
+	reflect.TypeOf(XSDIntLiteral(0)):                func(l *GenericLiteral) (interface{}, error) { return l.ToXSDInt(WithNumericCoercion()) },
+	reflect.TypeOf(XSDShortLiteral(0)):              func(l *GenericLiteral) (interface{}, error) { return l.ToXSDShort(WithNumericCoercion()) },
+	reflect.TypeOf(XSDByteLiteral(0)):               func(l *GenericLiteral) (interface{}, error) { return l.ToXSDByte(WithNumericCoercion()) },
+	reflect.TypeOf(XSDUnsignedLongLiteral(0)):       func(l *GenericLiteral) (interface{}, error) { return l.ToXSDUnsignedLong(WithNumericCoercion()) },
+	reflect.TypeOf(XSDUnsignedIntLiteral(0)):        func(l *GenericLiteral) (interface{}, error) { return l.ToXSDUnsignedInt(WithNumericCoercion()) },
+	reflect.TypeOf(XSDUnsignedShortLiteral(0)):      func(l *GenericLiteral) (interface{}, error) { return l.ToXSDUnsignedShort(WithNumericCoercion()) },
+	reflect.TypeOf(XSDUnsignedByteLiteral(0)):       func(l *GenericLiteral) (interface{}, error) { return l.ToXSDUnsignedByte(WithNumericCoercion()) },
+	reflect.TypeOf(XSDNonNegativeIntegerLiteral(0)): func(l *GenericLiteral) (interface{}, error) { return l.ToXSDNonNegativeInteger(WithNumericCoercion()) },
+	reflect.TypeOf(XSDPositiveIntegerLiteral(0)):    func(l *GenericLiteral) (interface{}, error) { return l.ToXSDPositiveInteger(WithNumericCoercion()) },
+	reflect.TypeOf(XSDFloatLiteral(0)):              func(l *GenericLiteral) (interface{}, error) { return l.ToXSDFloat(WithNumericCoercion()) },
+	reflect.TypeOf(XSDDoubleLiteral(0)):             func(l *GenericLiteral) (interface{}, error) { return l.ToXSDDouble(WithNumericCoercion()) },
+	reflect.TypeOf(XSDDateTimeLiteral{}):            func(l *GenericLiteral) (interface{}, error) { return l.ToXSDDateTime() },
+	reflect.TypeOf(XSDDateLiteral{}):                func(l *GenericLiteral) (interface{}, error) { return l.ToXSDDate() },
+	reflect.TypeOf(XSDTimeLiteral{}):                func(l *GenericLiteral) (interface{}, error) { return l.ToXSDTime() },
+	reflect.TypeOf(XSDGYearLiteral{}):               func(l *GenericLiteral) (interface{}, error) { return l.ToXSDGYear() },
+	reflect.TypeOf(XSDGYearMonthLiteral{}):          func(l *GenericLiteral) (interface{}, error) { return l.ToXSDGYearMonth() },
+	reflect.TypeOf(XSDDurationLiteral{}):            func(l *GenericLiteral) (interface{}, error) { return l.ToXSDDuration() },
+	reflect.TypeOf(XSDBase64BinaryLiteral{}):        func(l *GenericLiteral) (interface{}, error) { return l.ToXSDBase64Binary() },
+	reflect.TypeOf(XSDHexBinaryLiteral{}):           func(l *GenericLiteral) (interface{}, error) { return l.ToXSDHexBinary() },
+	reflect.TypeOf(LangStringLiteral{}):             func(l *GenericLiteral) (interface{}, error) { return l.ToLangString() },
+	reflect.TypeOf(XSDBigIntegerLiteral{}):          func(l *GenericLiteral) (interface{}, error) { return l.ToXSDBigInteger() },
+	reflect.TypeOf(XSDBigDecimalLiteral{}):          func(l *GenericLiteral) (interface{}, error) { return l.ToXSDBigDecimal() },
+}
+
+// structField is a struct field with its parsed `onto` tag.
+type structField struct {
+	name      string
+	value     reflect.Value
+	property  string
+	ref       bool
+	omitEmpty bool
+}
+
+// structFields returns the tagged fields of the struct value, flattening embedded structs without tag.
+func structFields(val reflect.Value) []structField {
+	fields := []structField{}
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		tag, ok := field.Tag.Lookup("onto")
+		if !ok && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, structFields(val.Field(i))...)
+			continue
+		}
+		if !ok || tag == "-" || field.PkgPath != "" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		f := structField{name: field.Name, value: val.Field(i), property: parts[0]}
+		for _, opt := range parts[1:] {
+			f.ref = f.ref || opt == "ref"
+			f.omitEmpty = f.omitEmpty || opt == "omitempty"
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// structFieldByTag returns the field of the struct value with the given property tag.
+func structFieldByTag(val reflect.Value, property string) (structField, bool) {
+	for _, f := range structFields(val) {
+		if f.property == property {
+			return f, true
+		}
+	}
+	return structField{}, false
+}
+
+// isNestedStruct checks if values of the type are mapped to nested individuals, i.e. the type is a struct (or pointer to a struct)
+// with an `@id` field that is no literal type.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType || literalDecoders[t] != nil {
+		return false
+	}
+	_, ok := structFieldByTag(reflect.New(t).Elem(), "@id")
+	return ok
+}
+
+// structMarshaler marshals structs into individuals and keeps track of the marshaled URIs to handle cyclic references.
+type structMarshaler struct {
+	indivs  []OntologyIndividual
+	visited map[string]bool
+}
+
+// marshal marshals the struct value and its nested structs and returns the URI of the individual.
+func (m *structMarshaler) marshal(val reflect.Value) (string, error) {
+	idField, ok := structFieldByTag(val, "@id")
+	if !ok || idField.value.Kind() != reflect.String {
+		return "", fmt.Errorf("%w: %s has no string field tagged with '@id'", ErrInvalidStructMapping, val.Type())
+	}
+	uri := idField.value.String()
+	if uri == "" {
+		return "", fmt.Errorf("%w: the '@id' field of %s is empty", ErrInvalidStructMapping, val.Type())
+	}
+	if m.visited[uri] {
+		return uri, nil
+	}
+	m.visited[uri] = true
+	index := len(m.indivs)
+	m.indivs = append(m.indivs, OntologyIndividual{URI: uri})
+	indiv := OntologyIndividual{URI: uri}
+	for _, f := range structFields(val) {
+		if f.property == "@id" {
+			continue
+		}
+		if f.omitEmpty && f.value.IsZero() {
+			continue
+		}
+		if f.property == "@type" {
+			types, err := stringValues(f)
+			if err != nil {
+				return "", err
+			}
+			indiv.Types = append(indiv.Types, types...)
+			continue
+		}
+		if err := m.marshalField(&indiv, f, f.value); err != nil {
+			return "", err
+		}
+	}
+	m.indivs[index] = indiv
+	return uri, nil
+}
+
+// marshalField adds the property values of the field value to the individual.
+func (m *structMarshaler) marshalField(indiv *OntologyIndividual, f structField, val reflect.Value) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		if val.Type() != bigIntType && val.Type() != bigRatType {
+			val = val.Elem()
+		}
+	}
+	switch {
+	case f.ref:
+		uris, err := stringValues(structField{name: f.name, value: val})
+		if err != nil {
+			return err
+		}
+		for _, uri := range uris {
+			indiv.AddObjectProperty(f.property, uri)
+		}
+		return nil
+	case isNestedStruct(val.Type()):
+		uri, err := m.marshal(val)
+		if err != nil {
+			return err
+		}
+		indiv.AddObjectProperty(f.property, uri)
+		return nil
+	case val.Kind() == reflect.Slice && val.Type().Elem().Kind() != reflect.Uint8 && !val.Type().Implements(literalValueType):
+		for i := 0; i < val.Len(); i++ {
+			if err := m.marshalField(indiv, f, val.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	lit, err := marshalLiteral(val)
+	if err != nil {
+		return fmt.Errorf("%w: field %s: %v", ErrInvalidStructMapping, f.name, err)
+	}
+	if !lit.Term().IsLiteral() {
+		// Empty plain strings (e.g. a `LangStringLiteral` without value and language) have no valid term
+		return nil
+	}
+	indiv.AddDataProperty(f.property, lit)
+	return nil
+}
+
+// marshalLiteral converts the value into a literal of the corresponding XSD datatype.
+func marshalLiteral(val reflect.Value) (GenericLiteral, error) {
+	if val.Type().Implements(literalValueType) {
+		return val.Interface().(literalValue).Generic(), nil
+	}
+	switch val.Type() {
+	case timeType:
+		return XSDDateTimeLiteral(val.Interface().(time.Time)).Generic(), nil
+	case durationType:
+		return NewXSDDurationLiteral(time.Duration(val.Int())).Generic(), nil
+	case bigIntType:
+		return XSDBigIntegerLiteral{Int: val.Interface().(*big.Int)}.Generic(), nil
+	case bigRatType:
+		return XSDBigDecimalLiteral{Rat: val.Interface().(*big.Rat)}.Generic(), nil
+	}
+	switch val.Kind() {
+	case reflect.String:
+		return XSDStringLiteral(val.String()).Generic(), nil
+	case reflect.Bool:
+		return XSDBooleanLiteral(val.Bool()).Generic(), nil
+	case reflect.Int:
+		return XSDIntegerLiteral(val.Int()).Generic(), nil
+	case reflect.Int64:
+		return XSDLongLiteral(val.Int()).Generic(), nil
+	case reflect.Int32:
+		return XSDIntLiteral(val.Int()).Generic(), nil
+	case reflect.Int16:
+		return XSDShortLiteral(val.Int()).Generic(), nil
+	case reflect.Int8:
+		return XSDByteLiteral(val.Int()).Generic(), nil
+	case reflect.Uint, reflect.Uint64:
+		return XSDUnsignedLongLiteral(val.Uint()).Generic(), nil
+	case reflect.Uint32:
+		return XSDUnsignedIntLiteral(val.Uint()).Generic(), nil
+	case reflect.Uint16:
+		return XSDUnsignedShortLiteral(val.Uint()).Generic(), nil
+	case reflect.Uint8:
+		return XSDUnsignedByteLiteral(val.Uint()).Generic(), nil
+	case reflect.Float32:
+		return XSDFloatLiteral(val.Float()).Generic(), nil
+	case reflect.Float64:
+		return XSDDoubleLiteral(val.Float()).Generic(), nil
+	case reflect.Slice:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			return XSDBase64BinaryLiteral(val.Bytes()).Generic(), nil
+		}
+	}
+	return GenericLiteral{}, fmt.Errorf("unsupported type %s", val.Type())
+}
+
+// stringValues returns the values of the string or string slice field.
+func stringValues(f structField) ([]string, error) {
+	val := f.value
+	switch {
+	case val.Kind() == reflect.String:
+		if val.String() == "" {
+			return []string{}, nil
+		}
+		return []string{val.String()}, nil
+	case val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.String:
+		values := []string{}
+		for i := 0; i < val.Len(); i++ {
+			values = append(values, val.Index(i).String())
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("%w: field %s must be a string or string slice", ErrInvalidStructMapping, f.name)
+}
+
+// setStringValues sets the string or string slice field to the values.
+func setStringValues(f structField, values []string) error {
+	val := f.value
+	switch {
+	case val.Kind() == reflect.String:
+		if len(values) > 0 {
+			val.SetString(values[0])
+		}
+		return nil
+	case val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.String:
+		slice := reflect.MakeSlice(val.Type(), len(values), len(values))
+		for i, value := range values {
+			slice.Index(i).SetString(value)
+		}
+		val.Set(slice)
+		return nil
+	}
+	return fmt.Errorf("%w: field %s must be a string or string slice", ErrInvalidStructMapping, f.name)
+}
+
+// structUnmarshaler unmarshals individuals into structs and keeps track of the loaded structs to resolve cyclic references.
+type structUnmarshaler struct {
+	lookup     func(uri string) (OntologyIndividual, error)
+	loaded     map[string]reflect.Value
+	inProgress map[string]bool
+}
+
+// unmarshal sets the fields of the struct value from the individual.
+func (u *structUnmarshaler) unmarshal(indiv OntologyIndividual, val reflect.Value) error {
+	key := indiv.URI + " " + val.Type().String()
+	u.inProgress[key] = true
+	defer delete(u.inProgress, key)
+	for _, f := range structFields(val) {
+		var err error
+		switch {
+		case f.property == "@id":
+			err = setStringValues(f, []string{indiv.URI})
+		case f.property == "@type":
+			err = setStringValues(f, indiv.Types)
+		case f.ref:
+			err = setStringValues(f, indiv.ObjectProperties[f.property])
+		case isNestedStruct(f.value.Type()) || (f.value.Kind() == reflect.Slice && isNestedStruct(f.value.Type().Elem())):
+			err = u.unmarshalNested(f, indiv.ObjectProperties[f.property])
+		default:
+			err = u.unmarshalLiterals(f, indiv.DataProperties[f.property])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unmarshalNested sets the nested struct (or slice of nested structs) field to the individuals with the given URIs.
+func (u *structUnmarshaler) unmarshalNested(f structField, uris []string) error {
+	if f.value.Kind() != reflect.Slice {
+		if len(uris) == 0 {
+			return nil
+		}
+		return u.unmarshalNestedValue(uris[0], f.value)
+	}
+	slice := reflect.MakeSlice(f.value.Type(), len(uris), len(uris))
+	for i, uri := range uris {
+		if err := u.unmarshalNestedValue(uri, slice.Index(i)); err != nil {
+			return err
+		}
+	}
+	f.value.Set(slice)
+	return nil
+}
+
+// unmarshalNestedValue loads the individual with the URI into the struct or struct pointer value.
+func (u *structUnmarshaler) unmarshalNestedValue(uri string, val reflect.Value) error {
+	structType := val.Type()
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	key := uri + " " + structType.String()
+	if ptr, ok := u.loaded[key]; ok {
+		if val.Kind() == reflect.Ptr {
+			val.Set(ptr)
+			return nil
+		}
+		if u.inProgress[key] {
+			// Value structs cannot refer to a struct that is still being loaded, so only the URI is set
+			idField, _ := structFieldByTag(val, "@id")
+			return setStringValues(idField, []string{uri})
+		}
+		val.Set(ptr.Elem())
+		return nil
+	}
+	if u.lookup == nil {
+		return fmt.Errorf("%w: no lookup function to load <%s>", ErrInvalidStructMapping, uri)
+	}
+	indiv, err := u.lookup(uri)
+	if err != nil {
+		return err
+	}
+	ptr := reflect.New(structType)
+	u.loaded[key] = ptr
+	if err := u.unmarshal(indiv, ptr.Elem()); err != nil {
+		return err
+	}
+	if val.Kind() == reflect.Ptr {
+		val.Set(ptr)
+	} else {
+		val.Set(ptr.Elem())
+	}
+	return nil
+}
+
+// unmarshalLiterals sets the field to the literals, i.e. to the first literal for scalar fields and to all literals for slice fields.
+func (u *structUnmarshaler) unmarshalLiterals(f structField, lits []GenericLiteral) error {
+	val := f.value
+	isList := val.Kind() == reflect.Slice && val.Type().Elem().Kind() != reflect.Uint8 && literalDecoders[val.Type()] == nil
+	if !isList {
+		if len(lits) == 0 {
+			return nil
+		}
+		if err := unmarshalLiteral(&lits[0], val); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+		return nil
+	}
+	slice := reflect.MakeSlice(val.Type(), len(lits), len(lits))
+	for i := range lits {
+		if err := unmarshalLiteral(&lits[i], slice.Index(i)); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+	val.Set(slice)
+	return nil
+}
+
+// unmarshalLiteral converts the literal into the type of the value and sets it, allocating pointers as needed.
+func unmarshalLiteral(l *GenericLiteral, val reflect.Value) error {
+	if val.Kind() == reflect.Ptr && val.Type() != bigIntType && val.Type() != bigRatType {
+		ptr := reflect.New(val.Type().Elem())
+		if err := unmarshalLiteral(l, ptr.Elem()); err != nil {
+			return err
+		}
+		val.Set(ptr)
+		return nil
+	}
+	if decode, ok := literalDecoders[val.Type()]; ok {
+		decoded, err := decode(l)
+		if err != nil {
+			return err
+		}
+		val.Set(reflect.ValueOf(decoded))
+		return nil
+	}
+	var err error
+	switch val.Type() {
+	case timeType:
+		var t time.Time
+		layout, ok := xsdTemporalLayouts[l.Type().URI]
+		if !ok {
+			return ErrLiteralTypeMismatch
+		}
+		t, err = l.parseTemporal(l.Type().URI, layout)
+		if err == nil {
+			val.Set(reflect.ValueOf(t))
+		}
+	case durationType:
+		var d XSDDurationLiteral
+		d, err = l.ToXSDDuration()
+		if err == nil {
+			var dur time.Duration
+			if dur, err = d.Duration(); err == nil {
+				val.SetInt(int64(dur))
+			}
+		}
+	case bigIntType:
+		var i XSDBigIntegerLiteral
+		i, err = l.ToXSDBigInteger()
+		if err == nil {
+			val.Set(reflect.ValueOf(i.Int))
+		}
+	case bigRatType:
+		var r XSDBigDecimalLiteral
+		r, err = l.ToXSDBigDecimal()
+		if err == nil {
+			val.Set(reflect.ValueOf(r.Rat))
+		}
+	default:
+		err = unmarshalLiteralKind(l, val)
+	}
+	return err
+}
+
+// unmarshalLiteralKind converts the literal into a value of the basic kind of the value and sets it. Numeric literals are coerced.
+func unmarshalLiteralKind(l *GenericLiteral, val reflect.Value) error {
+	coerce := []LiteralOption{WithNumericCoercion()}
+	switch val.Kind() {
+	case reflect.String:
+		val.SetString(l.Value())
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(l.Value()))
+		if err != nil {
+			return err
+		}
+		val.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := l.parseInt(XSDLong, val.Type().Bits(), coerce)
+		if err != nil {
+			return err
+		}
+		val.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := l.parseUint(XSDUnsignedLong, val.Type().Bits(), coerce)
+		if err != nil {
+			return err
+		}
+		val.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := l.ToXSDDouble(coerce...)
+		if err != nil {
+			return err
+		}
+		val.SetFloat(float64(f))
+	case reflect.Slice:
+		if val.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("%w: unsupported type %s", ErrInvalidStructMapping, val.Type())
+		}
+		if l.Type().URI == XSDHexBinary {
+			data, err := l.ToXSDHexBinary()
+			if err != nil {
+				return err
+			}
+			val.SetBytes(data)
+			return nil
+		}
+		data, err := l.ToXSDBase64Binary()
+		if err != nil {
+			return err
+		}
+		val.SetBytes(data)
+	default:
+		return fmt.Errorf("%w: unsupported type %s", ErrInvalidStructMapping, val.Type())
+	}
+	return nil
+}
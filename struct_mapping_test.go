@@ -0,0 +1,136 @@
+package ontograph_test
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+type mappedAddress struct {
+	URI    string `onto:"@id"`
+	Street string `onto:"http://example.com/onto#street"`
+}
+
+type mappedPerson struct {
+	URI      string            `onto:"@id"`
+	Types    []string          `onto:"@type"`
+	Name     string            `onto:"http://example.com/onto#name"`
+	Age      int               `onto:"http://example.com/onto#age"`
+	Height   *float64          `onto:"http://example.com/onto#height"`
+	Nickname string            `onto:"http://example.com/onto#nickname,omitempty"`
+	Tags     []string          `onto:"http://example.com/onto#tag"`
+	Born     XSDDateLiteral    `onto:"http://example.com/onto#born"`
+	Motto    LangStringLiteral `onto:"http://example.com/onto#motto"`
+	Balance  *big.Int          `onto:"http://example.com/onto#balance"`
+	Employer string            `onto:"http://example.com/onto#worksFor,ref"`
+	Address  mappedAddress     `onto:"http://example.com/onto#address"`
+	Friends  []*mappedPerson   `onto:"http://example.com/onto#knows"`
+	Ignored  string
+}
+
+var _ = Describe("Struct mapping", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should marshal structs into individuals", func() {
+		height := 1.75
+		alice := mappedPerson{
+			URI:      ns + "alice",
+			Types:    []string{ns + "Person"},
+			Name:     "Alice",
+			Age:      42,
+			Height:   &height,
+			Tags:     []string{"a", "b"},
+			Born:     XSDDateLiteral(time.Date(1980, 5, 1, 0, 0, 0, 0, time.UTC)),
+			Motto:    LangStringLiteral{Value: "Carpe diem", Lang: "la"},
+			Balance:  big.NewInt(1000),
+			Employer: ns + "acme",
+			Address:  mappedAddress{URI: ns + "home", Street: "Main St"},
+			Ignored:  "ignored",
+		}
+		indivs, err := MarshalIndividual(&alice)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indivs).To(HaveLen(2))
+		Expect(indivs[0].URI).To(Equal(ns + "alice"))
+		Expect(indivs[0].Types).To(Equal([]string{ns + "Person"}))
+		Expect(indivs[0].ObjectProperties).To(Equal(map[string][]string{ns + "worksFor": {ns + "acme"}, ns + "address": {ns + "home"}}))
+		terms := map[string][]Term{}
+		for prop, lits := range indivs[0].DataProperties {
+			for _, lit := range lits {
+				terms[prop] = append(terms[prop], lit.Term())
+			}
+		}
+		Expect(terms).To(Equal(map[string][]Term{
+			ns + "name":    {NewLiteralTerm("Alice", "", XSDString)},
+			ns + "age":     {NewLiteralTerm("42", "", XSDInteger)},
+			ns + "height":  {NewLiteralTerm("1.75", "", XSDDouble)},
+			ns + "tag":     {NewLiteralTerm("a", "", XSDString), NewLiteralTerm("b", "", XSDString)},
+			ns + "born":    {NewLiteralTerm("1980-05-01", "", XSDDate)},
+			ns + "motto":   {NewLiteralTerm("Carpe diem", "la", "")},
+			ns + "balance": {NewLiteralTerm("1000", "", XSDInteger)},
+		}))
+		Expect(indivs[1].URI).To(Equal(ns + "home"))
+	})
+
+	It("should round-trip structs with nested and cyclic references through the graph", func() {
+		alice := &mappedPerson{URI: ns + "alice", Types: []string{ns + "Person"}, Name: "Alice", Age: 42, Address: mappedAddress{URI: ns + "home", Street: "Main St"}}
+		bob := &mappedPerson{URI: ns + "bob", Name: "Bob", Age: 7, Friends: []*mappedPerson{alice}, Address: mappedAddress{URI: ns + "home", Street: "Main St"}}
+		alice.Friends = []*mappedPerson{bob}
+		Expect(ont.UpsertStruct(alice)).To(Succeed())
+
+		loaded := mappedPerson{}
+		Expect(ont.GetStruct(ns+"alice", &loaded)).To(Succeed())
+		Expect(loaded.Name).To(Equal("Alice"))
+		Expect(loaded.Age).To(Equal(42))
+		Expect(loaded.Types).To(ContainElement(ns + "Person"))
+		Expect(loaded.Address).To(Equal(mappedAddress{URI: ns + "home", Street: "Main St"}))
+		Expect(loaded.Friends).To(HaveLen(1))
+		Expect(loaded.Friends[0].Name).To(Equal("Bob"))
+		Expect(loaded.Friends[0].Friends).To(Equal([]*mappedPerson{&loaded}))
+	})
+
+	It("should coerce numeric literals and allocate pointers when unmarshaling", func() {
+		indiv := OntologyIndividual{URI: ns + "carol"}
+		indiv.AddDataProperty(ns+"age", XSDLongLiteral(30).Generic())
+		indiv.AddDataProperty(ns+"height", XSDDecimalLiteral(1.6).Generic())
+		indiv.AddDataProperty(ns+"balance", *NewGenericLiteral(NewLiteralTerm("123456789012345678901234567890", "", XSDInteger)))
+		person := mappedPerson{}
+		Expect(UnmarshalIndividual(indiv, &person, nil)).To(Succeed())
+		Expect(person.Age).To(Equal(30))
+		Expect(*person.Height).To(Equal(1.6))
+		Expect(person.Balance.String()).To(Equal("123456789012345678901234567890"))
+	})
+
+	It("should reject values of the wrong type", func() {
+		indiv := OntologyIndividual{URI: ns + "dave"}
+		indiv.AddDataProperty(ns+"age", XSDBooleanLiteral(true).Generic())
+		err := UnmarshalIndividual(indiv, &mappedPerson{}, nil)
+		Expect(errors.Is(err, ErrLiteralTypeMismatch)).To(BeTrue())
+	})
+
+	It("should reject unmappable structs", func() {
+		_, err := MarshalIndividual(mappedPerson{Name: "No URI"})
+		Expect(errors.Is(err, ErrInvalidStructMapping)).To(BeTrue())
+		_, err = MarshalIndividual(struct {
+			URI string   `onto:"@id"`
+			Ch  chan int `onto:"http://example.com/onto#ch"`
+		}{URI: ns + "x", Ch: make(chan int)})
+		Expect(errors.Is(err, ErrInvalidStructMapping)).To(BeTrue())
+		Expect(errors.Is(UnmarshalIndividual(OntologyIndividual{}, mappedPerson{}, nil), ErrInvalidStructMapping)).To(BeTrue())
+	})
+})
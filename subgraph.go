@@ -0,0 +1,45 @@
+package ontograph
+
+// ExtractSubgraph copies the resource and everything reachable from it through object properties up to the given depth into a new
+// memory store, e.g. to export, cache or process a single entity offline. Depth 0 only copies the triples of the resource itself,
+// depth 1 also the triples of the resources it references and so on. A negative depth follows all references. Blank nodes are copied
+// completely together with the resource that references them, and `rdf:type` references are not followed (i.e. class definitions are
+// not part of the subgraph). Errors with `ErrResourceNotFound` if the resource has no triples.
+func (ont *OntologyGraph) ExtractSubgraph(rootURI string, depth int) (GraphStore, error) {
+	rootURI = ont.prefixes.Expand(rootURI)
+	subgraph := NewMemoryStore(ont.GetURI())
+	visited := map[Term]bool{NewResourceTerm(rootURI): true}
+	level := []Term{NewResourceTerm(rootURI)}
+	for d := 0; len(level) > 0; d++ {
+		next := []Term{}
+		for len(level) > 0 {
+			t := level[0]
+			level = level[1:]
+			trps, err := ont.graph.GetAllMatches(t.String(), "", "")
+			if err != nil {
+				return nil, wrapResourceError("ExtractSubgraph", rootURI, err)
+			}
+			if len(trps) == 0 && d == 0 && !t.IsBlankNode() {
+				return nil, wrapResourceError("ExtractSubgraph", rootURI, ErrResourceNotFound)
+			}
+			if err := subgraph.AddTriplesUnchecked(trps); err != nil {
+				return nil, wrapResourceError("ExtractSubgraph", rootURI, err)
+			}
+			for _, trp := range trps {
+				if visited[trp.Object] || trp.Predicate == NewResourceTerm(RDFType) {
+					continue
+				}
+				if trp.Object.IsBlankNode() {
+					// Blank nodes belong to the description of the current resource
+					visited[trp.Object] = true
+					level = append(level, trp.Object)
+				} else if trp.Object.IsResource() && (depth < 0 || d < depth) {
+					visited[trp.Object] = true
+					next = append(next, trp.Object)
+				}
+			}
+		}
+		level = next
+	}
+	return subgraph, nil
+}
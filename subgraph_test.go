@@ -0,0 +1,70 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Subgraph extraction", func() {
+	const ns = "http://example.com/onto#"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:Order a owl:Class ; :note "class definitions are not followed" .
+:order1 a owl:NamedIndividual , :Order ;
+  :customer :alice ;
+  :address _:a .
+_:a :street "Main St" ; :city :berlin .
+:alice a owl:NamedIndividual ; :employer :acme .
+:berlin :country :germany .
+:acme :name "ACME" .
+:germany :name "Germany" .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	subjects := func(store GraphStore) []string {
+		trps, err := store.GetAllTriples()
+		Expect(err).NotTo(HaveOccurred())
+		seen := map[string]bool{}
+		uris := []string{}
+		for _, trp := range trps {
+			if trp.Subject.IsResource() && !seen[trp.Subject.Value()] {
+				seen[trp.Subject.Value()] = true
+				uris = append(uris, trp.Subject.Value())
+			}
+		}
+		return uris
+	}
+
+	It("should extract the resource with its blank nodes", func() {
+		subgraph, err := ont.ExtractSubgraph(ns+"order1", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subgraph.GetURI()).To(Equal(ont.GetURI()))
+		Expect(subgraph.Size()).To(Equal(6))
+		Expect(subjects(subgraph)).To(ConsistOf(ns + "order1"))
+	})
+
+	It("should follow object properties up to the depth", func() {
+		subgraph, err := ont.ExtractSubgraph(ns+"order1", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subjects(subgraph)).To(ConsistOf(ns+"order1", ns+"alice", ns+"berlin"))
+		subgraph, err = ont.ExtractSubgraph(ns+"order1", -1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(subjects(subgraph)).To(ConsistOf(ns+"order1", ns+"alice", ns+"berlin", ns+"acme", ns+"germany"))
+	})
+
+	It("should error for missing resources", func() {
+		_, err := ont.ExtractSubgraph(ns+"missing", 1)
+		Expect(err).To(MatchError(ErrResourceNotFound))
+	})
+})
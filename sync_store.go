@@ -0,0 +1,160 @@
+package ontograph
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// SyncStore wraps a graph store and makes it safe for concurrent use, e.g. to share a `MemoryStore` between the handlers of a server.
+// Reads may run concurrently, while every change holds the store exclusively. Changes of several operations are only atomic within a
+// transaction (see `Begin`).
+type SyncStore struct {
+	GraphStore
+	mu *sync.RWMutex
+}
+
+// NewSyncStore wraps the given store into a store that is safe for concurrent use.
+func NewSyncStore(store GraphStore) *SyncStore {
+	return &SyncStore{GraphStore: store, mu: &sync.RWMutex{}}
+}
+
+// WithContext returns a copy of the store bound to the context (see `StoreWithContext`). The copy shares the lock of the store.
+func (store *SyncStore) WithContext(ctx context.Context) GraphStore {
+	return &SyncStore{GraphStore: StoreWithContext(store.GraphStore, ctx), mu: store.mu}
+}
+
+// GetFirstMatch retrieves the first triple that matches the pattern. Empty strings in subject, predicate or object are treated as
+// wildcards.
+func (store *SyncStore) GetFirstMatch(subj, pred, obj string) (*Triple, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return store.GraphStore.GetFirstMatch(subj, pred, obj)
+}
+
+// GetAllMatches retrieves all triples that match the pattern. Empty strings in subject, predicate or object are treated as wildcards.
+func (store *SyncStore) GetAllMatches(subj, pred, obj string) ([]Triple, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return store.GraphStore.GetAllMatches(subj, pred, obj)
+}
+
+// DeleteAllMatches removes all triples that match the pattern. Empty strings in subject, predicate or object are treated as wildcards.
+func (store *SyncStore) DeleteAllMatches(subj, pred, obj string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.GraphStore.DeleteAllMatches(subj, pred, obj)
+}
+
+// GetAllTriples returns all triples in the store.
+func (store *SyncStore) GetAllTriples() ([]Triple, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return store.GraphStore.GetAllTriples()
+}
+
+// AddTriple adds the triple to the store. If the triple already exists, it errors with `ErrTripleAlreadyExists`.
+func (store *SyncStore) AddTriple(trp Triple) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.GraphStore.AddTriple(trp)
+}
+
+// AddTriples adds all the triples to the store. If one of the triples already exists, it errors with `ErrTripleAlreadyExists`.
+func (store *SyncStore) AddTriples(trps []Triple) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.GraphStore.AddTriples(trps)
+}
+
+// AddTripleUnchecked adds the triple to the store. It does not error if the triple already exists.
+func (store *SyncStore) AddTripleUnchecked(trp Triple) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.GraphStore.AddTripleUnchecked(trp)
+}
+
+// AddTriplesUnchecked adds all the triples to the store. It does not error if any of the triples already exists.
+func (store *SyncStore) AddTriplesUnchecked(trps []Triple) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.GraphStore.AddTriplesUnchecked(trps)
+}
+
+// DeleteTriple removes the triple from the store. If the triple does not exist, it errors with `ErrTripleDoesNotExist`.
+func (store *SyncStore) DeleteTriple(trp Triple) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.GraphStore.DeleteTriple(trp)
+}
+
+// DeleteTriples removes all the triples from the store. If one of the triples does not exist, it errors with `ErrTripleDoesNotExist`.
+func (store *SyncStore) DeleteTriples(trps []Triple) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.GraphStore.DeleteTriples(trps)
+}
+
+// DeleteTripleUnchecked removes the triple from the store. It does not error if the triple does not exist.
+func (store *SyncStore) DeleteTripleUnchecked(trp Triple) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.GraphStore.DeleteTripleUnchecked(trp)
+}
+
+// DeleteTriplesUnchecked removes all the triples from the store. It does not error if any of the triples does not exist.
+func (store *SyncStore) DeleteTriplesUnchecked(trps []Triple) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.GraphStore.DeleteTriplesUnchecked(trps)
+}
+
+// Drop removes all triples from the store.
+func (store *SyncStore) Drop() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.GraphStore.Drop()
+}
+
+// SerializeToTurtle writes the entire store into the writer in Turtle (TTL) format.
+func (store *SyncStore) SerializeToTurtle(w io.Writer, pretty bool) error {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return store.GraphStore.SerializeToTurtle(w, pretty)
+}
+
+// SerializeToTurtleWithOptions writes the entire store into the writer in Turtle (TTL) format with the serialize options.
+func (store *SyncStore) SerializeToTurtleWithOptions(w io.Writer, pretty bool, opts ...SerializeOption) error {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return SerializeToTurtle(store.GraphStore, w, pretty, opts...)
+}
+
+// Size returns the total number of triples in the store.
+func (store *SyncStore) Size() (int, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	return store.GraphStore.Size()
+}
+
+// Begin starts a new transaction on the store (see `Begin`). Reads through the transaction lock the store like any other read, the
+// changes are committed while holding the store exclusively, with a transaction on the wrapped store.
+func (store *SyncStore) Begin() (Tx, error) {
+	return newBufferedTx(store, func(deleted, added []Triple) error {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		tx, err := Begin(store.GraphStore)
+		if err != nil {
+			return err
+		}
+		if err := tx.DeleteTriplesUnchecked(deleted); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := tx.AddTriplesUnchecked(added); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	}), nil
+}
@@ -0,0 +1,55 @@
+package ontograph_test
+
+import (
+	"fmt"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Synchronized store", func() {
+	const testUri = "http://example.com/onto"
+	classTrp := func(name string) Triple {
+		return Triple{Subject: NewResourceTerm(testUri + "#" + name), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)}
+	}
+	var store *SyncStore
+
+	BeforeEach(func() {
+		store = NewSyncStore(NewMemoryStore(testUri))
+	})
+
+	It("should allow concurrent reads and writes", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					trp := classTrp(fmt.Sprintf("C%d_%d", i, j))
+					bnode := Triple{Subject: NewBlankNodeTerm(fmt.Sprintf("b%d_%d", i, j)), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLRestriction)}
+					Expect(store.AddTriples([]Triple{trp, bnode})).To(Succeed())
+					Expect(store.GetFirstMatch(trp.Subject.String(), "", "")).NotTo(BeNil())
+					_, err := store.GetAllMatches(bnode.Subject.String(), "", "")
+					Expect(err).NotTo(HaveOccurred())
+				}
+			}(i)
+		}
+		wg.Wait()
+		Expect(store.GetAllTriples()).To(HaveLen(800))
+	})
+
+	It("should commit transactions atomically", func() {
+		Expect(store.AddTriple(classTrp("A"))).To(Succeed())
+		tx, err := Begin(store)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tx.DeleteTriple(classTrp("A"))).To(Succeed())
+		Expect(tx.AddTriple(classTrp("B"))).To(Succeed())
+		Expect(store.GetAllTriples()).To(ConsistOf(classTrp("A")))
+		Expect(tx.Commit()).To(Succeed())
+		Expect(store.GetAllTriples()).To(ConsistOf(classTrp("B")))
+	})
+})
@@ -0,0 +1,134 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+)
+
+// SyntheticConfig configures the size and shape of a synthetic ontology (see `GenerateSyntheticOntology`).
+type SyntheticConfig struct {
+	// ClassDepth is the number of levels of the class tree.
+	ClassDepth int
+	// ClassBranching is the number of root classes and of subclasses per class.
+	ClassBranching int
+	// IndividualsPerClass is the number of individuals generated for each class.
+	IndividualsPerClass int
+	// ObjectProperties is the number of object properties.
+	ObjectProperties int
+	// DataProperties is the number of data properties. Each individual gets an integer value for each data property.
+	DataProperties int
+	// PropertyFanOut is the number of object property relations of each individual to other (randomly selected) individuals.
+	PropertyFanOut int
+	// Seed initializes the random number generator, so the same configuration always yields the same ontology.
+	Seed int64
+	// BatchSize is the number of triples added to the store at once. Defaults to 10000.
+	BatchSize int
+}
+
+// GenerateSyntheticOntology initializes a new ontology on the graph store and fills it with synthetic classes, properties and individuals
+// according to the configuration, e.g. for benchmarks, load tests and demo environments. Classes form a tree of the given depth and
+// branching, individuals are typed with their class and related via randomly selected object properties.
+func GenerateSyntheticOntology(graph GraphStore, cfg SyntheticConfig) (*OntologyGraph, error) {
+	if cfg.ClassDepth < 0 || cfg.ClassBranching < 0 || cfg.IndividualsPerClass < 0 || cfg.ObjectProperties < 0 || cfg.DataProperties < 0 || cfg.PropertyFanOut < 0 {
+		return nil, errors.New("Synthetic ontology configuration must not contain negative values")
+	}
+	if cfg.PropertyFanOut > 0 && cfg.ObjectProperties == 0 {
+		return nil, errors.New("Property fan-out requires at least one object property")
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 10000
+	}
+	ont, err := InitOntologyGraph(graph)
+	if err != nil {
+		return nil, err
+	}
+	rnd := rand.New(rand.NewSource(cfg.Seed))
+	batch := make([]Triple, 0, cfg.BatchSize)
+	add := func(trps []Triple) error {
+		for _, trp := range trps {
+			batch = append(batch, trp)
+			if len(batch) == cfg.BatchSize {
+				if err := graph.AddTriplesUnchecked(batch); err != nil {
+					return err
+				}
+				batch = batch[:0]
+			}
+		}
+		return nil
+	}
+	uri := graph.GetURI()
+
+	// Generate class tree level by level
+	classURIs := []string{}
+	level := []string{""}
+	for depth := 0; depth < cfg.ClassDepth; depth++ {
+		nextLevel := []string{}
+		for _, parent := range level {
+			for i := 1; i <= cfg.ClassBranching; i++ {
+				path := parent + "_" + strconv.Itoa(i)
+				class := OntologyClass{URI: uri + "#Class" + path, Label: map[string]string{"en": "Class" + path}}
+				if parent != "" {
+					class.SubClassOf = []string{uri + "#Class" + parent}
+				}
+				if err := add(class.ToTriples()); err != nil {
+					return nil, err
+				}
+				classURIs = append(classURIs, class.URI)
+				nextLevel = append(nextLevel, path)
+			}
+		}
+		level = nextLevel
+	}
+
+	// Generate properties
+	objPropURIs := make([]string, cfg.ObjectProperties)
+	for i := range objPropURIs {
+		prop := OntologyObjectProperty{URI: fmt.Sprintf("%s#objectProperty%d", uri, i+1), Label: map[string]string{"en": fmt.Sprintf("object property %d", i+1)}}
+		if err := add(prop.ToTriples()); err != nil {
+			return nil, err
+		}
+		objPropURIs[i] = prop.URI
+	}
+	dataPropURIs := make([]string, cfg.DataProperties)
+	for i := range dataPropURIs {
+		prop := OntologyDataProperty{URI: fmt.Sprintf("%s#dataProperty%d", uri, i+1), Ranges: []string{XSDInteger}, Label: map[string]string{"en": fmt.Sprintf("data property %d", i+1)}}
+		if err := add(prop.ToTriples()); err != nil {
+			return nil, err
+		}
+		dataPropURIs[i] = prop.URI
+	}
+
+	// Generate individuals (URIs first, so relations can target any individual)
+	indivURIs := make([]string, 0, len(classURIs)*cfg.IndividualsPerClass)
+	for range classURIs {
+		for i := 0; i < cfg.IndividualsPerClass; i++ {
+			indivURIs = append(indivURIs, fmt.Sprintf("%s#individual%d", uri, len(indivURIs)+1))
+		}
+	}
+	for n, indivURI := range indivURIs {
+		indiv := OntologyIndividual{
+			URI:   indivURI,
+			Types: []string{classURIs[n/cfg.IndividualsPerClass]},
+			Label: map[string]string{"en": fmt.Sprintf("individual %d", n+1)},
+		}
+		for i := 0; i < cfg.PropertyFanOut; i++ {
+			indiv.AddObjectProperty(objPropURIs[rnd.Intn(len(objPropURIs))], indivURIs[rnd.Intn(len(indivURIs))])
+		}
+		for _, prop := range dataPropURIs {
+			indiv.AddDataProperty(prop, XSDIntegerLiteral(rnd.Intn(1000)).Generic())
+		}
+		if err := add(indiv.ToTriples()); err != nil {
+			return nil, err
+		}
+	}
+
+	// Flush remaining triples
+	if len(batch) > 0 {
+		if err := graph.AddTriplesUnchecked(batch); err != nil {
+			return nil, err
+		}
+	}
+	return ont, nil
+}
@@ -0,0 +1,56 @@
+package ontograph_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Synthetic ontologies", func() {
+	const testUri = "http://example.com/synthetic"
+	cfg := SyntheticConfig{
+		ClassDepth:          3,
+		ClassBranching:      2,
+		IndividualsPerClass: 3,
+		ObjectProperties:    2,
+		DataProperties:      1,
+		PropertyFanOut:      2,
+		Seed:                42,
+		BatchSize:           50,
+	}
+
+	It("should generate an ontology of the configured shape", func() {
+		ont, err := GenerateSyntheticOntology(NewMemoryStore(testUri), cfg)
+		Expect(err).NotTo(HaveOccurred())
+		// 2 + 4 + 8 classes with 3 individuals each
+		indivs, err := ont.GetIndividuals(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indivs).To(HaveLen(42))
+		class, err := ont.GetClass(testUri + "#Class_2_1_2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.SubClassOf).To(ConsistOf(testUri + "#Class_2_1"))
+		indiv, err := ont.GetIndividual(testUri + "#individual1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indiv.Types).To(ConsistOf(testUri + "#Class_1"))
+		Expect(indiv.DataProperties[testUri+"#dataProperty1"]).To(HaveLen(1))
+	})
+
+	It("should be deterministic for the same seed", func() {
+		store1, store2 := NewMemoryStore(testUri), NewMemoryStore(testUri)
+		_, err := GenerateSyntheticOntology(store1, cfg)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = GenerateSyntheticOntology(store2, cfg)
+		Expect(err).NotTo(HaveOccurred())
+		trps1, err := store1.GetAllTriples()
+		Expect(err).NotTo(HaveOccurred())
+		trps2, err := store2.GetAllTriples()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trps1).To(ConsistOf(trps2))
+	})
+
+	It("should reject invalid configurations", func() {
+		_, err := GenerateSyntheticOntology(NewMemoryStore(testUri), SyntheticConfig{PropertyFanOut: 1})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,381 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// A Tx is a transaction on a graph store. All changes made through the transaction are buffered and only applied to the store on
+// `Commit`, so they either apply completely or not at all. Reads through the transaction see the store with the buffered changes
+// applied. After `Commit` or `Rollback`, all methods error with `ErrTransactionClosed`.
+type Tx interface {
+	GraphStore
+	// Commit should apply all changes of the transaction to the store.
+	Commit() error
+	// Rollback should discard all changes of the transaction.
+	Rollback() error
+}
+
+// A Transactor begins transactions natively. It is implemented by graph stores that can apply a change set atomically.
+type Transactor interface {
+	// Begin should start a new transaction on the store.
+	Begin() (Tx, error)
+}
+
+// Begin starts a new transaction on the store. Stores that implement `Transactor` begin the transaction themselves. For all other
+// stores, the changes are buffered and applied by deleting and adding the changed triples on commit. If adding fails, the deleted
+// triples are restored, but triples added until then may remain in the store.
+func Begin(store GraphStore) (Tx, error) {
+	if transactor, ok := store.(Transactor); ok {
+		return transactor.Begin()
+	}
+	return newBufferedTx(store, nil), nil
+}
+
+// Begin starts a new transaction on the store. The changes are checked according to the store options (see `WithStoreStrictness`)
+// before any of them is applied.
+func (store *MemoryStore) Begin() (Tx, error) {
+	return newBufferedTx(store, func(deleted, added []Triple) error {
+		if err := store.options.check(store, added); err != nil {
+			return err
+		}
+		if err := store.DeleteTriplesUnchecked(deleted); err != nil {
+			return err
+		}
+		return store.AddTriplesUnchecked(added)
+	}), nil
+}
+
+// Begin starts a new transaction on the store. The changes are applied with a single SPARQL update request, which Blazegraph executes
+// atomically.
+func (store *BlazegraphStore) Begin() (Tx, error) {
+	return newBufferedTx(store, func(deleted, added []Triple) error {
+		if err := store.options.check(store, added); err != nil {
+			return err
+		}
+		sparqlReq := sparqlDataUpdate(store.uri, deleted, added)
+		if sparqlReq == "" {
+			return nil
+		}
+		code, err := store.endpoint.DoSparqlUpdate(store.namespace, sparqlReq)
+		if err != nil {
+			return store.wrapErr("Commit", nil, sparqlReq, err)
+		}
+		if code != http.StatusOK {
+			return store.wrapErr("Commit", nil, sparqlReq, fmt.Errorf("Failed to update graph '%s' on namespace '%s' (HTTP %d)", store.uri, store.namespace, code))
+		}
+		store.options.observeTriples("blazegraph", len(added), len(deleted))
+		return nil
+	}), nil
+}
+
+// Transaction runs fn on a copy of the ontology graph whose store is a transaction (see `Begin`). If fn returns an error, all changes
+// are rolled back and the error is returned, otherwise they are committed. Transactions within fn are part of the running transaction.
+func (ont *OntologyGraph) Transaction(fn func(tx *OntologyGraph) error) error {
+	if _, ok := ont.graph.(Tx); ok {
+		return fn(ont)
+	}
+	tx, err := Begin(ont.graph)
+	if err != nil {
+		return err
+	}
+	txOnt := ont.detached(tx)
+	label, comment := copyStringMap(txOnt.label), copyStringMap(txOnt.comment)
+	if err := fn(txOnt); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	// Only merge the languages changed by the transaction, so concurrent changes of the ontology are kept
+	ont.mu.Lock()
+	defer ont.mu.Unlock()
+	mergeStringMapChanges(ont.label, label, txOnt.label)
+	mergeStringMapChanges(ont.comment, comment, txOnt.comment)
+	return nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrTransactionClosed is raised when a transaction is used after it was committed or rolled back.
+var ErrTransactionClosed error = errors.New("The transaction is already closed")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// detached returns a copy of the ontology on the graph store (e.g. a transaction) with its own label and comment maps and without TBox
+// cache, so its changes do not leak into the ontology.
+func (ont *OntologyGraph) detached(graph GraphStore) *OntologyGraph {
+	ont.mu.RLock()
+	defer ont.mu.RUnlock()
+	copied := *ont
+	copied.graph = graph
+	copied.mu = &sync.RWMutex{}
+	copied.label = copyStringMap(ont.label)
+	copied.comment = copyStringMap(ont.comment)
+	copied.tbox = nil
+	return &copied
+}
+
+// bufferedTx buffers the changes to a store. A triple is never both added and deleted, triples may be added even if they exist in the
+// store and deleted even if they do not exist in the store.
+type bufferedTx struct {
+	store   GraphStore
+	apply   func(deleted, added []Triple) error
+	added   map[Triple]bool
+	deleted map[Triple]bool
+	closed  bool
+}
+
+// newBufferedTx creates a new transaction on the store whose changes are applied with the given function (may be nil to apply them
+// with the methods of the store).
+func newBufferedTx(store GraphStore, apply func(deleted, added []Triple) error) *bufferedTx {
+	return &bufferedTx{store: store, apply: apply, added: map[Triple]bool{}, deleted: map[Triple]bool{}}
+}
+
+// GetURI returns the named graph URI of the store.
+func (tx *bufferedTx) GetURI() string {
+	return tx.store.GetURI()
+}
+
+// GetFirstMatch retrieves the first triple that matches the pattern.
+func (tx *bufferedTx) GetFirstMatch(subj, pred, obj string) (*Triple, error) {
+	trps, err := tx.GetAllMatches(subj, pred, obj)
+	if err != nil || len(trps) == 0 {
+		return nil, err
+	}
+	return &trps[0], nil
+}
+
+// GetAllMatches retrieves all triples of the store that match the pattern with the buffered changes applied.
+func (tx *bufferedTx) GetAllMatches(subj, pred, obj string) ([]Triple, error) {
+	if tx.closed {
+		return nil, ErrTransactionClosed
+	}
+	storeTrps, err := tx.store.GetAllMatches(subj, pred, obj)
+	if err != nil {
+		return nil, err
+	}
+	trps := []Triple{}
+	seen := map[Triple]bool{}
+	for _, trp := range storeTrps {
+		if !tx.deleted[trp] && !seen[trp] {
+			seen[trp] = true
+			trps = append(trps, trp)
+		}
+	}
+	added := []Triple{}
+	for trp := range tx.added {
+		if !seen[trp] && matchesPattern(trp, subj, pred, obj) {
+			added = append(added, trp)
+		}
+	}
+	SortTriples(added)
+	return append(trps, added...), nil
+}
+
+// DeleteAllMatches removes all triples that match the pattern.
+func (tx *bufferedTx) DeleteAllMatches(subj, pred, obj string) error {
+	trps, err := tx.GetAllMatches(subj, pred, obj)
+	if err != nil {
+		return err
+	}
+	return tx.DeleteTriplesUnchecked(trps)
+}
+
+// GetAllTriples returns all triples of the store with the buffered changes applied.
+func (tx *bufferedTx) GetAllTriples() ([]Triple, error) {
+	return tx.GetAllMatches("", "", "")
+}
+
+// AddTriple adds the triple. If the triple already exists, it errors with `ErrTripleAlreadyExists`.
+func (tx *bufferedTx) AddTriple(trp Triple) error {
+	return tx.AddTriples([]Triple{trp})
+}
+
+// AddTriples adds all the triples. If one of the triples already exists, none of them is added and it errors with
+// `ErrTripleAlreadyExists`.
+func (tx *bufferedTx) AddTriples(trps []Triple) error {
+	for _, trp := range trps {
+		exists, err := tx.exists(trp)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return wrapStoreError(tx.store, "transaction", "AddTriples", &trp, "", ErrTripleAlreadyExists)
+		}
+	}
+	return tx.AddTriplesUnchecked(trps)
+}
+
+// AddTripleUnchecked adds the triple. It does not error if the triple already exists.
+func (tx *bufferedTx) AddTripleUnchecked(trp Triple) error {
+	return tx.AddTriplesUnchecked([]Triple{trp})
+}
+
+// AddTriplesUnchecked adds all the triples. It does not error if any of the triples already exists.
+func (tx *bufferedTx) AddTriplesUnchecked(trps []Triple) error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+	for _, trp := range trps {
+		delete(tx.deleted, trp)
+		tx.added[trp] = true
+	}
+	return nil
+}
+
+// DeleteTriple removes the triple. If the triple does not exist, it errors with `ErrTripleDoesNotExist`.
+func (tx *bufferedTx) DeleteTriple(trp Triple) error {
+	return tx.DeleteTriples([]Triple{trp})
+}
+
+// DeleteTriples removes all the triples. If one of the triples does not exist, none of them is removed and it errors with
+// `ErrTripleDoesNotExist`.
+func (tx *bufferedTx) DeleteTriples(trps []Triple) error {
+	for _, trp := range trps {
+		exists, err := tx.exists(trp)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return wrapStoreError(tx.store, "transaction", "DeleteTriples", &trp, "", ErrTripleDoesNotExist)
+		}
+	}
+	return tx.DeleteTriplesUnchecked(trps)
+}
+
+// DeleteTripleUnchecked removes the triple. It does not error if the triple does not exist.
+func (tx *bufferedTx) DeleteTripleUnchecked(trp Triple) error {
+	return tx.DeleteTriplesUnchecked([]Triple{trp})
+}
+
+// DeleteTriplesUnchecked removes all the triples. It does not error if any of the triples does not exist.
+func (tx *bufferedTx) DeleteTriplesUnchecked(trps []Triple) error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+	for _, trp := range trps {
+		delete(tx.added, trp)
+		tx.deleted[trp] = true
+	}
+	return nil
+}
+
+// Drop removes all triples of the store on commit.
+func (tx *bufferedTx) Drop() error {
+	return tx.DeleteAllMatches("", "", "")
+}
+
+// SerializeToTurtle writes the triples of the store with the buffered changes applied into the writer in Turtle (TTL) format.
+func (tx *bufferedTx) SerializeToTurtle(w io.Writer, pretty bool) error {
+	return tx.SerializeToTurtleWithOptions(w, pretty)
+}
+
+// SerializeToTurtleWithOptions writes the triples of the store with the buffered changes applied into the writer in Turtle (TTL) format
+// with the serialize options.
+func (tx *bufferedTx) SerializeToTurtleWithOptions(w io.Writer, pretty bool, opts ...SerializeOption) error {
+	trps, err := tx.GetAllTriples()
+	if err != nil {
+		return err
+	}
+	view := NewMemoryStore(tx.GetURI())
+	if err := view.AddTriplesUnchecked(trps); err != nil {
+		return err
+	}
+	return view.SerializeToTurtleWithOptions(w, pretty, opts...)
+}
+
+// Size returns the number of triples of the store with the buffered changes applied.
+func (tx *bufferedTx) Size() (int, error) {
+	trps, err := tx.GetAllTriples()
+	return len(trps), err
+}
+
+// Commit applies the buffered changes to the store.
+func (tx *bufferedTx) Commit() error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+	tx.closed = true
+	deleted, added := sortedTripleSet(tx.deleted), sortedTripleSet(tx.added)
+	if tx.apply != nil {
+		return tx.apply(deleted, added)
+	}
+	if err := tx.store.DeleteTriplesUnchecked(deleted); err != nil {
+		return err
+	}
+	if err := tx.store.AddTriplesUnchecked(added); err != nil {
+		// Restore the deleted triples
+		_ = tx.store.AddTriplesUnchecked(deleted)
+		return err
+	}
+	return nil
+}
+
+// Rollback discards the buffered changes.
+func (tx *bufferedTx) Rollback() error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+	tx.closed = true
+	tx.added, tx.deleted = map[Triple]bool{}, map[Triple]bool{}
+	return nil
+}
+
+// exists checks if the triple exists in the store with the buffered changes applied.
+func (tx *bufferedTx) exists(trp Triple) (bool, error) {
+	if tx.closed {
+		return false, ErrTransactionClosed
+	}
+	if tx.added[trp] || tx.deleted[trp] {
+		return tx.added[trp], nil
+	}
+	found, err := tx.store.GetFirstMatch(trp.Subject.String(), trp.Predicate.String(), trp.Object.String())
+	return found != nil, err
+}
+
+// matchesPattern returns true if the triple matches the pattern. Empty strings in subject, predicate or object are wildcards.
+func matchesPattern(trp Triple, subj, pred, obj string) bool {
+	return (subj == "" || trp.Subject == Term(subj)) && (pred == "" || trp.Predicate == Term(pred)) && (obj == "" || trp.Object == Term(obj))
+}
+
+// sortedTripleSet returns the triples of the set in canonical order.
+func sortedTripleSet(set map[Triple]bool) []Triple {
+	trps := make([]Triple, 0, len(set))
+	for trp := range set {
+		trps = append(trps, trp)
+	}
+	SortTriples(trps)
+	return trps
+}
+
+// copyStringMap returns a copy of the map.
+func copyStringMap(m map[string]string) map[string]string {
+	copied := make(map[string]string, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
+
+// mergeStringMapChanges applies the entries that differ between the before and after maps to the destination map.
+func mergeStringMapChanges(dst, before, after map[string]string) {
+	for k, v := range after {
+		if old, ok := before[k]; !ok || old != v {
+			dst[k] = v
+		}
+	}
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			delete(dst, k)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package ontograph_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+// plainStore hides the native transactions of the embedded store.
+type plainStore struct {
+	GraphStore
+}
+
+// failingAddStore fails to add triples to the embedded store once.
+type failingAddStore struct {
+	GraphStore
+	failed bool
+}
+
+func (store *failingAddStore) AddTriplesUnchecked(trps []Triple) error {
+	if !store.failed {
+		store.failed = true
+		return errors.New("add failed")
+	}
+	return store.GraphStore.AddTriplesUnchecked(trps)
+}
+
+var _ = Describe("Transactions", func() {
+	const testUri = "http://example.com/onto"
+	classTrp := func(name string) Triple {
+		return Triple{Subject: NewResourceTerm(testUri + "#" + name), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)}
+	}
+	var store *MemoryStore
+
+	BeforeEach(func() {
+		store = NewMemoryStore(testUri)
+		Expect(store.AddTriples([]Triple{classTrp("A"), classTrp("B")})).To(Succeed())
+	})
+
+	for _, native := range []bool{true, false} {
+		native := native
+		begin := func() (Tx, error) {
+			if native {
+				return Begin(store)
+			}
+			return Begin(plainStore{store})
+		}
+
+		It("should buffer the changes until commit", func() {
+			tx, err := begin()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tx.DeleteTriple(classTrp("A"))).To(Succeed())
+			Expect(tx.AddTriple(classTrp("C"))).To(Succeed())
+			Expect(tx.AddTriple(classTrp("B"))).To(MatchError(ErrTripleAlreadyExists))
+			Expect(tx.DeleteTriple(classTrp("A"))).To(MatchError(ErrTripleDoesNotExist))
+			Expect(tx.GetAllTriples()).To(ConsistOf(classTrp("B"), classTrp("C")))
+			Expect(tx.Size()).To(Equal(2))
+			Expect(store.GetAllTriples()).To(ConsistOf(classTrp("A"), classTrp("B")))
+
+			Expect(tx.Commit()).To(Succeed())
+			Expect(store.GetAllTriples()).To(ConsistOf(classTrp("B"), classTrp("C")))
+			Expect(tx.Commit()).To(MatchError(ErrTransactionClosed))
+			_, err = tx.GetAllTriples()
+			Expect(err).To(MatchError(ErrTransactionClosed))
+		})
+
+		It("should discard the changes on rollback", func() {
+			tx, err := begin()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tx.Drop()).To(Succeed())
+			Expect(tx.Size()).To(Equal(0))
+			Expect(tx.Rollback()).To(Succeed())
+			Expect(store.GetAllTriples()).To(ConsistOf(classTrp("A"), classTrp("B")))
+			Expect(tx.AddTripleUnchecked(classTrp("C"))).To(MatchError(ErrTransactionClosed))
+		})
+	}
+
+	It("should restore deleted triples if the emulated commit fails", func() {
+		tx, err := Begin(&failingAddStore{GraphStore: store})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tx.DeleteTriple(classTrp("A"))).To(Succeed())
+		Expect(tx.AddTriple(classTrp("C"))).To(Succeed())
+		Expect(tx.Commit()).NotTo(Succeed())
+		Expect(store.GetAllTriples()).To(ConsistOf(classTrp("A"), classTrp("B")))
+	})
+
+	It("should commit to Blazegraph stores with a single update request", func() {
+		bodies := []string{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				body, _ := ioutil.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+			}
+			w.Write([]byte(`<?xml version="1.0"?><data modified="1" milliseconds="1"/>`))
+		}))
+		defer server.Close()
+		bgStore := NewBlazegraphEndpoint(server.URL).NewBlazegraphStore(testUri, "test")
+		tx, err := bgStore.Begin()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tx.AddTripleUnchecked(classTrp("C"))).To(Succeed())
+		Expect(tx.DeleteTripleUnchecked(classTrp("A"))).To(Succeed())
+		Expect(tx.Commit()).To(Succeed())
+		Expect(bodies).To(HaveLen(1))
+		Expect(bodies[0]).To(ContainSubstring("DELETE+DATA"))
+		Expect(bodies[0]).To(ContainSubstring("INSERT+DATA"))
+	})
+
+	It("should roll back ontology changes if the function fails", func() {
+		ont, err := InitOntologyGraph(NewMemoryStore(testUri))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#A", Label: map[string]string{"en": "A"}})).To(Succeed())
+		failure := errors.New("failure")
+		err = ont.Transaction(func(tx *OntologyGraph) error {
+			if err := tx.UpsertResource(&OntologyClass{URI: testUri + "#A", Label: map[string]string{"en": "Changed"}}); err != nil {
+				return err
+			}
+			if err := tx.UpsertResource(&OntologyClass{URI: testUri + "#B"}); err != nil {
+				return err
+			}
+			return failure
+		})
+		Expect(err).To(MatchError(failure))
+		class, err := ont.GetClass(testUri + "#A")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.Label).To(Equal(map[string]string{"en": "A"}))
+		_, err = ont.GetClass(testUri + "#B")
+		Expect(err).To(MatchError(ErrResourceNotFound))
+
+		Expect(ont.Transaction(func(tx *OntologyGraph) error {
+			return tx.UpsertResource(&OntologyClass{URI: testUri + "#B"})
+		})).To(Succeed())
+		_, err = ont.GetClass(testUri + "#B")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should keep concurrent label changes of the ontology", func() {
+		store := NewSyncStore(NewMemoryStore(testUri))
+		ont, err := InitOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					Expect(ont.UpsertResource(&OntologyClass{URI: fmt.Sprintf("%s#C%d_%d", testUri, i, j)})).To(Succeed())
+					Expect(ont.SetLabel(fmt.Sprintf("Label %d_%d", i, j), "en")).To(Succeed())
+				}
+			}(i)
+		}
+		wg.Wait()
+		labels, err := store.GetAllMatches(NewResourceTerm(testUri).String(), NewResourceTerm(RDFSLabel).String(), "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(labels).To(HaveLen(1))
+		Expect(ont.GetLabel("en")).To(Equal(labels[0].Object.Value()))
+	})
+})
@@ -0,0 +1,118 @@
+package ontograph
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// A TransitiveCloser computes transitive closures of properties. It is implemented by graph stores that can evaluate them natively.
+type TransitiveCloser interface {
+	// TransitiveClosure should return a triple `s p o` for every pair of terms where `o` is reachable from `s` via one or more `p`.
+	TransitiveClosure(propertyURI string) ([]Triple, error)
+}
+
+// TransitiveClosure returns the transitive closure of the property as triples, i.e. a triple `s p o` for every pair of terms where `o`
+// is reachable from `s` via one or more triples with the property. The asserted triples are part of the closure. This allows to answer
+// partonomy queries (e.g. all parts of a machine) without a reasoner. Stores that implement `TransitiveCloser` compute the closure
+// themselves, for all other stores it is computed in-process. The triples are sorted.
+func TransitiveClosure(store GraphStore, propertyURI string) ([]Triple, error) {
+	var trps []Triple
+	var err error
+	if closer, ok := store.(TransitiveCloser); ok {
+		trps, err = closer.TransitiveClosure(propertyURI)
+	} else {
+		trps, err = transitiveClosure(store, propertyURI)
+	}
+	if err != nil {
+		return nil, err
+	}
+	SortTriples(trps)
+	return trps, nil
+}
+
+// MaterializeTransitiveClosure adds the inferred triples of the transitive closure of the property to the store (see
+// `TransitiveClosure`) and returns the number of added triples.
+func MaterializeTransitiveClosure(store GraphStore, propertyURI string) (int, error) {
+	closure, err := TransitiveClosure(store, propertyURI)
+	if err != nil {
+		return 0, err
+	}
+	asserted, err := store.GetAllMatches("", NewResourceTerm(propertyURI).String(), "")
+	if err != nil {
+		return 0, err
+	}
+	isAsserted := map[Triple]bool{}
+	for _, trp := range asserted {
+		isAsserted[trp] = true
+	}
+	inferred := []Triple{}
+	for _, trp := range closure {
+		if !isAsserted[trp] {
+			inferred = append(inferred, trp)
+		}
+	}
+	return len(inferred), store.AddTriplesUnchecked(inferred)
+}
+
+// TransitiveClosure returns the transitive closure of the property (see `TransitiveClosure`). The closure is computed with a breadth
+// first search from every subject of the property.
+func (store *MemoryStore) TransitiveClosure(propertyURI string) ([]Triple, error) {
+	return transitiveClosure(store, propertyURI)
+}
+
+// TransitiveClosure returns the transitive closure of the property (see `TransitiveClosure`). The closure is computed by Blazegraph with
+// a SPARQL property path query.
+func (store *BlazegraphStore) TransitiveClosure(propertyURI string) ([]Triple, error) {
+	pred := NewResourceTerm(propertyURI)
+	sparqlReq := fmt.Sprintf(`SELECT DISTINCT ?s ?o WHERE { GRAPH <%s> { ?s %s+ ?o . } }`, store.uri, pred.String())
+	resSet, code, err := store.endpoint.DoSparqlJSONQuery(store.namespace, sparqlReq)
+	if err != nil {
+		return nil, store.wrapErr("TransitiveClosure", nil, sparqlReq, err)
+	}
+	if code != http.StatusOK {
+		return nil, store.wrapErr("TransitiveClosure", nil, sparqlReq, fmt.Errorf("Received unexpected status code from SPARQL query (HTTP %d): %s", code, sparqlReq))
+	}
+	trps := []Triple{}
+	for _, binding := range resSet.Results.Bindings {
+		trps = append(trps, Triple{Subject: binding2Term(binding["s"]), Predicate: pred, Object: binding2Term(binding["o"])})
+	}
+	return trps, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// transitiveClosure computes the transitive closure of the property in-process with a breadth first search from every subject.
+func transitiveClosure(store GraphStore, propertyURI string) ([]Triple, error) {
+	pred := NewResourceTerm(propertyURI)
+	asserted, err := store.GetAllMatches("", pred.String(), "")
+	if err != nil {
+		return nil, err
+	}
+	SortTriples(asserted)
+	successors := map[Term][]Term{}
+	subjects := []Term{}
+	for _, trp := range asserted {
+		if _, ok := successors[trp.Subject]; !ok {
+			subjects = append(subjects, trp.Subject)
+		}
+		successors[trp.Subject] = append(successors[trp.Subject], trp.Object)
+	}
+	trps := []Triple{}
+	for _, subj := range subjects {
+		reached := map[Term]bool{}
+		queue := append([]Term{}, successors[subj]...)
+		for len(queue) > 0 {
+			obj := queue[0]
+			queue = queue[1:]
+			if reached[obj] {
+				continue
+			}
+			reached[obj] = true
+			trps = append(trps, Triple{Subject: subj, Predicate: pred, Object: obj})
+			queue = append(queue, successors[obj]...)
+		}
+	}
+	return trps, nil
+}
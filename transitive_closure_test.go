@@ -0,0 +1,61 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Transitive closure", func() {
+	const ns = "http://example.com/onto#"
+	var store *MemoryStore
+
+	partOf := func(part, whole string) Triple {
+		return Triple{Subject: NewResourceTerm(ns + part), Predicate: NewResourceTerm(ns + "partOf"), Object: NewResourceTerm(ns + whole)}
+	}
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/onto> a owl:Ontology .
+:partOf a owl:ObjectProperty, owl:TransitiveProperty .
+:screw :partOf :wheel .
+:wheel :partOf :car .
+:car :partOf :fleet .
+:spoke :partOf :wheel .
+`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should compute the closure including the asserted triples", func() {
+		closure, err := TransitiveClosure(store, ns+"partOf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(closure).To(ConsistOf(
+			partOf("screw", "wheel"), partOf("screw", "car"), partOf("screw", "fleet"),
+			partOf("spoke", "wheel"), partOf("spoke", "car"), partOf("spoke", "fleet"),
+			partOf("wheel", "car"), partOf("wheel", "fleet"),
+			partOf("car", "fleet"),
+		))
+	})
+
+	It("should terminate on cycles", func() {
+		Expect(store.AddTriple(partOf("fleet", "car"))).To(Succeed())
+		closure, err := TransitiveClosure(store, ns+"partOf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(closure).To(ContainElements(partOf("car", "car"), partOf("fleet", "fleet"), partOf("fleet", "car")))
+	})
+
+	It("should materialize the inferred triples", func() {
+		n, err := MaterializeTransitiveClosure(store, ns+"partOf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(5))
+		Expect(store.Size()).To(Equal(12))
+		n, err = MaterializeTransitiveClosure(store, ns+"partOf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(BeZero())
+	})
+})
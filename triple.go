@@ -2,7 +2,11 @@ package ontograph
 
 import (
 	"fmt"
+	"math/rand"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 // ********************
@@ -29,6 +33,25 @@ func NewLiteralTerm(literal, language, datatype string) Term {
 	return Term(t)
 }
 
+// NewBlankNodeTerm creates a new blank node term with the given label in NTriple format (e.g. `_:b1`).
+func NewBlankNodeTerm(label string) Term {
+	return Term("_:" + label)
+}
+
+// GenerateBlankNodeTerm creates a term for a new blank node with a random label.
+func GenerateBlankNodeTerm() Term {
+	blankNodeIDs.Lock()
+	defer blankNodeIDs.Unlock()
+	return Term(fmt.Sprintf("_:n%d", blankNodeIDs.Int()))
+}
+
+// blankNodeIDs is the source of the IDs of new blank nodes. It is seeded randomly, so that new blank nodes do not collide with the
+// (small) IDs of parsed blank nodes or the blank nodes created by other processes.
+var blankNodeIDs = struct {
+	sync.Mutex
+	*rand.Rand
+}{Rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
 // String converts the term into a string. Equivalent to direct casting with string(t).
 func (t Term) String() string {
 	return string(t)
@@ -46,9 +69,17 @@ func (t Term) IsLiteral() bool {
 	return len(s) > 2 && string(s[0]) == "\"" && (string(s[len(s)-1]) == "\"" || strings.Contains(s, "\"@") || strings.Contains(s, "\"^^"))
 }
 
-// Value returns the value of the term (i.e. the URI or literal).
+// IsBlankNode returns true if the term is a blank node.
+func (t Term) IsBlankNode() bool {
+	return strings.HasPrefix(string(t), "_:") && len(t) > 2
+}
+
+// Value returns the value of the term (i.e. the URI, literal or blank node label).
 func (t Term) Value() string {
 	s := string(t)
+	if t.IsBlankNode() {
+		return s[2:]
+	}
 	if len(s) > 2 {
 		if string(s[0]) == "<" && string(s[len(s)-1]) == ">" {
 			return s[1 : len(s)-1]
@@ -101,14 +132,14 @@ type Triple struct {
 // NewTriple creates a new triple from the given string terms. The terms are checked and parsed. If you are sure that the terms are valid NTriples, initialize directly with the Triple structure.
 func NewTriple(subj, pred, obj Term) (*Triple, error) {
 	// Sanity check terms
-	if !subj.IsResource() {
-		return nil, fmt.Errorf("Subject '%s' is not a resource", subj)
+	if !subj.IsResource() && !subj.IsBlankNode() {
+		return nil, fmt.Errorf("Subject '%s' is not a resource or blank node", subj)
 	}
 	if !pred.IsResource() {
 		return nil, fmt.Errorf("Predicate '%s' is not a resource", pred)
 	}
-	if !obj.IsResource() && !obj.IsLiteral() {
-		return nil, fmt.Errorf("Object '%s' is not a resource or literal", obj)
+	if !obj.IsResource() && !obj.IsBlankNode() && !obj.IsLiteral() {
+		return nil, fmt.Errorf("Object '%s' is not a resource, blank node or literal", obj)
 	}
 	// All fine, return triple
 	trp := Triple{
@@ -118,3 +149,16 @@ func NewTriple(subj, pred, obj Term) (*Triple, error) {
 	}
 	return &trp, nil
 }
+
+// SortTriples sorts the triples in place into a stable canonical order (by subject, predicate and object).
+func SortTriples(trps []Triple) {
+	sort.Slice(trps, func(i, j int) bool {
+		if trps[i].Subject != trps[j].Subject {
+			return trps[i].Subject < trps[j].Subject
+		}
+		if trps[i].Predicate != trps[j].Predicate {
+			return trps[i].Predicate < trps[j].Predicate
+		}
+		return trps[i].Object < trps[j].Object
+	})
+}
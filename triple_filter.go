@@ -0,0 +1,415 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// A FilterTriple is an entry of a `TripleFilter`. Empty terms of the triple are wildcards. If a condition is set, the triple matches
+// literal objects that satisfy the condition and its object is ignored.
+type FilterTriple struct {
+	Triple
+	Condition *FilterCondition
+}
+
+// A FilterCondition matches the literal values of a data property in a `TripleFilter` entry (see `NewRegexFilterCondition`,
+// `NewContainsFilterCondition` and `NewRangeFilterCondition`).
+type FilterCondition struct {
+	kind     filterConditionKind
+	value    string
+	min, max Term
+}
+
+// A RangeMatcher retrieves triples whose literal objects lie within a range. It is implemented by graph stores that can evaluate range
+// filters natively.
+type RangeMatcher interface {
+	// GetRangeMatches should retrieve all triples with the subject and predicate whose objects are within the inclusive bounds. Empty
+	// strings in subject or predicate should be treated as wildcards and empty bounds as unbounded.
+	GetRangeMatches(subj, pred string, min, max Term) ([]Triple, error)
+}
+
+// A FilterMatcher evaluates triple filters. It is implemented by graph stores that can evaluate the whole filter natively, which avoids
+// a query per filter triple and per matching individual.
+type FilterMatcher interface {
+	// GetFilteredTriples should return all triples whose subjects match the filter, grouped by subject. Without filter entries, all
+	// subjects typed as `owl:NamedIndividual` match.
+	GetFilteredTriples(filters TripleFilter) ([]Triple, error)
+}
+
+// NewRegexFilterCondition creates a filter condition that matches literals whose lexical value matches the regular expression (e.g.
+// `(?i)^ann` for a case-insensitive prefix). The expression must be valid in both RE2 and XPath (SPARQL) syntax, so it matches the same
+// on all stores. Flags are only supported as leading group (e.g. `(?i)` or `(?ms)`).
+func NewRegexFilterCondition(pattern string) *FilterCondition {
+	return &FilterCondition{kind: filterRegex, value: pattern}
+}
+
+// NewContainsFilterCondition creates a filter condition that matches literals whose lexical value contains the substring
+// (case-sensitive).
+func NewContainsFilterCondition(substring string) *FilterCondition {
+	return &FilterCondition{kind: filterContains, value: substring}
+}
+
+// NewRangeFilterCondition creates a filter condition that matches literals within the inclusive bounds. Numeric literals are compared
+// by value (across all numeric XML Schema datatypes), dates and date times chronologically. Empty bounds are unbounded.
+func NewRangeFilterCondition(min, max Term) *FilterCondition {
+	return &FilterCondition{kind: filterRange, min: min, max: max}
+}
+
+// OrWithDataPropertyAny returns a generic triple filter that returns all individuals that have any value for the given data property.
+// The property filter is appended in OR-fashion to the list of filters.
+func (filter TripleFilter) OrWithDataPropertyAny(propertyURI string) TripleFilter {
+	return filter.or(FilterTriple{Triple: Triple{Predicate: NewResourceTerm(propertyURI)}})
+}
+
+// AndWithDataPropertyAny returns a generic triple filter that returns all individuals that have any value for the given data property.
+// The property filter is appended in AND-fashion to the last filter in the list (if there is any).
+func (filter TripleFilter) AndWithDataPropertyAny(propertyURI string) TripleFilter {
+	return filter.and(FilterTriple{Triple: Triple{Predicate: NewResourceTerm(propertyURI)}})
+}
+
+// OrWithDataPropertyRegex returns a generic triple filter that returns all individuals that have a value for the given data property
+// which matches the regular expression. The property filter is appended in OR-fashion to the list of filters.
+func (filter TripleFilter) OrWithDataPropertyRegex(propertyURI, pattern string) TripleFilter {
+	return filter.or(FilterTriple{Triple: Triple{Predicate: NewResourceTerm(propertyURI)}, Condition: NewRegexFilterCondition(pattern)})
+}
+
+// AndWithDataPropertyRegex returns a generic triple filter that returns all individuals that have a value for the given data property
+// which matches the regular expression. The property filter is appended in AND-fashion to the last filter in the list (if there is any).
+func (filter TripleFilter) AndWithDataPropertyRegex(propertyURI, pattern string) TripleFilter {
+	return filter.and(FilterTriple{Triple: Triple{Predicate: NewResourceTerm(propertyURI)}, Condition: NewRegexFilterCondition(pattern)})
+}
+
+// OrWithDataPropertyContains returns a generic triple filter that returns all individuals that have a value for the given data property
+// which contains the substring. The property filter is appended in OR-fashion to the list of filters.
+func (filter TripleFilter) OrWithDataPropertyContains(propertyURI, substring string) TripleFilter {
+	return filter.or(FilterTriple{Triple: Triple{Predicate: NewResourceTerm(propertyURI)}, Condition: NewContainsFilterCondition(substring)})
+}
+
+// AndWithDataPropertyContains returns a generic triple filter that returns all individuals that have a value for the given data
+// property which contains the substring. The property filter is appended in AND-fashion to the last filter in the list (if there is any).
+func (filter TripleFilter) AndWithDataPropertyContains(propertyURI, substring string) TripleFilter {
+	return filter.and(FilterTriple{Triple: Triple{Predicate: NewResourceTerm(propertyURI)}, Condition: NewContainsFilterCondition(substring)})
+}
+
+// OrWithDataPropertyRange returns a generic triple filter that returns all individuals that have a value for the given data property
+// within the inclusive range (see `NewRangeFilterCondition`). Pass an empty literal (`GenericLiteral{}`) for an open bound. The property
+// filter is appended in OR-fashion to the list of filters.
+func (filter TripleFilter) OrWithDataPropertyRange(propertyURI string, min, max GenericLiteral) TripleFilter {
+	return filter.or(FilterTriple{Triple: Triple{Predicate: NewResourceTerm(propertyURI)}, Condition: NewRangeFilterCondition(min.Term(), max.Term())})
+}
+
+// AndWithDataPropertyRange returns a generic triple filter that returns all individuals that have a value for the given data property
+// within the inclusive range (see `NewRangeFilterCondition`). Pass an empty literal (`GenericLiteral{}`) for an open bound. The property
+// filter is appended in AND-fashion to the last filter in the list (if there is any).
+func (filter TripleFilter) AndWithDataPropertyRange(propertyURI string, min, max GenericLiteral) TripleFilter {
+	return filter.and(FilterTriple{Triple: Triple{Predicate: NewResourceTerm(propertyURI)}, Condition: NewRangeFilterCondition(min.Term(), max.Term())})
+}
+
+// GetRangeMatches retrieves all triples with the subject and predicate whose objects are within the inclusive bounds (see
+// `NewRangeFilterCondition`). Empty strings in subject or predicate are treated as wildcards and empty bounds as unbounded.
+func (store *MemoryStore) GetRangeMatches(subj, pred string, min, max Term) ([]Triple, error) {
+	return getRangeMatches(store, subj, pred, min, max)
+}
+
+// GetRangeMatches retrieves all triples with the subject and predicate whose objects are within the inclusive bounds (see
+// `NewRangeFilterCondition`). The range is evaluated by Blazegraph with a SPARQL FILTER.
+func (store *BlazegraphStore) GetRangeMatches(subj, pred string, min, max Term) ([]Triple, error) {
+	s, p := "?s", "?p"
+	if subj != "" {
+		s = sparqlTerm(Term(subj))
+	}
+	if pred != "" {
+		p = sparqlTerm(Term(pred))
+	}
+	conditions := []string{"isLiteral(?o)"}
+	if min != "" {
+		conditions = append(conditions, "?o >= "+min.String())
+	}
+	if max != "" {
+		conditions = append(conditions, "?o <= "+max.String())
+	}
+	// Construct and execute SPARQL query
+	sparqlReq := fmt.Sprintf(`SELECT ?s ?p ?o WHERE { GRAPH <%s> { %s %s ?o . FILTER(%s) } }`, store.uri, s, p, strings.Join(conditions, " && "))
+	resSet, code, err := store.endpoint.DoSparqlJSONQuery(store.namespace, sparqlReq)
+	if err != nil {
+		return nil, store.wrapErr("GetRangeMatches", nil, sparqlReq, err)
+	}
+	if code != http.StatusOK {
+		return nil, store.wrapErr("GetRangeMatches", nil, sparqlReq, fmt.Errorf("Received unexpected status code from SPARQL query (HTTP %d): %s", code, sparqlReq))
+	}
+	trps := []Triple{}
+	for _, binding := range resSet.Results.Bindings {
+		trp := Triple{Subject: Term(subj), Predicate: Term(pred), Object: binding2Term(binding["o"])}
+		if subj == "" {
+			trp.Subject = binding2Term(binding["s"])
+		}
+		if pred == "" {
+			trp.Predicate = binding2Term(binding["p"])
+		}
+		trps = append(trps, trp)
+	}
+	return trps, nil
+}
+
+// GetFilteredTriples returns all triples whose subjects match the filter (see `FilterMatcher`). The filter is translated into a single
+// SPARQL query with a UNION of the OR-entries. Regular expressions are evaluated with the SPARQL REGEX function, with the leading flag
+// group passed as flags.
+func (store *BlazegraphStore) GetFilteredTriples(filters TripleFilter) ([]Triple, error) {
+	pattern, err := sparqlFilterPattern(filters)
+	if err != nil {
+		return nil, err
+	}
+	// Construct and execute SPARQL query
+	sparqlReq := fmt.Sprintf(`SELECT ?s ?p ?o WHERE { GRAPH <%s> { { SELECT DISTINCT ?s WHERE { %s } } ?s ?p ?o . } } ORDER BY ?s`, store.uri, pattern)
+	resSet, code, err := store.endpoint.DoSparqlJSONQuery(store.namespace, sparqlReq)
+	if err != nil {
+		return nil, store.wrapErr("GetFilteredTriples", nil, sparqlReq, err)
+	}
+	if code != http.StatusOK {
+		return nil, store.wrapErr("GetFilteredTriples", nil, sparqlReq, fmt.Errorf("Received unexpected status code from SPARQL query (HTTP %d): %s", code, sparqlReq))
+	}
+	trps := []Triple{}
+	for _, binding := range resSet.Results.Bindings {
+		trps = append(trps, Triple{Subject: binding2Term(binding["s"]), Predicate: binding2Term(binding["p"]), Object: binding2Term(binding["o"])})
+	}
+	return trps, nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrInvalidFilter is raised when a triple filter contains an invalid filter condition, e.g. a malformed regular expression.
+var ErrInvalidFilter error = errors.New("The triple filter is invalid")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// or appends the filter triple in OR-fashion to the list of filters.
+func (filter TripleFilter) or(filterTrp FilterTriple) TripleFilter {
+	return append(filter, []FilterTriple{filterTrp})
+}
+
+// and appends the filter triple in AND-fashion to the last filter in the list.
+func (filter TripleFilter) and(filterTrp FilterTriple) TripleFilter {
+	if len(filter) == 0 {
+		filter = append(filter, []FilterTriple{})
+	}
+	filter[len(filter)-1] = append(filter[len(filter)-1], filterTrp)
+	return filter
+}
+
+// filterConditionKind is the kind of a filter condition.
+type filterConditionKind int
+
+const (
+	filterRegex filterConditionKind = iota
+	filterContains
+	filterRange
+)
+
+// matcher returns a function that matches the literal terms that satisfy the condition. Errors with `ErrInvalidFilter` if the
+// condition is malformed.
+func (cond *FilterCondition) matcher() (func(Term) bool, error) {
+	switch cond.kind {
+	case filterRegex:
+		re, _, _, err := parseRegexFilter(cond.value)
+		if err != nil {
+			return nil, err
+		}
+		return func(t Term) bool {
+			return t.IsLiteral() && re.MatchString(unescapeLiteral(t.Value()))
+		}, nil
+	case filterContains:
+		return func(t Term) bool {
+			return t.IsLiteral() && strings.Contains(unescapeLiteral(t.Value()), cond.value)
+		}, nil
+	}
+	if err := cond.checkRange(); err != nil {
+		return nil, err
+	}
+	return func(t Term) bool {
+		return inRange(t, cond.min, cond.max)
+	}, nil
+}
+
+// checkRange errors with `ErrInvalidFilter` if a bound of the range condition cannot be compared.
+func (cond *FilterCondition) checkRange() error {
+	for _, bound := range []Term{cond.min, cond.max} {
+		if bound != "" && !isSPARQLComparable(bound) {
+			return fmt.Errorf("%w: Range bound %s is not comparable", ErrInvalidFilter, bound)
+		}
+	}
+	return nil
+}
+
+// sparqlConditions translates the condition into SPARQL FILTER conditions on the variable.
+func (cond *FilterCondition) sparqlConditions(variable string) ([]string, error) {
+	conditions := []string{"isLiteral(" + variable + ")"}
+	switch cond.kind {
+	case filterRegex:
+		_, pattern, flags, err := parseRegexFilter(cond.value)
+		if err != nil {
+			return nil, err
+		}
+		regex := fmt.Sprintf(`REGEX(STR(%s), "%s")`, variable, escapeLiteral(pattern))
+		if flags != "" {
+			regex = fmt.Sprintf(`REGEX(STR(%s), "%s", "%s")`, variable, escapeLiteral(pattern), flags)
+		}
+		return append(conditions, regex), nil
+	case filterContains:
+		return append(conditions, fmt.Sprintf(`CONTAINS(STR(%s), "%s")`, variable, escapeLiteral(cond.value))), nil
+	}
+	if err := cond.checkRange(); err != nil {
+		return nil, err
+	}
+	if cond.min != "" {
+		conditions = append(conditions, variable+" >= "+cond.min.String())
+	}
+	if cond.max != "" {
+		conditions = append(conditions, variable+" <= "+cond.max.String())
+	}
+	return conditions, nil
+}
+
+// inRange returns true if the term is a literal within the inclusive bounds. Empty bounds are unbounded.
+func inRange(t Term, min, max Term) bool {
+	if !t.IsLiteral() {
+		return false
+	}
+	if c, err := compareSPARQLValues(t, min); min != "" && (err != nil || c < 0) {
+		return false
+	}
+	if c, err := compareSPARQLValues(t, max); max != "" && (err != nil || c > 0) {
+		return false
+	}
+	return true
+}
+
+// getRangeMatches retrieves the matches of the subject and predicate and keeps the triples whose objects are within the bounds.
+func getRangeMatches(store GraphStore, subj, pred string, min, max Term) ([]Triple, error) {
+	trps, err := store.GetAllMatches(subj, pred, "")
+	if err != nil {
+		return nil, err
+	}
+	matches := []Triple{}
+	for _, trp := range trps {
+		if inRange(trp.Object, min, max) {
+			matches = append(matches, trp)
+		}
+	}
+	return matches, nil
+}
+
+// getFilterMatches retrieves all triples that match the filter triple. Empty terms are wildcards and conditions are matched against the
+// literal values of the predicate. Range conditions are evaluated by the store if it is a `RangeMatcher`.
+func (ont *OntologyGraph) getFilterMatches(filterTrp FilterTriple) ([]Triple, error) {
+	cond := filterTrp.Condition
+	if cond == nil {
+		return ont.graph.GetAllMatches(filterTrp.Subject.String(), filterTrp.Predicate.String(), filterTrp.Object.String())
+	}
+	if matcher, ok := ont.graph.(RangeMatcher); ok && cond.kind == filterRange {
+		if err := cond.checkRange(); err != nil {
+			return nil, err
+		}
+		return matcher.GetRangeMatches(filterTrp.Subject.String(), filterTrp.Predicate.String(), cond.min, cond.max)
+	}
+	match, err := cond.matcher()
+	if err != nil {
+		return nil, err
+	}
+	trps, err := ont.graph.GetAllMatches(filterTrp.Subject.String(), filterTrp.Predicate.String(), "")
+	if err != nil {
+		return nil, err
+	}
+	matches := []Triple{}
+	for _, trp := range trps {
+		if match(trp.Object) {
+			matches = append(matches, trp)
+		}
+	}
+	return matches, nil
+}
+
+// sparqlFilterPattern translates the filter into a SPARQL group graph pattern that binds the matching subjects to `?s`.
+func sparqlFilterPattern(filters TripleFilter) (string, error) {
+	if len(filters) == 0 {
+		return fmt.Sprintf("?s %s %s .", NewResourceTerm(RDFType), NewResourceTerm(OWLNamedIndividual)), nil
+	}
+	groups := []string{}
+	for _, filterTrps := range filters {
+		patterns := []string{}
+		for i, filterTrp := range filterTrps {
+			pattern, err := sparqlFilterTriplePattern(filterTrp, fmt.Sprintf("?v%d", i))
+			if err != nil {
+				return "", err
+			}
+			patterns = append(patterns, pattern)
+		}
+		groups = append(groups, "{ "+strings.Join(patterns, " ")+" }")
+	}
+	return strings.Join(groups, " UNION "), nil
+}
+
+// sparqlFilterTriplePattern translates the filter triple into a SPARQL triple pattern (with filter conditions) for the subject `?s`.
+// The variable is used for objects that are matched by conditions.
+func sparqlFilterTriplePattern(filterTrp FilterTriple, variable string) (string, error) {
+	subj := ""
+	if filterTrp.Subject != "" {
+		subj = fmt.Sprintf(" FILTER(?s = %s)", sparqlTerm(filterTrp.Subject))
+	}
+	pred := variable + "p"
+	if filterTrp.Predicate != "" {
+		pred = filterTrp.Predicate.String()
+	}
+	if filterTrp.Condition != nil {
+		conditions, err := filterTrp.Condition.sparqlConditions(variable)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("?s %s %s . FILTER(%s)%s", pred, variable, strings.Join(conditions, " && "), subj), nil
+	}
+	if filterTrp.Object != "" {
+		return fmt.Sprintf("?s %s %s .%s", pred, sparqlTerm(filterTrp.Object), subj), nil
+	}
+	return fmt.Sprintf("?s %s %s .%s", pred, variable, subj), nil
+}
+
+// regexFilterFlags matches the leading flag group of regular expressions with the flags that RE2 and XPath have in common.
+var regexFilterFlags = regexp.MustCompile(`^\(\?([ims]+)\)`)
+
+// parseRegexFilter compiles the regular expression of a regex filter condition and returns it together with the pattern and flags for the
+// SPARQL REGEX function. Syntax that XPath does not support (e.g. non-leading flag groups, `(?:`, `\b` or `\A`) is rejected with
+// `ErrInvalidFilter`.
+func parseRegexFilter(expr string) (*regexp.Regexp, string, string, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	pattern, flags := expr, ""
+	if m := regexFilterFlags.FindStringSubmatch(expr); m != nil {
+		pattern, flags = expr[len(m[0]):], m[1]
+	}
+	for i := 0; i < len(pattern); i++ {
+		unsupported := ""
+		switch {
+		case strings.HasPrefix(pattern[i:], "(?"):
+			unsupported = "(?"
+		case strings.HasPrefix(pattern[i:], "[[:"):
+			unsupported = "[[:"
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			i++
+			if strings.IndexByte("AzbBQECx", pattern[i]) >= 0 || ((pattern[i] == 'p' || pattern[i] == 'P') && !strings.HasPrefix(pattern[i+1:], "{")) {
+				unsupported = pattern[i-1 : i+1]
+			}
+		}
+		if unsupported != "" {
+			return nil, "", "", fmt.Errorf("%w: Regular expression syntax '%s' is not supported by SPARQL", ErrInvalidFilter, unsupported)
+		}
+	}
+	return re, pattern, flags, nil
+}
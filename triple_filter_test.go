@@ -0,0 +1,146 @@
+package ontograph_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Range matches", func() {
+	const ex = "http://example.com/onto#"
+	var store *MemoryStore
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.com/onto> a <http://www.w3.org/2002/07/owl#Ontology> .
+:a :amount "5"^^xsd:nonNegativeInteger ; :issued "2021-03-01"^^xsd:date ; :signed "2021-03-01T10:00:00Z"^^xsd:dateTime .
+:b :amount 12.5 ; :issued "2021-06-15"^^xsd:date ; :signed "2021-06-15T09:30:00"^^xsd:dateTime .
+:c :amount "many" ; :issued "soon" .
+`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	subjects := func(pred string, min, max Term) []Term {
+		trps, err := store.GetRangeMatches("", NewResourceTerm(ex+pred).String(), min, max)
+		Expect(err).NotTo(HaveOccurred())
+		subjs := []Term{}
+		for _, trp := range trps {
+			subjs = append(subjs, trp.Subject)
+		}
+		return subjs
+	}
+
+	It("should compare numeric literals across datatypes", func() {
+		Expect(subjects("amount", NewLiteralTerm("1", "", XSDInteger), NewLiteralTerm("10", "", XSDDouble))).To(Equal([]Term{NewResourceTerm(ex + "a")}))
+		Expect(subjects("amount", NewLiteralTerm("5", "", XSDInteger), "")).To(ConsistOf(NewResourceTerm(ex+"a"), NewResourceTerm(ex+"b")))
+	})
+
+	It("should compare dates and date times chronologically", func() {
+		Expect(subjects("issued", NewLiteralTerm("2021-04-01", "", XSDDate), "")).To(Equal([]Term{NewResourceTerm(ex + "b")}))
+		Expect(subjects("signed", "", NewLiteralTerm("2021-06-01T00:00:00Z", "", XSDDateTime))).To(Equal([]Term{NewResourceTerm(ex + "a")}))
+	})
+
+	It("should reject range filters with incomparable bounds", func() {
+		ont, err := LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ont.GetIndividuals(TripleFilter{}.AndWithDataPropertyRange(ex+"amount", *NewGenericLiteral(NewResourceTerm(ex + "a")), GenericLiteral{}))
+		Expect(err).To(MatchError(ErrInvalidFilter))
+	})
+})
+
+var _ = Describe("Regex filters", func() {
+	const ex = "http://example.com/onto#"
+
+	It("should pass leading flags to the SPARQL REGEX function", func() {
+		queries := []string{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).To(Succeed())
+			queries = append(queries, r.PostForm.Get("query"))
+			w.Write([]byte(`{"head":{"vars":["s","p","o"]},"results":{"bindings":[]}}`))
+		}))
+		defer server.Close()
+		store := NewBlazegraphEndpoint(server.URL).NewBlazegraphStore("http://example.com/onto", "test")
+		_, err := store.GetFilteredTriples(TripleFilter{}.AndWithDataPropertyRegex(ex+"name", `(?i)^ann\.`).OrWithDataPropertyRegex(ex+"name", "^Bob$"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(queries).To(HaveLen(1))
+		Expect(queries[0]).To(ContainSubstring(`REGEX(STR(?v0), "^ann\\.", "i")`))
+		Expect(queries[0]).To(ContainSubstring(`REGEX(STR(?v0), "^Bob$")`))
+	})
+
+	It("should match case-insensitively in memory stores", func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+<http://example.com/onto> a <http://www.w3.org/2002/07/owl#Ontology> .
+:a a <http://www.w3.org/2002/07/owl#NamedIndividual> ; :name "Anna" .
+:b a <http://www.w3.org/2002/07/owl#NamedIndividual> ; :name "Hannah" .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err := LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		indivs, err := ont.GetIndividuals(TripleFilter{}.AndWithDataPropertyRegex(ex+"name", "(?i)^ann"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indivs).To(HaveLen(1))
+		Expect(indivs[0].URI).To(Equal(ex + "a"))
+	})
+
+	It("should reject syntax that SPARQL does not support", func() {
+		store := NewBlazegraphEndpoint("http://localhost:0").NewBlazegraphStore("http://example.com/onto", "test")
+		ont, err := InitOntologyGraph(NewMemoryStore("http://example.com/onto"))
+		Expect(err).NotTo(HaveOccurred())
+		for _, pattern := range []string{`(?:ann)`, `^a(?i)nn`, `\bann`, `\Aann`, `[[:alpha:]]`, `\pL`, `(?U)a+`} {
+			_, err := store.GetFilteredTriples(TripleFilter{}.AndWithDataPropertyRegex(ex+"name", pattern))
+			Expect(err).To(MatchError(ErrInvalidFilter), pattern)
+			_, err = ont.GetIndividuals(TripleFilter{}.AndWithDataPropertyRegex(ex+"name", pattern))
+			Expect(err).To(MatchError(ErrInvalidFilter), pattern)
+		}
+	})
+})
+
+var _ = Describe("Filter conditions", func() {
+	const ex = "http://example.com/onto#"
+
+	It("should match object URIs literally and keep conditions out of the filter triple", func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+<http://example.com/onto> a <http://www.w3.org/2002/07/owl#Ontology> .
+:a a <http://www.w3.org/2002/07/owl#NamedIndividual> ; :tag <urn:ontograph:filter:regex:x> ; :note "a > b" .
+:b a <http://www.w3.org/2002/07/owl#NamedIndividual> ; :tag <urn:ontograph:filter:regex:y> ; :note "x" .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err := LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+		indivs, err := ont.GetIndividuals(TripleFilter{}.AndWithObjectProperty(ex+"tag", "urn:ontograph:filter:regex:x"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indivs).To(HaveLen(1))
+		Expect(indivs[0].URI).To(Equal(ex + "a"))
+		indivs, err = ont.GetIndividuals(TripleFilter{}.AndWithDataPropertyRegex(ex+"note", "^a > b$"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indivs).To(HaveLen(1))
+		Expect(indivs[0].URI).To(Equal(ex + "a"))
+
+		filter := TripleFilter{}.AndWithDataPropertyContains(ex+"note", "a > b")
+		Expect(filter[0][0].Object).To(BeEmpty())
+		Expect(filter[0][0].Condition).To(Equal(NewContainsFilterCondition("a > b")))
+	})
+
+	It("should push conditions with special characters down to SPARQL", func() {
+		queries := []string{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).To(Succeed())
+			queries = append(queries, r.PostForm.Get("query"))
+			w.Write([]byte(`{"head":{"vars":["s","p","o"]},"results":{"bindings":[]}}`))
+		}))
+		defer server.Close()
+		store := NewBlazegraphEndpoint(server.URL).NewBlazegraphStore("http://example.com/onto", "test")
+		_, err := store.GetFilteredTriples(TripleFilter{}.AndWithDataPropertyRegex(ex+"note", "^a > b$").OrWithDataPropertyContains(ex+"note", `say "hi"`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(queries).To(HaveLen(1))
+		Expect(queries[0]).To(ContainSubstring(`REGEX(STR(?v0), "^a > b$")`))
+		Expect(queries[0]).To(ContainSubstring(`CONTAINS(STR(?v0), "say \"hi\"")`))
+	})
+})
@@ -0,0 +1,126 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// A TripleIterator passes matching triples one by one to a callback instead of collecting them in a list. It is implemented by graph
+// stores that can stream their triples, so large graphs can be processed without allocating all triples at once.
+type TripleIterator interface {
+	// IterMatches should call fn for every triple that matches the pattern. Empty strings in subject, predicate or object should be
+	// treated as wildcards. If fn returns an error, the iteration should stop and return the error.
+	IterMatches(subj, pred, obj string, fn func(Triple) error) error
+}
+
+// IterTriples calls fn for every triple in the store (see `IterMatches`).
+func IterTriples(store GraphStore, fn func(Triple) error) error {
+	return IterMatches(store, "", "", "", fn)
+}
+
+// IterMatches calls fn for every triple in the store that matches the pattern. Empty strings in subject, predicate or object are treated
+// as wildcards. If fn returns an error, the iteration stops and the error is returned, except for `ErrStopIteration` which stops the
+// iteration without error. Stores that implement `TripleIterator` stream the triples, for all other stores the matching triples are
+// retrieved at once. The store must not be modified during the iteration.
+func IterMatches(store GraphStore, subj, pred, obj string, fn func(Triple) error) error {
+	if err := iterMatches(store, subj, pred, obj, fn); !errors.Is(err, ErrStopIteration) {
+		return err
+	}
+	return nil
+}
+
+// IterMatches calls fn for every triple that matches the pattern. Empty strings in subject, predicate or object are treated as wildcards.
+func (store *MemoryStore) IterMatches(subj, pred, obj string, fn func(Triple) error) error {
+	if subj != "" || pred != "" || obj != "" {
+		for _, trp := range allTriples(store.graph, store.toTerm(subj), store.toTerm(pred), store.toTerm(obj)) {
+			if err := fn(Triple{Subject: store.fromTerm(trp.Subject), Predicate: store.fromTerm(trp.Predicate), Object: store.fromTerm(trp.Object)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	ch := store.graph.IterTriples()
+	for trp := range ch {
+		if err := fn(Triple{Subject: store.fromTerm(trp.Subject), Predicate: store.fromTerm(trp.Predicate), Object: store.fromTerm(trp.Object)}); err != nil {
+			// Drain the channel so its goroutine terminates
+			for range ch {
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// IterMatches calls fn for every triple that matches the pattern. Empty strings in subject, predicate or object are treated as wildcards.
+// The triples are queried with a single request and decoded one by one while the response is read.
+func (store *BlazegraphStore) IterMatches(subj, pred, obj string, fn func(Triple) error) error {
+	sparqlReq := store.matchQuery(subj, pred, obj)
+	var fnErr error
+	code, err := store.endpoint.doSparqlJSONQueryStream(store.namespace, sparqlReq, func(binding map[string]JSONResultSetBinding) error {
+		fnErr = fn(matchTriple(binding, subj, pred, obj))
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return store.wrapPatternErr("IterMatches", subj, pred, obj, sparqlReq, err)
+	}
+	if code != http.StatusOK {
+		return store.wrapPatternErr("IterMatches", subj, pred, obj, sparqlReq, fmt.Errorf("Received unexpected status code from SPARQL query (HTTP %d): %s", code, sparqlReq))
+	}
+	return nil
+}
+
+// IterMatches calls fn for every distinct triple that matches the pattern in any of the stores. Empty strings in subject, predicate or
+// object are treated as wildcards.
+func (store *UnionStore) IterMatches(subj, pred, obj string, fn func(Triple) error) error {
+	seen := map[Triple]bool{}
+	for _, graph := range store.Stores() {
+		err := iterMatches(graph, subj, pred, obj, func(trp Triple) error {
+			if seen[trp] {
+				return nil
+			}
+			seen[trp] = true
+			return fn(trp)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrStopIteration can be returned by the callback of `IterMatches` and `IterTriples` to stop the iteration without error.
+var ErrStopIteration error = errors.New("The iteration was stopped")
+
+// ********************
+// * Helper functions *
+// ********************
+
+// iterMatches calls fn for every matching triple like `IterMatches`, but returns `ErrStopIteration` as well.
+func iterMatches(store GraphStore, subj, pred, obj string, fn func(Triple) error) error {
+	if it, ok := store.(TripleIterator); ok {
+		return it.IterMatches(subj, pred, obj, fn)
+	}
+	trps, err := store.GetAllMatches(subj, pred, obj)
+	if err != nil {
+		return err
+	}
+	return iterSlice(trps, fn)
+}
+
+// iterSlice calls fn for every triple in the list until it returns an error.
+func iterSlice(trps []Triple, fn func(Triple) error) error {
+	for _, trp := range trps {
+		if err := fn(trp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package ontograph_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Triple iteration", func() {
+	const ns = "http://example.com/onto#"
+	var store *MemoryStore
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+:A a owl:Class .
+:B a owl:Class .
+:C a owl:ObjectProperty .
+`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should pass all matching triples to the callback", func() {
+		trps := []Triple{}
+		Expect(IterTriples(store, func(trp Triple) error {
+			trps = append(trps, trp)
+			return nil
+		})).To(Succeed())
+		all, err := store.GetAllTriples()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trps).To(ConsistOf(all))
+
+		subjects := []Term{}
+		Expect(IterMatches(store, "", NewResourceTerm(RDFType).String(), NewResourceTerm(OWLClass).String(), func(trp Triple) error {
+			subjects = append(subjects, trp.Subject)
+			return nil
+		})).To(Succeed())
+		Expect(subjects).To(ConsistOf(NewResourceTerm(ns+"A"), NewResourceTerm(ns+"B")))
+	})
+
+	It("should stop the iteration when the callback errors", func() {
+		count := 0
+		Expect(IterTriples(store, func(trp Triple) error {
+			count++
+			return ErrStopIteration
+		})).To(Succeed())
+		Expect(count).To(Equal(1))
+
+		failure := errors.New("failure")
+		Expect(IterTriples(store, func(trp Triple) error { return failure })).To(MatchError(failure))
+	})
+
+	It("should iterate over the distinct triples of unions", func() {
+		other := NewMemoryStore(ns)
+		Expect(other.AddTriple(Triple{Subject: NewResourceTerm(ns + "A"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)})).To(Succeed())
+		Expect(other.AddTriple(Triple{Subject: NewResourceTerm(ns + "D"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)})).To(Succeed())
+		count := 0
+		Expect(IterTriples(NewUnionStore(store, other), func(trp Triple) error {
+			count++
+			return nil
+		})).To(Succeed())
+		Expect(count).To(Equal(4))
+	})
+
+	It("should stream the triples of Blazegraph stores from a single request", func() {
+		queries := []string{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			queries = append(queries, r.Form.Get("query"))
+			// The graph holds five triples
+			bindings := []string{}
+			for i := 0; i < 5; i++ {
+				bindings = append(bindings, fmt.Sprintf(`{"s":{"type":"uri","value":"%s%d"},"p":{"type":"uri","value":"%s"},"o":{"type":"uri","value":"%s"}}`, ns, i, RDFType, OWLClass))
+			}
+			w.Header().Set("Content-Type", "application/sparql-results+json")
+			fmt.Fprintf(w, `{"head":{"vars":["s","p","o"]},"results":{"distinct":false,"bindings":[%s]}}`, strings.Join(bindings, ","))
+		}))
+		defer server.Close()
+
+		remote := NewBlazegraphEndpoint(server.URL).NewBlazegraphStore(ns, "test")
+		subjects := []Term{}
+		Expect(IterTriples(remote, func(trp Triple) error {
+			subjects = append(subjects, trp.Subject)
+			return nil
+		})).To(Succeed())
+		Expect(subjects).To(HaveLen(5))
+		Expect(subjects[4]).To(Equal(NewResourceTerm(ns + "4")))
+		Expect(queries).To(HaveLen(1))
+		Expect(queries[0]).NotTo(ContainSubstring("LIMIT"))
+
+		subjects = []Term{}
+		Expect(IterMatches(remote, "", NewResourceTerm(RDFType).String(), "", func(trp Triple) error {
+			subjects = append(subjects, trp.Subject)
+			if len(subjects) == 2 {
+				return ErrStopIteration
+			}
+			return nil
+		})).To(Succeed())
+		Expect(subjects).To(HaveLen(2))
+	})
+})
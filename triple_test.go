@@ -23,6 +23,25 @@ var _ = Describe("Triple", func() {
 		})
 	})
 
+	Describe("Creating a new blank node term", func() {
+		It("should return the expected representation", func() {
+			Expect(NewBlankNodeTerm("b1").String()).To(Equal("_:b1"))
+			Expect(GenerateBlankNodeTerm().IsBlankNode()).To(BeTrue())
+			Expect(GenerateBlankNodeTerm()).NotTo(Equal(GenerateBlankNodeTerm()))
+		})
+	})
+
+	Describe("Checking if a term is a blank node", func() {
+		It("should only confirm blank node terms", func() {
+			Expect(Term("_:b1").IsBlankNode()).To(BeTrue())
+			Expect(Term("_:").IsBlankNode()).To(BeFalse())
+			Expect(Term("<https://www.ontograph.com/test>").IsBlankNode()).To(BeFalse())
+			Expect(Term(`"_:b1"`).IsBlankNode()).To(BeFalse())
+			Expect(Term("_:b1").IsResource()).To(BeFalse())
+			Expect(Term("_:b1").IsLiteral()).To(BeFalse())
+		})
+	})
+
 	Describe("Checking if a term is a resource", func() {
 		Context("when the term has a valid NTriple resource representation", func() {
 			It("should confirm the term", func() {
@@ -89,6 +108,11 @@ var _ = Describe("Triple", func() {
 				Expect(Term(`"some literal"^^<https://www.ontograph.com/test#literal>`).Value()).To(Equal("some literal"))
 			})
 		})
+		Context("when the term is a blank node", func() {
+			It("should return the label", func() {
+				Expect(Term("_:b1").Value()).To(Equal("b1"))
+			})
+		})
 		Context("when the term is invalid", func() {
 			It("should return an empty string", func() {
 				Expect(Term(`some literal`).Value()).To(Equal(""))
@@ -185,6 +209,20 @@ var _ = Describe("Triple", func() {
 				Expect(trp.Object.Datatype()).To(Equal("https://www.ontograph.com/test#literal"))
 			})
 		})
+		Context("when the subject or object is a blank node", func() {
+			It("should return a valid triple", func() {
+				trp, err := NewTriple("_:b1", "<https://www.ontograph.com/test#rel>", "_:b2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(trp.Subject).To(Equal(NewBlankNodeTerm("b1")))
+				Expect(trp.Object).To(Equal(NewBlankNodeTerm("b2")))
+			})
+		})
+		Context("when the predicate is a blank node", func() {
+			It("should error", func() {
+				_, err := NewTriple("<https://www.ontograph.com/test>", "_:b1", "<https://www.ontograph.com/test#a>")
+				Expect(err).To(HaveOccurred())
+			})
+		})
 		Context("when the subject is a valid NTriple literal", func() {
 			It("should error", func() {
 				_, err := NewTriple("\"some literal\"", "<https://www.ontograph.com/test#rel>", "<https://www.ontograph.com/test#a>")
@@ -0,0 +1,311 @@
+package ontograph
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+
+	rdf "github.com/deiu/gon3"
+	"github.com/deiu/rdf2go"
+)
+
+// ParseTurtleStream parses the TTL data given in the reader statement by statement and calls fn for every parsed triple.
+// In contrast to `ParseFromTurtle`, the data is never held in memory completely: Only the prefix and base directives and the
+// current statement are buffered. If fn returns an error, parsing stops and the error is returned.
+// Blank node labels are scoped to the whole data like in any Turtle document. Gzip compressed data is decompressed transparently.
+// The import can be configured with parse options.
+func ParseTurtleStream(r io.Reader, fn func(Triple) error, opts ...ParseOption) error {
+	options := newParseOptions(opts)
+	r, err := decompressReader(r)
+	if err != nil {
+		return err
+	}
+	scanner := turtleStatementScanner{r: bufio.NewReader(r)}
+	// Directives must be prepended to every statement, so keep them in a separate buffer
+	var directives strings.Builder
+	// Blank node IDs restart for every statement, so the anonymous blank nodes of every statement get new IDs, while labeled blank nodes
+	// (passed through the parser as stand-in IRIs) keep their ID for the whole data
+	nextID := 0
+	labeled := map[string]int{}
+	for {
+		stmt, err := scanner.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// Remember directives for the following statements
+		if isTurtleDirective(stmt) {
+			directives.WriteString(stmt)
+			directives.WriteString("\n")
+			continue
+		}
+		// Parse the single statement
+		parsed, err := rdf.NewParser(options.baseURI).Parse(strings.NewReader(directives.String() + stmt))
+		if err != nil {
+			return err
+		}
+		anonymous := map[int]int{}
+		toTerm := func(t rdf.Term) Term {
+			if bnode, ok := t.(*rdf.BlankNode); ok {
+				if _, ok := anonymous[bnode.Id]; !ok {
+					anonymous[bnode.Id] = nextID
+					nextID++
+				}
+				return Term(rdf2go.NewBlankNode(anonymous[bnode.Id]).String())
+			}
+			term := turtleTerm(t)
+			if res, ok := term.(*rdf2go.Resource); ok && strings.HasPrefix(res.URI, turtleStreamBlankNodePrefix) {
+				label := strings.TrimPrefix(res.URI, turtleStreamBlankNodePrefix)
+				if _, ok := labeled[label]; !ok {
+					labeled[label] = nextID
+					nextID++
+				}
+				return Term(rdf2go.NewBlankNode(labeled[label]).String())
+			}
+			return Term(options.rewriteRDFTerm(term).String())
+		}
+		trps := []Triple{}
+		for trp := range parsed.IterTriples() {
+			trps = append(trps, Triple{
+				Subject:   toTerm(trp.Subject),
+				Predicate: toTerm(trp.Predicate),
+				Object:    toTerm(trp.Object),
+			})
+		}
+		for _, trp := range trps {
+			// Check triple (datatypes declared in the data are unknown here, since the data is not held in memory)
+			if options.checksTriples() {
+				if err := handleTripleIssues(trp, checkTriple(trp, "", nil), options.strictness, options.warnings); err != nil {
+					return err
+				}
+			}
+			if err := fn(trp); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// LoadTurtleStream parses the TTL data given in the reader and adds the triples to the graph store in batches of the given size.
+// The triples are added unchecked, so already existing triples do not cause an error. Returns the number of parsed triples.
+func LoadTurtleStream(store GraphStore, r io.Reader, batchSize int, opts ...ParseOption) (int, error) {
+	if batchSize <= 0 {
+		return 0, errors.New("Batch size must be positive")
+	}
+	count := 0
+	batch := make([]Triple, 0, batchSize)
+	err := ParseTurtleStream(r, func(trp Triple) error {
+		batch = append(batch, trp)
+		count++
+		if len(batch) < batchSize {
+			return nil
+		}
+		// Flush full batch
+		err := store.AddTriplesUnchecked(batch)
+		batch = batch[:0]
+		return err
+	}, opts...)
+	if err != nil {
+		return count, err
+	}
+	// Flush remaining triples
+	if len(batch) > 0 {
+		if err := store.AddTriplesUnchecked(batch); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// turtleStreamBlankNodePrefix is the prefix of the IRIs that stand in for labeled blank nodes while parsing a single statement, so their
+// labels are not lost to the parser.
+const turtleStreamBlankNodePrefix = "urn:ontograph:stream-bnode:"
+
+// isTurtleDirective checks if the statement is a prefix or base directive (either in Turtle or SPARQL syntax).
+func isTurtleDirective(stmt string) bool {
+	fields := strings.Fields(stmt)
+	if len(fields) == 0 {
+		return false
+	}
+	switch strings.ToLower(fields[0]) {
+	case "@prefix", "@base", "prefix", "base":
+		return true
+	}
+	return false
+}
+
+// turtleStatementScanner splits TTL data into single statements. It keeps track of IRIs, string literals,
+// comments and nested blank nodes or collections to find the terminating dot of a statement. Blank node labels are replaced by stand-in
+// IRIs (see `turtleStreamBlankNodePrefix`).
+type turtleStatementScanner struct {
+	r   *bufio.Reader
+	buf strings.Builder
+	pos int // number of bytes consumed from the reader
+}
+
+// next returns the next statement in the data (trimmed and with comments removed). If there are no further statements, io.EOF is returned.
+func (s *turtleStatementScanner) next() (string, error) {
+	s.buf.Reset()
+	depth := 0
+	for {
+		c, size, err := s.r.ReadRune()
+		s.pos += size
+		if err == io.EOF {
+			if strings.TrimSpace(s.buf.String()) != "" {
+				return "", errors.New("Unexpected end of TTL data in unterminated statement")
+			}
+			return "", io.EOF
+		}
+		if err != nil {
+			return "", err
+		}
+		switch c {
+		case '#':
+			// Skip comment until the end of the line
+			comment, err := s.r.ReadString('\n')
+			s.pos += len(comment)
+			if err != nil && err != io.EOF {
+				return "", err
+			}
+			s.buf.WriteRune('\n')
+		case '<':
+			s.buf.WriteRune(c)
+			if err := s.readUntil('>'); err != nil {
+				return "", err
+			}
+			// SPARQL style directives are not terminated by a dot, but end with their IRI
+			if stmt := strings.TrimSpace(s.buf.String()); depth == 0 && isTurtleDirective(stmt) && !strings.HasPrefix(stmt, "@") {
+				return stmt, nil
+			}
+		case '"', '\'':
+			if err := s.readString(c); err != nil {
+				return "", err
+			}
+		case '[', '(':
+			depth++
+			s.buf.WriteRune(c)
+		case ']', ')':
+			depth--
+			s.buf.WriteRune(c)
+		case '_':
+			if err := s.readBlankNodeLabel(); err != nil {
+				return "", err
+			}
+		case '.':
+			s.buf.WriteRune(c)
+			if depth > 0 {
+				continue
+			}
+			// The dot only terminates the statement if it is not part of a name or number
+			next, err := s.r.Peek(1)
+			if err == io.EOF || (err == nil && (unicode.IsSpace(rune(next[0])) || next[0] == '#')) {
+				return strings.TrimSpace(s.buf.String()), nil
+			}
+		default:
+			s.buf.WriteRune(c)
+		}
+	}
+}
+
+// readBlankNodeLabel copies a blank node label (started by an underscore) into the buffer as stand-in IRI. Underscores that are part of
+// a name are copied as they are.
+func (s *turtleStatementScanner) readBlankNodeLabel() error {
+	buffered := s.buf.String()
+	if next, err := s.r.Peek(1); err != nil || next[0] != ':' || (buffered != "" && isTurtleNameRune(rune(buffered[len(buffered)-1]))) {
+		s.buf.WriteRune('_')
+		return nil
+	}
+	_, _ = s.r.Discard(1)
+	s.pos++
+	var label strings.Builder
+	for {
+		// Dots are only part of the label if they are followed by another name character
+		next, _ := s.r.Peek(2)
+		if len(next) == 0 || (next[0] == '.' && (len(next) < 2 || !isTurtleNameRune(rune(next[1])))) {
+			break
+		}
+		c, size, err := s.r.ReadRune()
+		if err != nil {
+			return err
+		}
+		if c != '.' && !isTurtleNameRune(c) {
+			_ = s.r.UnreadRune()
+			break
+		}
+		s.pos += size
+		label.WriteRune(c)
+	}
+	if label.Len() == 0 {
+		return errors.New("Empty blank node label in TTL data")
+	}
+	s.buf.WriteString("<" + turtleStreamBlankNodePrefix + label.String() + ">")
+	return nil
+}
+
+// isTurtleNameRune checks if the rune may be part of a (prefixed) name or blank node label, except for dots.
+func isTurtleNameRune(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '-' || c == 0xB7
+}
+
+// readUntil copies runes into the buffer until (and including) the given delimiter.
+func (s *turtleStatementScanner) readUntil(delim byte) error {
+	str, err := s.r.ReadString(delim)
+	s.pos += len(str)
+	s.buf.WriteString(str)
+	if err == io.EOF {
+		return errors.New("Unexpected end of TTL data")
+	}
+	return err
+}
+
+// readString copies a short or long string literal (started by the given quote) into the buffer.
+func (s *turtleStatementScanner) readString(quote rune) error {
+	s.buf.WriteRune(quote)
+	// Check for long string literals with three quotes
+	long := false
+	if next, err := s.r.Peek(2); err == nil && rune(next[0]) == quote && rune(next[1]) == quote {
+		long = true
+		s.buf.WriteRune(quote)
+		s.buf.WriteRune(quote)
+		_, _ = s.r.Discard(2)
+		s.pos += 2
+	}
+	quotes := 0
+	for {
+		c, size, err := s.r.ReadRune()
+		s.pos += size
+		if err == io.EOF {
+			return errors.New("Unexpected end of TTL data in string literal")
+		}
+		if err != nil {
+			return err
+		}
+		s.buf.WriteRune(c)
+		switch {
+		case c == '\\':
+			// Copy escaped character
+			esc, size, err := s.r.ReadRune()
+			s.pos += size
+			if err != nil {
+				return errors.New("Unexpected end of TTL data in string literal")
+			}
+			s.buf.WriteRune(esc)
+			quotes = 0
+		case c == quote:
+			quotes++
+			if !long || quotes == 3 {
+				return nil
+			}
+		default:
+			quotes = 0
+		}
+	}
+}
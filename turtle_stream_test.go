@@ -0,0 +1,100 @@
+package ontograph_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lithammer/shortuuid/v3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("TurtleStream", func() {
+	const testTTL = `# A test ontology
+@prefix ex: <http://example.com/onto#> .
+PREFIX owl: <http://www.w3.org/2002/07/owl#>
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+
+<http://example.com/onto> a owl:Ontology . # trailing comment
+ex:a ex:rel ex:b, ex:c ;
+    rdfs:label "A label with a . dot and a # hash"@en .
+ex:b rdfs:comment """A long
+comment with "quotes" .""" .
+ex:c ex:value 4.2 .
+ex:d.e ex:rel <http://example.com/onto#a> .
+`
+
+	Describe("Parsing TTL data as a stream", func() {
+		It("should emit the same triples as the in-memory parser", func() {
+			trps := []Triple{}
+			err := ParseTurtleStream(strings.NewReader(testTTL), func(trp Triple) error {
+				trps = append(trps, trp)
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			store, err := ParseFromTurtle(strings.NewReader(testTTL))
+			Expect(err).NotTo(HaveOccurred())
+			expTrps, err := store.GetAllTriples()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trps).To(HaveLen(7))
+			Expect(trps).To(ConsistOf(expTrps))
+		})
+		It("should stop and return the callback error", func() {
+			count := 0
+			testErr := errors.New("stop")
+			err := ParseTurtleStream(strings.NewReader(testTTL), func(trp Triple) error {
+				count++
+				return testErr
+			})
+			Expect(err).To(Equal(testErr))
+			Expect(count).To(Equal(1))
+		})
+		It("should scope blank node labels to the whole data", func() {
+			trps := []Triple{}
+			err := ParseTurtleStream(strings.NewReader(`@prefix ex: <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+_:r a owl:Restriction .
+ex:C rdfs:subClassOf _:r.
+ex:a_b ex:rel [ ex:rel "_:x" ] .
+ex:b ex:rel [ ex:rel "_:x" ] .
+`), func(trp Triple) error {
+				trps = append(trps, trp)
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trps).To(HaveLen(6))
+			Expect(trps[0].Subject.IsBlankNode()).To(BeTrue())
+			Expect(trps[1].Object).To(Equal(trps[0].Subject))
+			// Names and literals are kept, anonymous blank nodes of different statements are distinct
+			subjects := map[Term]bool{}
+			for _, trp := range trps[2:] {
+				subjects[trp.Subject] = true
+				if trp.Object.IsLiteral() {
+					Expect(trp.Object.Value()).To(Equal("_:x"))
+				}
+			}
+			Expect(subjects).To(HaveKey(NewResourceTerm("http://example.com/onto#a_b")))
+			Expect(subjects).To(HaveLen(4))
+		})
+		It("should error on unterminated statements", func() {
+			err := ParseTurtleStream(strings.NewReader(`<http://a.com#a> <http://a.com#b> "open`), func(trp Triple) error {
+				return nil
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Loading TTL data as a stream into a store", func() {
+		It("should add all triples in batches", func() {
+			graph := NewMemoryStore(fmt.Sprintf("https://www.ontograph.com/test-%s", shortuuid.New()))
+			count, err := LoadTurtleStream(graph, strings.NewReader(testTTL), 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(7))
+			Expect(graph.Size()).To(Equal(7))
+		})
+	})
+})
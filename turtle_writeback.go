@@ -0,0 +1,161 @@
+package ontograph
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/deiu/rdf2go"
+)
+
+// WriteBackTurtle writes the triples of the store into the writer in Turtle (TTL) format, using the original TTL data as template in
+// order to minimize the diff to the original file:
+//   - Directives, comments and the layout of unchanged statements are preserved.
+//   - Statements that lost some of their triples are regenerated in place (keeping their leading comments).
+//   - Statements whose triples were all removed are dropped together with their leading comments.
+//   - Triples that are not contained in the original data are appended at the end, grouped by subject.
+//
+// Regenerated and appended statements use the prefixes declared in the original data. Note that statements containing blank nodes
+// might be regenerated even if they did not change, since blank node labels are not stable.
+func WriteBackTurtle(original io.Reader, store GraphStore, w io.Writer) error {
+	original, err := decompressReader(original)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadAll(original)
+	if err != nil {
+		return err
+	}
+	text := string(data)
+	// Collect the current triples of the store
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return err
+	}
+	current := map[Triple]bool{}
+	for _, trp := range trps {
+		current[trp] = true
+	}
+	written := map[Triple]bool{}
+
+	// Walk through the statements of the original data
+	scanner := turtleStatementScanner{r: bufio.NewReader(strings.NewReader(text))}
+	prefixMap := PrefixMap{}
+	var directives, out strings.Builder
+	last := 0
+	for {
+		stmt, err := scanner.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		// Split segment into leading comments, statement and trailing comment on the same line
+		segment := text[last:scanner.pos]
+		lead := segment[:significantStart(segment)]
+		trail := trailingComment(text[scanner.pos:])
+		last = scanner.pos + len(trail)
+
+		// Keep directives unchanged
+		if isTurtleDirective(stmt) {
+			directives.WriteString(stmt)
+			directives.WriteString("\n")
+			if m := prefixDirectiveRegex.FindStringSubmatch(stmt); m != nil {
+				prefixMap[m[1]] = m[2]
+			}
+			out.WriteString(segment + trail)
+			continue
+		}
+
+		// Parse the triples of the original statement
+		g := rdf2go.NewGraph("")
+		if err := g.Parse(strings.NewReader(directives.String()+stmt), "text/turtle"); err != nil {
+			return err
+		}
+		origTrps, keptTrps := []Triple{}, []Triple{}
+		for rdfTrp := range g.IterTriples() {
+			trp := Triple{
+				Subject:   Term(rdfTrp.Subject.String()),
+				Predicate: Term(rdfTrp.Predicate.String()),
+				Object:    Term(rdfTrp.Object.String()),
+			}
+			origTrps = append(origTrps, trp)
+			if current[trp] {
+				keptTrps = append(keptTrps, trp)
+			}
+		}
+		for _, trp := range keptTrps {
+			written[trp] = true
+		}
+		// Write statement depending on how much of it is left
+		if len(keptTrps) == len(origTrps) {
+			out.WriteString(segment + trail)
+		} else if len(keptTrps) > 0 {
+			out.WriteString(lead + strings.TrimSuffix(formatTurtleStatements(keptTrps, prefixMap), "\n") + trail)
+		}
+	}
+	// Keep everything after the last statement
+	out.WriteString(text[last:])
+
+	// Append new triples
+	newTrps := []Triple{}
+	for _, trp := range trps {
+		if !written[trp] {
+			newTrps = append(newTrps, trp)
+		}
+	}
+	if len(newTrps) > 0 {
+		if out.Len() > 0 && !strings.HasSuffix(out.String(), "\n") {
+			out.WriteString("\n")
+		}
+		if out.Len() > 0 {
+			out.WriteString("\n")
+		}
+		out.WriteString(formatTurtleStatements(newTrps, prefixMap))
+	}
+
+	_, err = io.WriteString(w, out.String())
+	return err
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// prefixDirectiveRegex matches prefix directives in Turtle or SPARQL syntax and captures abbreviation and namespace.
+var prefixDirectiveRegex = regexp.MustCompile(`(?i)^@?prefix\s+([^\s:]*):\s*<([^>]*)>`)
+
+// significantStart returns the index of the first character in the TTL segment that is neither whitespace nor part of a comment.
+func significantStart(segment string) int {
+	i := 0
+	for i < len(segment) {
+		switch segment[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		case '#':
+			if end := strings.IndexByte(segment[i:], '\n'); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(segment)
+			}
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// trailingComment returns the rest of the current line (including the line break) if it contains only whitespace or a comment.
+func trailingComment(rest string) string {
+	line := rest
+	if end := strings.IndexByte(rest, '\n'); end >= 0 {
+		line = rest[:end+1]
+	}
+	if trimmed := strings.TrimSpace(line); trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return line
+	}
+	return ""
+}
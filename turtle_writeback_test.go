@@ -0,0 +1,82 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("TurtleWriteBack", func() {
+	const originalTTL = `@prefix ex: <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+
+# The ontology header
+<http://example.com/onto> a owl:Ontology .
+
+# Class A (to be changed)
+ex:A a owl:Class ;
+     ex:note "first" , "second" . # keep me
+
+# Class B (to be removed)
+ex:B a owl:Class .
+
+# Class C (unchanged)
+ex:C    a    owl:Class .
+# end of file
+`
+	var store *MemoryStore
+
+	BeforeEach(func() {
+		var err error
+		store, err = ParseFromTurtle(strings.NewReader(originalTTL))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reproduce the original data if nothing changed", func() {
+		var out strings.Builder
+		Expect(WriteBackTurtle(strings.NewReader(originalTTL), store, &out)).To(Succeed())
+		Expect(out.String()).To(Equal(originalTTL))
+	})
+
+	It("should only touch the changed statements", func() {
+		// Remove class B and one note of class A, add class D
+		Expect(store.DeleteAllMatches("<http://example.com/onto#B>", "", "")).To(Succeed())
+		Expect(store.DeleteTriple(Triple{
+			Subject:   NewResourceTerm("http://example.com/onto#A"),
+			Predicate: NewResourceTerm("http://example.com/onto#note"),
+			Object:    NewLiteralTerm("second", "", ""),
+		})).To(Succeed())
+		Expect(store.AddTriple(Triple{
+			Subject:   NewResourceTerm("http://example.com/onto#D"),
+			Predicate: NewResourceTerm(RDFType),
+			Object:    NewResourceTerm(OWLClass),
+		})).To(Succeed())
+
+		var out strings.Builder
+		Expect(WriteBackTurtle(strings.NewReader(originalTTL), store, &out)).To(Succeed())
+		result := out.String()
+		By("keeping comments and layout of unchanged statements")
+		Expect(result).To(ContainSubstring("# The ontology header\n<http://example.com/onto> a owl:Ontology .\n"))
+		Expect(result).To(ContainSubstring("# Class C (unchanged)\nex:C    a    owl:Class .\n# end of file\n"))
+		By("regenerating the changed statement in place")
+		Expect(result).To(ContainSubstring("# Class A (to be changed)\nex:A\n"))
+		Expect(result).To(ContainSubstring(" # keep me\n"))
+		Expect(result).NotTo(ContainSubstring(`"second"`))
+		By("dropping the removed statement with its comments")
+		Expect(result).NotTo(ContainSubstring("Class B"))
+		By("appending the new triples")
+		Expect(result).To(HaveSuffix("ex:D\n  <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> owl:Class .\n"))
+
+		By("loading to the same triples as the store")
+		loaded, err := ParseFromTurtle(strings.NewReader(result))
+		Expect(err).NotTo(HaveOccurred())
+		loadedTrps, err := loaded.GetAllTriples()
+		Expect(err).NotTo(HaveOccurred())
+		trps, err := store.GetAllTriples()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loadedTrps).To(ConsistOf(trps))
+	})
+})
@@ -0,0 +1,293 @@
+package ontograph
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// ClassConstraints contains the constraints on the properties of a class, e.g. for client-side validation of forms that edit
+// individuals of the class.
+type ClassConstraints struct {
+	Class      string                          `json:"class"`
+	Properties map[string]*PropertyConstraints `json:"properties"`
+}
+
+// PropertyConstraints contains the constraints on the values of a property. Bounds are numbers for numeric datatypes and lexical
+// values otherwise (e.g. for dates). Allowed values are URIs for resources and lexical values for literals.
+type PropertyConstraints struct {
+	Path         string        `json:"path"`
+	Name         string        `json:"name,omitempty"`
+	Required     bool          `json:"required,omitempty"`
+	MinCount     *int          `json:"minCount,omitempty"`
+	MaxCount     *int          `json:"maxCount,omitempty"`
+	Datatype     string        `json:"datatype,omitempty"`
+	Class        string        `json:"class,omitempty"`
+	Pattern      string        `json:"pattern,omitempty"`
+	Flags        string        `json:"flags,omitempty"`
+	MinLength    *int          `json:"minLength,omitempty"`
+	MaxLength    *int          `json:"maxLength,omitempty"`
+	MinInclusive interface{}   `json:"minInclusive,omitempty"`
+	MaxInclusive interface{}   `json:"maxInclusive,omitempty"`
+	MinExclusive interface{}   `json:"minExclusive,omitempty"`
+	MaxExclusive interface{}   `json:"maxExclusive,omitempty"`
+	In           []interface{} `json:"in,omitempty"`
+}
+
+// GetUIConstraints collects the constraints per class from the SHACL shapes (node shapes with target classes or shapes that are
+// classes themselves) and from the OWL restrictions of the classes (cardinalities, value restrictions and `owl:hasValue`). If both
+// constrain the same property, the stricter constraint wins.
+func (ont *OntologyGraph) GetUIConstraints() (map[string]*ClassConstraints, error) {
+	trps, err := ont.graph.GetAllTriples()
+	if err != nil {
+		return nil, err
+	}
+	bySubject := triplesBySubject(trps)
+	constraints := map[string]*ClassConstraints{}
+	propertyOf := func(class, path string) *PropertyConstraints {
+		if constraints[class] == nil {
+			constraints[class] = &ClassConstraints{Class: class, Properties: map[string]*PropertyConstraints{}}
+		}
+		if constraints[class].Properties[path] == nil {
+			constraints[class].Properties[path] = &PropertyConstraints{Path: path}
+		}
+		return constraints[class].Properties[path]
+	}
+
+	// Collect SHACL property shapes of the node shapes
+	for subj, shapeTrps := range bySubject {
+		classes := []string{}
+		isShape, isClass := false, false
+		for _, trp := range shapeTrps {
+			switch {
+			case trp.Predicate == NewResourceTerm(RDFType) && trp.Object == NewResourceTerm(SHNodeShape):
+				isShape = true
+			case trp.Predicate == NewResourceTerm(RDFType) && trp.Object == NewResourceTerm(OWLClass):
+				isClass = true
+			case trp.Predicate == NewResourceTerm(SHTargetClass) && trp.Object.IsResource():
+				classes = append(classes, trp.Object.Value())
+			}
+		}
+		if isShape && isClass && subj.IsResource() {
+			classes = append(classes, subj.Value())
+		}
+		for _, class := range classes {
+			for _, trp := range shapeTrps {
+				if trp.Predicate != NewResourceTerm(SHProperty) {
+					continue
+				}
+				path := firstObject(bySubject[trp.Object], SHPath)
+				if !path.IsResource() {
+					// Complex paths are not supported for form validation
+					continue
+				}
+				mergeSHACLConstraints(propertyOf(class, path.Value()), bySubject[trp.Object], bySubject)
+			}
+		}
+	}
+
+	// Collect OWL restrictions of the classes
+	for subj, classTrps := range bySubject {
+		if !subj.IsResource() {
+			continue
+		}
+		for _, trp := range classTrps {
+			if trp.Predicate != NewResourceTerm(RDFSSubClassOf) && trp.Predicate != NewResourceTerm(OWLEquivalentClass) {
+				continue
+			}
+			restriction := bySubject[trp.Object]
+			prop := firstObject(restriction, OWLOnProperty)
+			if firstObject(restriction, RDFType) != NewResourceTerm(OWLRestriction) || !prop.IsResource() {
+				continue
+			}
+			mergeOWLConstraints(propertyOf(subj.Value(), prop.Value()), restriction, bySubject)
+		}
+	}
+	return constraints, nil
+}
+
+// ExportUIConstraints writes the constraints of all classes (see `GetUIConstraints`) as compact JSON object keyed by class URI into
+// the writer, e.g. to serve them to web clients for form validation.
+func (ont *OntologyGraph) ExportUIConstraints(w io.Writer) error {
+	constraints, err := ont.GetUIConstraints()
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(constraints)
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// triplesBySubject groups the triples by their subject.
+func triplesBySubject(trps []Triple) map[Term][]Triple {
+	bySubject := map[Term][]Triple{}
+	for _, trp := range trps {
+		bySubject[trp.Subject] = append(bySubject[trp.Subject], trp)
+	}
+	return bySubject
+}
+
+// firstObject returns the object of the first triple with the given predicate or the empty term if there is none.
+func firstObject(trps []Triple, pred string) Term {
+	for _, trp := range trps {
+		if trp.Predicate == NewResourceTerm(pred) {
+			return trp.Object
+		}
+	}
+	return ""
+}
+
+// rdfList returns the elements of the RDF list with the given head.
+func rdfList(head Term, bySubject map[Term][]Triple) []Term {
+	elems := []Term{}
+	visited := map[Term]bool{}
+	for head != "" && head != NewResourceTerm(RDFNil) && !visited[head] {
+		visited[head] = true
+		if first := firstObject(bySubject[head], RDFFirst); first != "" {
+			elems = append(elems, first)
+		}
+		head = firstObject(bySubject[head], RDFRest)
+	}
+	return elems
+}
+
+// constraintValue converts the term into a JSON value: numbers for numeric literals, lexical values for other literals and URIs for
+// resources.
+func constraintValue(t Term) interface{} {
+	if n, ok := sparqlNumeric(t); ok {
+		return n
+	}
+	if t.IsLiteral() {
+		return unescapeLiteral(t.Value())
+	}
+	return t.Value()
+}
+
+// constraintInt parses the integer literal. Returns nil if the term is no valid non-negative integer.
+func constraintInt(t Term) *int {
+	n, err := strconv.Atoi(t.Value())
+	if t == "" || err != nil || n < 0 {
+		return nil
+	}
+	return &n
+}
+
+// mergeSHACLConstraints merges the constraints of the SHACL property shape into the property constraints.
+func mergeSHACLConstraints(pc *PropertyConstraints, shape []Triple, bySubject map[Term][]Triple) {
+	for _, trp := range shape {
+		switch trp.Predicate.Value() {
+		case SHName:
+			if pc.Name == "" {
+				pc.Name = unescapeLiteral(trp.Object.Value())
+			}
+		case SHMinCount:
+			pc.mergeMinCount(constraintInt(trp.Object))
+		case SHMaxCount:
+			pc.mergeMaxCount(constraintInt(trp.Object))
+		case SHDatatype:
+			pc.Datatype = trp.Object.Value()
+		case SHClass:
+			pc.Class = trp.Object.Value()
+		case SHPattern:
+			pc.Pattern = unescapeLiteral(trp.Object.Value())
+		case SHFlags:
+			pc.Flags = unescapeLiteral(trp.Object.Value())
+		case SHMinLength:
+			if n := constraintInt(trp.Object); n != nil && (pc.MinLength == nil || *n > *pc.MinLength) {
+				pc.MinLength = n
+			}
+		case SHMaxLength:
+			if n := constraintInt(trp.Object); n != nil && (pc.MaxLength == nil || *n < *pc.MaxLength) {
+				pc.MaxLength = n
+			}
+		case SHMinInclusive:
+			pc.MinInclusive = constraintValue(trp.Object)
+		case SHMaxInclusive:
+			pc.MaxInclusive = constraintValue(trp.Object)
+		case SHMinExclusive:
+			pc.MinExclusive = constraintValue(trp.Object)
+		case SHMaxExclusive:
+			pc.MaxExclusive = constraintValue(trp.Object)
+		case SHIn:
+			values := []interface{}{}
+			for _, elem := range rdfList(trp.Object, bySubject) {
+				values = append(values, constraintValue(elem))
+			}
+			pc.mergeIn(values)
+		case SHHasValue:
+			pc.mergeIn([]interface{}{constraintValue(trp.Object)})
+		}
+	}
+}
+
+// mergeOWLConstraints merges the constraints of the OWL restriction into the property constraints.
+func mergeOWLConstraints(pc *PropertyConstraints, restriction []Triple, bySubject map[Term][]Triple) {
+	one := 1
+	for _, trp := range restriction {
+		switch trp.Predicate.Value() {
+		case OWLCardinality, OWLQualifiedCardinality:
+			pc.mergeMinCount(constraintInt(trp.Object))
+			pc.mergeMaxCount(constraintInt(trp.Object))
+		case OWLMinCardinality, OWLMinQualifiedCardinality:
+			pc.mergeMinCount(constraintInt(trp.Object))
+		case OWLMaxCardinality, OWLMaxQualifiedCardinality:
+			pc.mergeMaxCount(constraintInt(trp.Object))
+		case OWLSomeValuesFrom:
+			pc.mergeMinCount(&one)
+			pc.mergeValueType(trp.Object, bySubject)
+		case OWLAllValuesFrom, OWLOnClass, OWLOnDataRange:
+			pc.mergeValueType(trp.Object, bySubject)
+		case OWLHasValue:
+			pc.mergeMinCount(&one)
+			pc.mergeIn([]interface{}{constraintValue(trp.Object)})
+		}
+	}
+}
+
+// mergeMinCount raises the minimum count if the given count is stricter.
+func (pc *PropertyConstraints) mergeMinCount(n *int) {
+	if n != nil && (pc.MinCount == nil || *n > *pc.MinCount) {
+		pc.MinCount = n
+	}
+	pc.Required = pc.MinCount != nil && *pc.MinCount > 0
+}
+
+// mergeMaxCount lowers the maximum count if the given count is stricter.
+func (pc *PropertyConstraints) mergeMaxCount(n *int) {
+	if n != nil && (pc.MaxCount == nil || *n < *pc.MaxCount) {
+		pc.MaxCount = n
+	}
+}
+
+// mergeIn restricts the allowed values to the intersection with the given values.
+func (pc *PropertyConstraints) mergeIn(values []interface{}) {
+	if pc.In == nil {
+		pc.In = values
+		return
+	}
+	allowed := map[interface{}]bool{}
+	for _, value := range values {
+		allowed[value] = true
+	}
+	in := []interface{}{}
+	for _, value := range pc.In {
+		if allowed[value] {
+			in = append(in, value)
+		}
+	}
+	pc.In = in
+}
+
+// mergeValueType sets the class or datatype of the values from the named class or datatype. Anonymous class expressions are ignored.
+func (pc *PropertyConstraints) mergeValueType(t Term, bySubject map[Term][]Triple) {
+	if !t.IsResource() {
+		return
+	}
+	if builtinDatatypes[t.Value()] || firstObject(bySubject[t], RDFType) == NewResourceTerm(RDFSDatatype) {
+		pc.Datatype = t.Value()
+		return
+	}
+	pc.Class = t.Value()
+}
@@ -0,0 +1,70 @@
+package ontograph_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("UI constraints", func() {
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		store, err := ParseFromTurtle(strings.NewReader(`@prefix : <http://example.com/onto#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdf: <http://www.w3.org/1999/02/22-rdf-syntax-ns#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix sh: <http://www.w3.org/ns/shacl#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+<http://example.com/onto> a owl:Ontology .
+:Person a owl:Class ;
+  rdfs:subClassOf _:nameRestriction , _:worksForRestriction .
+_:nameRestriction a owl:Restriction ; owl:onProperty :name ; owl:maxCardinality "1"^^xsd:nonNegativeInteger .
+_:worksForRestriction a owl:Restriction ; owl:onProperty :worksFor ; owl:someValuesFrom :Company .
+:PersonShape a sh:NodeShape ;
+  sh:targetClass :Person ;
+  sh:property _:nameShape , _:ageShape , _:statusShape .
+_:nameShape sh:path :name ; sh:name "Name" ; sh:minCount 1 ; sh:maxCount 2 ; sh:datatype xsd:string ; sh:pattern "^[A-Z]" ; sh:maxLength 50 .
+_:ageShape sh:path :age ; sh:datatype xsd:integer ; sh:minInclusive 0 ; sh:maxExclusive 150 .
+_:statusShape sh:path :status ; sh:in _:statusValues .
+_:statusValues rdf:first "active" ; rdf:rest _:statusRest .
+_:statusRest rdf:first "retired" ; rdf:rest rdf:nil .
+`))
+		Expect(err).NotTo(HaveOccurred())
+		ont, err = LoadOntologyGraph(store)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should combine SHACL shapes and OWL restrictions", func() {
+		constraints, err := ont.GetUIConstraints()
+		Expect(err).NotTo(HaveOccurred())
+		person := constraints["http://example.com/onto#Person"]
+		Expect(person).NotTo(BeNil())
+		name := person.Properties["http://example.com/onto#name"]
+		Expect(name.Name).To(Equal("Name"))
+		Expect(name.Required).To(BeTrue())
+		Expect(*name.MinCount).To(Equal(1))
+		Expect(*name.MaxCount).To(Equal(1))
+		Expect(name.Pattern).To(Equal("^[A-Z]"))
+		Expect(*name.MaxLength).To(Equal(50))
+		worksFor := person.Properties["http://example.com/onto#worksFor"]
+		Expect(worksFor.Required).To(BeTrue())
+		Expect(worksFor.Class).To(Equal("http://example.com/onto#Company"))
+		age := person.Properties["http://example.com/onto#age"]
+		Expect(age.Required).To(BeFalse())
+		Expect(age.Datatype).To(Equal(XSDInteger))
+		Expect(age.MinInclusive).To(Equal(0.0))
+		Expect(age.MaxExclusive).To(Equal(150.0))
+		Expect(person.Properties["http://example.com/onto#status"].In).To(Equal([]interface{}{"active", "retired"}))
+	})
+
+	It("should export the constraints as JSON", func() {
+		var buf bytes.Buffer
+		Expect(ont.ExportUIConstraints(&buf)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring(`"path":"http://example.com/onto#age","datatype":"http://www.w3.org/2001/XMLSchema#integer","minInclusive":0,"maxExclusive":150`))
+	})
+})
@@ -0,0 +1,97 @@
+package ontograph
+
+import (
+	"context"
+	"io"
+)
+
+// UnionStore combines a graph store with further stores, e.g. with the imports closure of an ontology (see
+// `OntologyGraph.ResolveImports`). Reads see the triples of all stores, while writes and deletes only affect the first store, so the
+// other stores are effectively read-only. Triples that are contained in several stores are only returned once.
+type UnionStore struct {
+	GraphStore
+	others []GraphStore
+}
+
+// NewUnionStore combines the graph store with the other stores. The URI of the union is the URI of the graph store.
+func NewUnionStore(store GraphStore, others ...GraphStore) *UnionStore {
+	return &UnionStore{GraphStore: store, others: others}
+}
+
+// Stores returns the graph store followed by the other stores of the union.
+func (store *UnionStore) Stores() []GraphStore {
+	return append([]GraphStore{store.GraphStore}, store.others...)
+}
+
+// WithContext returns a copy of the union whose stores are bound to the context (see `StoreWithContext`).
+func (store *UnionStore) WithContext(ctx context.Context) GraphStore {
+	others := []GraphStore{}
+	for _, other := range store.others {
+		others = append(others, StoreWithContext(other, ctx))
+	}
+	return NewUnionStore(StoreWithContext(store.GraphStore, ctx), others...)
+}
+
+// GetFirstMatch retrieves the first triple that matches the pattern in any of the stores. Empty strings in subject, predicate or
+// object are treated as wildcards.
+func (store *UnionStore) GetFirstMatch(subj, pred, obj string) (*Triple, error) {
+	for _, graph := range store.Stores() {
+		trp, err := graph.GetFirstMatch(subj, pred, obj)
+		if err != nil || trp != nil {
+			return trp, err
+		}
+	}
+	return nil, nil
+}
+
+// GetAllMatches retrieves all triples that match the pattern in any of the stores. Empty strings in subject, predicate or object are
+// treated as wildcards.
+func (store *UnionStore) GetAllMatches(subj, pred, obj string) ([]Triple, error) {
+	trps := []Triple{}
+	seen := map[Triple]bool{}
+	for _, graph := range store.Stores() {
+		graphTrps, err := graph.GetAllMatches(subj, pred, obj)
+		if err != nil {
+			return nil, err
+		}
+		for _, trp := range graphTrps {
+			if !seen[trp] {
+				seen[trp] = true
+				trps = append(trps, trp)
+			}
+		}
+	}
+	return trps, nil
+}
+
+// GetAllTriples returns all triples of all stores.
+func (store *UnionStore) GetAllTriples() ([]Triple, error) {
+	return store.GetAllMatches("", "", "")
+}
+
+// SerializeToTurtle writes the triples of all stores into the writer in Turtle (TTL) format.
+func (store *UnionStore) SerializeToTurtle(w io.Writer, pretty bool) error {
+	return store.SerializeToTurtleWithOptions(w, pretty)
+}
+
+// SerializeToTurtleWithOptions writes the triples of all stores into the writer in Turtle (TTL) format with the serialize options.
+func (store *UnionStore) SerializeToTurtleWithOptions(w io.Writer, pretty bool, opts ...SerializeOption) error {
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return err
+	}
+	union := NewMemoryStore(store.GetURI())
+	if err := union.AddTriplesUnchecked(trps); err != nil {
+		return err
+	}
+	return union.SerializeToTurtleWithOptions(w, pretty, opts...)
+}
+
+// Size returns the number of distinct triples in all stores.
+func (store *UnionStore) Size() (int, error) {
+	trps, err := store.GetAllTriples()
+	if err != nil {
+		return 0, err
+	}
+	return len(trps), nil
+}
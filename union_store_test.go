@@ -0,0 +1,50 @@
+package ontograph_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Union store", func() {
+	var primary, other *MemoryStore
+	var union *UnionStore
+
+	BeforeEach(func() {
+		var err error
+		primary, err = ParseFromTurtle(strings.NewReader(`@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/a> a owl:Ontology .
+<http://example.com/a#A> a owl:Class .`))
+		Expect(err).NotTo(HaveOccurred())
+		other, err = ParseFromTurtle(strings.NewReader(`@prefix owl: <http://www.w3.org/2002/07/owl#> .
+<http://example.com/b> a owl:Ontology .
+<http://example.com/a#A> a owl:Class .
+<http://example.com/b#B> a owl:Class .`))
+		Expect(err).NotTo(HaveOccurred())
+		union = NewUnionStore(primary, other)
+	})
+
+	It("should read distinct triples from all stores", func() {
+		trps, err := union.GetAllMatches("", NewResourceTerm(RDFType).String(), NewResourceTerm(OWLClass).String())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trps).To(HaveLen(2))
+		size, err := union.Size()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(size).To(Equal(4))
+		var sb strings.Builder
+		Expect(union.SerializeToTurtle(&sb, false)).To(Succeed())
+		Expect(sb.String()).To(ContainSubstring("<http://example.com/b#B>"))
+	})
+
+	It("should only write to the first store", func() {
+		trp := Triple{Subject: NewResourceTerm("http://example.com/a#C"), Predicate: NewResourceTerm(RDFType), Object: NewResourceTerm(OWLClass)}
+		Expect(union.AddTriple(trp)).To(Succeed())
+		Expect(union.DeleteAllMatches(NewResourceTerm("http://example.com/b#B").String(), "", "")).To(Succeed())
+		Expect(primary.Size()).To(Equal(3))
+		Expect(other.Size()).To(Equal(3))
+		Expect(union.Size()).To(Equal(5))
+	})
+})
@@ -0,0 +1,90 @@
+package ontograph
+
+import (
+	"strings"
+)
+
+// A URIMatcher decides which resource URIs belong to the namespace of an ontology, e.g. when resources are upserted or renamed.
+type URIMatcher interface {
+	// MatchesURI should return true if the URI belongs to the namespace of the ontology with the given URI.
+	MatchesURI(ontologyURI, uri string) bool
+	// ResourceURI should return a URI in the namespace of the ontology for the local name.
+	ResourceURI(ontologyURI, localName string) string
+}
+
+// DefaultURIMatcher is the URI matcher used by ontology graphs unless another one is set. It matches hash URIs.
+var DefaultURIMatcher URIMatcher = HashURIMatcher{}
+
+// SetURIMatcher sets the matcher that decides which resource URIs belong to the namespace of the ontology. It also determines the URIs
+// of new resources (see `NewResourceURI`). Use a `SlashURIMatcher` for ontologies with URIs like `https://example.com/onto/Class1`.
+func (ont *OntologyGraph) SetURIMatcher(matcher URIMatcher) {
+	ont.uriMatcher = matcher
+}
+
+// ********************
+// * Implementations *
+// ********************
+
+// HashURIMatcher matches URIs of the form `<ontologyURI>#<localName>`.
+type HashURIMatcher struct{}
+
+// MatchesURI returns true if the URI is the ontology URI followed by a fragment.
+func (m HashURIMatcher) MatchesURI(ontologyURI, uri string) bool {
+	return matchesSeparatedURI(ontologyURI, uri, "#")
+}
+
+// ResourceURI returns the ontology URI with the local name as fragment.
+func (m HashURIMatcher) ResourceURI(ontologyURI, localName string) string {
+	return ontologyURI + "#" + localName
+}
+
+// SlashURIMatcher matches URIs of the form `<ontologyURI>/<localName>`.
+type SlashURIMatcher struct{}
+
+// MatchesURI returns true if the URI is the ontology URI followed by a single path segment.
+func (m SlashURIMatcher) MatchesURI(ontologyURI, uri string) bool {
+	return matchesSeparatedURI(ontologyURI, uri, "/")
+}
+
+// ResourceURI returns the ontology URI with the local name as additional path segment.
+func (m SlashURIMatcher) ResourceURI(ontologyURI, localName string) string {
+	return ontologyURI + "/" + localName
+}
+
+// PrefixURIMatcher matches all URIs that start with the prefix (e.g. `https://example.com/`), independent of the ontology URI.
+type PrefixURIMatcher struct {
+	Prefix string
+}
+
+// MatchesURI returns true if the URI starts with the prefix.
+func (m PrefixURIMatcher) MatchesURI(ontologyURI, uri string) bool {
+	return strings.HasPrefix(uri, m.Prefix)
+}
+
+// ResourceURI returns the prefix followed by the local name.
+func (m PrefixURIMatcher) ResourceURI(ontologyURI, localName string) string {
+	return m.Prefix + localName
+}
+
+// URIMatcherFunc adapts a predicate to a URI matcher. The URIs of new resources are hash URIs.
+type URIMatcherFunc func(ontologyURI, uri string) bool
+
+// MatchesURI calls the predicate.
+func (fn URIMatcherFunc) MatchesURI(ontologyURI, uri string) bool {
+	return fn(ontologyURI, uri)
+}
+
+// ResourceURI returns the ontology URI with the local name as fragment.
+func (fn URIMatcherFunc) ResourceURI(ontologyURI, localName string) string {
+	return ontologyURI + "#" + localName
+}
+
+// ********************
+// * Helper functions *
+// ********************
+
+// matchesSeparatedURI returns true if the part of the URI before the last separator is the ontology URI.
+func matchesSeparatedURI(ontologyURI, uri, sep string) bool {
+	pos := strings.LastIndex(uri, sep)
+	return pos >= 0 && uri[:pos] == ontologyURI
+}
@@ -0,0 +1,56 @@
+package ontograph_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("URI matching", func() {
+	const testUri = "https://example.com/onto"
+	var ont *OntologyGraph
+
+	BeforeEach(func() {
+		var err error
+		ont, err = InitOntologyGraph(NewMemoryStore(testUri))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should only accept hash URIs by default", func() {
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#Class1"})).To(Succeed())
+		err := ont.UpsertResource(&OntologyClass{URI: testUri + "/Class1"})
+		Expect(errors.Is(err, ErrResourceDoesNotBelongToGraph)).To(BeTrue())
+		Expect(ont.NewResourceURI()).To(HavePrefix(testUri + "#"))
+	})
+
+	It("should accept slash URIs with a slash matcher", func() {
+		ont.SetURIMatcher(SlashURIMatcher{})
+		ont.SetIDGenerator(NewSequentialIDGenerator("id"))
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "/Class1"})).To(Succeed())
+		class, err := ont.GetClass(testUri + "/Class1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(class.URI).To(Equal(testUri + "/Class1"))
+		err = ont.UpsertResource(&OntologyClass{URI: testUri + "#Class2"})
+		Expect(errors.Is(err, ErrResourceDoesNotBelongToGraph)).To(BeTrue())
+		err = ont.UpsertResource(&OntologyClass{URI: testUri + "/sub/Class3"})
+		Expect(errors.Is(err, ErrResourceDoesNotBelongToGraph)).To(BeTrue())
+		Expect(ont.NewResourceURI()).To(Equal(testUri + "/id1"))
+	})
+
+	It("should accept URIs by prefix or custom predicate", func() {
+		ont.SetURIMatcher(PrefixURIMatcher{Prefix: "https://example.com/"})
+		Expect(ont.UpsertResource(&OntologyClass{URI: "https://example.com/terms/Class1"})).To(Succeed())
+		err := ont.UpsertResource(&OntologyClass{URI: "https://other.com/Class1"})
+		Expect(errors.Is(err, ErrResourceDoesNotBelongToGraph)).To(BeTrue())
+
+		ont.SetURIMatcher(URIMatcherFunc(func(ontologyURI, uri string) bool {
+			return strings.HasPrefix(uri, ontologyURI+"#") || strings.HasPrefix(uri, ontologyURI+"/")
+		}))
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "#Class2"})).To(Succeed())
+		Expect(ont.UpsertResource(&OntologyClass{URI: testUri + "/Class3"})).To(Succeed())
+	})
+})
@@ -0,0 +1,72 @@
+package ontograph
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// A Warning describes a non-fatal issue, e.g. a triple that was accepted despite an issue or a triple that was dropped as side effect
+// of an operation.
+type Warning struct {
+	// Triple is the affected triple.
+	Triple Triple
+	// Err describes the issue (e.g. `ErrUnknownDatatype` or `ErrDroppedTriple`).
+	Err error
+}
+
+// String returns a human readable description of the warning.
+func (w Warning) String() string {
+	return fmt.Sprintf("%s %s %s: %s", w.Triple.Subject, w.Triple.Predicate, w.Triple.Object, w.Err.Error())
+}
+
+// A WarningCollector receives the warnings of parsing, validation and upsert operations, so that callers can surface them to users.
+type WarningCollector interface {
+	// Warn should handle the given warning. It must not block for long, since it is called during the operation.
+	Warn(w Warning)
+}
+
+// WarningFunc adapts a function to a warning collector, e.g. to forward warnings into a channel.
+type WarningFunc func(w Warning)
+
+// Warn calls the function with the warning.
+func (fn WarningFunc) Warn(w Warning) {
+	fn(w)
+}
+
+// Report is a warning collector that records all warnings. It is safe for concurrent use.
+type Report struct {
+	mu       sync.Mutex
+	warnings []Warning
+}
+
+// Warn adds the warning to the report.
+func (r *Report) Warn(w Warning) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.warnings = append(r.warnings, w)
+}
+
+// Warnings returns the recorded warnings in the order they were reported.
+func (r *Report) Warnings() []Warning {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Warning{}, r.warnings...)
+}
+
+// HasWarnings returns true if at least one warning was reported.
+func (r *Report) HasWarnings() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.warnings) > 0
+}
+
+// *****************
+// * Shared Errors *
+// *****************
+
+// ErrDroppedTriple is reported when a triple is removed as side effect of an operation (e.g. a reference to a resource that is replaced by an upsert).
+var ErrDroppedTriple error = errors.New("The triple was dropped")
+
+// ErrDeprecatedTerm is reported when a triple references a resource that is marked as deprecated.
+var ErrDeprecatedTerm error = errors.New("The referenced resource is deprecated")
@@ -0,0 +1,56 @@
+package ontograph_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/kahefi/ontograph"
+)
+
+var _ = Describe("Warnings", func() {
+	const testUri = "http://example.com/onto"
+	var graph GraphStore
+	var ont *OntologyGraph
+	var report *Report
+	classA := OntologyClass{URI: testUri + "#A"}
+	classB := OntologyClass{URI: testUri + "#B", SubClassOf: []string{testUri + "#A"}}
+
+	BeforeEach(func() {
+		graph = NewMemoryStore(testUri)
+		var err error
+		ont, err = InitOntologyGraph(graph)
+		Expect(err).NotTo(HaveOccurred())
+		report = &Report{}
+		ont.SetWarningCollector(report)
+		Expect(ont.UpsertResource(&classA)).To(Succeed())
+		Expect(ont.UpsertResource(&classB)).To(Succeed())
+		Expect(report.HasWarnings()).To(BeFalse())
+	})
+
+	It("should report references dropped by an upsert", func() {
+		Expect(ont.UpsertResource(&classA)).To(Succeed())
+		Expect(report.Warnings()).To(HaveLen(1))
+		Expect(report.Warnings()[0].Err).To(Equal(ErrDroppedTriple))
+		Expect(report.Warnings()[0].Triple.Subject).To(Equal(NewResourceTerm(classB.URI)))
+	})
+
+	It("should report references to deprecated resources", func() {
+		Expect(graph.AddTriple(Triple{
+			Subject:   NewResourceTerm(classA.URI),
+			Predicate: NewResourceTerm(OWLDeprecated),
+			Object:    NewLiteralTerm("true", "", XSDBoolean),
+		})).To(Succeed())
+		classC := OntologyClass{URI: testUri + "#C", SubClassOf: []string{testUri + "#A"}}
+		Expect(ont.UpsertResource(&classC)).To(Succeed())
+		Expect(report.Warnings()).To(HaveLen(1))
+		Expect(report.Warnings()[0].Err).To(MatchError(ErrDeprecatedTerm))
+		Expect(report.Warnings()[0].Triple.Object).To(Equal(NewResourceTerm(classA.URI)))
+	})
+
+	It("should forward warnings to functions", func() {
+		warnings := make(chan Warning, 10)
+		ont.SetWarningCollector(WarningFunc(func(w Warning) { warnings <- w }))
+		Expect(ont.UpsertResource(&classA)).To(Succeed())
+		Expect(warnings).To(Receive())
+	})
+})